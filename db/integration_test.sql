@@ -3,8 +3,70 @@ CREATE TABLE IF NOT EXISTS expenses (
 		title TEXT,
 		amount FLOAT,
 		note TEXT,
-		tags TEXT[]
+		tags TEXT[],
+		date DATE NOT NULL DEFAULT CURRENT_DATE,
+		currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+		group_id INTEGER,
+		category TEXT,
+		search_text TEXT,
+		merged_into_id INTEGER,
+		deleted_at TIMESTAMPTZ,
+		search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', coalesce(search_text, ''))) STORED,
+		merchant_id INTEGER,
+		account_id INTEGER,
+		reconciled_at TIMESTAMPTZ,
+		type VARCHAR(8) NOT NULL DEFAULT 'expense',
+		to_account_id INTEGER,
+		refund_of_id INTEGER
 	);
 
-INSERT INTO expenses (title, amount, note, tags) VALUES 
-('strawberry smoothie', 79, 'night market promotion discount 10 bath', '{"food", "beverage"}');
\ No newline at end of file
+CREATE TABLE IF NOT EXISTS expense_events (
+		id SERIAL PRIMARY KEY,
+		event VARCHAR(32),
+		expense_id INTEGER,
+		payload TEXT,
+		created_at TIMESTAMPTZ,
+		dispatched_at TIMESTAMPTZ
+	);
+
+CREATE TABLE IF NOT EXISTS imports (
+		id SERIAL PRIMARY KEY,
+		filename TEXT,
+		format TEXT,
+		delimiter TEXT,
+		date_format TEXT,
+		tags TEXT[],
+		account_id INTEGER,
+		status TEXT,
+		total_rows INTEGER,
+		valid_rows INTEGER,
+		duplicate_rows INTEGER,
+		imported_rows INTEGER,
+		created_at TIMESTAMPTZ,
+		committed_at TIMESTAMPTZ
+	);
+
+CREATE TABLE IF NOT EXISTS import_rows (
+		id SERIAL PRIMARY KEY,
+		import_id INTEGER,
+		line INTEGER,
+		date DATE,
+		amount FLOAT,
+		description TEXT,
+		currency VARCHAR(3),
+		external_id TEXT,
+		error TEXT,
+		duplicate BOOLEAN,
+		expense_id INTEGER
+	);
+
+CREATE TABLE IF NOT EXISTS imported_transactions (
+		id SERIAL PRIMARY KEY,
+		external_id TEXT UNIQUE,
+		import_id INTEGER,
+		expense_id INTEGER,
+		created_at TIMESTAMPTZ
+	);
+
+INSERT INTO expenses (title, amount, note, tags, date, search_text) VALUES 
+('strawberry smoothie', 79, 'night market promotion discount 10 bath', '{"food", "beverage"}', '2023-01-01', 'strawberry smoothie night market promotion discount 10 bath');
@@ -1,10 +1,15 @@
 package helpers
 
-import "net/http"
+import (
+	"net/http"
+	"strings"
+)
 
 type AppError struct {
 	StatusCode int
 	Message    string
+	// Codes are the policies an expense violates when it is blocked.
+	Codes []string
 }
 
 func (se *AppError) Error() string {
@@ -18,3 +23,27 @@ func NewInternalServerError() error {
 func NewNotFoundError() error {
 	return &AppError{StatusCode: http.StatusNotFound, Message: "record not found"}
 }
+
+func NewBadRequestError(message string) error {
+	return &AppError{StatusCode: http.StatusBadRequest, Message: message}
+}
+
+func NewForbiddenError(message string) error {
+	return &AppError{StatusCode: http.StatusForbidden, Message: message}
+}
+
+func NewConflictError(message string) error {
+	return &AppError{StatusCode: http.StatusConflict, Message: message}
+}
+
+func NewUnsupportedMediaTypeError(message string) error {
+	return &AppError{StatusCode: http.StatusUnsupportedMediaType, Message: message}
+}
+
+func NewPolicyViolationError(codes []string) error {
+	return &AppError{
+		StatusCode: http.StatusUnprocessableEntity,
+		Message:    "the expense violates the policies " + strings.Join(codes, ", "),
+		Codes:      codes,
+	}
+}
@@ -0,0 +1,5 @@
+package helpers
+
+// GroupIDKey is the gin context key holding the id of the group a request
+// was authorized for.
+const GroupIDKey = "group_id"
@@ -0,0 +1,55 @@
+package helpers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BaseCurrency is the currency expenses default to and exchange rates are
+// quoted in.
+const BaseCurrency = "THB"
+
+// ExchangeRates hold the value of one unit of a currency in BaseCurrency.
+type ExchangeRates map[string]float64
+
+// ParseExchangeRates reads rates written as USD=35.5,EUR=38.2.
+func ParseExchangeRates(value string) (ExchangeRates, error) {
+	rates := ExchangeRates{}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		currency, rate, found := strings.Cut(pair, "=")
+		amount, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if !found || err != nil || amount <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %q", pair)
+		}
+		rates[strings.ToUpper(strings.TrimSpace(currency))] = amount
+	}
+
+	return rates, nil
+}
+
+// Convert returns amount in the to currency, false when a rate is missing.
+func (r ExchangeRates) Convert(amount float64, from string, to string) (float64, bool) {
+	if from == to {
+		return amount, true
+	}
+
+	fromRate, toRate := r.rate(from), r.rate(to)
+	if fromRate == 0 || toRate == 0 {
+		return 0, false
+	}
+
+	return amount * fromRate / toRate, true
+}
+
+func (r ExchangeRates) rate(currency string) float64 {
+	if currency == BaseCurrency {
+		return 1
+	}
+
+	return r[currency]
+}
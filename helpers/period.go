@@ -0,0 +1,37 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+// PeriodBounds returns the first day of the period containing t and the
+// first day of the period after it, both at midnight UTC.
+func PeriodBounds(period string, t time.Time) (time.Time, time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case models.PeriodWeekly:
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7)
+	case models.PeriodYearly:
+		start := time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0)
+	default:
+		start := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	}
+}
+
+// PeriodUnit maps a budget period to the matching postgres date_trunc unit.
+func PeriodUnit(period string) string {
+	switch period {
+	case models.PeriodWeekly:
+		return "week"
+	case models.PeriodYearly:
+		return "year"
+	default:
+		return "month"
+	}
+}
@@ -0,0 +1,79 @@
+package helpers
+
+import "strings"
+
+// Similarity scores how alike two titles are from 0 to 1, ignoring case and
+// punctuation. It is the better of the edit distance ratio, which forgives
+// typos, and the share of common words, which forgives added words.
+func Similarity(a string, b string) float64 {
+	wordsA := SplitWords(a)
+	wordsB := SplitWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	ratio := editRatio([]rune(strings.Join(wordsA, " ")), []rune(strings.Join(wordsB, " ")))
+	dice := diceCoefficient(wordsA, wordsB)
+	if dice > ratio {
+		return dice
+	}
+	return ratio
+}
+
+func editRatio(a []rune, b []rune) float64 {
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+func levenshtein(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minimum(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func minimum(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
+
+func diceCoefficient(a []string, b []string) float64 {
+	counts := map[string]int{}
+	for _, word := range a {
+		counts[word]++
+	}
+
+	common := 0
+	for _, word := range b {
+		if counts[word] > 0 {
+			counts[word]--
+			common++
+		}
+	}
+
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
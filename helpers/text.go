@@ -0,0 +1,93 @@
+package helpers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitWords splits text into lower case words of letters, digits and
+// marks. Thai is written without spaces between words, so a run of Thai is
+// returned as one word that IsThai reports.
+func SplitWords(text string) []string {
+	var words []string
+	var word []rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+
+	for _, r := range strings.ToLower(text) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) {
+			flush()
+			continue
+		}
+		// a switch between Thai and other scripts ends the word
+		if len(word) > 0 && unicode.Is(unicode.Thai, word[0]) != unicode.Is(unicode.Thai, r) {
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	return words
+}
+
+func IsThai(word string) bool {
+	for _, r := range word {
+		return unicode.Is(unicode.Thai, r)
+	}
+	return false
+}
+
+// Bigrams returns the overlapping pairs of characters of word, a single
+// character is returned as it is.
+func Bigrams(word string) []string {
+	runes := []rune(word)
+	if len(runes) < 2 {
+		return []string{word}
+	}
+
+	pairs := make([]string, 0, len(runes)-1)
+	for i := 0; i+1 < len(runes); i++ {
+		pairs = append(pairs, string(runes[i:i+2]))
+	}
+
+	return pairs
+}
+
+// SearchText prepares texts for the full-text index, Thai words are stored
+// as their bigrams so that any part of them can be found.
+func SearchText(texts ...string) string {
+	var terms []string
+	for _, text := range texts {
+		for _, word := range SplitWords(text) {
+			if IsThai(word) {
+				terms = append(terms, Bigrams(word)...)
+			} else {
+				terms = append(terms, word)
+			}
+		}
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// SearchQuery turns what a user typed into a tsquery matching every word.
+// Other words match as prefixes and Thai words match as their bigrams
+// following each other, which finds them inside longer Thai text too. It
+// returns an empty string when there is nothing to search for.
+func SearchQuery(text string) string {
+	var terms []string
+	for _, word := range SplitWords(text) {
+		if IsThai(word) && len([]rune(word)) > 1 {
+			terms = append(terms, "("+strings.Join(Bigrams(word), " <-> ")+")")
+		} else {
+			terms = append(terms, word+":*")
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
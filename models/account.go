@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	AccountCash       = "cash"
+	AccountBank       = "bank"
+	AccountCreditCard = "credit_card"
+	AccountEWallet    = "e_wallet"
+)
+
+// Account is where expenses are paid from: cash, a bank account, a credit
+// card or an e-wallet. Its balance starts at OpeningBalance on OpeningDate
+// and goes down with every expense paid from it since, a credit card's
+// balance is negative while money is owed.
+type Account struct {
+	ID             uint   `gorm:"primaryKey"`
+	Name           string `gorm:"uniqueIndex"`
+	Type           string
+	Currency       string `gorm:"size:3;not null;default:THB"`
+	OpeningBalance float64
+	OpeningDate    time.Time `gorm:"type:date;not null;default:CURRENT_DATE"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (a *Account) TableName() string {
+	return "accounts"
+}
@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+const (
+	DeliveryPending = "pending"
+	DeliverySent    = "sent"
+	DeliveryFailed  = "failed"
+)
+
+type Alert struct {
+	ID          uint `gorm:"primaryKey"`
+	BudgetID    uint `gorm:"uniqueIndex:idx_alerts_once"`
+	ExpenseID   uint
+	Tag         string
+	Period      string
+	PeriodStart time.Time `gorm:"type:date;uniqueIndex:idx_alerts_once"`
+	Threshold   int       `gorm:"uniqueIndex:idx_alerts_once"`
+	BudgetLimit float64
+	Spent       float64
+	PercentUsed float64
+	CreatedAt   time.Time
+	Deliveries  []AlertDelivery `gorm:"foreignKey:AlertID"`
+}
+
+func (a *Alert) TableName() string {
+	return "alerts"
+}
+
+type AlertWebhook struct {
+	ID        uint `gorm:"primaryKey"`
+	URL       string
+	CreatedAt time.Time
+}
+
+func (w *AlertWebhook) TableName() string {
+	return "alert_webhooks"
+}
+
+type AlertDelivery struct {
+	ID            uint `gorm:"primaryKey"`
+	AlertID       uint `gorm:"index"`
+	URL           string
+	Payload       string
+	Status        string `gorm:"index"`
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (d *AlertDelivery) TableName() string {
+	return "alert_deliveries"
+}
+
+type EmailOutbox struct {
+	ID            uint `gorm:"primaryKey"`
+	AlertID       uint `gorm:"index"`
+	Recipients    string
+	Subject       string
+	Body          string
+	Status        string `gorm:"index"`
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (e *EmailOutbox) TableName() string {
+	return "email_outbox"
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Anomaly flags a new expense that is far above the usual expenses of one of
+// its tags.
+type Anomaly struct {
+	ID        uint      `gorm:"primaryKey"`
+	ExpenseID uint      `gorm:"uniqueIndex:idx_anomalies_expense_tag"`
+	Tag       string    `gorm:"uniqueIndex:idx_anomalies_expense_tag"`
+	Date      time.Time `gorm:"type:date;index"`
+	Amount    float64
+	Currency  string `gorm:"size:3"`
+	Median    float64
+	Ratio     float64
+	Message   string
+	CreatedAt time.Time
+}
+
+func (a *Anomaly) TableName() string {
+	return "anomalies"
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Attachment is a file kept in blob storage under StorageKey, images also
+// get a JPEG thumbnail under ThumbnailKey.
+type Attachment struct {
+	ID           uint   `gorm:"primaryKey"`
+	ExpenseID    uint   `gorm:"not null;index"`
+	Filename     string `gorm:"not null"`
+	ContentType  string `gorm:"not null"`
+	Size         int64  `gorm:"not null"`
+	Checksum     string `gorm:"size:64;not null"`
+	StorageKey   string `gorm:"not null;uniqueIndex"`
+	ThumbnailKey string
+	CreatedAt    time.Time
+}
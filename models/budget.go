@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+const (
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+	PeriodYearly  = "yearly"
+)
+
+type Budget struct {
+	ID        uint `gorm:"primaryKey"`
+	Tag       string
+	Period    string
+	Amount    float64
+	Rollover  bool
+	StartDate time.Time `gorm:"type:date;not null;default:CURRENT_DATE"`
+}
+
+func (b *Budget) TableName() string {
+	return "budgets"
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Statuses of a claim, a draft is submitted and then approved and paid or
+// rejected.
+const (
+	ClaimDraft     = "draft"
+	ClaimSubmitted = "submitted"
+	ClaimApproved  = "approved"
+	ClaimRejected  = "rejected"
+	ClaimPaid      = "paid"
+)
+
+// Claim asks for the expenses a BasicAuth user paid for the company to be
+// reimbursed.
+type Claim struct {
+	ID        uint `gorm:"primaryKey"`
+	Title     string
+	Claimant  string    `gorm:"index"`
+	Status    string    `gorm:"index"`
+	Expenses  []Expense `gorm:"many2many:claim_expenses"`
+	Events    []ClaimEvent
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c *Claim) TableName() string {
+	return "claims"
+}
+
+// ClaimEvent records a change of status of a claim, by whom and why. The
+// creation of a claim is an event from no status to draft.
+type ClaimEvent struct {
+	ID        uint `gorm:"primaryKey"`
+	ClaimID   uint `gorm:"index"`
+	From      string
+	To        string
+	Actor     string
+	Comment   string
+	CreatedAt time.Time
+}
+
+func (e *ClaimEvent) TableName() string {
+	return "claim_events"
+}
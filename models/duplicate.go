@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	DuplicatePending   = "pending"
+	DuplicateDismissed = "dismissed"
+	DuplicateMerged    = "merged"
+)
+
+// DuplicateCandidate is a pair of expenses that look like the same spending,
+// ExpenseID is always the lower id of the two.
+type DuplicateCandidate struct {
+	ID          uint `gorm:"primaryKey"`
+	ExpenseID   uint `gorm:"uniqueIndex:idx_duplicate_candidates_pair"`
+	DuplicateID uint `gorm:"uniqueIndex:idx_duplicate_candidates_pair"`
+	Score       float64
+	Status      string `gorm:"index"`
+	CreatedAt   time.Time
+}
+
+func (d *DuplicateCandidate) TableName() string {
+	return "duplicate_candidates"
+}
+
+// ExpenseMerge records the expenses merged into ExpenseID and what the kept
+// expense looked like before.
+type ExpenseMerge struct {
+	ID         uint          `gorm:"primaryKey"`
+	ExpenseID  uint          `gorm:"index"`
+	MergedIDs  pq.Int64Array `gorm:"type:bigint[]"`
+	NoteBefore string
+	TagsBefore pq.StringArray `gorm:"type:text[]"`
+	CreatedAt  time.Time
+}
+
+func (m *ExpenseMerge) TableName() string {
+	return "expense_merges"
+}
@@ -1,13 +1,62 @@
 package models
 
-import "github.com/lib/pq"
+import (
+	"time"
 
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const (
+	TypeExpense  = "expense"
+	TypeIncome   = "income"
+	TypeTransfer = "transfer"
+	TypeRefund   = "refund"
+)
+
+// Spending is the SQL condition of the records counted as spending, and
+// SpentAmount what each adds to it: a refund takes its amount back.
+const (
+	Spending    = "expenses.type IN ('expense', 'refund')"
+	SpentAmount = "CASE WHEN expenses.type = 'refund' THEN -expenses.amount ELSE expenses.amount END"
+)
+
+// Expense is any money record, Type tells an expense from income, a
+// transfer between accounts or a refund.
 type Expense struct {
-	ID     uint `gorm:"primaryKey"`
-	Title  string
-	Amount float64
-	Note   string
-	Tags   pq.StringArray `gorm:"type:text[]"`
+	ID       uint   `gorm:"primaryKey"`
+	Type     string `gorm:"size:8;not null;default:expense;index"`
+	Title    string
+	Amount   float64
+	Note     string
+	Tags     pq.StringArray `gorm:"type:text[];index:idx_expenses_tags,type:gin"`
+	Date     time.Time      `gorm:"type:date;not null;default:CURRENT_DATE"`
+	Currency string         `gorm:"size:3;not null;default:THB"`
+	Category string
+	// MerchantID is the merchant the title was matched to, nil when none
+	// matched.
+	MerchantID *uint `gorm:"index"`
+	// AccountID is the account or payment method the expense was paid
+	// from, nil when unknown.
+	AccountID *uint `gorm:"index"`
+	// ToAccountID is the account a transfer goes to.
+	ToAccountID *uint `gorm:"index"`
+	// RefundOfID is the expense a refund gives money back for.
+	RefundOfID *uint `gorm:"index"`
+	// ReconciledAt is when the expense was confirmed against a bank
+	// statement, reconciled expenses cannot be changed.
+	ReconciledAt *time.Time
+	// GroupID is the group whose ledger holds the expense, nil for the
+	// personal ledger.
+	GroupID *uint `gorm:"index"`
+	// MergedIntoID is the expense a duplicate was merged into, merged
+	// expenses are soft deleted and kept for their history.
+	MergedIntoID *uint
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	// SearchText is the title and note prepared by helpers.SearchText,
+	// Postgres derives SearchVector from it.
+	SearchText   string
+	SearchVector string `gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(search_text, ''))) STORED;index:idx_expenses_search,type:gin"`
 }
 
 func (e *Expense) TableName() string {
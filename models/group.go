@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+const (
+	GroupHousehold = "household"
+	GroupTrip      = "trip"
+	GroupTeam      = "team"
+)
+
+// Roles of group members, each role can do everything the ones after it can.
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// Group owns a ledger of expenses shared by its members.
+type Group struct {
+	ID        uint `gorm:"primaryKey"`
+	Name      string
+	Kind      string
+	CreatedBy string
+	CreatedAt time.Time
+	Members   []GroupMember `gorm:"foreignKey:GroupID"`
+}
+
+func (g *Group) TableName() string {
+	return "groups"
+}
+
+// GroupMember gives a BasicAuth account a role in a group.
+type GroupMember struct {
+	ID       uint   `gorm:"primaryKey"`
+	GroupID  uint   `gorm:"uniqueIndex:idx_group_members_group_username"`
+	Username string `gorm:"uniqueIndex:idx_group_members_group_username;index"`
+	Role     string
+	JoinedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (m *GroupMember) TableName() string {
+	return "group_members"
+}
+
+// GroupInvitation lets whoever holds the token join the group once, only
+// the SHA-256 of the token is stored.
+type GroupInvitation struct {
+	ID         uint   `gorm:"primaryKey"`
+	GroupID    uint   `gorm:"index"`
+	TokenHash  string `gorm:"size:64;uniqueIndex"`
+	Role       string
+	CreatedBy  string
+	ExpiresAt  time.Time
+	AcceptedBy string
+	AcceptedAt *time.Time
+	CreatedAt  time.Time
+}
+
+func (i *GroupInvitation) TableName() string {
+	return "group_invitations"
+}
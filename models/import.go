@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	ImportPending    = "pending"
+	ImportCommitting = "committing"
+	ImportCommitted  = "committed"
+)
+
+type Import struct {
+	ID            uint `gorm:"primaryKey"`
+	Filename      string
+	Format        string
+	Delimiter     string
+	DateFormat    string
+	Tags          pq.StringArray `gorm:"type:text[]"`
+	AccountID     *uint
+	Status        string
+	TotalRows     int
+	ValidRows     int
+	DuplicateRows int
+	ImportedRows  int
+	CreatedAt     time.Time
+	CommittedAt   *time.Time
+	Rows          []ImportRow `gorm:"foreignKey:ImportID"`
+}
+
+func (i *Import) TableName() string {
+	return "imports"
+}
+
+type ImportRow struct {
+	ID          uint `gorm:"primaryKey"`
+	ImportID    uint `gorm:"index"`
+	Line        int
+	Date        *time.Time `gorm:"type:date"`
+	Amount      float64
+	Description string
+	Currency    string `gorm:"size:3"`
+	ExternalID  string
+	Error       string
+	Duplicate   bool
+	ExpenseID   *uint
+}
+
+func (r *ImportRow) TableName() string {
+	return "import_rows"
+}
+
+// ImportedTransaction remembers every statement line turned into an expense
+// so that importing an overlapping statement again skips it.
+type ImportedTransaction struct {
+	ID         uint   `gorm:"primaryKey"`
+	ExternalID string `gorm:"uniqueIndex"`
+	ImportID   uint
+	ExpenseID  uint
+	CreatedAt  time.Time
+}
+
+func (t *ImportedTransaction) TableName() string {
+	return "imported_transactions"
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Merchant is a shop that expenses are linked to whatever their titles
+// call it.
+type Merchant struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"uniqueIndex"`
+	Category  string
+	Aliases   []MerchantAlias
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (m *Merchant) TableName() string {
+	return "merchants"
+}
+
+// MerchantAlias is another name of a merchant, Key is the alias as compared
+// with titles, in lower case words.
+type MerchantAlias struct {
+	ID         uint `gorm:"primaryKey"`
+	MerchantID uint `gorm:"index"`
+	Alias      string
+	Key        string `gorm:"uniqueIndex"`
+}
+
+func (a *MerchantAlias) TableName() string {
+	return "merchant_aliases"
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Kinds of policies.
+const (
+	PolicyMaxAmount = "max_amount"
+	PolicyWeekend   = "weekend"
+	PolicyReceipt   = "receipt"
+	PolicyMerchant  = "merchant"
+)
+
+// Actions of a violated policy, a warning is returned with the expense while
+// a block stops it from being stored.
+const (
+	PolicyWarn  = "warn"
+	PolicyBlock = "block"
+)
+
+// Policy is a spending rule of the company checked on every expense that has
+// Tag, or on every expense without one. Amount is the limit of a max_amount
+// policy and the amount above which a receipt policy wants a receipt, both in
+// Currency. MerchantID is the forbidden merchant of a merchant policy.
+type Policy struct {
+	ID         uint   `gorm:"primaryKey"`
+	Code       string `gorm:"uniqueIndex"`
+	Name       string
+	Kind       string
+	Action     string
+	Tag        string
+	Amount     float64
+	Currency   string `gorm:"size:3"`
+	MerchantID *uint  `gorm:"index"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (p *Policy) TableName() string {
+	return "policies"
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+const (
+	MatchSuggested = "suggested"
+	MatchConfirmed = "confirmed"
+	MatchRejected  = "rejected"
+)
+
+// Reconciliation compares the lines of an imported statement with the
+// expenses entered by hand over the same days.
+type Reconciliation struct {
+	ID       uint `gorm:"primaryKey"`
+	ImportID uint `gorm:"uniqueIndex"`
+	// Days is how far apart the dates of a line and its expense may be.
+	Days      int
+	From      time.Time `gorm:"type:date"`
+	To        time.Time `gorm:"type:date"`
+	Matches   []ReconciliationMatch
+	CreatedAt time.Time
+}
+
+func (r *Reconciliation) TableName() string {
+	return "reconciliations"
+}
+
+// ReconciliationMatch pairs a statement line with the expense it probably
+// is, Score from 0.5 to 1 tells how sure the match is.
+type ReconciliationMatch struct {
+	ID               uint `gorm:"primaryKey"`
+	ReconciliationID uint `gorm:"index"`
+	ImportRowID      uint
+	ExpenseID        uint `gorm:"index"`
+	Score            float64
+	Status           string
+	UpdatedAt        time.Time
+}
+
+func (m *ReconciliationMatch) TableName() string {
+	return "reconciliation_matches"
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+// RecurringExpense is a template materialized into expenses on its schedule.
+// Weekly schedules run on Weekday, monthly ones on DayOfMonth (-1 for the
+// last day, clamped to short months) or on the last business day.
+type RecurringExpense struct {
+	ID              uint `gorm:"primaryKey"`
+	Title           string
+	Amount          float64
+	Note            string
+	Tags            pq.StringArray `gorm:"type:text[]"`
+	Frequency       string
+	Interval        int
+	Weekday         *int
+	DayOfMonth      int
+	LastBusinessDay bool
+	StartDate       time.Time  `gorm:"type:date;not null"`
+	EndDate         *time.Time `gorm:"type:date"`
+	NextRunDate     time.Time  `gorm:"type:date;not null;index"`
+	Paused          bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (r *RecurringExpense) TableName() string {
+	return "recurring_expenses"
+}
+
+type RecurringOccurrence struct {
+	ID                 uint      `gorm:"primaryKey"`
+	RecurringExpenseID uint      `gorm:"uniqueIndex:idx_recurring_occurrences_once"`
+	Date               time.Time `gorm:"type:date;uniqueIndex:idx_recurring_occurrences_once"`
+	// ExpenseID is 0 when the occurrence was skipped because its expense
+	// was rejected.
+	ExpenseID uint
+	CreatedAt time.Time
+}
+
+func (o *RecurringOccurrence) TableName() string {
+	return "recurring_occurrences"
+}
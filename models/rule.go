@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Rule changes new expenses matching all of its conditions, rules run by
+// ascending Priority and the first rule setting a field wins.
+type Rule struct {
+	ID             uint `gorm:"primaryKey"`
+	Name           string
+	Priority       int
+	Disabled       bool
+	StopProcessing bool
+	// conditions, empty ones match everything
+	TitlePattern string
+	NotePattern  string
+	MinAmount    *float64
+	MaxAmount    *float64
+	Merchant     string
+	Currency     string `gorm:"size:3"`
+	// actions
+	AddTags     pq.StringArray `gorm:"type:text[]"`
+	SetCategory string
+	SetNote     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (r *Rule) TableName() string {
+	return "rules"
+}
+
+const (
+	RuleRunPending   = "pending"
+	RuleRunRunning   = "running"
+	RuleRunCompleted = "completed"
+	RuleRunFailed    = "failed"
+)
+
+// RuleRun is a job applying the enabled rules to every existing expense.
+type RuleRun struct {
+	ID         uint `gorm:"primaryKey"`
+	Status     string
+	Scanned    int
+	Changed    int
+	Error      string
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+func (r *RuleRun) TableName() string {
+	return "rule_runs"
+}
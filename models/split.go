@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+const (
+	SplitEqual      = "equal"
+	SplitExact      = "exact"
+	SplitPercentage = "percentage"
+)
+
+// ExpenseSplit records who paid an expense and the share every participant
+// owes for it, amounts follow the amount of the expense.
+type ExpenseSplit struct {
+	ID        uint `gorm:"primaryKey"`
+	ExpenseID uint `gorm:"uniqueIndex"`
+	PaidBy    string
+	Method    string
+	Amount    float64
+	Currency  string         `gorm:"size:3;not null;default:THB"`
+	Shares    []ExpenseShare `gorm:"foreignKey:SplitID;constraint:OnDelete:CASCADE"`
+}
+
+func (s *ExpenseSplit) TableName() string {
+	return "expense_splits"
+}
+
+type ExpenseShare struct {
+	ID          uint `gorm:"primaryKey"`
+	SplitID     uint `gorm:"index"`
+	Participant string
+	Percentage  float64
+	Amount      float64
+}
+
+func (s *ExpenseShare) TableName() string {
+	return "expense_shares"
+}
+
+// Settlement is a payment from Payer to Payee that pays back what Payer owes.
+type Settlement struct {
+	ID        uint `gorm:"primaryKey"`
+	Payer     string
+	Payee     string
+	Amount    float64
+	Currency  string    `gorm:"size:3;not null;default:THB"`
+	Date      time.Time `gorm:"type:date;not null;default:CURRENT_DATE"`
+	Note      string
+	CreatedAt time.Time
+}
+
+func (s *Settlement) TableName() string {
+	return "settlements"
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Tag holds the display metadata of a tag, the tags themselves live on the
+// expenses. A "/" in the name makes it the child of the part before it.
+type Tag struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"uniqueIndex"`
+	Color     string `gorm:"size:7"`
+	Icon      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (t *Tag) TableName() string {
+	return "tags"
+}
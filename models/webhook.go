@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// Events of the expense lifecycle delivered to webhook subscriptions.
+const (
+	EventExpenseCreated = "expense.created"
+	EventExpenseUpdated = "expense.updated"
+	EventExpenseDeleted = "expense.deleted"
+)
+
+// DeliveryDead is the status of a webhook delivery that failed every
+// attempt, it stays in the log until it is replayed.
+const DeliveryDead = "dead"
+
+// ExpenseEvent is the outbox of expense changes, it is written in the
+// transaction of the change and fanned out to the subscriptions later.
+// Payload is the expense as returned by the API.
+type ExpenseEvent struct {
+	ID           uint   `gorm:"primaryKey"`
+	Event        string `gorm:"size:32"`
+	ExpenseID    uint   `gorm:"index"`
+	Payload      string
+	CreatedAt    time.Time
+	DispatchedAt *time.Time `gorm:"index"`
+}
+
+func (e *ExpenseEvent) TableName() string {
+	return "expense_events"
+}
+
+// WebhookSubscription receives the Events, or every event when it has
+// none, signed with Secret. Deleted subscriptions are soft deleted so their
+// delivery log is kept.
+type WebhookSubscription struct {
+	ID        uint `gorm:"primaryKey"`
+	URL       string
+	Events    pq.StringArray `gorm:"type:text[]"`
+	Secret    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (s *WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookDelivery sends one event to one subscription. ReplayOfID is the
+// delivery it replays.
+type WebhookDelivery struct {
+	ID             uint   `gorm:"primaryKey"`
+	SubscriptionID uint   `gorm:"index"`
+	EventID        uint   `gorm:"index"`
+	Event          string `gorm:"size:32"`
+	Status         string `gorm:"index"`
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+	ReplayOfID     *uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (d *WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
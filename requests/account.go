@@ -0,0 +1,16 @@
+package requests
+
+import "time"
+
+type AccountRequest struct {
+	Name           string    `json:"name" binding:"required"`
+	Type           string    `json:"type" binding:"required,oneof=cash bank credit_card e_wallet"`
+	Currency       string    `json:"currency" binding:"omitempty,len=3,uppercase"`
+	OpeningBalance float64   `json:"opening_balance"`
+	OpeningDate    time.Time `json:"opening_date"`
+}
+
+type AccountLedgerQuery struct {
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02"`
+}
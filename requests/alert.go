@@ -0,0 +1,10 @@
+package requests
+
+type AlertQuery struct {
+	BudgetID uint `form:"budget_id"`
+	Limit    int  `form:"limit" binding:"omitempty,min=1,max=500"`
+}
+
+type AlertWebhookRequest struct {
+	URL string `json:"url" binding:"required,url"`
+}
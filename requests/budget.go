@@ -0,0 +1,15 @@
+package requests
+
+import "time"
+
+type BudgetRequest struct {
+	Tag       string    `json:"tag"`
+	Period    string    `json:"period" binding:"required,oneof=weekly monthly yearly"`
+	Amount    float64   `json:"amount" binding:"required,gt=0"`
+	Rollover  bool      `json:"rollover"`
+	StartDate time.Time `json:"start_date"`
+}
+
+type BudgetStatusQuery struct {
+	Date time.Time `form:"date" time_format:"2006-01-02"`
+}
@@ -0,0 +1,19 @@
+package requests
+
+type ClaimRequest struct {
+	Title      string `json:"title" binding:"required"`
+	ExpenseIDs []uint `json:"expense_ids" binding:"required,min=1"`
+}
+
+type ClaimTransitionRequest struct {
+	Comment string `json:"comment"`
+}
+
+type ClaimQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft submitted approved rejected paid"`
+}
+
+type ClaimEventQuery struct {
+	After uint `form:"after"`
+	Limit int  `form:"limit" binding:"omitempty,min=1,max=100"`
+}
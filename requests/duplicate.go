@@ -0,0 +1,10 @@
+package requests
+
+type MergeExpensesRequest struct {
+	KeepID   uint   `json:"keep_id" binding:"required"`
+	MergeIDs []uint `json:"merge_ids" binding:"required,min=1,dive,required"`
+}
+
+type DismissDuplicatesRequest struct {
+	ExpenseIDs []uint `json:"expense_ids" binding:"required,min=2,dive,required"`
+}
@@ -1,10 +1,63 @@
 package requests
 
-import "github.com/lib/pq"
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
 
 type ExpenseRequest struct {
-	Title  string         `json:"title" binding:"required"`
-	Amount float64        `json:"amount" binding:"required"`
-	Note   string         `json:"note" binding:"required"`
-	Tags   pq.StringArray `json:"tags" binding:"required"`
+	Type        string         `json:"type" binding:"omitempty,oneof=expense income transfer refund"`
+	Title       string         `json:"title" binding:"required"`
+	Amount      float64        `json:"amount" binding:"required"`
+	Note        string         `json:"note" binding:"required"`
+	Tags        pq.StringArray `json:"tags" binding:"required"`
+	Date        time.Time      `json:"date"`
+	Currency    string         `json:"currency" binding:"omitempty,len=3,uppercase"`
+	Category    string         `json:"category"`
+	AccountID   *uint          `json:"account_id"`
+	ToAccountID *uint          `json:"to_account_id"`
+	RefundOfID  *uint          `json:"refund_of_id"`
+}
+
+// ExpenseQuery filters the expense listing and its exports.
+type ExpenseQuery struct {
+	From      time.Time `form:"from" time_format:"2006-01-02"`
+	To        time.Time `form:"to" time_format:"2006-01-02"`
+	Tags      []string  `form:"tags"`
+	AccountID uint      `form:"account_id"`
+	Type      string    `form:"type" binding:"omitempty,oneof=expense income transfer refund"`
+}
+
+type SearchQuery struct {
+	ExpenseQuery
+	Q     string `form:"q" binding:"required"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type ExportQuery struct {
+	ExpenseQuery
+	Format string `form:"format" binding:"required,oneof=csv jsonl xlsx ofx"`
+	Locale string `form:"locale"`
+}
+
+type SummaryQuery struct {
+	GroupBy string    `form:"group_by"`
+	ByTag   bool      `form:"by_tag"`
+	Rollup  bool      `form:"rollup"`
+	From    time.Time `form:"from" time_format:"2006-01-02"`
+	To      time.Time `form:"to" time_format:"2006-01-02"`
+	Tags    []string  `form:"tags"`
+	// AccountID limits the summary to an account, ByAccount breaks it
+	// down by account.
+	AccountID uint `form:"account_id"`
+	ByAccount bool `form:"by_account"`
+}
+
+// CashFlowQuery asks for income, spending and what was saved per period.
+type CashFlowQuery struct {
+	GroupBy   string    `form:"group_by" binding:"omitempty,oneof=week month year"`
+	From      time.Time `form:"from" time_format:"2006-01-02"`
+	To        time.Time `form:"to" time_format:"2006-01-02"`
+	AccountID uint      `form:"account_id"`
 }
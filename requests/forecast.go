@@ -0,0 +1,8 @@
+package requests
+
+import "time"
+
+type ForecastQuery struct {
+	Period string    `form:"period" binding:"omitempty,oneof=monthly yearly"`
+	Date   time.Time `form:"date" time_format:"2006-01-02"`
+}
@@ -0,0 +1,16 @@
+package requests
+
+type GroupRequest struct {
+	Name string `json:"name" binding:"required"`
+	Kind string `json:"kind" binding:"required,oneof=household trip team"`
+}
+
+type InvitationRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner editor viewer"`
+	// ExpiresInHours defaults to a week.
+	ExpiresInHours int `json:"expires_in_hours" binding:"gte=0,lte=720"`
+}
+
+type MemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
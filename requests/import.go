@@ -0,0 +1,18 @@
+package requests
+
+import "github.com/lib/pq"
+
+type ImportRequest struct {
+	Format            string         `form:"format" binding:"omitempty,oneof=csv ofx qif camt053"`
+	Delimiter         string         `form:"delimiter" binding:"omitempty,oneof=comma semicolon tab pipe"`
+	DateFormat        string         `form:"date_format"`
+	DateColumn        string         `form:"date_column"`
+	AmountColumn      string         `form:"amount_column"`
+	DescriptionColumn string         `form:"description_column"`
+	CurrencyColumn    string         `form:"currency_column"`
+	NoHeader          bool           `form:"no_header"`
+	DebitAmounts      bool           `form:"debit_amounts"`
+	Currency          string         `form:"currency" binding:"omitempty,len=3,uppercase"`
+	Tags              pq.StringArray `form:"tags"`
+	AccountID         *uint          `form:"account_id"`
+}
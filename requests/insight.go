@@ -0,0 +1,5 @@
+package requests
+
+type InsightQuery struct {
+	Month string `form:"month"`
+}
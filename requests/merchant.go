@@ -0,0 +1,18 @@
+package requests
+
+import "time"
+
+type MerchantRequest struct {
+	Name     string   `json:"name" binding:"required"`
+	Category string   `json:"category"`
+	Aliases  []string `json:"aliases"`
+}
+
+type MerchantAliasRequest struct {
+	Alias string `json:"alias" binding:"required"`
+}
+
+type MerchantStatsQuery struct {
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02"`
+}
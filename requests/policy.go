@@ -0,0 +1,22 @@
+package requests
+
+import "time"
+
+type PolicyRequest struct {
+	Code       string  `json:"code" binding:"required,max=32"`
+	Name       string  `json:"name" binding:"required"`
+	Kind       string  `json:"kind" binding:"required,oneof=max_amount weekend receipt merchant"`
+	Action     string  `json:"action" binding:"required,oneof=warn block"`
+	Tag        string  `json:"tag"`
+	Amount     float64 `json:"amount" binding:"omitempty,gt=0"`
+	Currency   string  `json:"currency" binding:"omitempty,len=3,uppercase"`
+	MerchantID *uint   `json:"merchant_id"`
+}
+
+// PolicyViolationQuery filters the expenses checked for violations by date
+// and the violations by policy code.
+type PolicyViolationQuery struct {
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02"`
+	Code string    `form:"code"`
+}
@@ -0,0 +1,6 @@
+package requests
+
+type ReconciliationRequest struct {
+	ImportID uint `json:"import_id" binding:"required"`
+	Days     *int `json:"days" binding:"omitempty,min=0,max=14"`
+}
@@ -0,0 +1,25 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type RecurringExpenseRequest struct {
+	Title           string         `json:"title" binding:"required"`
+	Amount          float64        `json:"amount" binding:"required"`
+	Note            string         `json:"note"`
+	Tags            pq.StringArray `json:"tags"`
+	Frequency       string         `json:"frequency" binding:"required,oneof=daily weekly monthly"`
+	Interval        int            `json:"interval" binding:"omitempty,min=1"`
+	Weekday         *int           `json:"weekday" binding:"omitempty,min=0,max=6"`
+	DayOfMonth      int            `json:"day_of_month" binding:"omitempty,min=-1,max=31"`
+	LastBusinessDay bool           `json:"last_business_day"`
+	StartDate       time.Time      `json:"start_date"`
+	EndDate         *time.Time     `json:"end_date"`
+}
+
+type RecurringPreviewQuery struct {
+	Count int `form:"count" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,7 @@
+package requests
+
+type StatementQuery struct {
+	Month    string `form:"month" binding:"required"`
+	Format   string `form:"format" binding:"omitempty,oneof=pdf json"`
+	Currency string `form:"currency" binding:"omitempty,len=3,uppercase"`
+}
@@ -0,0 +1,19 @@
+package requests
+
+import "github.com/lib/pq"
+
+type RuleRequest struct {
+	Name           string         `json:"name" binding:"required"`
+	Priority       int            `json:"priority"`
+	Disabled       bool           `json:"disabled"`
+	StopProcessing bool           `json:"stop_processing"`
+	TitlePattern   string         `json:"title_pattern"`
+	NotePattern    string         `json:"note_pattern"`
+	MinAmount      *float64       `json:"min_amount"`
+	MaxAmount      *float64       `json:"max_amount"`
+	Merchant       string         `json:"merchant"`
+	Currency       string         `json:"currency" binding:"omitempty,len=3,uppercase"`
+	AddTags        pq.StringArray `json:"add_tags"`
+	SetCategory    string         `json:"set_category"`
+	SetNote        string         `json:"set_note"`
+}
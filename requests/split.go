@@ -0,0 +1,30 @@
+package requests
+
+import "time"
+
+type SplitRequest struct {
+	PaidBy string         `json:"paid_by" binding:"required"`
+	Method string         `json:"method" binding:"required,oneof=equal exact percentage"`
+	Shares []ShareRequest `json:"shares" binding:"required,min=1,dive"`
+}
+
+// ShareRequest names a participant, Amount is read for exact splits and
+// Percentage for percentage splits.
+type ShareRequest struct {
+	Participant string  `json:"participant" binding:"required"`
+	Amount      float64 `json:"amount"`
+	Percentage  float64 `json:"percentage" binding:"gte=0,lte=100"`
+}
+
+type SettlementRequest struct {
+	Payer    string    `json:"payer" binding:"required"`
+	Payee    string    `json:"payee" binding:"required,nefield=Payer"`
+	Amount   float64   `json:"amount" binding:"required,gt=0"`
+	Currency string    `json:"currency" binding:"omitempty,len=3,uppercase"`
+	Date     time.Time `json:"date"`
+	Note     string    `json:"note"`
+}
+
+type BalanceQuery struct {
+	Currency string `form:"currency" binding:"omitempty,len=3,uppercase"`
+}
@@ -0,0 +1,23 @@
+package requests
+
+type TagRequest struct {
+	Name  string `json:"name" binding:"required"`
+	Color string `json:"color" binding:"omitempty,hexcolor"`
+	Icon  string `json:"icon" binding:"max=64"`
+}
+
+type RenameTagRequest struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required,nefield=From"`
+}
+
+type MergeTagsRequest struct {
+	From []string `json:"from" binding:"required,min=1,dive,required"`
+	To   string   `json:"to" binding:"required"`
+}
+
+type SuggestTagsQuery struct {
+	Title string `form:"title" binding:"required"`
+	Note  string `form:"note"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=20"`
+}
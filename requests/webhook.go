@@ -0,0 +1,14 @@
+package requests
+
+type WebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url"`
+	Events []string `json:"events" binding:"dive,oneof=expense.created expense.updated expense.deleted"`
+	// Secret signs the payloads, one is generated when a new subscription
+	// has none and an update without one keeps it.
+	Secret string `json:"secret" binding:"omitempty,min=16"`
+}
+
+type WebhookDeliveryQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending sent dead"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=500"`
+}
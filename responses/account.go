@@ -0,0 +1,29 @@
+package responses
+
+import "time"
+
+type AccountResponse struct {
+	ID             uint      `json:"id"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	Currency       string    `json:"currency"`
+	OpeningBalance float64   `json:"opening_balance"`
+	OpeningDate    time.Time `json:"opening_date"`
+	Spent          float64   `json:"spent"`
+	Balance        float64   `json:"balance"`
+}
+
+// AccountEntry is a record of an account with what it changed the balance
+// by and the balance after it.
+type AccountEntry struct {
+	ExpenseResponse
+	Change  float64 `json:"change"`
+	Balance float64 `json:"balance"`
+}
+
+type AccountLedgerResponse struct {
+	Account        AccountResponse `json:"account"`
+	OpeningBalance float64         `json:"opening_balance"`
+	ClosingBalance float64         `json:"closing_balance"`
+	Entries        []AccountEntry  `json:"entries"`
+}
@@ -0,0 +1,46 @@
+package responses
+
+import "time"
+
+type AlertDeliveryResponse struct {
+	ID            uint      `json:"id"`
+	URL           string    `json:"url"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+type AlertResponse struct {
+	ID          uint                    `json:"id"`
+	BudgetID    uint                    `json:"budget_id"`
+	ExpenseID   uint                    `json:"expense_id"`
+	Tag         string                  `json:"tag"`
+	Period      string                  `json:"period"`
+	PeriodStart time.Time               `json:"period_start"`
+	Threshold   int                     `json:"threshold"`
+	BudgetLimit float64                 `json:"limit"`
+	Spent       float64                 `json:"spent"`
+	PercentUsed float64                 `json:"percent_used"`
+	CreatedAt   time.Time               `json:"created_at"`
+	Deliveries  []AlertDeliveryResponse `json:"deliveries,omitempty"`
+}
+
+type AlertWebhookResponse struct {
+	ID        uint      `json:"id"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type AlertEvent struct {
+	Event       string    `json:"event"`
+	BudgetID    uint      `json:"budget_id"`
+	ExpenseID   uint      `json:"expense_id"`
+	Tag         string    `json:"tag"`
+	Period      string    `json:"period"`
+	PeriodStart time.Time `json:"period_start"`
+	Threshold   int       `json:"threshold"`
+	Limit       float64   `json:"limit"`
+	Spent       float64   `json:"spent"`
+	PercentUsed float64   `json:"percent_used"`
+}
@@ -0,0 +1,14 @@
+package responses
+
+import "time"
+
+type AttachmentResponse struct {
+	ID           uint      `json:"id"`
+	ExpenseID    uint      `json:"expense_id"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Checksum     string    `json:"checksum"`
+	HasThumbnail bool      `json:"has_thumbnail"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,29 @@
+package responses
+
+import "time"
+
+type BudgetResponse struct {
+	ID        uint      `json:"id"`
+	Tag       string    `json:"tag"`
+	Period    string    `json:"period"`
+	Amount    float64   `json:"amount"`
+	Rollover  bool      `json:"rollover"`
+	StartDate time.Time `json:"start_date"`
+}
+
+type BudgetStatusResponse struct {
+	BudgetID           uint      `json:"budget_id"`
+	Tag                string    `json:"tag"`
+	Period             string    `json:"period"`
+	PeriodStart        time.Time `json:"period_start"`
+	PeriodEnd          time.Time `json:"period_end"`
+	Limit              float64   `json:"limit"`
+	RolloverAmount     float64   `json:"rollover_amount"`
+	Available          float64   `json:"available"`
+	Spent              float64   `json:"spent"`
+	Remaining          float64   `json:"remaining"`
+	PercentUsed        float64   `json:"percent_used"`
+	Projected          float64   `json:"projected"`
+	Overspent          bool      `json:"overspent"`
+	ProjectedOverspend bool      `json:"projected_overspend"`
+}
@@ -0,0 +1,26 @@
+package responses
+
+import "time"
+
+type ClaimEventResponse struct {
+	ID        uint      `json:"id"`
+	ClaimID   uint      `json:"claim_id"`
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to"`
+	Actor     string    `json:"actor"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ClaimResponse struct {
+	ID        uint                 `json:"id"`
+	Title     string               `json:"title"`
+	Claimant  string               `json:"claimant"`
+	Status    string               `json:"status"`
+	Currency  string               `json:"currency"`
+	Total     float64              `json:"total"`
+	Expenses  []ExpenseResponse    `json:"expenses"`
+	Events    []ClaimEventResponse `json:"events,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
@@ -0,0 +1,13 @@
+package responses
+
+// DuplicateCluster holds expenses that look like the same spending, Score is
+// the similarity of the closest pair from 0 to 1.
+type DuplicateCluster struct {
+	Score    float64           `json:"score"`
+	Expenses []ExpenseResponse `json:"expenses"`
+}
+
+type MergeResponse struct {
+	Expense   ExpenseResponse `json:"expense"`
+	MergedIDs []uint          `json:"merged_ids"`
+}
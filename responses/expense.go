@@ -1,11 +1,82 @@
 package responses
 
-import "github.com/lib/pq"
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
 
 type ExpenseResponse struct {
-	ID     uint           `json:"id"`
-	Title  string         `json:"title"`
-	Amount float64        `json:"amount"`
-	Note   string         `json:"note"`
-	Tags   pq.StringArray `json:"tags"`
+	ID           uint           `json:"id"`
+	Type         string         `json:"type"`
+	Title        string         `json:"title"`
+	Amount       float64        `json:"amount"`
+	Note         string         `json:"note"`
+	Tags         pq.StringArray `json:"tags"`
+	Date         time.Time      `json:"date"`
+	Currency     string         `json:"currency"`
+	Category     string         `json:"category,omitempty"`
+	MerchantID   *uint          `json:"merchant_id,omitempty"`
+	AccountID    *uint          `json:"account_id,omitempty"`
+	ToAccountID  *uint          `json:"to_account_id,omitempty"`
+	RefundOfID   *uint          `json:"refund_of_id,omitempty"`
+	ReconciledAt *time.Time     `json:"reconciled_at,omitempty"`
+	GroupID      *uint          `json:"group_id,omitempty"`
+	// PolicyWarnings are the policies the expense violates that only warn,
+	// set when it is created or changed.
+	PolicyWarnings []PolicyViolation `json:"policy_warnings,omitempty"`
+}
+
+// SearchResultResponse is an expense found by a search with its title and a
+// snippet of its note, the matches are HTML escaped and wrapped in <mark>.
+type SearchResultResponse struct {
+	ExpenseResponse
+	Rank      float64         `json:"rank"`
+	Highlight SearchHighlight `json:"highlight"`
+}
+
+type SearchHighlight struct {
+	Title string `json:"title"`
+	Note  string `json:"note"`
+}
+
+type SummaryStats struct {
+	Total   float64 `json:"total"`
+	Count   int64   `json:"count"`
+	Average float64 `json:"average"`
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+}
+
+type SummaryGroup struct {
+	Period    string `json:"period,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+	AccountID *uint  `json:"account_id,omitempty"`
+	SummaryStats
+}
+
+type SummaryResponse struct {
+	GroupBy   string         `json:"group_by,omitempty"`
+	ByTag     bool           `json:"by_tag"`
+	ByAccount bool           `json:"by_account,omitempty"`
+	Overall   SummaryStats   `json:"overall"`
+	Groups    []SummaryGroup `json:"groups"`
+}
+
+// CashFlow is the money that came in and went out in a currency, Expenses
+// are net of refunds and transfers are left out. SavingsRate is the share
+// of income saved, nil without income.
+type CashFlow struct {
+	Period      string   `json:"period,omitempty"`
+	Currency    string   `json:"currency"`
+	Income      float64  `json:"income"`
+	Expenses    float64  `json:"expenses"`
+	Net         float64  `json:"net"`
+	SavingsRate *float64 `json:"savings_rate"`
+}
+
+type CashFlowResponse struct {
+	GroupBy string     `json:"group_by"`
+	Periods []CashFlow `json:"periods"`
+	Totals  []CashFlow `json:"totals"`
 }
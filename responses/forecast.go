@@ -0,0 +1,24 @@
+package responses
+
+import "time"
+
+// Forecast projects the spending of a tag in a currency at the end of the
+// period, an empty Tag stands for all spending. Low and High bound the
+// projection with 80% confidence.
+type Forecast struct {
+	Tag          string  `json:"tag"`
+	Currency     string  `json:"currency"`
+	Spent        float64 `json:"spent"`
+	DailyAverage float64 `json:"daily_average"`
+	Projected    float64 `json:"projected"`
+	Low          float64 `json:"low"`
+	High         float64 `json:"high"`
+}
+
+type ForecastResponse struct {
+	Period      string     `json:"period"`
+	PeriodStart time.Time  `json:"period_start"`
+	PeriodEnd   time.Time  `json:"period_end"`
+	Date        time.Time  `json:"date"`
+	Forecasts   []Forecast `json:"forecasts"`
+}
@@ -0,0 +1,27 @@
+package responses
+
+import "time"
+
+type GroupResponse struct {
+	ID        uint             `json:"id"`
+	Name      string           `json:"name"`
+	Kind      string           `json:"kind"`
+	CreatedBy string           `json:"created_by"`
+	CreatedAt time.Time        `json:"created_at"`
+	Members   []MemberResponse `json:"members"`
+}
+
+type MemberResponse struct {
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// InvitationResponse carries the token, it cannot be read again later.
+type InvitationResponse struct {
+	ID        uint      `json:"id"`
+	GroupID   uint      `json:"group_id"`
+	Role      string    `json:"role"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
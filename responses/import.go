@@ -0,0 +1,36 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ImportRowResponse struct {
+	Line        int        `json:"line"`
+	Date        *time.Time `json:"date,omitempty"`
+	Amount      float64    `json:"amount"`
+	Description string     `json:"description"`
+	Currency    string     `json:"currency"`
+	Error       string     `json:"error,omitempty"`
+	Duplicate   bool       `json:"duplicate"`
+	ExpenseID   *uint      `json:"expense_id,omitempty"`
+}
+
+type ImportResponse struct {
+	ID            uint                `json:"id"`
+	Filename      string              `json:"filename"`
+	Format        string              `json:"format"`
+	Delimiter     string              `json:"delimiter,omitempty"`
+	DateFormat    string              `json:"date_format,omitempty"`
+	Tags          pq.StringArray      `json:"tags"`
+	AccountID     *uint               `json:"account_id,omitempty"`
+	Status        string              `json:"status"`
+	TotalRows     int                 `json:"total_rows"`
+	ValidRows     int                 `json:"valid_rows"`
+	DuplicateRows int                 `json:"duplicate_rows"`
+	ImportedRows  int                 `json:"imported_rows"`
+	CreatedAt     time.Time           `json:"created_at"`
+	CommittedAt   *time.Time          `json:"committed_at,omitempty"`
+	Rows          []ImportRowResponse `json:"rows,omitempty"`
+}
@@ -0,0 +1,30 @@
+package responses
+
+// Baseline describes the usual monthly spending of a tag in a currency, an
+// empty Tag stands for all spending.
+type Baseline struct {
+	Tag         string  `json:"tag"`
+	Currency    string  `json:"currency"`
+	Months      int     `json:"months"`
+	Mean        float64 `json:"mean"`
+	Median      float64 `json:"median"`
+	StdDev      float64 `json:"std_dev"`
+	MonthToDate float64 `json:"month_to_date"`
+}
+
+type Insight struct {
+	Kind      string  `json:"kind"`
+	Tag       string  `json:"tag"`
+	Currency  string  `json:"currency"`
+	Amount    float64 `json:"amount"`
+	Usual     float64 `json:"usual"`
+	Ratio     float64 `json:"ratio"`
+	ExpenseID *uint   `json:"expense_id,omitempty"`
+	Message   string  `json:"message"`
+}
+
+type InsightsResponse struct {
+	Month     string     `json:"month"`
+	Insights  []Insight  `json:"insights"`
+	Baselines []Baseline `json:"baselines"`
+}
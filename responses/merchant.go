@@ -0,0 +1,33 @@
+package responses
+
+import "time"
+
+type MerchantResponse struct {
+	ID       uint                    `json:"id"`
+	Name     string                  `json:"name"`
+	Category string                  `json:"category,omitempty"`
+	Aliases  []MerchantAliasResponse `json:"aliases"`
+}
+
+type MerchantAliasResponse struct {
+	ID    uint   `json:"id"`
+	Alias string `json:"alias"`
+}
+
+// MerchantStatsResponse sums the personal expenses at a merchant in a
+// currency, every expense counts as a visit.
+type MerchantStatsResponse struct {
+	MerchantID uint      `json:"merchant_id"`
+	Name       string    `json:"name"`
+	Currency   string    `json:"currency"`
+	Total      float64   `json:"total"`
+	Visits     int64     `json:"visits"`
+	Average    float64   `json:"average"`
+	FirstVisit time.Time `json:"first_visit"`
+	LastVisit  time.Time `json:"last_visit"`
+}
+
+type MerchantMatchResponse struct {
+	Scanned int `json:"scanned"`
+	Matched int `json:"matched"`
+}
@@ -0,0 +1,32 @@
+package responses
+
+import "time"
+
+type PolicyResponse struct {
+	ID         uint      `json:"id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	Kind       string    `json:"kind"`
+	Action     string    `json:"action"`
+	Tag        string    `json:"tag,omitempty"`
+	Amount     float64   `json:"amount,omitempty"`
+	Currency   string    `json:"currency,omitempty"`
+	MerchantID *uint     `json:"merchant_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// PolicyViolation tells which policy an expense violates and how.
+type PolicyViolation struct {
+	Code    string `json:"code"`
+	Kind    string `json:"kind"`
+	Action  string `json:"action"`
+	Message string `json:"message"`
+}
+
+// ExpenseViolationsResponse is an existing expense with the policies it
+// violates.
+type ExpenseViolationsResponse struct {
+	Expense    ExpenseResponse   `json:"expense"`
+	Violations []PolicyViolation `json:"violations"`
+}
@@ -0,0 +1,29 @@
+package responses
+
+import "time"
+
+type ReconciliationMatchResponse struct {
+	ID      uint              `json:"id"`
+	Line    ImportRowResponse `json:"line"`
+	Expense ExpenseResponse   `json:"expense"`
+	Score   float64           `json:"score"`
+	Status  string            `json:"status"`
+}
+
+// ReconciliationResponse reports the matches of a reconciliation with the
+// lines and expenses left unmatched, the lists are only filled for a single
+// reconciliation.
+type ReconciliationResponse struct {
+	ID                uint                          `json:"id"`
+	ImportID          uint                          `json:"import_id"`
+	Days              int                           `json:"days"`
+	From              time.Time                     `json:"from"`
+	To                time.Time                     `json:"to"`
+	Suggested         int                           `json:"suggested"`
+	Confirmed         int                           `json:"confirmed"`
+	Rejected          int                           `json:"rejected"`
+	CreatedAt         time.Time                     `json:"created_at"`
+	Matches           []ReconciliationMatchResponse `json:"matches,omitempty"`
+	UnmatchedLines    []ImportRowResponse           `json:"unmatched_lines,omitempty"`
+	UnmatchedExpenses []ExpenseResponse             `json:"unmatched_expenses,omitempty"`
+}
@@ -0,0 +1,29 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type RecurringExpenseResponse struct {
+	ID              uint           `json:"id"`
+	Title           string         `json:"title"`
+	Amount          float64        `json:"amount"`
+	Note            string         `json:"note"`
+	Tags            pq.StringArray `json:"tags"`
+	Frequency       string         `json:"frequency"`
+	Interval        int            `json:"interval"`
+	Weekday         *int           `json:"weekday,omitempty"`
+	DayOfMonth      int            `json:"day_of_month,omitempty"`
+	LastBusinessDay bool           `json:"last_business_day"`
+	StartDate       time.Time      `json:"start_date"`
+	EndDate         *time.Time     `json:"end_date,omitempty"`
+	NextRunDate     time.Time      `json:"next_run_date"`
+	Paused          bool           `json:"paused"`
+}
+
+type RecurringPreviewResponse struct {
+	ID          uint     `json:"id"`
+	Occurrences []string `json:"occurrences"`
+}
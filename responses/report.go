@@ -0,0 +1,47 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// StatementResponse is a monthly statement, totals are in Currency and leave
+// out expenses that could not be converted, those are listed in Unconverted.
+type StatementResponse struct {
+	Month        string                   `json:"month"`
+	PeriodStart  time.Time                `json:"period_start"`
+	PeriodEnd    time.Time                `json:"period_end"`
+	Currency     string                   `json:"currency"`
+	GeneratedAt  time.Time                `json:"generated_at"`
+	Total        float64                  `json:"total"`
+	Count        int                      `json:"count"`
+	Tags         []StatementTagTotal      `json:"tags"`
+	Unconverted  []StatementCurrencyTotal `json:"unconverted"`
+	Transactions []StatementTransaction   `json:"transactions"`
+}
+
+// StatementTagTotal counts an expense under each of its tags, so the tag
+// totals can add up to more than the statement total.
+type StatementTagTotal struct {
+	Tag   string  `json:"tag"`
+	Count int     `json:"count"`
+	Total float64 `json:"total"`
+}
+
+type StatementCurrencyTotal struct {
+	Currency string  `json:"currency"`
+	Count    int     `json:"count"`
+	Total    float64 `json:"total"`
+}
+
+type StatementTransaction struct {
+	ID              uint           `json:"id"`
+	Date            time.Time      `json:"date"`
+	Title           string         `json:"title"`
+	Note            string         `json:"note"`
+	Tags            pq.StringArray `json:"tags"`
+	Amount          float64        `json:"amount"`
+	Currency        string         `json:"currency"`
+	ReportingAmount *float64       `json:"reporting_amount"`
+}
@@ -0,0 +1,55 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type RuleResponse struct {
+	ID             uint           `json:"id"`
+	Name           string         `json:"name"`
+	Priority       int            `json:"priority"`
+	Disabled       bool           `json:"disabled"`
+	StopProcessing bool           `json:"stop_processing"`
+	TitlePattern   string         `json:"title_pattern,omitempty"`
+	NotePattern    string         `json:"note_pattern,omitempty"`
+	MinAmount      *float64       `json:"min_amount,omitempty"`
+	MaxAmount      *float64       `json:"max_amount,omitempty"`
+	Merchant       string         `json:"merchant,omitempty"`
+	Currency       string         `json:"currency,omitempty"`
+	AddTags        pq.StringArray `json:"add_tags"`
+	SetCategory    string         `json:"set_category,omitempty"`
+	SetNote        string         `json:"set_note,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// RuleChange is an expense a rule would change, with its values before and
+// after.
+type RuleChange struct {
+	ExpenseID      uint           `json:"expense_id"`
+	Title          string         `json:"title"`
+	AddedTags      pq.StringArray `json:"added_tags,omitempty"`
+	CategoryBefore string         `json:"category_before,omitempty"`
+	CategoryAfter  string         `json:"category_after,omitempty"`
+	NoteBefore     string         `json:"note_before,omitempty"`
+	NoteAfter      string         `json:"note_after,omitempty"`
+}
+
+type DryRunResponse struct {
+	Scanned int          `json:"scanned"`
+	Matched int          `json:"matched"`
+	Changes []RuleChange `json:"changes"`
+}
+
+type RuleRunResponse struct {
+	ID         uint       `json:"id"`
+	Status     string     `json:"status"`
+	Scanned    int        `json:"scanned"`
+	Changed    int        `json:"changed"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+}
@@ -0,0 +1,54 @@
+package responses
+
+import "time"
+
+type SplitResponse struct {
+	ExpenseID uint            `json:"expense_id"`
+	PaidBy    string          `json:"paid_by"`
+	Method    string          `json:"method"`
+	Amount    float64         `json:"amount"`
+	Currency  string          `json:"currency"`
+	Shares    []ShareResponse `json:"shares"`
+}
+
+type ShareResponse struct {
+	Participant string  `json:"participant"`
+	Percentage  float64 `json:"percentage,omitempty"`
+	Amount      float64 `json:"amount"`
+}
+
+type SettlementResponse struct {
+	ID        uint      `json:"id"`
+	Payer     string    `json:"payer"`
+	Payee     string    `json:"payee"`
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	Date      time.Time `json:"date"`
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParticipantBalance is positive when the participant is owed money.
+type ParticipantBalance struct {
+	Participant string  `json:"participant"`
+	Paid        float64 `json:"paid"`
+	Share       float64 `json:"share"`
+	SettledOut  float64 `json:"settled_out"`
+	SettledIn   float64 `json:"settled_in"`
+	Net         float64 `json:"net"`
+}
+
+type Transfer struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Amount float64 `json:"amount"`
+}
+
+// BalanceResponse holds the balances of one currency, Debts lists what each
+// participant owes another and SettleUp the fewest transfers clearing them.
+type BalanceResponse struct {
+	Currency string               `json:"currency"`
+	Balances []ParticipantBalance `json:"balances"`
+	Debts    []Transfer           `json:"debts"`
+	SettleUp []Transfer           `json:"settle_up"`
+}
@@ -0,0 +1,18 @@
+package responses
+
+// TagResponse counts the expenses tagged exactly Name in Count and the ones
+// tagged Name or any of its children in TotalCount.
+type TagResponse struct {
+	Name       string `json:"name"`
+	Parent     string `json:"parent,omitempty"`
+	Count      int64  `json:"count"`
+	TotalCount int64  `json:"total_count"`
+	Color      string `json:"color,omitempty"`
+	Icon       string `json:"icon,omitempty"`
+}
+
+// TagSuggestion is a tag with the probability that it fits the expense.
+type TagSuggestion struct {
+	Tag   string  `json:"tag"`
+	Score float64 `json:"score"`
+}
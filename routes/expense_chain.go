@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"github.com/wytquant/assessment/config"
+	"github.com/wytquant/assessment/helpers"
+	accountRepositories "github.com/wytquant/assessment/src/account/repositories"
+	accountServices "github.com/wytquant/assessment/src/account/services"
+	alertServices "github.com/wytquant/assessment/src/alert/services"
+	budgetServices "github.com/wytquant/assessment/src/budget/services"
+	claimRepositories "github.com/wytquant/assessment/src/claim/repositories"
+	claimServices "github.com/wytquant/assessment/src/claim/services"
+	duplicateRepositories "github.com/wytquant/assessment/src/duplicate/repositories"
+	duplicateServices "github.com/wytquant/assessment/src/duplicate/services"
+	"github.com/wytquant/assessment/src/expense/repositories"
+	"github.com/wytquant/assessment/src/expense/services"
+	insightRepositories "github.com/wytquant/assessment/src/insight/repositories"
+	insightServices "github.com/wytquant/assessment/src/insight/services"
+	merchantRepositories "github.com/wytquant/assessment/src/merchant/repositories"
+	merchantServices "github.com/wytquant/assessment/src/merchant/services"
+	policyRepositories "github.com/wytquant/assessment/src/policy/repositories"
+	policyServices "github.com/wytquant/assessment/src/policy/services"
+	reconciliationRepositories "github.com/wytquant/assessment/src/reconciliation/repositories"
+	reconciliationServices "github.com/wytquant/assessment/src/reconciliation/services"
+	ruleRepositories "github.com/wytquant/assessment/src/rule/repositories"
+	ruleServices "github.com/wytquant/assessment/src/rule/services"
+	splitRepositories "github.com/wytquant/assessment/src/split/repositories"
+	splitServices "github.com/wytquant/assessment/src/split/services"
+	suggestionServices "github.com/wytquant/assessment/src/suggestion/services"
+)
+
+// expenseChain holds the services that process, check and observe every new
+// or changed expense, whether a request, an import or a recurring template
+// creates it.
+type expenseChain struct {
+	alert          alertServices.AlertService
+	rule           ruleServices.RuleService
+	account        accountServices.AccountService
+	merchant       merchantServices.MerchantService
+	reconciliation reconciliationServices.ReconciliationService
+	claim          claimServices.ClaimService
+	split          splitServices.SplitService
+	policy         policyServices.PolicyService
+	duplicate      duplicateServices.DuplicateService
+	suggestion     suggestionServices.SuggestionService
+	insight        insightServices.InsightService
+}
+
+func newExpenseChain(budgetService budgetServices.BudgetService, rates helpers.ExchangeRates) expenseChain {
+	merchantService := merchantServices.NewMerchantService(merchantRepositories.NewMerchantRepositoryDB(config.DB))
+	reconciliationService := reconciliationServices.NewReconciliationService(reconciliationRepositories.NewReconciliationRepositoryDB(config.DB), merchantService)
+	claimService := claimServices.NewClaimService(claimRepositories.NewClaimRepositoryDB(config.DB), claimRoles())
+
+	return expenseChain{
+		alert:          newAlertService(budgetService),
+		rule:           ruleServices.NewRuleService(ruleRepositories.NewRuleRepositoryDB(config.DB), repositories.NewExpenseRepositoryDB(config.DB)),
+		account:        accountServices.NewAccountService(accountRepositories.NewAccountRepositoryDB(config.DB)),
+		merchant:       merchantService,
+		reconciliation: reconciliationService,
+		claim:          claimService,
+		split:          splitServices.NewSplitService(splitRepositories.NewSplitRepositoryDB(config.DB), repositories.NewExpenseRepositoryDB(config.DB)),
+		policy:         policyServices.NewPolicyService(policyRepositories.NewPolicyRepositoryDB(config.DB), rates),
+		duplicate:      duplicateServices.NewDuplicateService(duplicateRepositories.NewDuplicateRepositoryDB(config.DB), reconciliationService, claimService),
+		suggestion:     suggestionServices.NewSuggestionService(repositories.NewExpenseRepositoryDB(config.DB)),
+		insight:        insightServices.NewInsightService(insightRepositories.NewInsightRepositoryDB(config.DB)),
+	}
+}
+
+// expenseService returns the expense service of the personal ledger going
+// through the chain, policies last so they see the processed expense.
+func (c expenseChain) expenseService() services.ExpenseService {
+	processors := []services.ExpenseProcessor{c.account, c.rule, c.merchant, c.reconciliation, c.claim, c.split, c.policy}
+	return services.NewExpenseService(repositories.NewExpenseRepositoryDB(config.DB), processors, c.alert, c.suggestion, c.duplicate, c.insight, c.merchant, c.split)
+}
@@ -1,32 +1,387 @@
 package routes
 
 import (
+	"log"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/wytquant/assessment/config"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	accountHandlers "github.com/wytquant/assessment/src/account/handlers"
+	alertHandlers "github.com/wytquant/assessment/src/alert/handlers"
+	alertSenders "github.com/wytquant/assessment/src/alert/senders"
+	attachmentHandlers "github.com/wytquant/assessment/src/attachment/handlers"
+	attachmentRepositories "github.com/wytquant/assessment/src/attachment/repositories"
+	attachmentServices "github.com/wytquant/assessment/src/attachment/services"
+	"github.com/wytquant/assessment/src/attachment/storage"
+	budgetHandlers "github.com/wytquant/assessment/src/budget/handlers"
+	budgetRepositories "github.com/wytquant/assessment/src/budget/repositories"
+	budgetServices "github.com/wytquant/assessment/src/budget/services"
+	claimHandlers "github.com/wytquant/assessment/src/claim/handlers"
+	claimServices "github.com/wytquant/assessment/src/claim/services"
+	duplicateHandlers "github.com/wytquant/assessment/src/duplicate/handlers"
 	"github.com/wytquant/assessment/src/expense/handlers"
 	"github.com/wytquant/assessment/src/expense/repositories"
-	"github.com/wytquant/assessment/src/expense/services"
+	forecastHandlers "github.com/wytquant/assessment/src/forecast/handlers"
+	forecastRepositories "github.com/wytquant/assessment/src/forecast/repositories"
+	forecastServices "github.com/wytquant/assessment/src/forecast/services"
+	groupHandlers "github.com/wytquant/assessment/src/group/handlers"
+	groupRepositories "github.com/wytquant/assessment/src/group/repositories"
+	groupServices "github.com/wytquant/assessment/src/group/services"
+	importHandlers "github.com/wytquant/assessment/src/imports/handlers"
+	importRepositories "github.com/wytquant/assessment/src/imports/repositories"
+	importServices "github.com/wytquant/assessment/src/imports/services"
+	insightHandlers "github.com/wytquant/assessment/src/insight/handlers"
+	merchantHandlers "github.com/wytquant/assessment/src/merchant/handlers"
+	policyHandlers "github.com/wytquant/assessment/src/policy/handlers"
+	reconciliationHandlers "github.com/wytquant/assessment/src/reconciliation/handlers"
+	recurringHandlers "github.com/wytquant/assessment/src/recurring/handlers"
+	recurringRepositories "github.com/wytquant/assessment/src/recurring/repositories"
+	recurringServices "github.com/wytquant/assessment/src/recurring/services"
+	reportHandlers "github.com/wytquant/assessment/src/report/handlers"
+	reportServices "github.com/wytquant/assessment/src/report/services"
+	ruleHandlers "github.com/wytquant/assessment/src/rule/handlers"
+	splitHandlers "github.com/wytquant/assessment/src/split/handlers"
+	suggestionHandlers "github.com/wytquant/assessment/src/suggestion/handlers"
+	tagHandlers "github.com/wytquant/assessment/src/tag/handlers"
+	tagRepositories "github.com/wytquant/assessment/src/tag/repositories"
+	tagServices "github.com/wytquant/assessment/src/tag/services"
+	webhookHandlers "github.com/wytquant/assessment/src/webhook/handlers"
+	webhookRepositories "github.com/wytquant/assessment/src/webhook/repositories"
+	webhookServices "github.com/wytquant/assessment/src/webhook/services"
 )
 
 func SetupRouter() *gin.Engine {
 	r := gin.Default()
 
-	authozired := r.Group("/", gin.BasicAuth(gin.Accounts{
-		os.Getenv("USERNAME"): os.Getenv("PASSWORD"),
-	}))
+	authozired := r.Group("/", gin.BasicAuth(accounts()))
+	// the personal ledger and everything around it belong to USERNAME,
+	// the other accounts only reach their groups and their own claims
+	owner := authozired.Group("/", requireOwner())
+
+	rates := exchangeRates()
+	budgetService := budgetServices.NewBudgetService(budgetRepositories.NewBudgetRepositoryDB(config.DB), rates)
+
+	chain := newExpenseChain(budgetService, rates)
+	expenseService := chain.expenseService()
+
+	groupHandler := groupHandlers.NewGroupHandler(groupServices.NewGroupService(groupRepositories.NewGroupRepositoryDB(config.DB)))
+
+	{
+		authozired.POST("/groups", groupHandler.CreateGroup)
+		authozired.GET("/groups", groupHandler.GetGroups)
+		authozired.GET("/groups/:gid", groupHandler.GetGroupByID)
+		authozired.POST("/groups/:gid/invitations", groupHandler.CreateInvitation)
+		authozired.PUT("/groups/:gid/members/:username", groupHandler.UpdateMember)
+		authozired.DELETE("/groups/:gid/members/:username", groupHandler.RemoveMember)
+		authozired.POST("/invitations/:token/accept", groupHandler.AcceptInvitation)
+	}
+
+	{
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+		suggestionHandler := suggestionHandlers.NewSuggestionHandler(chain.suggestion)
+		duplicateHandler := duplicateHandlers.NewDuplicateHandler(chain.duplicate)
+
+		// the same handlers work on a group's ledger below /groups/:gid
+		viewer := authozired.Group("/groups/:gid", groupHandler.RequireRole(models.RoleViewer))
+		editor := authozired.Group("/groups/:gid", groupHandler.RequireRole(models.RoleEditor))
+		editor.POST("/expenses", expenseHandler.CreateExpense)
+		viewer.GET("/expenses/summary", expenseHandler.GetSummary)
+		viewer.GET("/expenses/cash-flow", expenseHandler.GetCashFlow)
+		viewer.GET("/expenses/export", expenseHandler.ExportExpenses)
+		viewer.GET("/expenses/search", expenseHandler.SearchExpenses)
+		viewer.GET("/expenses/:id", expenseHandler.GetExpenseByID)
+		editor.PUT("/expenses/:id", expenseHandler.UpdateExpenseByID)
+		viewer.GET("/expenses", expenseHandler.GetAllExpenses)
+
+		owner.POST("/expenses", expenseHandler.CreateExpense)
+		owner.GET("/expenses/summary", expenseHandler.GetSummary)
+		owner.GET("/expenses/cash-flow", expenseHandler.GetCashFlow)
+		owner.GET("/expenses/export", expenseHandler.ExportExpenses)
+		owner.GET("/expenses/search", expenseHandler.SearchExpenses)
+		owner.GET("/expenses/suggest-tags", suggestionHandler.SuggestTags)
+		owner.GET("/expenses/duplicates", duplicateHandler.GetDuplicates)
+		owner.POST("/expenses/duplicates/dismiss", duplicateHandler.DismissDuplicates)
+		owner.POST("/expenses/merge", duplicateHandler.MergeExpenses)
+		owner.GET("/expenses/:id", expenseHandler.GetExpenseByID)
+		owner.PUT("/expenses/:id", expenseHandler.UpdateExpenseByID)
+		owner.GET("/expenses", expenseHandler.GetAllExpenses)
+	}
+
+	{
+		ruleHandler := ruleHandlers.NewRuleHandler(chain.rule)
+
+		owner.POST("/rules", ruleHandler.CreateRule)
+		owner.POST("/rules/dry-run", ruleHandler.DryRun)
+		owner.POST("/rules/apply", ruleHandler.StartRun)
+		owner.GET("/rules/runs/:id", ruleHandler.GetRunByID)
+		owner.GET("/rules/:id", ruleHandler.GetRuleByID)
+		owner.GET("/rules/:id/dry-run", ruleHandler.DryRunByID)
+		owner.PUT("/rules/:id", ruleHandler.UpdateRuleByID)
+		owner.DELETE("/rules/:id", ruleHandler.DeleteRuleByID)
+		owner.GET("/rules", ruleHandler.GetAllRules)
+	}
+
+	{
+		accountHandler := accountHandlers.NewAccountHandler(chain.account)
+
+		owner.POST("/accounts", accountHandler.CreateAccount)
+		owner.GET("/accounts/:id", accountHandler.GetAccountByID)
+		owner.GET("/accounts/:id/ledger", accountHandler.GetLedger)
+		owner.PUT("/accounts/:id", accountHandler.UpdateAccountByID)
+		owner.DELETE("/accounts/:id", accountHandler.DeleteAccountByID)
+		owner.GET("/accounts", accountHandler.GetAllAccounts)
+	}
+
+	{
+		merchantHandler := merchantHandlers.NewMerchantHandler(chain.merchant)
+
+		owner.POST("/merchants", merchantHandler.CreateMerchant)
+		owner.GET("/merchants/stats", merchantHandler.GetStats)
+		owner.POST("/merchants/match", merchantHandler.MatchExpenses)
+		owner.GET("/merchants/:id", merchantHandler.GetMerchantByID)
+		owner.PUT("/merchants/:id", merchantHandler.UpdateMerchantByID)
+		owner.DELETE("/merchants/:id", merchantHandler.DeleteMerchantByID)
+		owner.POST("/merchants/:id/aliases", merchantHandler.AddAlias)
+		owner.DELETE("/merchants/:id/aliases/:alias_id", merchantHandler.DeleteAlias)
+		owner.GET("/merchants", merchantHandler.GetAllMerchants)
+	}
+
+	{
+		reconciliationHandler := reconciliationHandlers.NewReconciliationHandler(chain.reconciliation)
+
+		owner.POST("/reconciliations", reconciliationHandler.CreateReconciliation)
+		owner.GET("/reconciliations/:id", reconciliationHandler.GetReconciliationByID)
+		owner.POST("/reconciliations/:id/matches/:match_id/confirm", reconciliationHandler.ConfirmMatch)
+		owner.POST("/reconciliations/:id/matches/:match_id/reject", reconciliationHandler.RejectMatch)
+		owner.GET("/reconciliations", reconciliationHandler.GetAllReconciliations)
+	}
+
+	{
+		claimHandler := claimHandlers.NewClaimHandler(chain.claim)
+
+		authozired.POST("/claims", claimHandler.CreateClaim)
+		authozired.GET("/claims/events", claimHandler.GetEvents)
+		authozired.GET("/claims/:id", claimHandler.GetClaimByID)
+		authozired.PUT("/claims/:id", claimHandler.UpdateClaimByID)
+		authozired.DELETE("/claims/:id", claimHandler.DeleteClaimByID)
+		authozired.POST("/claims/:id/submit", claimHandler.SubmitClaim)
+		authozired.POST("/claims/:id/approve", claimHandler.ApproveClaim)
+		authozired.POST("/claims/:id/reject", claimHandler.RejectClaim)
+		authozired.POST("/claims/:id/pay", claimHandler.PayClaim)
+		authozired.GET("/claims", claimHandler.GetAllClaims)
+	}
+
+	{
+		policyHandler := policyHandlers.NewPolicyHandler(chain.policy)
+
+		owner.POST("/policies", policyHandler.CreatePolicy)
+		owner.GET("/policies/violations", policyHandler.GetViolations)
+		owner.GET("/policies/:id", policyHandler.GetPolicyByID)
+		owner.PUT("/policies/:id", policyHandler.UpdatePolicyByID)
+		owner.DELETE("/policies/:id", policyHandler.DeletePolicyByID)
+		owner.GET("/policies", policyHandler.GetAllPolicies)
+	}
+
+	{
+		webhookHandler := webhookHandlers.NewWebhookHandler(webhookServices.NewWebhookService(webhookRepositories.NewWebhookRepositoryDB(config.DB), alertSenders.NewHTTPWebhookSender(10*time.Second)))
+
+		owner.POST("/webhooks", webhookHandler.CreateWebhook)
+		owner.GET("/webhooks/:id", webhookHandler.GetWebhookByID)
+		owner.PUT("/webhooks/:id", webhookHandler.UpdateWebhookByID)
+		owner.DELETE("/webhooks/:id", webhookHandler.DeleteWebhookByID)
+		owner.GET("/webhooks/:id/deliveries", webhookHandler.GetDeliveries)
+		owner.POST("/webhooks/:id/deliveries/:delivery_id/replay", webhookHandler.ReplayDelivery)
+		owner.GET("/webhooks", webhookHandler.GetAllWebhooks)
+	}
+
+	{
+		budgetHandler := budgetHandlers.NewBudgetHandler(budgetService)
+
+		owner.POST("/budgets", budgetHandler.CreateBudget)
+		owner.GET("/budgets/status", budgetHandler.GetBudgetStatus)
+		owner.GET("/budgets/:id", budgetHandler.GetBudgetByID)
+		owner.PUT("/budgets/:id", budgetHandler.UpdateBudgetByID)
+		owner.DELETE("/budgets/:id", budgetHandler.DeleteBudgetByID)
+		owner.GET("/budgets", budgetHandler.GetAllBudgets)
+	}
+
+	{
+		alertHandler := alertHandlers.NewAlertHandler(chain.alert)
+
+		owner.GET("/alerts", alertHandler.GetAlerts)
+		owner.POST("/alerts/webhooks", alertHandler.CreateWebhook)
+		owner.GET("/alerts/webhooks", alertHandler.GetWebhooks)
+		owner.DELETE("/alerts/webhooks/:id", alertHandler.DeleteWebhookByID)
+	}
+
+	{
+		insightHandler := insightHandlers.NewInsightHandler(chain.insight)
+
+		owner.GET("/insights", insightHandler.GetInsights)
+	}
+
+	{
+		forecastHandler := forecastHandlers.NewForecastHandler(forecastServices.NewForecastService(forecastRepositories.NewForecastRepositoryDB(config.DB)))
+
+		owner.GET("/forecast", forecastHandler.GetForecast)
+	}
 
 	{
-		repo := repositories.NewExpenseRepositoryDB(config.DB)
-		service := services.NewExpenseService(repo)
-		expenseHandler := handlers.NewExpenseHandler(service)
+		repo := recurringRepositories.NewRecurringExpenseRepositoryDB(config.DB)
+		service := recurringServices.NewRecurringExpenseService(repo, expenseService)
+		recurringHandler := recurringHandlers.NewRecurringExpenseHandler(service)
 
-		authozired.POST("/expenses", expenseHandler.CreateExpense)
-		authozired.GET("/expenses/:id", expenseHandler.GetExpenseByID)
-		authozired.PUT("/expenses/:id", expenseHandler.UpdateExpenseByID)
-		authozired.GET("/expenses", expenseHandler.GetAllExpenses)
+		owner.POST("/recurring-expenses", recurringHandler.CreateRecurringExpense)
+		owner.GET("/recurring-expenses/:id", recurringHandler.GetRecurringExpenseByID)
+		owner.PUT("/recurring-expenses/:id", recurringHandler.UpdateRecurringExpenseByID)
+		owner.DELETE("/recurring-expenses/:id", recurringHandler.DeleteRecurringExpenseByID)
+		owner.GET("/recurring-expenses", recurringHandler.GetAllRecurringExpenses)
+		owner.POST("/recurring-expenses/:id/pause", recurringHandler.PauseRecurringExpense)
+		owner.POST("/recurring-expenses/:id/resume", recurringHandler.ResumeRecurringExpense)
+		owner.POST("/recurring-expenses/:id/skip-next", recurringHandler.SkipNextOccurrence)
+		owner.GET("/recurring-expenses/:id/preview", recurringHandler.PreviewOccurrences)
+	}
+
+	{
+		repo := importRepositories.NewImportRepositoryDB(config.DB)
+		service := importServices.NewImportService(repo, expenseService)
+		importHandler := importHandlers.NewImportHandler(service)
+
+		owner.POST("/imports", importHandler.CreateImport)
+		owner.GET("/imports/:id", importHandler.GetImportByID)
+		owner.GET("/imports", importHandler.GetAllImports)
+		owner.POST("/imports/:id/commit", importHandler.CommitImport)
+	}
+
+	{
+		repo := attachmentRepositories.NewAttachmentRepositoryDB(config.DB)
+		service := attachmentServices.NewAttachmentService(repo, repositories.NewExpenseRepositoryDB(config.DB), newAttachmentStorage())
+		attachmentHandler := attachmentHandlers.NewAttachmentHandler(service)
+
+		owner.POST("/expenses/:id/attachments", attachmentHandler.UploadAttachment)
+		owner.GET("/expenses/:id/attachments", attachmentHandler.GetAttachments)
+		owner.GET("/expenses/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachment)
+		owner.GET("/expenses/:id/attachments/:attachment_id/thumbnail", attachmentHandler.GetThumbnail)
+		owner.DELETE("/expenses/:id/attachments/:attachment_id", attachmentHandler.DeleteAttachment)
+	}
+
+	{
+		splitHandler := splitHandlers.NewSplitHandler(chain.split)
+
+		owner.PUT("/expenses/:id/split", splitHandler.SplitExpense)
+		owner.GET("/expenses/:id/split", splitHandler.GetSplit)
+		owner.DELETE("/expenses/:id/split", splitHandler.DeleteSplit)
+		owner.GET("/balances", splitHandler.GetBalances)
+		owner.POST("/settlements", splitHandler.CreateSettlement)
+		owner.GET("/settlements", splitHandler.GetSettlements)
+		owner.DELETE("/settlements/:id", splitHandler.DeleteSettlementByID)
+	}
+
+	{
+		tagHandler := tagHandlers.NewTagHandler(tagServices.NewTagService(tagRepositories.NewTagRepositoryDB(config.DB)))
+
+		owner.GET("/tags", tagHandler.GetTags)
+		owner.PUT("/tags", tagHandler.SetTag)
+		owner.POST("/tags/rename", tagHandler.RenameTag)
+		owner.POST("/tags/merge", tagHandler.MergeTags)
+	}
+
+	{
+		service := reportServices.NewReportService(repositories.NewExpenseRepositoryDB(config.DB), rates)
+		reportHandler := reportHandlers.NewReportHandler(service)
+
+		owner.GET("/reports/statement", reportHandler.GetStatement)
 	}
 
 	return r
 }
+
+// accounts allows USERNAME, the owner, plus the comma separated
+// user:password pairs of ACCOUNTS, the usernames identify group members.
+func accounts() gin.Accounts {
+	accounts := gin.Accounts{
+		os.Getenv("USERNAME"): os.Getenv("PASSWORD"),
+	}
+
+	if extra := os.Getenv("ACCOUNTS"); extra != "" {
+		for _, account := range strings.Split(extra, ",") {
+			username, password, ok := strings.Cut(strings.TrimSpace(account), ":")
+			if !ok || username == "" || password == "" {
+				log.Fatalf("ACCOUNTS entry %q must look like user:password", account)
+			}
+			accounts[username] = password
+		}
+	}
+
+	return accounts
+}
+
+// requireOwner lets only USERNAME through.
+func requireOwner() gin.HandlerFunc {
+	username := os.Getenv("USERNAME")
+	return func(c *gin.Context) {
+		if c.GetString(gin.AuthUserKey) != username {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "only the owner account can use the personal ledger"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// exchangeRates reads EXCHANGE_RATES such as USD=35.5,EUR=38.2.
+func exchangeRates() helpers.ExchangeRates {
+	rates, err := helpers.ParseExchangeRates(os.Getenv("EXCHANGE_RATES"))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return rates
+}
+
+// claimRoles reads the comma separated usernames of CLAIM_APPROVERS and
+// CLAIM_PAYERS, USERNAME owns the personal ledger.
+func claimRoles() claimServices.ClaimRoles {
+	return claimServices.ClaimRoles{
+		Approvers: usernames(os.Getenv("CLAIM_APPROVERS")),
+		Payers:    usernames(os.Getenv("CLAIM_PAYERS")),
+		Owner:     os.Getenv("USERNAME"),
+	}
+}
+
+func usernames(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// newAttachmentStorage keeps receipts on the local disk unless
+// ATTACHMENT_STORAGE selects an S3 compatible bucket.
+func newAttachmentStorage() storage.Storage {
+	switch backend := os.Getenv("ATTACHMENT_STORAGE"); backend {
+	case "", "local":
+		dir := os.Getenv("ATTACHMENT_DIR")
+		if dir == "" {
+			dir = "attachments"
+		}
+		return storage.NewLocalStorage(dir)
+	case "s3":
+		return storage.NewS3Storage(storage.S3Options{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		}, &http.Client{Timeout: 30 * time.Second})
+	default:
+		log.Fatalf("unknown ATTACHMENT_STORAGE %q", backend)
+		return nil
+	}
+}
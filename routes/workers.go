@@ -0,0 +1,68 @@
+package routes
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wytquant/assessment/config"
+	alertRepositories "github.com/wytquant/assessment/src/alert/repositories"
+	"github.com/wytquant/assessment/src/alert/senders"
+	alertServices "github.com/wytquant/assessment/src/alert/services"
+	budgetRepositories "github.com/wytquant/assessment/src/budget/repositories"
+	budgetServices "github.com/wytquant/assessment/src/budget/services"
+	duplicateServices "github.com/wytquant/assessment/src/duplicate/services"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	recurringRepositories "github.com/wytquant/assessment/src/recurring/repositories"
+	recurringServices "github.com/wytquant/assessment/src/recurring/services"
+	ruleServices "github.com/wytquant/assessment/src/rule/services"
+	webhookRepositories "github.com/wytquant/assessment/src/webhook/repositories"
+	webhookServices "github.com/wytquant/assessment/src/webhook/services"
+)
+
+// StartWorkers runs the background jobs of the application until ctx is done.
+func StartWorkers(ctx context.Context) {
+	rates := exchangeRates()
+	budgetService := budgetServices.NewBudgetService(budgetRepositories.NewBudgetRepositoryDB(config.DB), rates)
+
+	chain := newExpenseChain(budgetService, rates)
+	recurringService := recurringServices.NewRecurringExpenseService(recurringRepositories.NewRecurringExpenseRepositoryDB(config.DB), chain.expenseService())
+
+	go alertServices.RunDispatcher(ctx, chain.alert, 15*time.Second)
+	go recurringServices.RunScheduler(ctx, recurringService, time.Minute)
+	go ruleServices.RunReapplier(ctx, chain.rule, 10*time.Second)
+	go duplicateServices.RunScanner(ctx, chain.duplicate, 6*time.Hour)
+	go webhookServices.RunDispatcher(ctx, webhookServices.NewWebhookService(webhookRepositories.NewWebhookRepositoryDB(config.DB), senders.NewHTTPWebhookSender(10*time.Second)), 10*time.Second)
+
+	// expenses stored before search existed are indexed once
+	go func() {
+		indexed, err := expenseRepositories.NewExpenseRepositoryDB(config.DB).IndexSearchText(500)
+		if err != nil {
+			log.Println("fail to index expenses for search:", err)
+		} else if indexed > 0 {
+			log.Println("indexed expenses for search:", indexed)
+		}
+	}()
+}
+
+func newAlertService(budgetService budgetServices.BudgetService) alertServices.AlertService {
+	var emailSender senders.EmailSender
+	if addr := os.Getenv("SMTP_ADDR"); addr != "" {
+		emailSender = senders.NewSMTPEmailSender(addr, os.Getenv("SMTP_FROM"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"))
+	}
+
+	var recipients []string
+	if to := os.Getenv("ALERT_EMAIL_TO"); to != "" {
+		recipients = strings.Split(to, ",")
+	}
+
+	return alertServices.NewAlertService(
+		alertRepositories.NewAlertRepositoryDB(config.DB),
+		budgetService,
+		senders.NewHTTPWebhookSender(10*time.Second),
+		emailSender,
+		recipients,
+	)
+}
@@ -28,11 +28,52 @@ func main() {
 	}
 	defer config.CloseDB()
 
-	config.DB.AutoMigrate(&models.Expense{})
+	config.DB.AutoMigrate(
+		&models.Expense{},
+		&models.Budget{},
+		&models.Alert{},
+		&models.AlertWebhook{},
+		&models.AlertDelivery{},
+		&models.EmailOutbox{},
+		&models.RecurringExpense{},
+		&models.RecurringOccurrence{},
+		&models.Import{},
+		&models.ImportRow{},
+		&models.ImportedTransaction{},
+		&models.Attachment{},
+		&models.ExpenseSplit{},
+		&models.ExpenseShare{},
+		&models.Settlement{},
+		&models.Group{},
+		&models.GroupMember{},
+		&models.GroupInvitation{},
+		&models.Tag{},
+		&models.Rule{},
+		&models.RuleRun{},
+		&models.DuplicateCandidate{},
+		&models.ExpenseMerge{},
+		&models.Anomaly{},
+		&models.Merchant{},
+		&models.MerchantAlias{},
+		&models.Account{},
+		&models.Reconciliation{},
+		&models.ReconciliationMatch{},
+		&models.Claim{},
+		&models.ClaimEvent{},
+		&models.Policy{},
+		&models.ExpenseEvent{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+	)
 
 	//setup routes
 	r := routes.SetupRouter()
 
+	//start background workers
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	routes.StartWorkers(workerCtx)
+
 	//implement graceful shutdown
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%s", os.Getenv("PORT")),
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/account/services"
+)
+
+type accountHandler struct {
+	accountService services.AccountService
+}
+
+func NewAccountHandler(accountService services.AccountService) accountHandler {
+	return accountHandler{accountService: accountService}
+}
+
+func (h accountHandler) CreateAccount(c *gin.Context) {
+	var accountReq requests.AccountRequest
+	if err := c.ShouldBindJSON(&accountReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	accountResp, err := h.accountService.CreateAccount(accountReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, accountResp)
+}
+
+func (h accountHandler) GetAccountByID(c *gin.Context) {
+	accountResp, err := h.accountService.GetAccountByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, accountResp)
+}
+
+func (h accountHandler) UpdateAccountByID(c *gin.Context) {
+	var accountReq requests.AccountRequest
+	if err := c.ShouldBindJSON(&accountReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	accountResp, err := h.accountService.UpdateAccountByID(c.Param("id"), accountReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, accountResp)
+}
+
+func (h accountHandler) DeleteAccountByID(c *gin.Context) {
+	if err := h.accountService.DeleteAccountByID(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h accountHandler) GetAllAccounts(c *gin.Context) {
+	accountsResp, err := h.accountService.GetAccounts()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, accountsResp)
+}
+
+func (h accountHandler) GetLedger(c *gin.Context) {
+	var query requests.AccountLedgerQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	ledgerResp, err := h.accountService.GetLedger(c.Param("id"), query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ledgerResp)
+}
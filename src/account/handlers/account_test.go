@@ -0,0 +1,81 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/account/handlers"
+	services "github.com/wytquant/assessment/src/account/services/mock"
+)
+
+func TestCreateAccountHandler(t *testing.T) {
+	t.Run("create account success case", func(t *testing.T) {
+		//arrange
+		accountService := services.NewAccountServiceMock()
+		accountService.On("CreateAccount", requests.AccountRequest{Name: "Cash", Type: "cash", OpeningBalance: 2000}).
+			Return(responses.AccountResponse{ID: 1, Name: "Cash", Type: "cash", Currency: "THB", OpeningBalance: 2000, Balance: 2000}, nil)
+
+		accountHandler := handlers.NewAccountHandler(accountService)
+
+		r := gin.Default()
+		r.POST("/accounts", accountHandler.CreateAccount)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"name": "Cash", "type": "cash", "opening_balance": 2000}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		accountService.AssertExpectations(t)
+	})
+
+	t.Run("create account fail bad request because type is unknown", func(t *testing.T) {
+		//arrange
+		accountHandler := handlers.NewAccountHandler(services.NewAccountServiceMock())
+
+		r := gin.Default()
+		r.POST("/accounts", accountHandler.CreateAccount)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(`{"name": "Cash", "type": "piggy_bank"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetLedgerHandler(t *testing.T) {
+	t.Run("get ledger fail not found", func(t *testing.T) {
+		//arrange
+		accountService := services.NewAccountServiceMock()
+		accountService.On("GetLedger", "9", requests.AccountLedgerQuery{}).Return(responses.AccountLedgerResponse{}, helpers.NewNotFoundError())
+
+		accountHandler := handlers.NewAccountHandler(accountService)
+
+		r := gin.Default()
+		r.GET("/accounts/:id/ledger", accountHandler.GetLedger)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/accounts/9/ledger", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/src/webhook/outbox"
+	"gorm.io/gorm"
+)
+
+type accountRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewAccountRepositoryDB(db *gorm.DB) AccountRepository {
+	return accountRepositoryDB{db: db}
+}
+
+func (r accountRepositoryDB) Create(account *models.Account) error {
+	query := r.db
+	if err := query.Create(account).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r accountRepositoryDB) GetByID(id string) (models.Account, error) {
+	var account models.Account
+	query := r.db
+	if err := query.Where("id = ?", id).First(&account).Error; err != nil {
+		return models.Account{}, err
+	}
+
+	return account, nil
+}
+
+func (r accountRepositoryDB) UpdateByID(id string, account models.Account) (models.Account, error) {
+	query := r.db
+	accountDB, err := r.GetByID(id)
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	// an account is replaced as a whole so that the opening balance can be
+	// set back to zero
+	if err := query.Model(&accountDB).Select("name", "type", "currency", "opening_balance", "opening_date").Updates(account).Error; err != nil {
+		return models.Account{}, err
+	}
+
+	return accountDB, nil
+}
+
+func (r accountRepositoryDB) DeleteByID(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var linked []uint
+		if err := tx.Model(&models.Expense{}).Where("account_id = ? OR to_account_id = ?", id, id).Pluck("id", &linked).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Expense{}).Unscoped().Where("account_id = ?", id).UpdateColumn("account_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Expense{}).Unscoped().Where("to_account_id = ?", id).UpdateColumn("to_account_id", nil).Error; err != nil {
+			return err
+		}
+		if err := outbox.RecordChanged(tx, models.EventExpenseUpdated, linked...); err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&models.Account{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r accountRepositoryDB) GetAll() ([]models.Account, error) {
+	query := r.db
+	var accounts []models.Account
+
+	if err := query.Order("name").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// outflows lists what every record of the personal ledger takes out of each
+// account it touches, income and refunds bring money in and a transfer also
+// brings it into the account it goes to.
+const outflows = `SELECT account_id, date, CASE WHEN type IN ('income', 'refund') THEN -amount ELSE amount END AS amount
+		FROM expenses WHERE account_id IS NOT NULL AND group_id IS NULL AND deleted_at IS NULL
+	UNION ALL
+	SELECT to_account_id, date, -amount
+		FROM expenses WHERE type = 'transfer' AND to_account_id IS NOT NULL AND group_id IS NULL AND deleted_at IS NULL`
+
+func (r accountRepositoryDB) GetSpent(accountID uint, from time.Time, before time.Time) (float64, error) {
+	var spent float64
+
+	query := r.db.Table("("+outflows+") AS outflows").Where("account_id = ? AND date >= ?", accountID, from)
+	if !before.IsZero() {
+		query = query.Where("date < ?", before)
+	}
+	if err := query.Select("COALESCE(SUM(amount), 0)").Scan(&spent).Error; err != nil {
+		return 0, err
+	}
+
+	return spent, nil
+}
+
+func (r accountRepositoryDB) GetSpentByAccount() ([]AccountSpent, error) {
+	var spent []AccountSpent
+
+	err := r.db.Table("(" + outflows + ") AS outflows").
+		Select("outflows.account_id, SUM(outflows.amount) AS total").
+		Joins("JOIN accounts ON accounts.id = outflows.account_id").
+		Where("outflows.date >= accounts.opening_date").
+		Group("outflows.account_id").
+		Scan(&spent).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return spent, nil
+}
+
+func (r accountRepositoryDB) GetEntries(accountID uint, from time.Time, to time.Time) ([]models.Expense, error) {
+	var expenses []models.Expense
+
+	query := r.db.Where("(account_id = ? OR (type = ? AND to_account_id = ?)) AND group_id IS NULL AND date >= ?", accountID, models.TypeTransfer, accountID, from)
+	if !to.IsZero() {
+		query = query.Where("date <= ?", to)
+	}
+	if err := query.Order("date, id").Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r accountRepositoryDB) CountExpenses(accountID uint) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&models.Expense{}).Where("(account_id = ? OR to_account_id = ?) AND group_id IS NULL", accountID, accountID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type accountRepositoryMock struct {
+	mock.Mock
+}
+
+func NewAccountRepositoryMock() *accountRepositoryMock {
+	return &accountRepositoryMock{}
+}
+
+func (m *accountRepositoryMock) Create(account *models.Account) error {
+	args := m.Called(account)
+	return args.Error(0)
+}
+
+func (m *accountRepositoryMock) GetByID(id string) (models.Account, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Account), args.Error(1)
+}
+
+func (m *accountRepositoryMock) UpdateByID(id string, account models.Account) (models.Account, error) {
+	args := m.Called(id, account)
+	return args.Get(0).(models.Account), args.Error(1)
+}
+
+func (m *accountRepositoryMock) DeleteByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *accountRepositoryMock) GetAll() ([]models.Account, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Account), args.Error(1)
+}
+
+func (m *accountRepositoryMock) GetSpent(accountID uint, from time.Time, before time.Time) (float64, error) {
+	args := m.Called(accountID, from, before)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *accountRepositoryMock) GetSpentByAccount() ([]AccountSpent, error) {
+	args := m.Called()
+	return args.Get(0).([]AccountSpent), args.Error(1)
+}
+
+func (m *accountRepositoryMock) GetEntries(accountID uint, from time.Time, to time.Time) ([]models.Expense, error) {
+	args := m.Called(accountID, from, to)
+	return args.Get(0).([]models.Expense), args.Error(1)
+}
+
+func (m *accountRepositoryMock) CountExpenses(accountID uint) (int64, error) {
+	args := m.Called(accountID)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+type AccountSpent struct {
+	AccountID uint
+	Total     float64
+}
+
+// AccountRepository reads the expenses of every ledger, an account pays for
+// group expenses too.
+type AccountRepository interface {
+	Create(*models.Account) error
+	GetByID(id string) (models.Account, error)
+	UpdateByID(id string, account models.Account) (models.Account, error)
+	// DeleteByID deletes the account and unlinks its expenses.
+	DeleteByID(id string) error
+	GetAll() ([]models.Account, error)
+	// GetSpent sums what left the account, less what came in, dated from
+	// from until before before, a zero before has no end.
+	GetSpent(accountID uint, from time.Time, before time.Time) (float64, error)
+	// GetSpentByAccount sums what left every account, less what came in,
+	// dated from its opening date.
+	GetSpentByAccount() ([]AccountSpent, error)
+	// GetEntries returns the records of the account and the transfers into
+	// it dated from from through to, a zero to has no end, in date order.
+	GetEntries(accountID uint, from time.Time, to time.Time) ([]models.Expense, error)
+	CountExpenses(accountID uint) (int64, error)
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// AccountService manages accounts and checks the account of new and changed
+// expenses.
+type AccountService interface {
+	CreateAccount(accountReq requests.AccountRequest) (responses.AccountResponse, error)
+	GetAccountByID(id string) (responses.AccountResponse, error)
+	UpdateAccountByID(id string, accountReq requests.AccountRequest) (responses.AccountResponse, error)
+	DeleteAccountByID(id string) error
+	GetAccounts() ([]responses.AccountResponse, error)
+	// GetLedger lists the expenses paid from the account with the running
+	// balance.
+	GetLedger(id string, query requests.AccountLedgerQuery) (responses.AccountLedgerResponse, error)
+	ProcessExpense(expense *models.Expense) error
+	ValidateExpense(expense models.Expense) error
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/account/repositories"
+)
+
+type accountService struct {
+	accountRepo repositories.AccountRepository
+	now         func() time.Time
+}
+
+func NewAccountService(accountRepo repositories.AccountRepository) AccountService {
+	return accountService{accountRepo: accountRepo, now: time.Now}
+}
+
+func (s accountService) CreateAccount(accountReq requests.AccountRequest) (responses.AccountResponse, error) {
+	account := s.account(accountReq)
+
+	if err := s.checkName(0, account.Name); err != nil {
+		return responses.AccountResponse{}, err
+	}
+
+	if err := s.accountRepo.Create(&account); err != nil {
+		return responses.AccountResponse{}, helpers.NewInternalServerError()
+	}
+
+	return accountResponse(account, 0), nil
+}
+
+func (s accountService) GetAccountByID(id string) (responses.AccountResponse, error) {
+	account, err := s.accountRepo.GetByID(id)
+	if err != nil {
+		return responses.AccountResponse{}, helpers.NewNotFoundError()
+	}
+
+	spent, err := s.accountRepo.GetSpent(account.ID, account.OpeningDate, time.Time{})
+	if err != nil {
+		return responses.AccountResponse{}, helpers.NewInternalServerError()
+	}
+
+	return accountResponse(account, spent), nil
+}
+
+func (s accountService) UpdateAccountByID(id string, accountReq requests.AccountRequest) (responses.AccountResponse, error) {
+	accountDB, err := s.accountRepo.GetByID(id)
+	if err != nil {
+		return responses.AccountResponse{}, helpers.NewNotFoundError()
+	}
+
+	account := s.account(accountReq)
+
+	if err := s.checkName(accountDB.ID, account.Name); err != nil {
+		return responses.AccountResponse{}, err
+	}
+	// the expenses of an account are all in its currency
+	if account.Currency != accountDB.Currency {
+		count, err := s.accountRepo.CountExpenses(accountDB.ID)
+		if err != nil {
+			return responses.AccountResponse{}, helpers.NewInternalServerError()
+		}
+		if count > 0 {
+			return responses.AccountResponse{}, helpers.NewConflictError(fmt.Sprintf("account %s has expenses in %s, its currency cannot change", accountDB.Name, accountDB.Currency))
+		}
+	}
+
+	updatedAccount, err := s.accountRepo.UpdateByID(id, account)
+	if err != nil {
+		return responses.AccountResponse{}, helpers.NewInternalServerError()
+	}
+
+	spent, err := s.accountRepo.GetSpent(updatedAccount.ID, updatedAccount.OpeningDate, time.Time{})
+	if err != nil {
+		return responses.AccountResponse{}, helpers.NewInternalServerError()
+	}
+
+	return accountResponse(updatedAccount, spent), nil
+}
+
+func (s accountService) DeleteAccountByID(id string) error {
+	if err := s.accountRepo.DeleteByID(id); err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func (s accountService) GetAccounts() ([]responses.AccountResponse, error) {
+	accountsResp := []responses.AccountResponse{}
+
+	accounts, err := s.accountRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+	spentByAccount, err := s.accountRepo.GetSpentByAccount()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	spent := map[uint]float64{}
+	for _, total := range spentByAccount {
+		spent[total.AccountID] = total.Total
+	}
+	for _, account := range accounts {
+		accountsResp = append(accountsResp, accountResponse(account, spent[account.ID]))
+	}
+
+	return accountsResp, nil
+}
+
+// GetLedger lists the expenses of the account from its opening date or from
+// the requested date if later. The opening balance of the ledger is the
+// balance before its first day.
+func (s accountService) GetLedger(id string, query requests.AccountLedgerQuery) (responses.AccountLedgerResponse, error) {
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return responses.AccountLedgerResponse{}, helpers.NewBadRequestError("to must not be before from")
+	}
+
+	account, err := s.accountRepo.GetByID(id)
+	if err != nil {
+		return responses.AccountLedgerResponse{}, helpers.NewNotFoundError()
+	}
+
+	spent, err := s.accountRepo.GetSpent(account.ID, account.OpeningDate, time.Time{})
+	if err != nil {
+		return responses.AccountLedgerResponse{}, helpers.NewInternalServerError()
+	}
+
+	from := account.OpeningDate
+	balance := account.OpeningBalance
+	if query.From.After(from) {
+		spentBefore, err := s.accountRepo.GetSpent(account.ID, account.OpeningDate, query.From)
+		if err != nil {
+			return responses.AccountLedgerResponse{}, helpers.NewInternalServerError()
+		}
+		from = query.From
+		balance -= spentBefore
+	}
+
+	expenses, err := s.accountRepo.GetEntries(account.ID, from, query.To)
+	if err != nil {
+		return responses.AccountLedgerResponse{}, helpers.NewInternalServerError()
+	}
+
+	ledgerResp := responses.AccountLedgerResponse{
+		Account:        accountResponse(account, spent),
+		OpeningBalance: round(balance),
+		Entries:        []responses.AccountEntry{},
+	}
+	for _, expense := range expenses {
+		change := changeOf(expense, account.ID)
+		balance += change
+
+		entry := responses.AccountEntry{Change: round(change), Balance: round(balance)}
+		copier.Copy(&entry.ExpenseResponse, &expense)
+		ledgerResp.Entries = append(ledgerResp.Entries, entry)
+	}
+	ledgerResp.ClosingBalance = round(balance)
+
+	return ledgerResp, nil
+}
+
+// changeOf is what a record adds to the balance of the account, income,
+// refunds and transfers into the account add to it.
+func changeOf(expense models.Expense, accountID uint) float64 {
+	switch {
+	case expense.Type == models.TypeTransfer && expense.ToAccountID != nil && *expense.ToAccountID == accountID:
+		return expense.Amount
+	case expense.Type == models.TypeIncome || expense.Type == models.TypeRefund:
+		return expense.Amount
+	default:
+		return -expense.Amount
+	}
+}
+
+// ProcessExpense checks the accounts of a new expense.
+func (s accountService) ProcessExpense(expense *models.Expense) error {
+	return s.ValidateExpense(*expense)
+}
+
+// ValidateExpense checks that the account of the expense, and the one a
+// transfer goes to, exist and are in the currency of the expense. Accounts
+// are personal, an expense of a group ledger has none.
+func (s accountService) ValidateExpense(expense models.Expense) error {
+	if expense.GroupID != nil && (expense.AccountID != nil || expense.ToAccountID != nil) {
+		return helpers.NewBadRequestError("accounts are personal, an expense of a group cannot have one")
+	}
+
+	for _, accountID := range []*uint{expense.AccountID, expense.ToAccountID} {
+		if accountID == nil {
+			continue
+		}
+
+		account, err := s.accountRepo.GetByID(strconv.FormatUint(uint64(*accountID), 10))
+		if err != nil {
+			return helpers.NewBadRequestError(fmt.Sprintf("account %d does not exist", *accountID))
+		}
+
+		currency := expense.Currency
+		if currency == "" {
+			currency = helpers.BaseCurrency
+		}
+		if currency != account.Currency {
+			return helpers.NewBadRequestError(fmt.Sprintf("the %s is in %s but account %s is in %s", typeOf(expense), currency, account.Name, account.Currency))
+		}
+	}
+
+	return nil
+}
+
+func typeOf(expense models.Expense) string {
+	if expense.Type == "" {
+		return models.TypeExpense
+	}
+	return expense.Type
+}
+
+func (s accountService) account(accountReq requests.AccountRequest) models.Account {
+	var account models.Account
+
+	copier.Copy(&account, &accountReq)
+
+	if account.Currency == "" {
+		account.Currency = helpers.BaseCurrency
+	}
+	if account.OpeningDate.IsZero() {
+		account.OpeningDate = s.now()
+	}
+	account.OpeningDate = time.Date(account.OpeningDate.Year(), account.OpeningDate.Month(), account.OpeningDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	return account
+}
+
+// checkName fails when another account than the one with the id, 0 for a
+// new account, has the name in any case.
+func (s accountService) checkName(id uint, name string) error {
+	accounts, err := s.accountRepo.GetAll()
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+
+	for _, account := range accounts {
+		if account.ID != id && strings.EqualFold(account.Name, name) {
+			return helpers.NewConflictError(fmt.Sprintf("account %s already exists", account.Name))
+		}
+	}
+
+	return nil
+}
+
+func accountResponse(account models.Account, spent float64) responses.AccountResponse {
+	var accountResp responses.AccountResponse
+
+	copier.Copy(&accountResp, &account)
+	accountResp.Spent = round(spent)
+	accountResp.Balance = round(account.OpeningBalance - spent)
+
+	return accountResp
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
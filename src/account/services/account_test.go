@@ -0,0 +1,281 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/account/repositories"
+	"github.com/wytquant/assessment/src/account/services"
+)
+
+var openingDate = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func accounts() []models.Account {
+	return []models.Account{
+		{ID: 1, Name: "Cash", Type: models.AccountCash, Currency: "THB", OpeningBalance: 2000, OpeningDate: openingDate},
+		{ID: 2, Name: "Visa", Type: models.AccountCreditCard, Currency: "THB", OpeningDate: openingDate},
+	}
+}
+
+func TestCreateAccountService(t *testing.T) {
+	t.Run("create account success case", func(t *testing.T) {
+		//arrange
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetAll").Return(accounts(), nil)
+		accountRepo.On("Create", &models.Account{
+			Name:           "Wise",
+			Type:           models.AccountBank,
+			Currency:       "THB",
+			OpeningBalance: 500,
+			OpeningDate:    openingDate,
+		}).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Account).ID = 3
+		})
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		got, err := accountService.CreateAccount(requests.AccountRequest{
+			Name:           "Wise",
+			Type:           models.AccountBank,
+			OpeningBalance: 500,
+			OpeningDate:    openingDate.Add(10 * time.Hour),
+		})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, responses.AccountResponse{
+			ID:             3,
+			Name:           "Wise",
+			Type:           models.AccountBank,
+			Currency:       "THB",
+			OpeningBalance: 500,
+			OpeningDate:    openingDate,
+			Balance:        500,
+		}, got)
+	})
+
+	t.Run("create account fail conflict because the name is taken", func(t *testing.T) {
+		//arrange
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetAll").Return(accounts(), nil)
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		_, err := accountService.CreateAccount(requests.AccountRequest{Name: "VISA", Type: models.AccountCreditCard})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+			assert.Equal(t, "account Visa already exists", appErr.Message)
+		}
+	})
+}
+
+func TestUpdateAccountService(t *testing.T) {
+	t.Run("update account fail conflict because its expenses are in another currency", func(t *testing.T) {
+		//arrange
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetByID", "2").Return(accounts()[1], nil)
+		accountRepo.On("GetAll").Return(accounts(), nil)
+		accountRepo.On("CountExpenses", uint(2)).Return(int64(4), nil)
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		_, err := accountService.UpdateAccountByID("2", requests.AccountRequest{Name: "Visa", Type: models.AccountCreditCard, Currency: "USD", OpeningDate: openingDate})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+			assert.Equal(t, "account Visa has expenses in THB, its currency cannot change", appErr.Message)
+		}
+		accountRepo.AssertNotCalled(t, "UpdateByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetAccountsService(t *testing.T) {
+	t.Run("get accounts with their balances success case", func(t *testing.T) {
+		//arrange
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetAll").Return(accounts(), nil)
+		accountRepo.On("GetSpentByAccount").Return([]repositories.AccountSpent{{AccountID: 2, Total: 1250.5}}, nil)
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		got, err := accountService.GetAccounts()
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, float64(0), got[0].Spent)
+			assert.Equal(t, float64(2000), got[0].Balance)
+			assert.Equal(t, 1250.5, got[1].Spent)
+			assert.Equal(t, -1250.5, got[1].Balance)
+		}
+	})
+}
+
+func TestGetLedgerService(t *testing.T) {
+	t.Run("get ledger keeps a running balance success case", func(t *testing.T) {
+		//arrange
+		from := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetByID", "1").Return(accounts()[0], nil)
+		accountRepo.On("GetSpent", uint(1), openingDate, time.Time{}).Return(float64(800), nil)
+		accountRepo.On("GetSpent", uint(1), openingDate, from).Return(float64(500), nil)
+		accountRepo.On("GetEntries", uint(1), from, time.Time{}).Return([]models.Expense{
+			{ID: 7, Title: "market", Amount: 120, Date: from},
+			{ID: 9, Title: "taxi", Amount: 180, Date: from.AddDate(0, 0, 3)},
+		}, nil)
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		got, err := accountService.GetLedger("1", requests.AccountLedgerQuery{From: from})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, float64(1200), got.Account.Balance)
+		assert.Equal(t, float64(1500), got.OpeningBalance)
+		assert.Equal(t, float64(1200), got.ClosingBalance)
+		if assert.Len(t, got.Entries, 2) {
+			assert.Equal(t, uint(7), got.Entries[0].ID)
+			assert.Equal(t, float64(1380), got.Entries[0].Balance)
+			assert.Equal(t, float64(1200), got.Entries[1].Balance)
+		}
+	})
+
+	t.Run("get ledger adds income and transfers into the account success case", func(t *testing.T) {
+		//arrange
+		cashID, visaID := uint(1), uint(2)
+
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetByID", "1").Return(accounts()[0], nil)
+		accountRepo.On("GetSpent", uint(1), openingDate, time.Time{}).Return(float64(-300), nil)
+		accountRepo.On("GetEntries", uint(1), openingDate, time.Time{}).Return([]models.Expense{
+			{ID: 3, Type: models.TypeIncome, Title: "salary", Amount: 500, Date: openingDate, AccountID: &cashID},
+			{ID: 4, Type: models.TypeTransfer, Title: "card payment", Amount: 300, Date: openingDate, AccountID: &cashID, ToAccountID: &visaID},
+			{ID: 5, Type: models.TypeTransfer, Title: "cash back", Amount: 100, Date: openingDate, AccountID: &visaID, ToAccountID: &cashID},
+		}, nil)
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		got, err := accountService.GetLedger("1", requests.AccountLedgerQuery{})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, float64(2300), got.ClosingBalance)
+		if assert.Len(t, got.Entries, 3) {
+			assert.Equal(t, float64(500), got.Entries[0].Change)
+			assert.Equal(t, float64(-300), got.Entries[1].Change)
+			assert.Equal(t, float64(100), got.Entries[2].Change)
+		}
+	})
+
+	t.Run("get ledger fail not found", func(t *testing.T) {
+		//arrange
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetByID", "9").Return(models.Account{}, errors.New(""))
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		_, err := accountService.GetLedger("9", requests.AccountLedgerQuery{})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+		}
+	})
+}
+
+func TestValidateExpenseService(t *testing.T) {
+	accountID := uint(2)
+
+	t.Run("expense in the currency of its account is valid", func(t *testing.T) {
+		//arrange
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetByID", "2").Return(accounts()[1], nil)
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		err := accountService.ProcessExpense(&models.Expense{Title: "dinner", Amount: 800, AccountID: &accountID})
+
+		//assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("expense fail bad request because it is in another currency than its account", func(t *testing.T) {
+		//arrange
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetByID", "2").Return(accounts()[1], nil)
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		err := accountService.ValidateExpense(models.Expense{Title: "dinner", Amount: 30, Currency: "USD", AccountID: &accountID})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, "the expense is in USD but account Visa is in THB", appErr.Message)
+		}
+	})
+
+	t.Run("expense fail bad request because its account does not exist", func(t *testing.T) {
+		//arrange
+		accountRepo := repositories.NewAccountRepositoryMock()
+		accountRepo.On("GetByID", "2").Return(models.Account{}, errors.New(""))
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		err := accountService.ValidateExpense(models.Expense{Title: "dinner", Amount: 800, AccountID: &accountID})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, "account 2 does not exist", appErr.Message)
+		}
+	})
+
+	t.Run("expense fail bad request because it is in a group ledger", func(t *testing.T) {
+		//arrange
+		groupID := uint(7)
+		accountRepo := repositories.NewAccountRepositoryMock()
+
+		accountService := services.NewAccountService(accountRepo)
+
+		//act
+		err := accountService.ValidateExpense(models.Expense{Title: "dinner", Amount: 800, AccountID: &accountID, GroupID: &groupID})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, "accounts are personal, an expense of a group cannot have one", appErr.Message)
+		}
+		accountRepo.AssertNotCalled(t, "GetByID", "2")
+	})
+}
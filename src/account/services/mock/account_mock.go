@@ -0,0 +1,56 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type accountServiceMock struct {
+	mock.Mock
+}
+
+func NewAccountServiceMock() *accountServiceMock {
+	return &accountServiceMock{}
+}
+
+func (m *accountServiceMock) CreateAccount(accountReq requests.AccountRequest) (responses.AccountResponse, error) {
+	args := m.Called(accountReq)
+	return args.Get(0).(responses.AccountResponse), args.Error(1)
+}
+
+func (m *accountServiceMock) GetAccountByID(id string) (responses.AccountResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.AccountResponse), args.Error(1)
+}
+
+func (m *accountServiceMock) UpdateAccountByID(id string, accountReq requests.AccountRequest) (responses.AccountResponse, error) {
+	args := m.Called(id, accountReq)
+	return args.Get(0).(responses.AccountResponse), args.Error(1)
+}
+
+func (m *accountServiceMock) DeleteAccountByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *accountServiceMock) GetAccounts() ([]responses.AccountResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.AccountResponse), args.Error(1)
+}
+
+func (m *accountServiceMock) GetLedger(id string, query requests.AccountLedgerQuery) (responses.AccountLedgerResponse, error) {
+	args := m.Called(id, query)
+	return args.Get(0).(responses.AccountLedgerResponse), args.Error(1)
+}
+
+func (m *accountServiceMock) ProcessExpense(expense *models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
+
+func (m *accountServiceMock) ValidateExpense(expense models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/alert/services"
+)
+
+type alertHandler struct {
+	alertService services.AlertService
+}
+
+func NewAlertHandler(alertService services.AlertService) alertHandler {
+	return alertHandler{alertService: alertService}
+}
+
+func (h alertHandler) GetAlerts(c *gin.Context) {
+	var query requests.AlertQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	alertsResp, err := h.alertService.GetAlerts(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, alertsResp)
+}
+
+func (h alertHandler) CreateWebhook(c *gin.Context) {
+	var webhookReq requests.AlertWebhookRequest
+	if err := c.ShouldBindJSON(&webhookReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	webhookResp, err := h.alertService.CreateWebhook(webhookReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhookResp)
+}
+
+func (h alertHandler) GetWebhooks(c *gin.Context) {
+	webhooksResp, err := h.alertService.GetWebhooks()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooksResp)
+}
+
+func (h alertHandler) DeleteWebhookByID(c *gin.Context) {
+	if err := h.alertService.DeleteWebhookByID(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,128 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/alert/handlers"
+	services "github.com/wytquant/assessment/src/alert/services/mock"
+)
+
+func TestGetAlertsHandler(t *testing.T) {
+	t.Run("get alert history success case", func(t *testing.T) {
+		//arrange
+		want := []responses.AlertResponse{{ID: 1, BudgetID: 2, Tag: "food", Threshold: 80, BudgetLimit: 5000, Spent: 4100, PercentUsed: 82}}
+
+		alertService := services.NewAlertServiceMock()
+		alertService.On("GetAlerts", requests.AlertQuery{BudgetID: 2}).Return(want, nil)
+
+		alertHandler := handlers.NewAlertHandler(alertService)
+
+		r := gin.Default()
+		r.GET("/alerts", alertHandler.GetAlerts)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/alerts?budget_id=2", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+		var got []responses.AlertResponse
+		json.NewDecoder(w.Body).Decode(&got)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		if !assert.ObjectsAreEqual(want, got) {
+			t.Errorf("not equal. want: %#v, got: %#v", want, got)
+		}
+	})
+
+	t.Run("get alert history fail bad request because limit is out of range", func(t *testing.T) {
+		//arrange
+		alertService := services.NewAlertServiceMock()
+		alertHandler := handlers.NewAlertHandler(alertService)
+
+		r := gin.Default()
+		r.GET("/alerts", alertHandler.GetAlerts)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/alerts?limit=1000", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCreateWebhookHandler(t *testing.T) {
+	t.Run("create webhook success case", func(t *testing.T) {
+		//arrange
+		want := responses.AlertWebhookResponse{ID: 1, URL: "http://hooks.local/alerts"}
+
+		alertService := services.NewAlertServiceMock()
+		alertService.On("CreateWebhook").Return(want, nil)
+
+		alertHandler := handlers.NewAlertHandler(alertService)
+
+		r := gin.Default()
+		r.POST("/alerts/webhooks", alertHandler.CreateWebhook)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/alerts/webhooks", strings.NewReader(`{"url": "http://hooks.local/alerts"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("create webhook fail bad request because url is invalid", func(t *testing.T) {
+		//arrange
+		alertService := services.NewAlertServiceMock()
+		alertHandler := handlers.NewAlertHandler(alertService)
+
+		r := gin.Default()
+		r.POST("/alerts/webhooks", alertHandler.CreateWebhook)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/alerts/webhooks", strings.NewReader(`{"url": "not a url"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("delete webhook fail case record not found", func(t *testing.T) {
+		//arrange
+		alertService := services.NewAlertServiceMock()
+		alertService.On("DeleteWebhookByID", "9").Return(helpers.NewNotFoundError())
+
+		alertHandler := handlers.NewAlertHandler(alertService)
+
+		r := gin.Default()
+		r.DELETE("/alerts/webhooks/:id", alertHandler.DeleteWebhookByID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/alerts/webhooks/9", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type alertRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewAlertRepositoryDB(db *gorm.DB) AlertRepository {
+	return alertRepositoryDB{db: db}
+}
+
+// CreateAlert stores the alert together with its pending deliveries. It
+// reports false without queueing anything when the same budget already
+// alerted on this threshold for the period.
+func (r alertRepositoryDB) CreateAlert(alert *models.Alert, deliveries []models.AlertDelivery, emails []models.EmailOutbox) (bool, error) {
+	created := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit("Deliveries").Create(alert)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		created = true
+
+		for i := range deliveries {
+			deliveries[i].AlertID = alert.ID
+		}
+		for i := range emails {
+			emails[i].AlertID = alert.ID
+		}
+
+		if len(deliveries) > 0 {
+			if err := tx.Create(&deliveries).Error; err != nil {
+				return err
+			}
+		}
+		if len(emails) > 0 {
+			if err := tx.Create(&emails).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return created, err
+}
+
+func (r alertRepositoryDB) GetAlerts(filter AlertFilter) ([]models.Alert, error) {
+	var alerts []models.Alert
+
+	query := r.db.Preload("Deliveries").Order("created_at DESC, id DESC")
+	if filter.BudgetID != 0 {
+		query = query.Where("budget_id = ?", filter.BudgetID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+func (r alertRepositoryDB) CreateWebhook(webhook *models.AlertWebhook) error {
+	query := r.db
+	if err := query.Create(webhook).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r alertRepositoryDB) GetWebhooks() ([]models.AlertWebhook, error) {
+	query := r.db
+	var webhooks []models.AlertWebhook
+
+	if err := query.Order("id").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r alertRepositoryDB) DeleteWebhookByID(id string) error {
+	query := r.db
+	result := query.Where("id = $1", id).Delete(&models.AlertWebhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries leases pending deliveries by pushing their next attempt
+// into the future, so concurrent dispatchers never pick the same row.
+func (r alertRepositoryDB) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.AlertDelivery, error) {
+	var deliveries []models.AlertDelivery
+
+	err := r.db.Raw(`UPDATE alert_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM alert_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), models.DeliveryPending, now, limit).Scan(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r alertRepositoryDB) UpdateDelivery(delivery models.AlertDelivery) error {
+	query := r.db
+	if err := query.Model(&delivery).Select("status", "attempts", "next_attempt_at", "last_error").Updates(delivery).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r alertRepositoryDB) ClaimDueEmails(now time.Time, lease time.Duration, limit int) ([]models.EmailOutbox, error) {
+	var emails []models.EmailOutbox
+
+	err := r.db.Raw(`UPDATE email_outbox SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM email_outbox
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), models.DeliveryPending, now, limit).Scan(&emails).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return emails, nil
+}
+
+func (r alertRepositoryDB) UpdateEmail(email models.EmailOutbox) error {
+	query := r.db
+	if err := query.Model(&email).Select("status", "attempts", "next_attempt_at", "last_error").Updates(email).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type alertRepositoryMock struct {
+	mock.Mock
+}
+
+func NewAlertRepositoryMock() *alertRepositoryMock {
+	return &alertRepositoryMock{}
+}
+
+func (m *alertRepositoryMock) CreateAlert(alert *models.Alert, deliveries []models.AlertDelivery, emails []models.EmailOutbox) (bool, error) {
+	args := m.Called(*alert, deliveries, emails)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *alertRepositoryMock) GetAlerts(filter AlertFilter) ([]models.Alert, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]models.Alert), args.Error(1)
+}
+
+func (m *alertRepositoryMock) CreateWebhook(webhook *models.AlertWebhook) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *alertRepositoryMock) GetWebhooks() ([]models.AlertWebhook, error) {
+	args := m.Called()
+	return args.Get(0).([]models.AlertWebhook), args.Error(1)
+}
+
+func (m *alertRepositoryMock) DeleteWebhookByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *alertRepositoryMock) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.AlertDelivery, error) {
+	args := m.Called()
+	return args.Get(0).([]models.AlertDelivery), args.Error(1)
+}
+
+func (m *alertRepositoryMock) UpdateDelivery(delivery models.AlertDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *alertRepositoryMock) ClaimDueEmails(now time.Time, lease time.Duration, limit int) ([]models.EmailOutbox, error) {
+	args := m.Called()
+	return args.Get(0).([]models.EmailOutbox), args.Error(1)
+}
+
+func (m *alertRepositoryMock) UpdateEmail(email models.EmailOutbox) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+type AlertFilter struct {
+	BudgetID uint
+	Limit    int
+}
+
+type AlertRepository interface {
+	CreateAlert(alert *models.Alert, deliveries []models.AlertDelivery, emails []models.EmailOutbox) (bool, error)
+	GetAlerts(filter AlertFilter) ([]models.Alert, error)
+	CreateWebhook(*models.AlertWebhook) error
+	GetWebhooks() ([]models.AlertWebhook, error)
+	DeleteWebhookByID(id string) error
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.AlertDelivery, error)
+	UpdateDelivery(delivery models.AlertDelivery) error
+	ClaimDueEmails(now time.Time, lease time.Duration, limit int) ([]models.EmailOutbox, error)
+	UpdateEmail(email models.EmailOutbox) error
+}
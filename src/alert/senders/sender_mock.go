@@ -0,0 +1,29 @@
+package senders
+
+import "github.com/stretchr/testify/mock"
+
+type webhookSenderMock struct {
+	mock.Mock
+}
+
+func NewWebhookSenderMock() *webhookSenderMock {
+	return &webhookSenderMock{}
+}
+
+func (m *webhookSenderMock) Send(url string, payload []byte, headers map[string]string) (int, error) {
+	args := m.Called(url, payload, headers)
+	return args.Int(0), args.Error(1)
+}
+
+type emailSenderMock struct {
+	mock.Mock
+}
+
+func NewEmailSenderMock() *emailSenderMock {
+	return &emailSenderMock{}
+}
+
+func (m *emailSenderMock) Send(recipients []string, subject string, body string) error {
+	args := m.Called(recipients)
+	return args.Error(0)
+}
@@ -0,0 +1,11 @@
+package senders
+
+// WebhookSender posts a payload with the headers and returns the status
+// code of the response, 0 when there was none.
+type WebhookSender interface {
+	Send(url string, payload []byte, headers map[string]string) (int, error)
+}
+
+type EmailSender interface {
+	Send(recipients []string, subject string, body string) error
+}
@@ -0,0 +1,39 @@
+package senders
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type smtpEmailSender struct {
+	addr     string
+	from     string
+	username string
+	password string
+}
+
+// NewSMTPEmailSender sends plain text mails through addr (host:port). Leave
+// username empty for mail catchers such as MailHog that accept anonymous
+// submissions.
+func NewSMTPEmailSender(addr string, from string, username string, password string) EmailSender {
+	return smtpEmailSender{addr: addr, from: from, username: username, password: password}
+}
+
+func (s smtpEmailSender) Send(recipients []string, subject string, body string) error {
+	var auth smtp.Auth
+	if s.username != "" {
+		host, _, err := net.SplitHostPort(s.addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", s.username, s.password, host)
+	}
+
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		s.from, strings.Join(recipients, ", "), mime.QEncoding.Encode("utf-8", subject), body)
+
+	return smtp.SendMail(s.addr, auth, s.from, recipients, []byte(msg))
+}
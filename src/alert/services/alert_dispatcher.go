@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunDispatcher delivers pending alerts every interval until ctx is done.
+func RunDispatcher(ctx context.Context, alertService AlertService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := alertService.DispatchPending(now); err != nil {
+				log.Println("fail to dispatch alerts:", err)
+			}
+		}
+	}
+}
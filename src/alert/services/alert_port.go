@@ -0,0 +1,20 @@
+package services
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type AlertService interface {
+	ExpenseCreated(expense models.Expense)
+	ExpenseUpdated(expense models.Expense)
+	CheckThresholds(expense models.Expense) error
+	DispatchPending(now time.Time) error
+	GetAlerts(query requests.AlertQuery) ([]responses.AlertResponse, error)
+	CreateWebhook(webhookReq requests.AlertWebhookRequest) (responses.AlertWebhookResponse, error)
+	GetWebhooks() ([]responses.AlertWebhookResponse, error)
+	DeleteWebhookByID(id string) error
+}
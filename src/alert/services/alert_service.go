@@ -0,0 +1,268 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/alert/repositories"
+	"github.com/wytquant/assessment/src/alert/senders"
+	budgetServices "github.com/wytquant/assessment/src/budget/services"
+)
+
+var alertThresholds = []int{50, 80, 100}
+
+const (
+	maxDeliveryAttempts = 5
+	deliveryRetryBase   = 30 * time.Second
+	deliveryLease       = time.Minute
+	deliveryBatchSize   = 50
+)
+
+type alertService struct {
+	alertRepo     repositories.AlertRepository
+	budgetService budgetServices.BudgetService
+	webhookSender senders.WebhookSender
+	emailSender   senders.EmailSender
+	recipients    []string
+}
+
+// NewAlertService creates the alert service. Emails are only queued when
+// both an email sender and at least one recipient are configured.
+func NewAlertService(alertRepo repositories.AlertRepository, budgetService budgetServices.BudgetService, webhookSender senders.WebhookSender, emailSender senders.EmailSender, recipients []string) AlertService {
+	return alertService{
+		alertRepo:     alertRepo,
+		budgetService: budgetService,
+		webhookSender: webhookSender,
+		emailSender:   emailSender,
+		recipients:    recipients,
+	}
+}
+
+func (s alertService) ExpenseCreated(expense models.Expense) {
+	// budgets cover the personal ledger only
+	if expense.GroupID != nil {
+		return
+	}
+	if err := s.CheckThresholds(expense); err != nil {
+		log.Println("fail to check budget thresholds:", err)
+	}
+}
+
+// ExpenseUpdated does nothing, alerts are raised when spending is added.
+func (s alertService) ExpenseUpdated(expense models.Expense) {}
+
+func (s alertService) CheckThresholds(expense models.Expense) error {
+	date := expense.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	statuses, err := s.budgetService.GetBudgetStatus(requests.BudgetStatusQuery{Date: date})
+	if err != nil {
+		return err
+	}
+
+	var webhooks []models.AlertWebhook
+	webhooksLoaded := false
+
+	for _, status := range statuses {
+		if status.Tag != "" && !containsTag(expense.Tags, status.Tag) {
+			continue
+		}
+
+		for _, threshold := range alertThresholds {
+			if status.PercentUsed < float64(threshold) {
+				break
+			}
+
+			if !webhooksLoaded {
+				if webhooks, err = s.alertRepo.GetWebhooks(); err != nil {
+					return err
+				}
+				webhooksLoaded = true
+			}
+
+			alert := models.Alert{
+				BudgetID:    status.BudgetID,
+				ExpenseID:   expense.ID,
+				Tag:         status.Tag,
+				Period:      status.Period,
+				PeriodStart: status.PeriodStart,
+				Threshold:   threshold,
+				BudgetLimit: status.Available,
+				Spent:       status.Spent,
+				PercentUsed: status.PercentUsed,
+			}
+
+			if _, err := s.alertRepo.CreateAlert(&alert, s.webhookDeliveries(alert, webhooks), s.emails(alert)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s alertService) webhookDeliveries(alert models.Alert, webhooks []models.AlertWebhook) []models.AlertDelivery {
+	deliveries := []models.AlertDelivery{}
+	if len(webhooks) == 0 {
+		return deliveries
+	}
+
+	var event responses.AlertEvent
+	copier.Copy(&event, &alert)
+	event.Event = "budget.threshold_reached"
+	event.Limit = alert.BudgetLimit
+	payload, _ := json.Marshal(event)
+
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, models.AlertDelivery{
+			URL:           webhook.URL,
+			Payload:       string(payload),
+			Status:        models.DeliveryPending,
+			NextAttemptAt: time.Now(),
+		})
+	}
+
+	return deliveries
+}
+
+func (s alertService) emails(alert models.Alert) []models.EmailOutbox {
+	if s.emailSender == nil || len(s.recipients) == 0 {
+		return []models.EmailOutbox{}
+	}
+
+	name := alert.Tag
+	if name == "" {
+		name = "overall"
+	}
+
+	return []models.EmailOutbox{{
+		Recipients: strings.Join(s.recipients, ","),
+		Subject:    fmt.Sprintf("Budget alert: %s reached %d%% of its %s limit", name, alert.Threshold, alert.Period),
+		Body: fmt.Sprintf("Your %s %s budget starting %s has used %.2f of %.2f (%.2f%%).",
+			name, alert.Period, alert.PeriodStart.Format("2006-01-02"), alert.Spent, alert.BudgetLimit, alert.PercentUsed),
+		Status:        models.DeliveryPending,
+		NextAttemptAt: time.Now(),
+	}}
+}
+
+// DispatchPending sends due webhook deliveries and emails once, rescheduling
+// failures with exponential backoff until maxDeliveryAttempts is reached.
+func (s alertService) DispatchPending(now time.Time) error {
+	deliveries, err := s.alertRepo.ClaimDueDeliveries(now, deliveryLease, deliveryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		_, sendErr := s.webhookSender.Send(delivery.URL, []byte(delivery.Payload), nil)
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastError = nextDeliveryState(now, delivery.Attempts, sendErr)
+		if err := s.alertRepo.UpdateDelivery(delivery); err != nil {
+			return err
+		}
+	}
+
+	if s.emailSender == nil {
+		return nil
+	}
+
+	emails, err := s.alertRepo.ClaimDueEmails(now, deliveryLease, deliveryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, email := range emails {
+		email.Status, email.Attempts, email.NextAttemptAt, email.LastError =
+			nextDeliveryState(now, email.Attempts, s.emailSender.Send(strings.Split(email.Recipients, ","), email.Subject, email.Body))
+		if err := s.alertRepo.UpdateEmail(email); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func nextDeliveryState(now time.Time, attempts int, sendErr error) (string, int, time.Time, string) {
+	attempts++
+	if sendErr == nil {
+		return models.DeliverySent, attempts, now, ""
+	}
+	if attempts >= maxDeliveryAttempts {
+		return models.DeliveryFailed, attempts, now, sendErr.Error()
+	}
+
+	return models.DeliveryPending, attempts, now.Add(deliveryRetryBase << (attempts - 1)), sendErr.Error()
+}
+
+func (s alertService) GetAlerts(query requests.AlertQuery) ([]responses.AlertResponse, error) {
+	alertsResp := []responses.AlertResponse{}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = 100
+	}
+
+	alerts, err := s.alertRepo.GetAlerts(repositories.AlertFilter{BudgetID: query.BudgetID, Limit: limit})
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&alertsResp, &alerts)
+
+	return alertsResp, nil
+}
+
+func (s alertService) CreateWebhook(webhookReq requests.AlertWebhookRequest) (responses.AlertWebhookResponse, error) {
+	var webhook models.AlertWebhook
+	var webhookResp responses.AlertWebhookResponse
+
+	copier.Copy(&webhook, &webhookReq)
+
+	if err := s.alertRepo.CreateWebhook(&webhook); err != nil {
+		return responses.AlertWebhookResponse{}, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&webhookResp, &webhook)
+
+	return webhookResp, nil
+}
+
+func (s alertService) GetWebhooks() ([]responses.AlertWebhookResponse, error) {
+	webhooksResp := []responses.AlertWebhookResponse{}
+
+	webhooks, err := s.alertRepo.GetWebhooks()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&webhooksResp, &webhooks)
+
+	return webhooksResp, nil
+}
+
+func (s alertService) DeleteWebhookByID(id string) error {
+	if err := s.alertRepo.DeleteWebhookByID(id); err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
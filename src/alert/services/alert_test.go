@@ -0,0 +1,221 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/alert/repositories"
+	"github.com/wytquant/assessment/src/alert/senders"
+	"github.com/wytquant/assessment/src/alert/services"
+	budgetServices "github.com/wytquant/assessment/src/budget/services/mock"
+)
+
+func TestCheckThresholdsService(t *testing.T) {
+	expenseDate := time.Date(2023, 4, 10, 0, 0, 0, 0, time.UTC)
+	periodStart := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("record an alert for every threshold reached by a matching budget", func(t *testing.T) {
+		//arrange
+		budgetService := budgetServices.NewBudgetServiceMock()
+		budgetService.On("GetBudgetStatus", requests.BudgetStatusQuery{Date: expenseDate}).Return([]responses.BudgetStatusResponse{
+			{BudgetID: 1, Tag: "food", Period: "monthly", PeriodStart: periodStart, Available: 5000, Spent: 4200, PercentUsed: 84},
+			{BudgetID: 2, Tag: "travel", Period: "monthly", PeriodStart: periodStart, Available: 1000, Spent: 1000, PercentUsed: 100},
+		}, nil)
+
+		alertRepo := repositories.NewAlertRepositoryMock()
+		alertRepo.On("GetWebhooks").Return([]models.AlertWebhook{{ID: 1, URL: "http://hooks.local/alerts"}}, nil)
+		alertRepo.On("CreateAlert", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+		alertService := services.NewAlertService(alertRepo, budgetService, senders.NewWebhookSenderMock(), senders.NewEmailSenderMock(), []string{"me@example.com"})
+
+		//act
+		err := alertService.CheckThresholds(models.Expense{ID: 7, Amount: 200, Tags: pq.StringArray{"food"}, Date: expenseDate})
+
+		//assert
+		assert.NoError(t, err)
+		alertRepo.AssertNumberOfCalls(t, "CreateAlert", 2)
+
+		thresholds := []int{}
+		for _, call := range alertRepo.Calls {
+			if call.Method != "CreateAlert" {
+				continue
+			}
+			alert := call.Arguments.Get(0).(models.Alert)
+			deliveries := call.Arguments.Get(1).([]models.AlertDelivery)
+			emails := call.Arguments.Get(2).([]models.EmailOutbox)
+
+			assert.Equal(t, uint(1), alert.BudgetID)
+			assert.Equal(t, uint(7), alert.ExpenseID)
+			assert.Equal(t, 1, len(deliveries))
+			assert.Equal(t, "http://hooks.local/alerts", deliveries[0].URL)
+			assert.Equal(t, 1, len(emails))
+			thresholds = append(thresholds, alert.Threshold)
+		}
+		assert.Equal(t, []int{50, 80}, thresholds)
+	})
+
+	t.Run("skip emails when no email sender is configured", func(t *testing.T) {
+		//arrange
+		budgetService := budgetServices.NewBudgetServiceMock()
+		budgetService.On("GetBudgetStatus", requests.BudgetStatusQuery{Date: expenseDate}).Return([]responses.BudgetStatusResponse{
+			{BudgetID: 3, Period: "monthly", PeriodStart: periodStart, Available: 1000, Spent: 600, PercentUsed: 60},
+		}, nil)
+
+		alertRepo := repositories.NewAlertRepositoryMock()
+		alertRepo.On("GetWebhooks").Return([]models.AlertWebhook{}, nil)
+		alertRepo.On("CreateAlert", mock.Anything, []models.AlertDelivery{}, []models.EmailOutbox{}).Return(true, nil)
+
+		alertService := services.NewAlertService(alertRepo, budgetService, senders.NewWebhookSenderMock(), nil, []string{"me@example.com"})
+
+		//act
+		err := alertService.CheckThresholds(models.Expense{ID: 8, Amount: 100, Date: expenseDate})
+
+		//assert
+		assert.NoError(t, err)
+		alertRepo.AssertNumberOfCalls(t, "CreateAlert", 1)
+	})
+
+	t.Run("check thresholds fail case because budget status is unavailable", func(t *testing.T) {
+		//arrange
+		budgetService := budgetServices.NewBudgetServiceMock()
+		budgetService.On("GetBudgetStatus", requests.BudgetStatusQuery{Date: expenseDate}).
+			Return([]responses.BudgetStatusResponse{}, helpers.NewInternalServerError())
+
+		alertService := services.NewAlertService(repositories.NewAlertRepositoryMock(), budgetService, senders.NewWebhookSenderMock(), nil, nil)
+
+		//act
+		err := alertService.CheckThresholds(models.Expense{Date: expenseDate})
+
+		//assert
+		assert.Error(t, err)
+	})
+}
+
+func TestDispatchPendingService(t *testing.T) {
+	now := time.Date(2023, 4, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("mark delivered webhooks and emails as sent", func(t *testing.T) {
+		//arrange
+		alertRepo := repositories.NewAlertRepositoryMock()
+		alertRepo.On("ClaimDueDeliveries").Return([]models.AlertDelivery{{ID: 1, URL: "http://hooks.local/alerts", Status: models.DeliveryPending}}, nil)
+		alertRepo.On("UpdateDelivery", models.AlertDelivery{ID: 1, URL: "http://hooks.local/alerts", Status: models.DeliverySent, Attempts: 1, NextAttemptAt: now}).Return(nil)
+		alertRepo.On("ClaimDueEmails").Return([]models.EmailOutbox{{ID: 2, Recipients: "a@example.com,b@example.com", Status: models.DeliveryPending}}, nil)
+		alertRepo.On("UpdateEmail", models.EmailOutbox{ID: 2, Recipients: "a@example.com,b@example.com", Status: models.DeliverySent, Attempts: 1, NextAttemptAt: now}).Return(nil)
+
+		webhookSender := senders.NewWebhookSenderMock()
+		webhookSender.On("Send", "http://hooks.local/alerts", mock.Anything, mock.Anything).Return(http.StatusOK, nil)
+		emailSender := senders.NewEmailSenderMock()
+		emailSender.On("Send", []string{"a@example.com", "b@example.com"}).Return(nil)
+
+		alertService := services.NewAlertService(alertRepo, budgetServices.NewBudgetServiceMock(), webhookSender, emailSender, nil)
+
+		//act
+		err := alertService.DispatchPending(now)
+
+		//assert
+		assert.NoError(t, err)
+		alertRepo.AssertExpectations(t)
+	})
+
+	t.Run("reschedule failed webhook with exponential backoff", func(t *testing.T) {
+		//arrange
+		alertRepo := repositories.NewAlertRepositoryMock()
+		alertRepo.On("ClaimDueDeliveries").Return([]models.AlertDelivery{{ID: 1, URL: "http://hooks.local/alerts", Status: models.DeliveryPending, Attempts: 2}}, nil)
+		alertRepo.On("UpdateDelivery", models.AlertDelivery{
+			ID:            1,
+			URL:           "http://hooks.local/alerts",
+			Status:        models.DeliveryPending,
+			Attempts:      3,
+			NextAttemptAt: now.Add(2 * time.Minute),
+			LastError:     "connection refused",
+		}).Return(nil)
+
+		webhookSender := senders.NewWebhookSenderMock()
+		webhookSender.On("Send", "http://hooks.local/alerts", mock.Anything, mock.Anything).Return(0, errors.New("connection refused"))
+
+		alertService := services.NewAlertService(alertRepo, budgetServices.NewBudgetServiceMock(), webhookSender, nil, nil)
+
+		//act
+		err := alertService.DispatchPending(now)
+
+		//assert
+		assert.NoError(t, err)
+		alertRepo.AssertExpectations(t)
+	})
+
+	t.Run("give up on webhook after the last attempt", func(t *testing.T) {
+		//arrange
+		alertRepo := repositories.NewAlertRepositoryMock()
+		alertRepo.On("ClaimDueDeliveries").Return([]models.AlertDelivery{{ID: 1, URL: "http://hooks.local/alerts", Status: models.DeliveryPending, Attempts: 4}}, nil)
+		alertRepo.On("UpdateDelivery", models.AlertDelivery{
+			ID:            1,
+			URL:           "http://hooks.local/alerts",
+			Status:        models.DeliveryFailed,
+			Attempts:      5,
+			NextAttemptAt: now,
+			LastError:     "webhook responded with status 500",
+		}).Return(nil)
+
+		webhookSender := senders.NewWebhookSenderMock()
+		webhookSender.On("Send", "http://hooks.local/alerts", mock.Anything, mock.Anything).Return(http.StatusInternalServerError, errors.New("webhook responded with status 500"))
+
+		alertService := services.NewAlertService(alertRepo, budgetServices.NewBudgetServiceMock(), webhookSender, nil, nil)
+
+		//act
+		err := alertService.DispatchPending(now)
+
+		//assert
+		assert.NoError(t, err)
+		alertRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetAlertsService(t *testing.T) {
+	t.Run("get alerts applies default limit", func(t *testing.T) {
+		//arrange
+		alertRepo := repositories.NewAlertRepositoryMock()
+		alertRepo.On("GetAlerts", repositories.AlertFilter{BudgetID: 1, Limit: 100}).Return([]models.Alert{
+			{ID: 1, BudgetID: 1, Threshold: 50, Deliveries: []models.AlertDelivery{{ID: 1, Status: models.DeliverySent}}},
+		}, nil)
+
+		alertService := services.NewAlertService(alertRepo, budgetServices.NewBudgetServiceMock(), senders.NewWebhookSenderMock(), nil, nil)
+
+		//act
+		got, err := alertService.GetAlerts(requests.AlertQuery{BudgetID: 1})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 1, len(got)) {
+			assert.Equal(t, 50, got[0].Threshold)
+			assert.Equal(t, models.DeliverySent, got[0].Deliveries[0].Status)
+		}
+	})
+
+	t.Run("get alerts fail case because internal server error", func(t *testing.T) {
+		//arrange
+		alertRepo := repositories.NewAlertRepositoryMock()
+		alertRepo.On("GetAlerts", repositories.AlertFilter{Limit: 100}).Return([]models.Alert{}, helpers.NewInternalServerError())
+
+		alertService := services.NewAlertService(alertRepo, budgetServices.NewBudgetServiceMock(), senders.NewWebhookSenderMock(), nil, nil)
+
+		//act
+		_, err := alertService.GetAlerts(requests.AlertQuery{})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusInternalServerError, appErr.StatusCode)
+		}
+	})
+}
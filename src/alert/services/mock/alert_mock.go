@@ -0,0 +1,56 @@
+package services
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type alertServiceMock struct {
+	mock.Mock
+}
+
+func NewAlertServiceMock() *alertServiceMock {
+	return &alertServiceMock{}
+}
+
+func (m *alertServiceMock) ExpenseCreated(expense models.Expense) {
+	m.Called(expense)
+}
+
+func (m *alertServiceMock) ExpenseUpdated(expense models.Expense) {
+	m.Called(expense)
+}
+
+func (m *alertServiceMock) CheckThresholds(expense models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
+
+func (m *alertServiceMock) DispatchPending(now time.Time) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *alertServiceMock) GetAlerts(query requests.AlertQuery) ([]responses.AlertResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).([]responses.AlertResponse), args.Error(1)
+}
+
+func (m *alertServiceMock) CreateWebhook(webhookReq requests.AlertWebhookRequest) (responses.AlertWebhookResponse, error) {
+	args := m.Called()
+	return args.Get(0).(responses.AlertWebhookResponse), args.Error(1)
+}
+
+func (m *alertServiceMock) GetWebhooks() ([]responses.AlertWebhookResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.AlertWebhookResponse), args.Error(1)
+}
+
+func (m *alertServiceMock) DeleteWebhookByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
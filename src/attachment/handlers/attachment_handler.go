@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/src/attachment/services"
+)
+
+type attachmentHandler struct {
+	attachmentService services.AttachmentService
+}
+
+func NewAttachmentHandler(attachmentService services.AttachmentService) attachmentHandler {
+	return attachmentHandler{attachmentService: attachmentService}
+}
+
+func (h attachmentHandler) UploadAttachment(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "file is required"})
+		return
+	}
+	if fileHeader.Size > services.MaxAttachmentSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"message": "file must not be larger than 10 MB"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	attachmentResp, err := h.attachmentService.UploadAttachment(c.Param("id"), fileHeader.Filename, content)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachmentResp)
+}
+
+func (h attachmentHandler) GetAttachments(c *gin.Context) {
+	attachmentsResp, err := h.attachmentService.GetAttachments(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, attachmentsResp)
+}
+
+func (h attachmentHandler) DownloadAttachment(c *gin.Context) {
+	attachmentResp, content, err := h.attachmentService.GetAttachmentContent(c.Param("id"), c.Param("attachment_id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+	defer content.Close()
+
+	// served as a download so an uploaded file is never rendered inline
+	c.DataFromReader(http.StatusOK, attachmentResp.Size, attachmentResp.ContentType, content, map[string]string{
+		"Content-Disposition":    fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(attachmentResp.Filename)),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h attachmentHandler) GetThumbnail(c *gin.Context) {
+	content, err := h.attachmentService.GetThumbnail(c.Param("id"), c.Param("attachment_id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, -1, "image/jpeg", content, map[string]string{
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h attachmentHandler) DeleteAttachment(c *gin.Context) {
+	if err := h.attachmentService.DeleteAttachment(c.Param("id"), c.Param("attachment_id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,168 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/attachment/handlers"
+	services "github.com/wytquant/assessment/src/attachment/services/mock"
+)
+
+func multipartBody(t *testing.T, filename string, content string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if filename != "" {
+		part, err := writer.CreateFormFile("file", filename)
+		assert.NoError(t, err)
+		part.Write([]byte(content))
+	}
+	writer.Close()
+
+	return body, writer.FormDataContentType()
+}
+
+func TestUploadAttachmentHandler(t *testing.T) {
+	t.Run("upload attachment success case", func(t *testing.T) {
+		//arrange
+		attachmentService := services.NewAttachmentServiceMock()
+		attachmentService.On("UploadAttachment", "1", "receipt.pdf", "%PDF-1.4").Return(responses.AttachmentResponse{ID: 1, ExpenseID: 1, Filename: "receipt.pdf"}, nil)
+
+		attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+
+		r := gin.Default()
+		r.POST("/expenses/:id/attachments", attachmentHandler.UploadAttachment)
+
+		body, contentType := multipartBody(t, "receipt.pdf", "%PDF-1.4")
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/expenses/1/attachments", body)
+		req.Header.Set("Content-Type", contentType)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		attachmentService.AssertExpectations(t)
+	})
+
+	t.Run("upload attachment fail bad request because file is missing", func(t *testing.T) {
+		//arrange
+		attachmentHandler := handlers.NewAttachmentHandler(services.NewAttachmentServiceMock())
+
+		r := gin.Default()
+		r.POST("/expenses/:id/attachments", attachmentHandler.UploadAttachment)
+
+		body, contentType := multipartBody(t, "", "")
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/expenses/1/attachments", body)
+		req.Header.Set("Content-Type", contentType)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("upload attachment fail case because the type is not accepted", func(t *testing.T) {
+		//arrange
+		attachmentService := services.NewAttachmentServiceMock()
+		attachmentService.On("UploadAttachment", "1", "notes.txt", "hello").Return(responses.AttachmentResponse{}, helpers.NewUnsupportedMediaTypeError("text/plain; charset=utf-8 files are not accepted, upload an image or a PDF"))
+
+		attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+
+		r := gin.Default()
+		r.POST("/expenses/:id/attachments", attachmentHandler.UploadAttachment)
+
+		body, contentType := multipartBody(t, "notes.txt", "hello")
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/expenses/1/attachments", body)
+		req.Header.Set("Content-Type", contentType)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+}
+
+func TestDownloadAttachmentHandler(t *testing.T) {
+	t.Run("download attachment success case", func(t *testing.T) {
+		//arrange
+		attachmentService := services.NewAttachmentServiceMock()
+		attachmentService.On("GetAttachmentContent", "1", "2").Return(responses.AttachmentResponse{ID: 2, Filename: "ใบเสร็จ.pdf", ContentType: "application/pdf", Size: 8}, "%PDF-1.4", nil)
+
+		attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+
+		r := gin.Default()
+		r.GET("/expenses/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachment)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/1/attachments/2", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename*=UTF-8''%E0%B9%83")
+		assert.Equal(t, "%PDF-1.4", w.Body.String())
+	})
+
+	t.Run("download attachment fail case because it does not exist", func(t *testing.T) {
+		//arrange
+		attachmentService := services.NewAttachmentServiceMock()
+		attachmentService.On("GetAttachmentContent", "1", "2").Return(responses.AttachmentResponse{}, "", helpers.NewNotFoundError())
+
+		attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+
+		r := gin.Default()
+		r.GET("/expenses/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachment)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/1/attachments/2", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestDeleteAttachmentHandler(t *testing.T) {
+	t.Run("delete attachment success case", func(t *testing.T) {
+		//arrange
+		attachmentService := services.NewAttachmentServiceMock()
+		attachmentService.On("DeleteAttachment", "1", "2").Return(nil)
+
+		attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+
+		r := gin.Default()
+		r.DELETE("/expenses/:id/attachments/:attachment_id", attachmentHandler.DeleteAttachment)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/expenses/1/attachments/2", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+)
+
+type attachmentRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepositoryDB(db *gorm.DB) AttachmentRepository {
+	return attachmentRepositoryDB{db: db}
+}
+
+func (r attachmentRepositoryDB) Create(attachment *models.Attachment) error {
+	query := r.db
+	if err := query.Create(attachment).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r attachmentRepositoryDB) GetByID(expenseID string, id string) (models.Attachment, error) {
+	var attachment models.Attachment
+	query := r.db
+	if err := query.Where("expense_id = ? AND id = ?", expenseID, id).First(&attachment).Error; err != nil {
+		return models.Attachment{}, err
+	}
+
+	return attachment, nil
+}
+
+func (r attachmentRepositoryDB) GetAllByExpenseID(expenseID string) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	query := r.db
+	if err := query.Where("expense_id = ?", expenseID).Order("id").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r attachmentRepositoryDB) DeleteByID(expenseID string, id string) error {
+	query := r.db.Where("expense_id = ? AND id = ?", expenseID, id).Delete(&models.Attachment{})
+	if err := query.Error; err != nil {
+		return err
+	}
+	if query.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type attachmentRepositoryMock struct {
+	mock.Mock
+}
+
+func NewAttachmentRepositoryMock() *attachmentRepositoryMock {
+	return &attachmentRepositoryMock{}
+}
+
+func (m *attachmentRepositoryMock) Create(attachment *models.Attachment) error {
+	args := m.Called(attachment)
+	return args.Error(0)
+}
+
+func (m *attachmentRepositoryMock) GetByID(expenseID string, id string) (models.Attachment, error) {
+	args := m.Called(expenseID, id)
+	return args.Get(0).(models.Attachment), args.Error(1)
+}
+
+func (m *attachmentRepositoryMock) GetAllByExpenseID(expenseID string) ([]models.Attachment, error) {
+	args := m.Called(expenseID)
+	return args.Get(0).([]models.Attachment), args.Error(1)
+}
+
+func (m *attachmentRepositoryMock) DeleteByID(expenseID string, id string) error {
+	args := m.Called(expenseID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,10 @@
+package repositories
+
+import "github.com/wytquant/assessment/models"
+
+type AttachmentRepository interface {
+	Create(*models.Attachment) error
+	GetByID(expenseID string, id string) (models.Attachment, error)
+	GetAllByExpenseID(expenseID string) ([]models.Attachment, error)
+	DeleteByID(expenseID string, id string) error
+}
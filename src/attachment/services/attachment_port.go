@@ -0,0 +1,15 @@
+package services
+
+import (
+	"io"
+
+	"github.com/wytquant/assessment/responses"
+)
+
+type AttachmentService interface {
+	UploadAttachment(expenseID string, filename string, content []byte) (responses.AttachmentResponse, error)
+	GetAttachments(expenseID string) ([]responses.AttachmentResponse, error)
+	GetAttachmentContent(expenseID string, id string) (responses.AttachmentResponse, io.ReadCloser, error)
+	GetThumbnail(expenseID string, id string) (io.ReadCloser, error)
+	DeleteAttachment(expenseID string, id string) error
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/attachment/repositories"
+	"github.com/wytquant/assessment/src/attachment/storage"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+)
+
+// MaxAttachmentSize is the largest accepted upload.
+const MaxAttachmentSize = 10 << 20
+
+// allowedTypes maps the sniffed content types accepted as receipts to the
+// extension of the stored blob.
+var allowedTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+type attachmentService struct {
+	attachmentRepo repositories.AttachmentRepository
+	expenseRepo    expenseRepositories.ExpenseRepository
+	storage        storage.Storage
+}
+
+func NewAttachmentService(attachmentRepo repositories.AttachmentRepository, expenseRepo expenseRepositories.ExpenseRepository, storage storage.Storage) AttachmentService {
+	return attachmentService{attachmentRepo: attachmentRepo, expenseRepo: expenseRepo, storage: storage}
+}
+
+// UploadAttachment stores the file and its thumbnail before the metadata, a
+// failed insert removes the blobs again.
+func (s attachmentService) UploadAttachment(expenseID string, filename string, content []byte) (responses.AttachmentResponse, error) {
+	expense, err := s.expenseRepo.GetByID(expenseID)
+	if err != nil {
+		return responses.AttachmentResponse{}, helpers.NewNotFoundError()
+	}
+
+	if len(content) == 0 {
+		return responses.AttachmentResponse{}, helpers.NewBadRequestError("file is empty")
+	}
+	if len(content) > MaxAttachmentSize {
+		return responses.AttachmentResponse{}, helpers.NewBadRequestError("file must not be larger than 10 MB")
+	}
+
+	// the type is sniffed from the content, the client's claim is ignored
+	contentType := http.DetectContentType(content)
+	extension, ok := allowedTypes[contentType]
+	if !ok {
+		return responses.AttachmentResponse{}, helpers.NewUnsupportedMediaTypeError(fmt.Sprintf("%s files are not accepted, upload an image or a PDF", contentType))
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return responses.AttachmentResponse{}, helpers.NewInternalServerError()
+	}
+	checksum := sha256.Sum256(content)
+
+	attachment := models.Attachment{
+		ExpenseID:   expense.ID,
+		Filename:    cleanFilename(filename, extension),
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		Checksum:    hex.EncodeToString(checksum[:]),
+		StorageKey:  fmt.Sprintf("expenses/%d/%s%s", expense.ID, name, extension),
+	}
+
+	if err := s.storage.Put(attachment.StorageKey, content, contentType); err != nil {
+		log.Println("fail to store attachment:", attachment.StorageKey, err)
+		return responses.AttachmentResponse{}, helpers.NewInternalServerError()
+	}
+
+	if strings.HasPrefix(contentType, "image/") {
+		if thumbnail, ok := makeThumbnail(content); ok {
+			key := fmt.Sprintf("expenses/%d/%s.thumbnail.jpg", expense.ID, name)
+			if err := s.storage.Put(key, thumbnail, "image/jpeg"); err != nil {
+				log.Println("fail to store thumbnail:", key, err)
+			} else {
+				attachment.ThumbnailKey = key
+			}
+		}
+	}
+
+	if err := s.attachmentRepo.Create(&attachment); err != nil {
+		s.deleteBlobs(attachment)
+		return responses.AttachmentResponse{}, helpers.NewInternalServerError()
+	}
+
+	return toAttachmentResponse(attachment), nil
+}
+
+func (s attachmentService) GetAttachments(expenseID string) ([]responses.AttachmentResponse, error) {
+	if _, err := s.expenseRepo.GetByID(expenseID); err != nil {
+		return nil, helpers.NewNotFoundError()
+	}
+
+	attachments, err := s.attachmentRepo.GetAllByExpenseID(expenseID)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	attachmentsResp := []responses.AttachmentResponse{}
+	for _, attachment := range attachments {
+		attachmentsResp = append(attachmentsResp, toAttachmentResponse(attachment))
+	}
+
+	return attachmentsResp, nil
+}
+
+// GetAttachmentContent returns the metadata and the content, which the
+// caller has to close.
+func (s attachmentService) GetAttachmentContent(expenseID string, id string) (responses.AttachmentResponse, io.ReadCloser, error) {
+	attachment, err := s.attachmentRepo.GetByID(expenseID, id)
+	if err != nil {
+		return responses.AttachmentResponse{}, nil, helpers.NewNotFoundError()
+	}
+
+	content, err := s.open(attachment.StorageKey)
+	if err != nil {
+		return responses.AttachmentResponse{}, nil, err
+	}
+
+	return toAttachmentResponse(attachment), content, nil
+}
+
+func (s attachmentService) GetThumbnail(expenseID string, id string) (io.ReadCloser, error) {
+	attachment, err := s.attachmentRepo.GetByID(expenseID, id)
+	if err != nil || attachment.ThumbnailKey == "" {
+		return nil, helpers.NewNotFoundError()
+	}
+
+	return s.open(attachment.ThumbnailKey)
+}
+
+// DeleteAttachment removes the metadata first, a blob left behind by a
+// storage failure is harmless while a row without its blob is not.
+func (s attachmentService) DeleteAttachment(expenseID string, id string) error {
+	attachment, err := s.attachmentRepo.GetByID(expenseID, id)
+	if err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	if err := s.attachmentRepo.DeleteByID(expenseID, id); err != nil {
+		return helpers.NewInternalServerError()
+	}
+
+	s.deleteBlobs(attachment)
+
+	return nil
+}
+
+func (s attachmentService) open(key string) (io.ReadCloser, error) {
+	content, err := s.storage.Get(key)
+	if err == storage.ErrNotFound {
+		return nil, helpers.NewNotFoundError()
+	}
+	if err != nil {
+		log.Println("fail to read attachment:", key, err)
+		return nil, helpers.NewInternalServerError()
+	}
+
+	return content, nil
+}
+
+func (s attachmentService) deleteBlobs(attachment models.Attachment) {
+	for _, key := range []string{attachment.StorageKey, attachment.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.storage.Delete(key); err != nil {
+			log.Println("fail to delete attachment:", key, err)
+		}
+	}
+}
+
+func toAttachmentResponse(attachment models.Attachment) responses.AttachmentResponse {
+	return responses.AttachmentResponse{
+		ID:           attachment.ID,
+		ExpenseID:    attachment.ExpenseID,
+		Filename:     attachment.Filename,
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		Checksum:     attachment.Checksum,
+		HasThumbnail: attachment.ThumbnailKey != "",
+		CreatedAt:    attachment.CreatedAt,
+	}
+}
+
+func randomName() (string, error) {
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(name), nil
+}
+
+// cleanFilename keeps the base name of the upload for downloads, falling
+// back to a generic name.
+func cleanFilename(filename string, extension string) string {
+	name := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "receipt" + extension
+	}
+	if runes := []rune(name); len(runes) > 255 {
+		name = string(runes[len(runes)-255:])
+	}
+
+	return name
+}
@@ -0,0 +1,207 @@
+//go:build unit
+
+package services_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/src/attachment/repositories"
+	"github.com/wytquant/assessment/src/attachment/services"
+	"github.com/wytquant/assessment/src/attachment/storage"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	"gorm.io/gorm"
+)
+
+func pngReceipt(t *testing.T, width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, height/2, color.Black)
+	}
+
+	var content bytes.Buffer
+	assert.NoError(t, png.Encode(&content, img))
+
+	return content.Bytes()
+}
+
+func TestUploadAttachmentService(t *testing.T) {
+	t.Run("upload image receipt success case", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1}, nil)
+
+		var created models.Attachment
+		attachmentRepo := repositories.NewAttachmentRepositoryMock()
+		attachmentRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			created = *args.Get(0).(*models.Attachment)
+		}).Return(nil)
+
+		blobs := storage.NewLocalStorage(t.TempDir())
+		attachmentService := services.NewAttachmentService(attachmentRepo, expenseRepo, blobs)
+
+		//act
+		got, err := attachmentService.UploadAttachment("1", "../../receipt.png", pngReceipt(t, 1024, 512))
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "receipt.png", got.Filename)
+		assert.Equal(t, "image/png", got.ContentType)
+		assert.Len(t, got.Checksum, 64)
+		assert.True(t, got.HasThumbnail)
+
+		thumbnail, err := blobs.Get(created.ThumbnailKey)
+		if assert.NoError(t, err) {
+			defer thumbnail.Close()
+			config, err := jpeg.DecodeConfig(thumbnail)
+			assert.NoError(t, err)
+			assert.Equal(t, 256, config.Width)
+			assert.Equal(t, 128, config.Height)
+		}
+	})
+
+	t.Run("upload pdf receipt success case without thumbnail", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1}, nil)
+
+		attachmentRepo := repositories.NewAttachmentRepositoryMock()
+		attachmentRepo.On("Create", mock.Anything).Return(nil)
+
+		attachmentService := services.NewAttachmentService(attachmentRepo, expenseRepo, storage.NewLocalStorage(t.TempDir()))
+
+		//act
+		got, err := attachmentService.UploadAttachment("1", "invoice.pdf", []byte("%PDF-1.4\n%âãÏÓ\n"))
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "application/pdf", got.ContentType)
+		assert.False(t, got.HasThumbnail)
+	})
+
+	t.Run("upload attachment fail case because the type is not accepted", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1}, nil)
+
+		attachmentService := services.NewAttachmentService(repositories.NewAttachmentRepositoryMock(), expenseRepo, storage.NewLocalStorage(t.TempDir()))
+
+		//act
+		_, err := attachmentService.UploadAttachment("1", "receipt.png", []byte("<html><script>alert(1)</script></html>"))
+
+		//assert
+		assert.Equal(t, http.StatusUnsupportedMediaType, err.(*helpers.AppError).StatusCode)
+	})
+
+	t.Run("upload attachment fail case because the expense does not exist", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{}, gorm.ErrRecordNotFound)
+
+		attachmentService := services.NewAttachmentService(repositories.NewAttachmentRepositoryMock(), expenseRepo, storage.NewLocalStorage(t.TempDir()))
+
+		//act
+		_, err := attachmentService.UploadAttachment("1", "receipt.png", pngReceipt(t, 10, 10))
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+	})
+
+	t.Run("upload attachment fail case removes the blobs when saving fails", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1}, nil)
+
+		var created models.Attachment
+		attachmentRepo := repositories.NewAttachmentRepositoryMock()
+		attachmentRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+			created = *args.Get(0).(*models.Attachment)
+		}).Return(errors.New("db down"))
+
+		blobs := storage.NewLocalStorage(t.TempDir())
+		attachmentService := services.NewAttachmentService(attachmentRepo, expenseRepo, blobs)
+
+		//act
+		_, err := attachmentService.UploadAttachment("1", "receipt.png", pngReceipt(t, 10, 10))
+
+		//assert
+		assert.Equal(t, helpers.NewInternalServerError(), err)
+		_, err = blobs.Get(created.StorageKey)
+		assert.Equal(t, storage.ErrNotFound, err)
+		_, err = blobs.Get(created.ThumbnailKey)
+		assert.Equal(t, storage.ErrNotFound, err)
+	})
+}
+
+func TestGetAttachmentContentService(t *testing.T) {
+	t.Run("get attachment content success case", func(t *testing.T) {
+		//arrange
+		blobs := storage.NewLocalStorage(t.TempDir())
+		assert.NoError(t, blobs.Put("expenses/1/a.pdf", []byte("%PDF-1.4"), "application/pdf"))
+
+		attachmentRepo := repositories.NewAttachmentRepositoryMock()
+		attachmentRepo.On("GetByID", "1", "2").Return(models.Attachment{ID: 2, ExpenseID: 1, StorageKey: "expenses/1/a.pdf"}, nil)
+
+		attachmentService := services.NewAttachmentService(attachmentRepo, expenseRepositories.NewExpenseReporitoryMock(), blobs)
+
+		//act
+		got, content, err := attachmentService.GetAttachmentContent("1", "2")
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), got.ID)
+		body, _ := io.ReadAll(content)
+		content.Close()
+		assert.Equal(t, "%PDF-1.4", string(body))
+	})
+
+	t.Run("get thumbnail fail case because the attachment has none", func(t *testing.T) {
+		//arrange
+		attachmentRepo := repositories.NewAttachmentRepositoryMock()
+		attachmentRepo.On("GetByID", "1", "2").Return(models.Attachment{ID: 2, StorageKey: "expenses/1/a.pdf"}, nil)
+
+		attachmentService := services.NewAttachmentService(attachmentRepo, expenseRepositories.NewExpenseReporitoryMock(), storage.NewLocalStorage(t.TempDir()))
+
+		//act
+		_, err := attachmentService.GetThumbnail("1", "2")
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+	})
+}
+
+func TestDeleteAttachmentService(t *testing.T) {
+	t.Run("delete attachment success case", func(t *testing.T) {
+		//arrange
+		blobs := storage.NewLocalStorage(t.TempDir())
+		assert.NoError(t, blobs.Put("expenses/1/a.png", []byte("png"), "image/png"))
+		assert.NoError(t, blobs.Put("expenses/1/a.thumbnail.jpg", []byte("jpg"), "image/jpeg"))
+
+		attachmentRepo := repositories.NewAttachmentRepositoryMock()
+		attachmentRepo.On("GetByID", "1", "2").Return(models.Attachment{ID: 2, StorageKey: "expenses/1/a.png", ThumbnailKey: "expenses/1/a.thumbnail.jpg"}, nil)
+		attachmentRepo.On("DeleteByID", "1", "2").Return(nil)
+
+		attachmentService := services.NewAttachmentService(attachmentRepo, expenseRepositories.NewExpenseReporitoryMock(), blobs)
+
+		//act
+		err := attachmentService.DeleteAttachment("1", "2")
+
+		//assert
+		assert.NoError(t, err)
+		_, err = blobs.Get("expenses/1/a.png")
+		assert.Equal(t, storage.ErrNotFound, err)
+		_, err = blobs.Get("expenses/1/a.thumbnail.jpg")
+		assert.Equal(t, storage.ErrNotFound, err)
+	})
+}
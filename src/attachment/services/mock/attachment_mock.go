@@ -0,0 +1,49 @@
+package services
+
+import (
+	"io"
+	"strings"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/responses"
+)
+
+type attachmentServiceMock struct {
+	mock.Mock
+}
+
+func NewAttachmentServiceMock() *attachmentServiceMock {
+	return &attachmentServiceMock{}
+}
+
+func (m *attachmentServiceMock) UploadAttachment(expenseID string, filename string, content []byte) (responses.AttachmentResponse, error) {
+	args := m.Called(expenseID, filename, string(content))
+	return args.Get(0).(responses.AttachmentResponse), args.Error(1)
+}
+
+func (m *attachmentServiceMock) GetAttachments(expenseID string) ([]responses.AttachmentResponse, error) {
+	args := m.Called(expenseID)
+	return args.Get(0).([]responses.AttachmentResponse), args.Error(1)
+}
+
+// GetAttachmentContent returns the second mocked value as the content.
+func (m *attachmentServiceMock) GetAttachmentContent(expenseID string, id string) (responses.AttachmentResponse, io.ReadCloser, error) {
+	args := m.Called(expenseID, id)
+	if args.Error(2) != nil {
+		return args.Get(0).(responses.AttachmentResponse), nil, args.Error(2)
+	}
+	return args.Get(0).(responses.AttachmentResponse), io.NopCloser(strings.NewReader(args.String(1))), nil
+}
+
+func (m *attachmentServiceMock) GetThumbnail(expenseID string, id string) (io.ReadCloser, error) {
+	args := m.Called(expenseID, id)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return io.NopCloser(strings.NewReader(args.String(0))), nil
+}
+
+func (m *attachmentServiceMock) DeleteAttachment(expenseID string, id string) error {
+	args := m.Called(expenseID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	thumbnailSize = 256
+	// maxThumbnailPixels keeps small files declaring huge dimensions from
+	// being decoded.
+	maxThumbnailPixels = 50_000_000
+)
+
+// makeThumbnail scales an image to fit a 256 px square as a JPEG, it reports
+// false for content it cannot decode.
+func makeThumbnail(content []byte) ([]byte, bool) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil || config.Width == 0 || config.Height == 0 || config.Width*config.Height > maxThumbnailPixels {
+		return nil, false
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, false
+	}
+
+	width, height := config.Width, config.Height
+	if width >= height && width > thumbnailSize {
+		width, height = thumbnailSize, height*thumbnailSize/width
+	} else if height > width && height > thumbnailSize {
+		width, height = width*thumbnailSize/height, thumbnailSize
+	}
+	if width == 0 {
+		width = 1
+	}
+	if height == 0 {
+		height = 1
+	}
+
+	// JPEG has no transparency, transparent areas become white
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Over, nil)
+
+	var thumbnail bytes.Buffer
+	if err := jpeg.Encode(&thumbnail, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, false
+	}
+
+	return thumbnail.Bytes(), true
+}
@@ -0,0 +1,47 @@
+//go:build unit
+
+package storage_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/src/attachment/storage"
+)
+
+func TestLocalStorage(t *testing.T) {
+	t.Run("put, get and delete a file", func(t *testing.T) {
+		//arrange
+		s := storage.NewLocalStorage(t.TempDir())
+
+		//act
+		err := s.Put("expenses/1/receipt.png", []byte("png"), "image/png")
+		assert.NoError(t, err)
+
+		body, err := s.Get("expenses/1/receipt.png")
+
+		//assert
+		if assert.NoError(t, err) {
+			content, _ := io.ReadAll(body)
+			body.Close()
+			assert.Equal(t, "png", string(content))
+		}
+
+		assert.NoError(t, s.Delete("expenses/1/receipt.png"))
+		assert.NoError(t, s.Delete("expenses/1/receipt.png"))
+		_, err = s.Get("expenses/1/receipt.png")
+		assert.Equal(t, storage.ErrNotFound, err)
+	})
+
+	t.Run("reject keys escaping the root", func(t *testing.T) {
+		//arrange
+		s := storage.NewLocalStorage(t.TempDir())
+
+		//act and assert
+		assert.Error(t, s.Put("../outside", []byte("x"), "text/plain"))
+		assert.Error(t, s.Put("/etc/passwd", []byte("x"), "text/plain"))
+		_, err := s.Get("expenses/../../outside")
+		assert.Error(t, err)
+	})
+}
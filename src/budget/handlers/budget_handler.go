@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/budget/services"
+)
+
+type budgetHandler struct {
+	budgetService services.BudgetService
+}
+
+func NewBudgetHandler(budgetService services.BudgetService) budgetHandler {
+	return budgetHandler{budgetService: budgetService}
+}
+
+func (h budgetHandler) CreateBudget(c *gin.Context) {
+	var budgetReq requests.BudgetRequest
+	if err := c.ShouldBindJSON(&budgetReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	budgetResp, err := h.budgetService.CreateBudget(budgetReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, budgetResp)
+}
+
+func (h budgetHandler) GetBudgetByID(c *gin.Context) {
+	budgetResp, err := h.budgetService.GetBudgetByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, budgetResp)
+}
+
+func (h budgetHandler) UpdateBudgetByID(c *gin.Context) {
+	var budgetReq requests.BudgetRequest
+	if err := c.ShouldBindJSON(&budgetReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	budgetResp, err := h.budgetService.UpdateBudgetByID(c.Param("id"), budgetReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, budgetResp)
+}
+
+func (h budgetHandler) DeleteBudgetByID(c *gin.Context) {
+	if err := h.budgetService.DeleteBudgetByID(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h budgetHandler) GetAllBudgets(c *gin.Context) {
+	budgetsResp, err := h.budgetService.GetBudgets()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, budgetsResp)
+}
+
+func (h budgetHandler) GetBudgetStatus(c *gin.Context) {
+	var query requests.BudgetStatusQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	statusResp, err := h.budgetService.GetBudgetStatus(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResp)
+}
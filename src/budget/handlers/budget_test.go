@@ -0,0 +1,165 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/budget/handlers"
+	services "github.com/wytquant/assessment/src/budget/services/mock"
+)
+
+func TestCreateBudgetHandler(t *testing.T) {
+	t.Run("create budget success", func(t *testing.T) {
+		//arrange
+		want := responses.BudgetResponse{ID: 1, Tag: "food", Period: "monthly", Amount: 5000}
+
+		budgetService := services.NewBudgetServiceMock()
+		budgetService.On("CreateBudget").Return(want, nil)
+
+		budgetHandler := handlers.NewBudgetHandler(budgetService)
+
+		r := gin.Default()
+		r.POST("/budgets", budgetHandler.CreateBudget)
+
+		payload := strings.NewReader(`{"tag": "food", "period": "monthly", "amount": 5000}`)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/budgets", payload)
+
+		//act
+		r.ServeHTTP(w, req)
+		var got responses.BudgetResponse
+		json.NewDecoder(w.Body).Decode(&got)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		if !assert.ObjectsAreEqual(want, got) {
+			t.Errorf("not equal. want: %#v, got: %#v", want, got)
+		}
+	})
+
+	t.Run("create budget fail bad request because period is unknown", func(t *testing.T) {
+		//arrange
+		budgetService := services.NewBudgetServiceMock()
+		budgetHandler := handlers.NewBudgetHandler(budgetService)
+
+		r := gin.Default()
+		r.POST("/budgets", budgetHandler.CreateBudget)
+
+		payload := strings.NewReader(`{"tag": "food", "period": "daily", "amount": 5000}`)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/budgets", payload)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestDeleteBudgetByIDHandler(t *testing.T) {
+	t.Run("delete budget by id success case", func(t *testing.T) {
+		//arrange
+		budgetService := services.NewBudgetServiceMock()
+		budgetService.On("DeleteBudgetByID", "1").Return(nil)
+
+		budgetHandler := handlers.NewBudgetHandler(budgetService)
+
+		r := gin.Default()
+		r.DELETE("/budgets/:id", budgetHandler.DeleteBudgetByID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/budgets/1", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("delete budget by id fail case record not found", func(t *testing.T) {
+		//arrange
+		budgetService := services.NewBudgetServiceMock()
+		budgetService.On("DeleteBudgetByID", "1").Return(helpers.NewNotFoundError())
+
+		budgetHandler := handlers.NewBudgetHandler(budgetService)
+
+		r := gin.Default()
+		r.DELETE("/budgets/:id", budgetHandler.DeleteBudgetByID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/budgets/1", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestGetBudgetStatusHandler(t *testing.T) {
+	t.Run("get budget status success case", func(t *testing.T) {
+		//arrange
+		query := requests.BudgetStatusQuery{Date: time.Date(2023, 4, 10, 0, 0, 0, 0, time.Local)}
+		want := []responses.BudgetStatusResponse{
+			{BudgetID: 1, Tag: "food", Period: "monthly", Limit: 5000, Available: 5000, Spent: 2000, Remaining: 3000, PercentUsed: 40, Projected: 6000, ProjectedOverspend: true},
+		}
+
+		budgetService := services.NewBudgetServiceMock()
+		budgetService.On("GetBudgetStatus", query).Return(want, nil)
+
+		budgetHandler := handlers.NewBudgetHandler(budgetService)
+
+		r := gin.Default()
+		r.GET("/budgets/status", budgetHandler.GetBudgetStatus)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/budgets/status?date=2023-04-10", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+		var got []responses.BudgetStatusResponse
+		json.NewDecoder(w.Body).Decode(&got)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		if !assert.ObjectsAreEqual(want, got) {
+			t.Errorf("not equal. want: %#v, got: %#v", want, got)
+		}
+	})
+
+	t.Run("get budget status fail case because internal server error", func(t *testing.T) {
+		//arrange
+		budgetService := services.NewBudgetServiceMock()
+		budgetService.On("GetBudgetStatus", requests.BudgetStatusQuery{}).Return([]responses.BudgetStatusResponse{}, helpers.NewInternalServerError())
+
+		budgetHandler := handlers.NewBudgetHandler(budgetService)
+
+		r := gin.Default()
+		r.GET("/budgets/status", budgetHandler.GetBudgetStatus)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/budgets/status", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+)
+
+type budgetRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewBudgetRepositoryDB(db *gorm.DB) BudgetRepository {
+	return budgetRepositoryDB{db: db}
+}
+
+func (r budgetRepositoryDB) Create(budget *models.Budget) error {
+	query := r.db
+	if err := query.Create(budget).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r budgetRepositoryDB) GetByID(id string) (models.Budget, error) {
+	var budget models.Budget
+	query := r.db
+	if err := query.Where("id = $1", id).First(&budget).Error; err != nil {
+		return models.Budget{}, err
+	}
+
+	return budget, nil
+}
+
+func (r budgetRepositoryDB) UpdateByID(id string, budget models.Budget) (models.Budget, error) {
+	query := r.db
+	budgetDB, err := r.GetByID(id)
+	if err != nil {
+		return models.Budget{}, err
+	}
+
+	if budget.StartDate.IsZero() {
+		budget.StartDate = budgetDB.StartDate
+	}
+
+	// select every column so that rollover can be switched off and tag cleared
+	if err := query.Model(&budgetDB).Select("tag", "period", "amount", "rollover", "start_date").Updates(budget).Error; err != nil {
+		return models.Budget{}, err
+	}
+
+	return budgetDB, nil
+}
+
+func (r budgetRepositoryDB) DeleteByID(id string) error {
+	query := r.db
+	result := query.Where("id = $1", id).Delete(&models.Budget{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r budgetRepositoryDB) GetAll() ([]models.Budget, error) {
+	query := r.db
+	var budgets []models.Budget
+
+	if err := query.Order("id").Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+
+	return budgets, nil
+}
+
+func (r budgetRepositoryDB) GetSpending(tag string, unit string, from time.Time, to time.Time) ([]PeriodSpending, error) {
+	var spending []PeriodSpending
+
+	query := r.db.Model(&models.Expense{}).
+		Select(fmt.Sprintf("date_trunc('%s', date)::date AS period, currency, SUM(%s) AS total", unit, models.SpentAmount)).
+		Where("group_id IS NULL AND date >= ? AND date < ?", from, to).
+		Where(models.Spending)
+	if tag != "" {
+		query = query.Where("? = ANY(tags)", tag)
+	}
+
+	if err := query.Group("period, currency").Order("period, currency").Scan(&spending).Error; err != nil {
+		return nil, err
+	}
+
+	return spending, nil
+}
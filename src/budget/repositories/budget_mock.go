@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type budgetRepositoryMock struct {
+	mock.Mock
+}
+
+func NewBudgetRepositoryMock() *budgetRepositoryMock {
+	return &budgetRepositoryMock{}
+}
+
+func (m *budgetRepositoryMock) Create(budget *models.Budget) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *budgetRepositoryMock) GetByID(id string) (models.Budget, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Budget), args.Error(1)
+}
+
+func (m *budgetRepositoryMock) UpdateByID(id string, budget models.Budget) (models.Budget, error) {
+	args := m.Called(id, budget)
+	return args.Get(0).(models.Budget), args.Error(1)
+}
+
+func (m *budgetRepositoryMock) DeleteByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *budgetRepositoryMock) GetAll() ([]models.Budget, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Budget), args.Error(1)
+}
+
+func (m *budgetRepositoryMock) GetSpending(tag string, unit string, from time.Time, to time.Time) ([]PeriodSpending, error) {
+	args := m.Called(tag, unit, from, to)
+	return args.Get(0).([]PeriodSpending), args.Error(1)
+}
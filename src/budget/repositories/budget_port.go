@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+// PeriodSpending is what was spent in one currency during a period.
+type PeriodSpending struct {
+	Period   time.Time
+	Currency string
+	Total    float64
+}
+
+type BudgetRepository interface {
+	Create(*models.Budget) error
+	GetByID(id string) (models.Budget, error)
+	UpdateByID(id string, budget models.Budget) (models.Budget, error)
+	DeleteByID(id string) error
+	GetAll() ([]models.Budget, error)
+	GetSpending(tag string, unit string, from time.Time, to time.Time) ([]PeriodSpending, error)
+}
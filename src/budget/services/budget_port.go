@@ -0,0 +1,15 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type BudgetService interface {
+	CreateBudget(budgetReq requests.BudgetRequest) (responses.BudgetResponse, error)
+	GetBudgetByID(id string) (responses.BudgetResponse, error)
+	UpdateBudgetByID(id string, budgetReq requests.BudgetRequest) (responses.BudgetResponse, error)
+	DeleteBudgetByID(id string) error
+	GetBudgets() ([]responses.BudgetResponse, error)
+	GetBudgetStatus(query requests.BudgetStatusQuery) ([]responses.BudgetStatusResponse, error)
+}
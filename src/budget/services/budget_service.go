@@ -0,0 +1,185 @@
+package services
+
+import (
+	"log"
+	"math"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/budget/repositories"
+)
+
+type budgetService struct {
+	budgetRepo repositories.BudgetRepository
+	rates      helpers.ExchangeRates
+}
+
+// NewBudgetService creates the service, budgets are in helpers.BaseCurrency
+// and spending in other currencies is converted with rates.
+func NewBudgetService(budgetRepo repositories.BudgetRepository, rates helpers.ExchangeRates) BudgetService {
+	return budgetService{budgetRepo: budgetRepo, rates: rates}
+}
+
+func (s budgetService) CreateBudget(budgetReq requests.BudgetRequest) (responses.BudgetResponse, error) {
+	var budget models.Budget
+	var budgetResp responses.BudgetResponse
+
+	copier.Copy(&budget, &budgetReq)
+
+	if err := s.budgetRepo.Create(&budget); err != nil {
+		return responses.BudgetResponse{}, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&budgetResp, &budget)
+
+	return budgetResp, nil
+}
+
+func (s budgetService) GetBudgetByID(id string) (responses.BudgetResponse, error) {
+	var budgetResp responses.BudgetResponse
+
+	budget, err := s.budgetRepo.GetByID(id)
+	if err != nil {
+		return responses.BudgetResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&budgetResp, &budget)
+
+	return budgetResp, nil
+}
+
+func (s budgetService) UpdateBudgetByID(id string, budgetReq requests.BudgetRequest) (responses.BudgetResponse, error) {
+	var budget models.Budget
+	var budgetResp responses.BudgetResponse
+
+	copier.Copy(&budget, &budgetReq)
+
+	updatedBudget, err := s.budgetRepo.UpdateByID(id, budget)
+	if err != nil {
+		return responses.BudgetResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&budgetResp, &updatedBudget)
+
+	return budgetResp, nil
+}
+
+func (s budgetService) DeleteBudgetByID(id string) error {
+	if err := s.budgetRepo.DeleteByID(id); err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func (s budgetService) GetBudgets() ([]responses.BudgetResponse, error) {
+	budgetsResp := []responses.BudgetResponse{}
+
+	budgets, err := s.budgetRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&budgetsResp, &budgets)
+
+	return budgetsResp, nil
+}
+
+func (s budgetService) GetBudgetStatus(query requests.BudgetStatusQuery) ([]responses.BudgetStatusResponse, error) {
+	statusResp := []responses.BudgetStatusResponse{}
+
+	date := query.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	budgets, err := s.budgetRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	for _, budget := range budgets {
+		status, active, err := s.budgetStatus(budget, date)
+		if err != nil {
+			return nil, helpers.NewInternalServerError()
+		}
+		if active {
+			statusResp = append(statusResp, status)
+		}
+	}
+
+	return statusResp, nil
+}
+
+// budgetStatus computes spending of the budget period containing date. Budgets
+// starting after that period are reported as inactive.
+func (s budgetService) budgetStatus(budget models.Budget, date time.Time) (responses.BudgetStatusResponse, bool, error) {
+	start, end := helpers.PeriodBounds(budget.Period, date)
+	if !budget.StartDate.Before(end) {
+		return responses.BudgetStatusResponse{}, false, nil
+	}
+
+	from := start
+	if budget.Rollover {
+		from, _ = helpers.PeriodBounds(budget.Period, budget.StartDate)
+	}
+
+	spending, err := s.budgetRepo.GetSpending(budget.Tag, helpers.PeriodUnit(budget.Period), from, end)
+	if err != nil {
+		return responses.BudgetStatusResponse{}, false, err
+	}
+
+	spentByPeriod := map[string]float64{}
+	for _, period := range spending {
+		total, ok := s.rates.Convert(period.Total, period.Currency, helpers.BaseCurrency)
+		if !ok {
+			log.Println("fail to count budget spending, no exchange rate for:", period.Currency)
+			continue
+		}
+		spentByPeriod[period.Period.Format("2006-01-02")] += total
+	}
+
+	// unused amounts carry over, overspending a period never reduces the next one
+	var carry float64
+	for period := from; period.Before(start); _, period = helpers.PeriodBounds(budget.Period, period) {
+		carry = math.Max(0, budget.Amount+carry-spentByPeriod[period.Format("2006-01-02")])
+	}
+
+	spent := spentByPeriod[start.Format("2006-01-02")]
+	available := budget.Amount + carry
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	elapsedDays := day.Sub(start).Hours()/24 + 1
+	totalDays := end.Sub(start).Hours() / 24
+	projected := spent / elapsedDays * totalDays
+
+	var percentUsed float64
+	if available > 0 {
+		percentUsed = spent / available * 100
+	}
+
+	return responses.BudgetStatusResponse{
+		BudgetID:           budget.ID,
+		Tag:                budget.Tag,
+		Period:             budget.Period,
+		PeriodStart:        start,
+		PeriodEnd:          end.AddDate(0, 0, -1),
+		Limit:              budget.Amount,
+		RolloverAmount:     round(carry),
+		Available:          round(available),
+		Spent:              round(spent),
+		Remaining:          round(available - spent),
+		PercentUsed:        round(percentUsed),
+		Projected:          round(projected),
+		Overspent:          spent > available,
+		ProjectedOverspend: projected > available,
+	}, true, nil
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
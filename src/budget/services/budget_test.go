@@ -0,0 +1,216 @@
+//go:build unit
+
+package services_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/budget/repositories"
+	"github.com/wytquant/assessment/src/budget/services"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCreateBudgetService(t *testing.T) {
+	t.Run("create budget success case", func(t *testing.T) {
+		//arrange
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("Create").Return(nil)
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		got, err := budgetService.CreateBudget(requests.BudgetRequest{Tag: "food", Period: models.PeriodMonthly, Amount: 5000})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "food", got.Tag)
+		assert.Equal(t, float64(5000), got.Amount)
+	})
+
+	t.Run("create budget fail case because internal server error", func(t *testing.T) {
+		//arrange
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("Create").Return(helpers.NewInternalServerError())
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		_, err := budgetService.CreateBudget(requests.BudgetRequest{})
+
+		//assert
+		assert.EqualError(t, err, helpers.NewInternalServerError().Error())
+	})
+}
+
+func TestGetBudgetByIDService(t *testing.T) {
+	t.Run("get budget by id fail case due to record not found", func(t *testing.T) {
+		//arrange
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("GetByID", "1").Return(models.Budget{}, helpers.NewNotFoundError())
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		_, err := budgetService.GetBudgetByID("1")
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+		}
+	})
+}
+
+func TestDeleteBudgetByIDService(t *testing.T) {
+	t.Run("delete budget by id success case", func(t *testing.T) {
+		//arrange
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("DeleteByID", "1").Return(nil)
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		err := budgetService.DeleteBudgetByID("1")
+
+		//assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("delete budget by id fail case due to record not found", func(t *testing.T) {
+		//arrange
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("DeleteByID", "1").Return(helpers.NewNotFoundError())
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		err := budgetService.DeleteBudgetByID("1")
+
+		//assert
+		assert.EqualError(t, err, helpers.NewNotFoundError().Error())
+	})
+}
+
+func TestGetBudgetStatusService(t *testing.T) {
+	t.Run("get monthly budget status with projection", func(t *testing.T) {
+		//arrange
+		budget := models.Budget{ID: 1, Tag: "food", Period: models.PeriodMonthly, Amount: 5000, StartDate: date(2023, 1, 1)}
+
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("GetAll").Return([]models.Budget{budget}, nil)
+		budgetRepo.On("GetSpending", "food", "month", date(2023, 4, 1), date(2023, 5, 1)).
+			Return([]repositories.PeriodSpending{{Period: date(2023, 4, 1), Currency: "THB", Total: 2000}}, nil)
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		got, err := budgetService.GetBudgetStatus(requests.BudgetStatusQuery{Date: date(2023, 4, 10)})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 1, len(got)) {
+			assert.Equal(t, date(2023, 4, 1), got[0].PeriodStart)
+			assert.Equal(t, date(2023, 4, 30), got[0].PeriodEnd)
+			assert.Equal(t, float64(2000), got[0].Spent)
+			assert.Equal(t, float64(3000), got[0].Remaining)
+			assert.Equal(t, float64(40), got[0].PercentUsed)
+			assert.Equal(t, float64(6000), got[0].Projected)
+			assert.False(t, got[0].Overspent)
+			assert.True(t, got[0].ProjectedOverspend)
+		}
+	})
+
+	t.Run("get budget status converts spending in other currencies", func(t *testing.T) {
+		//arrange
+		budget := models.Budget{ID: 1, Tag: "food", Period: models.PeriodMonthly, Amount: 5000, StartDate: date(2023, 1, 1)}
+
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("GetAll").Return([]models.Budget{budget}, nil)
+		budgetRepo.On("GetSpending", "food", "month", date(2023, 4, 1), date(2023, 5, 1)).
+			Return([]repositories.PeriodSpending{
+				{Period: date(2023, 4, 1), Currency: "THB", Total: 1000},
+				{Period: date(2023, 4, 1), Currency: "USD", Total: 20},
+				{Period: date(2023, 4, 1), Currency: "XYZ", Total: 5},
+			}, nil)
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		got, err := budgetService.GetBudgetStatus(requests.BudgetStatusQuery{Date: date(2023, 4, 10)})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 1, len(got)) {
+			assert.Equal(t, float64(1700), got[0].Spent)
+		}
+	})
+
+	t.Run("get weekly budget status with rollover of unused amounts", func(t *testing.T) {
+		//arrange
+		budget := models.Budget{ID: 2, Period: models.PeriodWeekly, Amount: 1000, Rollover: true, StartDate: date(2023, 1, 4)}
+
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("GetAll").Return([]models.Budget{budget}, nil)
+		budgetRepo.On("GetSpending", "", "week", date(2023, 1, 2), date(2023, 1, 23)).
+			Return([]repositories.PeriodSpending{
+				{Period: date(2023, 1, 2), Currency: "THB", Total: 600},
+				{Period: date(2023, 1, 9), Currency: "THB", Total: 1800},
+				{Period: date(2023, 1, 16), Currency: "THB", Total: 900},
+			}, nil)
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		got, err := budgetService.GetBudgetStatus(requests.BudgetStatusQuery{Date: date(2023, 1, 18)})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 1, len(got)) {
+			// week one leaves 400, week two overspends 1400 of 1400 and carries nothing
+			assert.Equal(t, float64(0), got[0].RolloverAmount)
+			assert.Equal(t, float64(1000), got[0].Available)
+			assert.Equal(t, float64(900), got[0].Spent)
+			assert.Equal(t, float64(90), got[0].PercentUsed)
+		}
+	})
+
+	t.Run("get budget status skips budgets that have not started", func(t *testing.T) {
+		//arrange
+		budget := models.Budget{ID: 3, Period: models.PeriodMonthly, Amount: 1000, StartDate: date(2023, 5, 1)}
+
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("GetAll").Return([]models.Budget{budget}, nil)
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		got, err := budgetService.GetBudgetStatus(requests.BudgetStatusQuery{Date: date(2023, 4, 10)})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(got))
+	})
+
+	t.Run("get budget status fail case because internal server error", func(t *testing.T) {
+		//arrange
+		budgetRepo := repositories.NewBudgetRepositoryMock()
+		budgetRepo.On("GetAll").Return([]models.Budget{}, helpers.NewInternalServerError())
+
+		budgetService := services.NewBudgetService(budgetRepo, helpers.ExchangeRates{"USD": 35})
+
+		//act
+		_, err := budgetService.GetBudgetStatus(requests.BudgetStatusQuery{Date: date(2023, 4, 10)})
+
+		//assert
+		assert.EqualError(t, err, helpers.NewInternalServerError().Error())
+	})
+}
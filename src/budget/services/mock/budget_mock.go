@@ -0,0 +1,45 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type budgetServiceMock struct {
+	mock.Mock
+}
+
+func NewBudgetServiceMock() *budgetServiceMock {
+	return &budgetServiceMock{}
+}
+
+func (m *budgetServiceMock) CreateBudget(budgetReq requests.BudgetRequest) (responses.BudgetResponse, error) {
+	args := m.Called()
+	return args.Get(0).(responses.BudgetResponse), args.Error(1)
+}
+
+func (m *budgetServiceMock) GetBudgetByID(id string) (responses.BudgetResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.BudgetResponse), args.Error(1)
+}
+
+func (m *budgetServiceMock) UpdateBudgetByID(id string, budgetReq requests.BudgetRequest) (responses.BudgetResponse, error) {
+	args := m.Called(id, budgetReq)
+	return args.Get(0).(responses.BudgetResponse), args.Error(1)
+}
+
+func (m *budgetServiceMock) DeleteBudgetByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *budgetServiceMock) GetBudgets() ([]responses.BudgetResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.BudgetResponse), args.Error(1)
+}
+
+func (m *budgetServiceMock) GetBudgetStatus(query requests.BudgetStatusQuery) ([]responses.BudgetStatusResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).([]responses.BudgetStatusResponse), args.Error(1)
+}
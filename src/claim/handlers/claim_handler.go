@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/claim/services"
+)
+
+type claimHandler struct {
+	claimService services.ClaimService
+}
+
+func NewClaimHandler(claimService services.ClaimService) claimHandler {
+	return claimHandler{claimService: claimService}
+}
+
+func (h claimHandler) CreateClaim(c *gin.Context) {
+	var claimReq requests.ClaimRequest
+	if err := c.ShouldBindJSON(&claimReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	claimResp, err := h.claimService.CreateClaim(c.GetString(gin.AuthUserKey), claimReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, claimResp)
+}
+
+func (h claimHandler) GetClaimByID(c *gin.Context) {
+	claimResp, err := h.claimService.GetClaimByID(c.Param("id"), c.GetString(gin.AuthUserKey))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, claimResp)
+}
+
+func (h claimHandler) GetAllClaims(c *gin.Context) {
+	var query requests.ClaimQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	claimsResp, err := h.claimService.GetClaims(c.GetString(gin.AuthUserKey), query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, claimsResp)
+}
+
+func (h claimHandler) UpdateClaimByID(c *gin.Context) {
+	var claimReq requests.ClaimRequest
+	if err := c.ShouldBindJSON(&claimReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	claimResp, err := h.claimService.UpdateClaimByID(c.Param("id"), c.GetString(gin.AuthUserKey), claimReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, claimResp)
+}
+
+func (h claimHandler) DeleteClaimByID(c *gin.Context) {
+	if err := h.claimService.DeleteClaimByID(c.Param("id"), c.GetString(gin.AuthUserKey)); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h claimHandler) SubmitClaim(c *gin.Context) {
+	h.transition(c, services.ActionSubmit)
+}
+
+func (h claimHandler) ApproveClaim(c *gin.Context) {
+	h.transition(c, services.ActionApprove)
+}
+
+func (h claimHandler) RejectClaim(c *gin.Context) {
+	h.transition(c, services.ActionReject)
+}
+
+func (h claimHandler) PayClaim(c *gin.Context) {
+	h.transition(c, services.ActionPay)
+}
+
+func (h claimHandler) GetEvents(c *gin.Context) {
+	var query requests.ClaimEventQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	eventsResp, err := h.claimService.GetEvents(c.GetString(gin.AuthUserKey), query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, eventsResp)
+}
+
+// transition applies the action, the body with a comment is optional.
+func (h claimHandler) transition(c *gin.Context, action string) {
+	var transitionReq requests.ClaimTransitionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&transitionReq); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	claimResp, err := h.claimService.Transition(c.Param("id"), c.GetString(gin.AuthUserKey), action, transitionReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, claimResp)
+}
@@ -0,0 +1,177 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/claim/handlers"
+	claimServices "github.com/wytquant/assessment/src/claim/services"
+	services "github.com/wytquant/assessment/src/claim/services/mock"
+)
+
+func TestCreateClaimHandler(t *testing.T) {
+	t.Run("create claim success case", func(t *testing.T) {
+		//arrange
+		claimService := services.NewClaimServiceMock()
+		claimService.On("CreateClaim", "ann", requests.ClaimRequest{Title: "trip", ExpenseIDs: []uint{1, 2}}).
+			Return(responses.ClaimResponse{ID: 3, Claimant: "ann", Status: models.ClaimDraft}, nil)
+
+		claimHandler := handlers.NewClaimHandler(claimService)
+
+		r := gin.Default()
+		r.POST("/claims", gin.BasicAuth(gin.Accounts{"ann": "secret"}), claimHandler.CreateClaim)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/claims", bytes.NewBufferString(`{"title": "trip", "expense_ids": [1, 2]}`))
+		req.SetBasicAuth("ann", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		claimService.AssertExpectations(t)
+	})
+
+	t.Run("create claim fail bad request because there are no expenses", func(t *testing.T) {
+		//arrange
+		claimHandler := handlers.NewClaimHandler(services.NewClaimServiceMock())
+
+		r := gin.Default()
+		r.POST("/claims", gin.BasicAuth(gin.Accounts{"ann": "secret"}), claimHandler.CreateClaim)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/claims", bytes.NewBufferString(`{"title": "trip", "expense_ids": []}`))
+		req.SetBasicAuth("ann", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestTransitionClaimHandler(t *testing.T) {
+	t.Run("submit claim without a body success case", func(t *testing.T) {
+		//arrange
+		claimService := services.NewClaimServiceMock()
+		claimService.On("Transition", "3", "ann", claimServices.ActionSubmit, requests.ClaimTransitionRequest{}).
+			Return(responses.ClaimResponse{ID: 3, Status: models.ClaimSubmitted}, nil)
+
+		claimHandler := handlers.NewClaimHandler(claimService)
+
+		r := gin.Default()
+		r.POST("/claims/:id/submit", gin.BasicAuth(gin.Accounts{"ann": "secret"}), claimHandler.SubmitClaim)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/claims/3/submit", nil)
+		req.SetBasicAuth("ann", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		claimService.AssertExpectations(t)
+	})
+
+	t.Run("reject claim with a comment success case", func(t *testing.T) {
+		//arrange
+		claimService := services.NewClaimServiceMock()
+		claimService.On("Transition", "3", "bob", claimServices.ActionReject, requests.ClaimTransitionRequest{Comment: "no receipt"}).
+			Return(responses.ClaimResponse{ID: 3, Status: models.ClaimRejected}, nil)
+
+		claimHandler := handlers.NewClaimHandler(claimService)
+
+		r := gin.Default()
+		r.POST("/claims/:id/reject", gin.BasicAuth(gin.Accounts{"bob": "secret"}), claimHandler.RejectClaim)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/claims/3/reject", bytes.NewBufferString(`{"comment": "no receipt"}`))
+		req.SetBasicAuth("bob", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		claimService.AssertExpectations(t)
+	})
+
+	t.Run("pay claim fail 409 because it is not approved", func(t *testing.T) {
+		//arrange
+		claimService := services.NewClaimServiceMock()
+		claimService.On("Transition", "3", "cat", claimServices.ActionPay, requests.ClaimTransitionRequest{}).
+			Return(responses.ClaimResponse{}, helpers.NewConflictError("claim 3 is submitted and cannot be paid"))
+
+		claimHandler := handlers.NewClaimHandler(claimService)
+
+		r := gin.Default()
+		r.POST("/claims/:id/pay", gin.BasicAuth(gin.Accounts{"cat": "secret"}), claimHandler.PayClaim)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/claims/3/pay", nil)
+		req.SetBasicAuth("cat", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"message": "claim 3 is submitted and cannot be paid"}`, w.Body.String())
+	})
+
+	t.Run("approve claim fail bad request because the body is not json", func(t *testing.T) {
+		//arrange
+		claimHandler := handlers.NewClaimHandler(services.NewClaimServiceMock())
+
+		r := gin.Default()
+		r.POST("/claims/:id/approve", gin.BasicAuth(gin.Accounts{"bob": "secret"}), claimHandler.ApproveClaim)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/claims/3/approve", bytes.NewBufferString(`looks good`))
+		req.SetBasicAuth("bob", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetClaimEventsHandler(t *testing.T) {
+	t.Run("get claim events success case", func(t *testing.T) {
+		//arrange
+		claimService := services.NewClaimServiceMock()
+		claimService.On("GetEvents", "ann", requests.ClaimEventQuery{After: 4}).
+			Return([]responses.ClaimEventResponse{{ID: 5, ClaimID: 3, To: models.ClaimApproved, Actor: "bob"}}, nil)
+
+		claimHandler := handlers.NewClaimHandler(claimService)
+
+		r := gin.Default()
+		r.GET("/claims/events", gin.BasicAuth(gin.Accounts{"ann": "secret"}), claimHandler.GetEvents)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/claims/events?after=4", nil)
+		req.SetBasicAuth("ann", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		claimService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+)
+
+type claimRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewClaimRepositoryDB(db *gorm.DB) ClaimRepository {
+	return claimRepositoryDB{db: db}
+}
+
+func (r claimRepositoryDB) Create(claim *models.Claim, event models.ClaimEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// the expenses exist, only the links are created
+		if err := tx.Omit("Expenses.*").Create(claim).Error; err != nil {
+			return err
+		}
+
+		event.ClaimID = claim.ID
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+		claim.Events = []models.ClaimEvent{event}
+
+		return nil
+	})
+}
+
+func (r claimRepositoryDB) GetByID(id string) (models.Claim, error) {
+	var claim models.Claim
+	query := r.db
+	if err := query.Preload("Expenses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("date, id")
+	}).Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("id = ?", id).First(&claim).Error; err != nil {
+		return models.Claim{}, err
+	}
+
+	return claim, nil
+}
+
+func (r claimRepositoryDB) GetAll(claimant string, status string) ([]models.Claim, error) {
+	query := r.db
+	var claims []models.Claim
+
+	if claimant != "" {
+		query = query.Where("claimant = ?", claimant)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Preload("Expenses", func(db *gorm.DB) *gorm.DB {
+		return db.Order("date, id")
+	}).Order("id DESC").Find(&claims).Error; err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (r claimRepositoryDB) UpdateByID(claim models.Claim, expenses []models.Expense) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&claim).Update("title", claim.Title).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&claim).Omit("Expenses.*").Association("Expenses").Replace(expenses)
+	})
+}
+
+func (r claimRepositoryDB) DeleteByID(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM claim_expenses WHERE claim_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("claim_id = ?", id).Delete(&models.ClaimEvent{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&models.Claim{}).Error
+	})
+}
+
+func (r claimRepositoryDB) GetExpenses(ids []uint, username string, personal bool) ([]models.Expense, error) {
+	var expenses []models.Expense
+	if len(ids) == 0 {
+		return expenses, nil
+	}
+
+	groups := r.db.Model(&models.GroupMember{}).Select("group_id").
+		Where("username = ? AND role IN ?", username, []string{models.RoleOwner, models.RoleEditor})
+	query := r.db.Model(&models.Expense{}).Where("id IN ?", ids)
+	if personal {
+		query = query.Where("group_id IS NULL OR group_id IN (?)", groups)
+	} else {
+		query = query.Where("group_id IN (?)", groups)
+	}
+	if err := query.Order("date, id").Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r claimRepositoryDB) GetClaimed(ids []uint, exceptID uint) ([]ClaimedExpense, error) {
+	var claimed []ClaimedExpense
+	if len(ids) == 0 {
+		return claimed, nil
+	}
+
+	if err := r.db.Table("claim_expenses").
+		Select("claim_expenses.expense_id, claims.id AS claim_id, claims.status").
+		Joins("JOIN claims ON claims.id = claim_expenses.claim_id").
+		Where("claim_expenses.expense_id IN ? AND claims.id <> ? AND claims.status <> ?", ids, exceptID, models.ClaimRejected).
+		Order("claim_expenses.expense_id").
+		Scan(&claimed).Error; err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+var errMoved = errors.New("claim is no longer in the expected status")
+
+func (r claimRepositoryDB) Transition(claimID uint, from string, event models.ClaimEvent) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Claim{}).Where("id = ? AND status = ?", claimID, from).
+			Update("status", event.To)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errMoved
+		}
+
+		event.ClaimID = claimID
+		event.From = from
+		return tx.Create(&event).Error
+	})
+	if errors.Is(err, errMoved) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r claimRepositoryDB) GetLocking(expenseID uint) (models.Claim, bool, error) {
+	var claims []models.Claim
+	if err := r.db.Model(&models.Claim{}).
+		Joins("JOIN claim_expenses ON claim_expenses.claim_id = claims.id").
+		Where("claim_expenses.expense_id = ? AND claims.status IN ?", expenseID,
+			[]string{models.ClaimSubmitted, models.ClaimApproved, models.ClaimPaid}).
+		Limit(1).Find(&claims).Error; err != nil {
+		return models.Claim{}, false, err
+	}
+	if len(claims) == 0 {
+		return models.Claim{}, false, nil
+	}
+
+	return claims[0], true, nil
+}
+
+func (r claimRepositoryDB) GetEvents(claimant string, after uint, limit int) ([]models.ClaimEvent, error) {
+	var events []models.ClaimEvent
+	query := r.db.Model(&models.ClaimEvent{}).Where("claim_events.id > ?", after)
+	if claimant != "" {
+		query = query.Joins("JOIN claims ON claims.id = claim_events.claim_id").
+			Where("claims.claimant = ?", claimant)
+	}
+
+	if err := query.Order("claim_events.id").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
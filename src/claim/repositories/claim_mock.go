@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type claimRepositoryMock struct {
+	mock.Mock
+}
+
+func NewClaimRepositoryMock() *claimRepositoryMock {
+	return &claimRepositoryMock{}
+}
+
+func (m *claimRepositoryMock) Create(claim *models.Claim, event models.ClaimEvent) error {
+	args := m.Called(claim, event)
+	return args.Error(0)
+}
+
+func (m *claimRepositoryMock) GetByID(id string) (models.Claim, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Claim), args.Error(1)
+}
+
+func (m *claimRepositoryMock) GetAll(claimant string, status string) ([]models.Claim, error) {
+	args := m.Called(claimant, status)
+	return args.Get(0).([]models.Claim), args.Error(1)
+}
+
+func (m *claimRepositoryMock) UpdateByID(claim models.Claim, expenses []models.Expense) error {
+	args := m.Called(claim, expenses)
+	return args.Error(0)
+}
+
+func (m *claimRepositoryMock) DeleteByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *claimRepositoryMock) GetExpenses(ids []uint, username string, personal bool) ([]models.Expense, error) {
+	args := m.Called(ids, username, personal)
+	return args.Get(0).([]models.Expense), args.Error(1)
+}
+
+func (m *claimRepositoryMock) GetClaimed(ids []uint, exceptID uint) ([]ClaimedExpense, error) {
+	args := m.Called(ids, exceptID)
+	return args.Get(0).([]ClaimedExpense), args.Error(1)
+}
+
+func (m *claimRepositoryMock) Transition(claimID uint, from string, event models.ClaimEvent) (bool, error) {
+	args := m.Called(claimID, from, event)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *claimRepositoryMock) GetLocking(expenseID uint) (models.Claim, bool, error) {
+	args := m.Called(expenseID)
+	return args.Get(0).(models.Claim), args.Bool(1), args.Error(2)
+}
+
+func (m *claimRepositoryMock) GetEvents(claimant string, after uint, limit int) ([]models.ClaimEvent, error) {
+	args := m.Called(claimant, after, limit)
+	return args.Get(0).([]models.ClaimEvent), args.Error(1)
+}
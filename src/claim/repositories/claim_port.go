@@ -0,0 +1,42 @@
+package repositories
+
+import "github.com/wytquant/assessment/models"
+
+// ClaimedExpense tells which claim holds an expense.
+type ClaimedExpense struct {
+	ExpenseID uint
+	ClaimID   uint
+	Status    string
+}
+
+// ClaimRepository claims expenses of the personal ledger only, a group's
+// expenses are shared and nobody paid them alone.
+type ClaimRepository interface {
+	// Create saves the claim, its expenses and its first event.
+	Create(claim *models.Claim, event models.ClaimEvent) error
+	// GetByID returns the claim with its expenses by date and its events in
+	// order.
+	GetByID(id string) (models.Claim, error)
+	// GetAll returns the claims of the claimant, or of everyone when the
+	// claimant is empty, newest first.
+	GetAll(claimant string, status string) ([]models.Claim, error)
+	// UpdateByID saves the title and replaces the expenses of the claim.
+	UpdateByID(claim models.Claim, expenses []models.Expense) error
+	DeleteByID(id string) error
+	// GetExpenses returns the expenses among ids in the ledgers the user
+	// keeps: the group ledgers they own or edit, and the personal ledger
+	// when personal is set.
+	GetExpenses(ids []uint, username string, personal bool) ([]models.Expense, error)
+	// GetClaimed returns the expenses among ids held by a claim other than
+	// exceptID that was not rejected.
+	GetClaimed(ids []uint, exceptID uint) ([]ClaimedExpense, error)
+	// Transition moves the claim from from to event.To and records the
+	// event, it reports false when the claim was no longer in from.
+	Transition(claimID uint, from string, event models.ClaimEvent) (bool, error)
+	// GetLocking returns the submitted, approved or paid claim holding the
+	// expense and whether there is one.
+	GetLocking(expenseID uint) (models.Claim, bool, error)
+	// GetEvents returns up to limit events after the id, of the claimant's
+	// claims or of every claim when the claimant is empty.
+	GetEvents(claimant string, after uint, limit int) ([]models.ClaimEvent, error)
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// Actions move a claim from one status to the next.
+const (
+	ActionSubmit  = "submit"
+	ActionApprove = "approve"
+	ActionReject  = "reject"
+	ActionPay     = "pay"
+)
+
+// ClaimRoles names the BasicAuth users who approve or reject claims and the
+// ones who pay them. They see every claim, the other users only their own.
+// Owner keeps the personal ledger, only they claim its expenses.
+type ClaimRoles struct {
+	Approvers []string
+	Payers    []string
+	Owner     string
+}
+
+// ClaimService bundles expenses into reimbursement claims and moves them
+// through the approval workflow. It is a processor of the expense service so
+// that the expenses of a submitted claim cannot be changed.
+type ClaimService interface {
+	CreateClaim(username string, claimReq requests.ClaimRequest) (responses.ClaimResponse, error)
+	GetClaimByID(id string, username string) (responses.ClaimResponse, error)
+	GetClaims(username string, query requests.ClaimQuery) ([]responses.ClaimResponse, error)
+	UpdateClaimByID(id string, username string, claimReq requests.ClaimRequest) (responses.ClaimResponse, error)
+	DeleteClaimByID(id string, username string) error
+	// Transition applies one of the actions to the claim and records who did
+	// it and why, a rejection needs a comment.
+	Transition(id string, username string, action string, transitionReq requests.ClaimTransitionRequest) (responses.ClaimResponse, error)
+	// GetEvents is the feed of status changes the user may see, in order.
+	GetEvents(username string, query requests.ClaimEventQuery) ([]responses.ClaimEventResponse, error)
+	ProcessExpense(expense *models.Expense) error
+	ValidateExpense(expense models.Expense) error
+}
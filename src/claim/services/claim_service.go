@@ -0,0 +1,363 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/claim/repositories"
+)
+
+const defaultEventLimit = 50
+
+// transition is the status an action applies to and the one it leads to,
+// every other move is illegal.
+type transition struct {
+	from string
+	to   string
+}
+
+var transitions = map[string]transition{
+	ActionSubmit:  {from: models.ClaimDraft, to: models.ClaimSubmitted},
+	ActionApprove: {from: models.ClaimSubmitted, to: models.ClaimApproved},
+	ActionReject:  {from: models.ClaimSubmitted, to: models.ClaimRejected},
+	ActionPay:     {from: models.ClaimApproved, to: models.ClaimPaid},
+}
+
+type claimService struct {
+	claimRepo repositories.ClaimRepository
+	roles     ClaimRoles
+}
+
+func NewClaimService(claimRepo repositories.ClaimRepository, roles ClaimRoles) ClaimService {
+	return claimService{claimRepo: claimRepo, roles: roles}
+}
+
+func (s claimService) CreateClaim(username string, claimReq requests.ClaimRequest) (responses.ClaimResponse, error) {
+	expenses, err := s.claimable(username, claimReq.ExpenseIDs, 0)
+	if err != nil {
+		return responses.ClaimResponse{}, err
+	}
+
+	claim := models.Claim{
+		Title:    claimReq.Title,
+		Claimant: username,
+		Status:   models.ClaimDraft,
+		Expenses: expenses,
+	}
+	event := models.ClaimEvent{To: models.ClaimDraft, Actor: username}
+	if err := s.claimRepo.Create(&claim, event); err != nil {
+		return responses.ClaimResponse{}, helpers.NewInternalServerError()
+	}
+
+	return claimResponse(claim), nil
+}
+
+func (s claimService) GetClaimByID(id string, username string) (responses.ClaimResponse, error) {
+	claim, err := s.get(id, username)
+	if err != nil {
+		return responses.ClaimResponse{}, err
+	}
+
+	return claimResponse(claim), nil
+}
+
+func (s claimService) GetClaims(username string, query requests.ClaimQuery) ([]responses.ClaimResponse, error) {
+	claimant := username
+	if s.reviews(username) {
+		claimant = ""
+	}
+
+	claims, err := s.claimRepo.GetAll(claimant, query.Status)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	claimsResp := []responses.ClaimResponse{}
+	for _, claim := range claims {
+		claimsResp = append(claimsResp, claimResponse(claim))
+	}
+
+	return claimsResp, nil
+}
+
+func (s claimService) UpdateClaimByID(id string, username string, claimReq requests.ClaimRequest) (responses.ClaimResponse, error) {
+	claim, err := s.draft(id, username, "changed")
+	if err != nil {
+		return responses.ClaimResponse{}, err
+	}
+
+	expenses, err := s.claimable(username, claimReq.ExpenseIDs, claim.ID)
+	if err != nil {
+		return responses.ClaimResponse{}, err
+	}
+
+	claim.Title = claimReq.Title
+	if err := s.claimRepo.UpdateByID(claim, expenses); err != nil {
+		return responses.ClaimResponse{}, helpers.NewInternalServerError()
+	}
+	claim.Expenses = expenses
+
+	return claimResponse(claim), nil
+}
+
+func (s claimService) DeleteClaimByID(id string, username string) error {
+	if _, err := s.draft(id, username, "deleted"); err != nil {
+		return err
+	}
+
+	if err := s.claimRepo.DeleteByID(id); err != nil {
+		return helpers.NewInternalServerError()
+	}
+
+	return nil
+}
+
+func (s claimService) Transition(id string, username string, action string, transitionReq requests.ClaimTransitionRequest) (responses.ClaimResponse, error) {
+	move, ok := transitions[action]
+	if !ok {
+		return responses.ClaimResponse{}, helpers.NewBadRequestError(fmt.Sprintf("%s is not an action on claims", action))
+	}
+
+	claim, err := s.get(id, username)
+	if err != nil {
+		return responses.ClaimResponse{}, err
+	}
+	if claim.Status != move.from {
+		return responses.ClaimResponse{}, helpers.NewConflictError(fmt.Sprintf("claim %d is %s and cannot be %s", claim.ID, claim.Status, move.to))
+	}
+	if err := s.authorize(claim, username, action); err != nil {
+		return responses.ClaimResponse{}, err
+	}
+	if action == ActionReject && transitionReq.Comment == "" {
+		return responses.ClaimResponse{}, helpers.NewBadRequestError("a rejection needs a comment")
+	}
+	// the expenses of a draft are not locked, they may have changed since
+	if action == ActionSubmit {
+		if len(claim.Expenses) == 0 {
+			return responses.ClaimResponse{}, helpers.NewBadRequestError(fmt.Sprintf("claim %d has no expenses", claim.ID))
+		}
+		if err := checkExpenses(claim.Expenses); err != nil {
+			return responses.ClaimResponse{}, err
+		}
+	}
+
+	event := models.ClaimEvent{To: move.to, Actor: username, Comment: transitionReq.Comment}
+	moved, err := s.claimRepo.Transition(claim.ID, move.from, event)
+	if err != nil {
+		return responses.ClaimResponse{}, helpers.NewInternalServerError()
+	}
+	if !moved {
+		return responses.ClaimResponse{}, helpers.NewConflictError(fmt.Sprintf("claim %d was changed meanwhile", claim.ID))
+	}
+
+	return s.GetClaimByID(id, username)
+}
+
+func (s claimService) GetEvents(username string, query requests.ClaimEventQuery) ([]responses.ClaimEventResponse, error) {
+	claimant := username
+	if s.reviews(username) {
+		claimant = ""
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultEventLimit
+	}
+
+	events, err := s.claimRepo.GetEvents(claimant, query.After, limit)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	eventsResp := []responses.ClaimEventResponse{}
+	for _, event := range events {
+		var eventResp responses.ClaimEventResponse
+		copier.Copy(&eventResp, &event)
+		eventsResp = append(eventsResp, eventResp)
+	}
+
+	return eventsResp, nil
+}
+
+// ProcessExpense does nothing, new expenses are in no claim.
+func (s claimService) ProcessExpense(expense *models.Expense) error {
+	return nil
+}
+
+// ValidateExpense locks the expenses of a claim once it is submitted, the
+// approver decided on what was submitted.
+func (s claimService) ValidateExpense(expense models.Expense) error {
+	if expense.ID == 0 {
+		return nil
+	}
+
+	claim, ok, err := s.claimRepo.GetLocking(expense.ID)
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+	if ok {
+		return helpers.NewConflictError(fmt.Sprintf("expense %d is in claim %d which is %s and cannot be changed", expense.ID, claim.ID, claim.Status))
+	}
+
+	return nil
+}
+
+// get returns the claim when the user may see it.
+func (s claimService) get(id string, username string) (models.Claim, error) {
+	claim, err := s.claimRepo.GetByID(id)
+	if err != nil {
+		return models.Claim{}, helpers.NewNotFoundError()
+	}
+	if claim.Claimant != username && !s.reviews(username) {
+		return models.Claim{}, helpers.NewNotFoundError()
+	}
+
+	return claim, nil
+}
+
+// draft returns the claim when the user is its claimant and it can still be
+// changed.
+func (s claimService) draft(id string, username string, verb string) (models.Claim, error) {
+	claim, err := s.get(id, username)
+	if err != nil {
+		return models.Claim{}, err
+	}
+	if claim.Claimant != username {
+		return models.Claim{}, helpers.NewForbiddenError(fmt.Sprintf("only the claimant can change claim %d", claim.ID))
+	}
+	if claim.Status != models.ClaimDraft {
+		return models.Claim{}, helpers.NewConflictError(fmt.Sprintf("claim %d is %s and cannot be %s", claim.ID, claim.Status, verb))
+	}
+
+	return claim, nil
+}
+
+// authorize checks the role the action needs. Nobody approves, rejects or
+// pays their own claim.
+func (s claimService) authorize(claim models.Claim, username string, action string) error {
+	if action == ActionSubmit {
+		if claim.Claimant != username {
+			return helpers.NewForbiddenError(fmt.Sprintf("only the claimant can submit claim %d", claim.ID))
+		}
+		return nil
+	}
+
+	if action == ActionPay {
+		if !contains(s.roles.Payers, username) {
+			return helpers.NewForbiddenError("this requires the payer role")
+		}
+	} else if !contains(s.roles.Approvers, username) {
+		return helpers.NewForbiddenError("this requires the approver role")
+	}
+	if claim.Claimant == username {
+		return helpers.NewForbiddenError(fmt.Sprintf("you cannot %s your own claim", action))
+	}
+
+	return nil
+}
+
+// reviews tells whether the user sees every claim.
+func (s claimService) reviews(username string) bool {
+	return contains(s.roles.Approvers, username) || contains(s.roles.Payers, username)
+}
+
+// claimable returns the expenses of the ids when they can go into the claim,
+// all must be in ledgers the user keeps and none may be in another claim
+// unless that one was rejected.
+func (s claimService) claimable(username string, ids []uint, claimID uint) ([]models.Expense, error) {
+	ids = unique(ids)
+	expenses, err := s.claimRepo.GetExpenses(ids, username, username == s.roles.Owner)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	if len(expenses) != len(ids) {
+		return nil, helpers.NewNotFoundError()
+	}
+	if err := checkExpenses(expenses); err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.claimRepo.GetClaimed(ids, claimID)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+	if len(claimed) > 0 {
+		return nil, helpers.NewConflictError(fmt.Sprintf("expense %d is already in claim %d", claimed[0].ExpenseID, claimed[0].ClaimID))
+	}
+
+	return expenses, nil
+}
+
+// checkExpenses allows only spending, in a single currency.
+func checkExpenses(expenses []models.Expense) error {
+	for _, expense := range expenses {
+		if expense.Type != "" && expense.Type != models.TypeExpense {
+			return helpers.NewBadRequestError(fmt.Sprintf("expense %d is of type %s, only expenses can be claimed", expense.ID, expense.Type))
+		}
+		if expense.Currency != expenses[0].Currency {
+			return helpers.NewBadRequestError(fmt.Sprintf("a claim is in one currency, found %s and %s", expenses[0].Currency, expense.Currency))
+		}
+	}
+
+	return nil
+}
+
+func claimResponse(claim models.Claim) responses.ClaimResponse {
+	claimResp := responses.ClaimResponse{
+		ID:        claim.ID,
+		Title:     claim.Title,
+		Claimant:  claim.Claimant,
+		Status:    claim.Status,
+		Expenses:  []responses.ExpenseResponse{},
+		CreatedAt: claim.CreatedAt,
+		UpdatedAt: claim.UpdatedAt,
+	}
+
+	var total float64
+	for _, expense := range claim.Expenses {
+		var expenseResp responses.ExpenseResponse
+		copier.Copy(&expenseResp, &expense)
+		claimResp.Expenses = append(claimResp.Expenses, expenseResp)
+		claimResp.Currency = expense.Currency
+		total += expense.Amount
+	}
+	claimResp.Total = round(total)
+
+	for _, event := range claim.Events {
+		var eventResp responses.ClaimEventResponse
+		copier.Copy(&eventResp, &event)
+		claimResp.Events = append(claimResp.Events, eventResp)
+	}
+
+	return claimResp
+}
+
+func contains(usernames []string, username string) bool {
+	for _, name := range usernames {
+		if name == username {
+			return true
+		}
+	}
+	return false
+}
+
+func unique(ids []uint) []uint {
+	seen := map[uint]bool{}
+	var uniqueIDs []uint
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+	return uniqueIDs
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
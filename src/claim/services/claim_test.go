@@ -0,0 +1,445 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/claim/repositories"
+	"github.com/wytquant/assessment/src/claim/services"
+	"gorm.io/gorm"
+)
+
+// ann and bob approve, cat pays and dan has no role but owns the personal
+// ledger
+var roles = services.ClaimRoles{Approvers: []string{"ann", "bob"}, Payers: []string{"cat"}, Owner: "dan"}
+
+func claim(status string) models.Claim {
+	return models.Claim{ID: 3, Title: "trip to Chiang Mai", Claimant: "ann", Status: status, Expenses: []models.Expense{
+		{ID: 1, Title: "flight", Amount: 1500.5, Currency: "THB", Type: models.TypeExpense},
+		{ID: 2, Title: "hotel", Amount: 2400.25, Currency: "THB", Type: models.TypeExpense},
+	}}
+}
+
+func TestCreateClaimService(t *testing.T) {
+	t.Run("create claim success case", func(t *testing.T) {
+		//arrange
+		expenses := claim(models.ClaimDraft).Expenses
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetExpenses", []uint{1, 2}, "dan", true).Return(expenses, nil)
+		claimRepo.On("GetClaimed", []uint{1, 2}, uint(0)).Return([]repositories.ClaimedExpense{}, nil)
+		claimRepo.On("Create", mock.Anything, models.ClaimEvent{To: models.ClaimDraft, Actor: "dan"}).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Claim).ID = 3
+		})
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		got, err := claimService.CreateClaim("dan", requests.ClaimRequest{Title: "trip", ExpenseIDs: []uint{1, 2, 1}})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint(3), got.ID)
+		assert.Equal(t, "dan", got.Claimant)
+		assert.Equal(t, models.ClaimDraft, got.Status)
+		assert.Equal(t, "THB", got.Currency)
+		assert.Equal(t, 3900.75, got.Total)
+		assert.Len(t, got.Expenses, 2)
+		claimRepo.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name     string
+		expenses []models.Expense
+		claimed  []repositories.ClaimedExpense
+		want     error
+	}{
+		{
+			name:     "create claim fail 404 because an expense is not in a ledger the claimant keeps",
+			expenses: []models.Expense{{ID: 1, Currency: "THB", Type: models.TypeExpense}},
+			want:     helpers.NewNotFoundError(),
+		},
+		{
+			name:     "create claim fail 400 because an expense is an income",
+			expenses: []models.Expense{{ID: 1, Currency: "THB", Type: models.TypeExpense}, {ID: 2, Currency: "THB", Type: models.TypeIncome}},
+			want:     helpers.NewBadRequestError("expense 2 is of type income, only expenses can be claimed"),
+		},
+		{
+			name:     "create claim fail 400 because the currencies differ",
+			expenses: []models.Expense{{ID: 1, Currency: "THB", Type: models.TypeExpense}, {ID: 2, Currency: "USD", Type: models.TypeExpense}},
+			want:     helpers.NewBadRequestError("a claim is in one currency, found THB and USD"),
+		},
+		{
+			name:     "create claim fail 409 because an expense is in another claim",
+			expenses: claim(models.ClaimDraft).Expenses,
+			claimed:  []repositories.ClaimedExpense{{ExpenseID: 2, ClaimID: 7, Status: models.ClaimSubmitted}},
+			want:     helpers.NewConflictError("expense 2 is already in claim 7"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			//arrange
+			claimRepo := repositories.NewClaimRepositoryMock()
+			claimRepo.On("GetExpenses", []uint{1, 2}, "ann", false).Return(tc.expenses, nil)
+			claimRepo.On("GetClaimed", []uint{1, 2}, uint(0)).Return(tc.claimed, nil)
+
+			claimService := services.NewClaimService(claimRepo, roles)
+
+			//act
+			_, err := claimService.CreateClaim("ann", requests.ClaimRequest{Title: "trip", ExpenseIDs: []uint{1, 2}})
+
+			//assert
+			assert.Equal(t, tc.want, err)
+			claimRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetClaimService(t *testing.T) {
+	t.Run("get claim of another user fail 404 because only reviewers see it", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetByID", "3").Return(claim(models.ClaimDraft), nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		_, err := claimService.GetClaimByID("3", "dan")
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+	})
+
+	t.Run("get claims of a payer lists everyone's claims success case", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetAll", "", models.ClaimApproved).Return([]models.Claim{claim(models.ClaimApproved)}, nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		got, err := claimService.GetClaims("cat", requests.ClaimQuery{Status: models.ClaimApproved})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		claimRepo.AssertExpectations(t)
+	})
+
+	t.Run("get claims of a claimant lists their own success case", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetAll", "dan", "").Return([]models.Claim{}, nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		got, err := claimService.GetClaims("dan", requests.ClaimQuery{})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+		claimRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateClaimService(t *testing.T) {
+	t.Run("update claim success case", func(t *testing.T) {
+		//arrange
+		stored := claim(models.ClaimDraft)
+		expenses := stored.Expenses[:1]
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetByID", "3").Return(stored, nil)
+		claimRepo.On("GetExpenses", []uint{1}, "ann", false).Return(expenses, nil)
+		claimRepo.On("GetClaimed", []uint{1}, uint(3)).Return([]repositories.ClaimedExpense{}, nil)
+		stored.Title = "flight only"
+		claimRepo.On("UpdateByID", stored, expenses).Return(nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		got, err := claimService.UpdateClaimByID("3", "ann", requests.ClaimRequest{Title: "flight only", ExpenseIDs: []uint{1}})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "flight only", got.Title)
+		assert.Equal(t, 1500.5, got.Total)
+		claimRepo.AssertExpectations(t)
+	})
+
+	t.Run("update claim fail 404 because a non-owner adds an expense of the personal ledger", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetByID", "3").Return(claim(models.ClaimDraft), nil)
+		claimRepo.On("GetExpenses", []uint{1, 9}, "ann", false).Return(claim(models.ClaimDraft).Expenses[:1], nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		_, err := claimService.UpdateClaimByID("3", "ann", requests.ClaimRequest{Title: "trip", ExpenseIDs: []uint{1, 9}})
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+		claimRepo.AssertNotCalled(t, "UpdateByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update claim fail 409 because it was submitted", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetByID", "3").Return(claim(models.ClaimSubmitted), nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		_, err := claimService.UpdateClaimByID("3", "ann", requests.ClaimRequest{Title: "trip", ExpenseIDs: []uint{1}})
+
+		//assert
+		assert.Equal(t, helpers.NewConflictError("claim 3 is submitted and cannot be changed"), err)
+	})
+
+	t.Run("delete claim fail 403 because only the claimant may", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetByID", "3").Return(claim(models.ClaimDraft), nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		err := claimService.DeleteClaimByID("3", "bob")
+
+		//assert
+		assert.Equal(t, helpers.NewForbiddenError("only the claimant can change claim 3"), err)
+		claimRepo.AssertNotCalled(t, "DeleteByID", mock.Anything)
+	})
+
+	t.Run("delete claim success case", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetByID", "3").Return(claim(models.ClaimDraft), nil)
+		claimRepo.On("DeleteByID", "3").Return(nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		err := claimService.DeleteClaimByID("3", "ann")
+
+		//assert
+		assert.NoError(t, err)
+		claimRepo.AssertExpectations(t)
+	})
+}
+
+// actors are allowed to take the action whenever the claim's status permits.
+var actors = map[string]string{
+	services.ActionSubmit:  "ann",
+	services.ActionApprove: "bob",
+	services.ActionReject:  "bob",
+	services.ActionPay:     "cat",
+}
+
+var legal = map[string]string{
+	models.ClaimDraft + services.ActionSubmit:      models.ClaimSubmitted,
+	models.ClaimSubmitted + services.ActionApprove: models.ClaimApproved,
+	models.ClaimSubmitted + services.ActionReject:  models.ClaimRejected,
+	models.ClaimApproved + services.ActionPay:      models.ClaimPaid,
+}
+
+var pastTense = map[string]string{
+	services.ActionSubmit:  models.ClaimSubmitted,
+	services.ActionApprove: models.ClaimApproved,
+	services.ActionReject:  models.ClaimRejected,
+	services.ActionPay:     models.ClaimPaid,
+}
+
+func TestTransitionService(t *testing.T) {
+	statuses := []string{models.ClaimDraft, models.ClaimSubmitted, models.ClaimApproved, models.ClaimRejected, models.ClaimPaid}
+	actions := []string{services.ActionSubmit, services.ActionApprove, services.ActionReject, services.ActionPay}
+
+	for _, status := range statuses {
+		for _, action := range actions {
+			status, action := status, action
+			to, ok := legal[status+action]
+			if !ok {
+				t.Run(fmt.Sprintf("%s a %s claim fail 409 because it is illegal", action, status), func(t *testing.T) {
+					//arrange
+					claimRepo := repositories.NewClaimRepositoryMock()
+					claimRepo.On("GetByID", "3").Return(claim(status), nil)
+
+					claimService := services.NewClaimService(claimRepo, roles)
+
+					//act
+					_, err := claimService.Transition("3", actors[action], action, requests.ClaimTransitionRequest{Comment: "receipts attached"})
+
+					//assert
+					assert.Equal(t, helpers.NewConflictError(fmt.Sprintf("claim 3 is %s and cannot be %s", status, pastTense[action])), err)
+					claimRepo.AssertNotCalled(t, "Transition", mock.Anything, mock.Anything, mock.Anything)
+				})
+				continue
+			}
+
+			t.Run(fmt.Sprintf("%s a %s claim success case", action, status), func(t *testing.T) {
+				//arrange
+				event := models.ClaimEvent{To: to, Actor: actors[action], Comment: "receipts attached"}
+				claimRepo := repositories.NewClaimRepositoryMock()
+				claimRepo.On("GetByID", "3").Return(claim(status), nil).Once()
+				claimRepo.On("Transition", uint(3), status, event).Return(true, nil)
+				claimRepo.On("GetByID", "3").Return(claim(to), nil).Once()
+
+				claimService := services.NewClaimService(claimRepo, roles)
+
+				//act
+				got, err := claimService.Transition("3", actors[action], action, requests.ClaimTransitionRequest{Comment: "receipts attached"})
+
+				//assert
+				assert.NoError(t, err)
+				assert.Equal(t, to, got.Status)
+				claimRepo.AssertExpectations(t)
+			})
+		}
+	}
+
+	t.Run("transition fail 400 because the action is unknown", func(t *testing.T) {
+		//arrange
+		claimService := services.NewClaimService(repositories.NewClaimRepositoryMock(), roles)
+
+		//act
+		_, err := claimService.Transition("3", "ann", "reopen", requests.ClaimTransitionRequest{})
+
+		//assert
+		assert.Equal(t, helpers.NewBadRequestError("reopen is not an action on claims"), err)
+	})
+}
+
+func TestTransitionRulesService(t *testing.T) {
+	empty := claim(models.ClaimDraft)
+	empty.Expenses = nil
+	mixed := claim(models.ClaimDraft)
+	mixed.Expenses[1].Currency = "USD"
+
+	cases := []struct {
+		name     string
+		claim    models.Claim
+		err      error
+		username string
+		action   string
+		comment  string
+		want     error
+	}{
+		{name: "submit fail 403 because only the claimant may", claim: claim(models.ClaimDraft), username: "bob", action: services.ActionSubmit, want: helpers.NewForbiddenError("only the claimant can submit claim 3")},
+		{name: "approve fail 403 because the approver is the claimant", claim: claim(models.ClaimSubmitted), username: "ann", action: services.ActionApprove, want: helpers.NewForbiddenError("you cannot approve your own claim")},
+		{name: "reject fail 403 because the approver is the claimant", claim: claim(models.ClaimSubmitted), username: "ann", action: services.ActionReject, comment: "no", want: helpers.NewForbiddenError("you cannot reject your own claim")},
+		{name: "approve fail 403 because a payer is no approver", claim: claim(models.ClaimSubmitted), username: "cat", action: services.ActionApprove, want: helpers.NewForbiddenError("this requires the approver role")},
+		{name: "pay fail 403 because an approver is no payer", claim: claim(models.ClaimApproved), username: "bob", action: services.ActionPay, want: helpers.NewForbiddenError("this requires the payer role")},
+		{name: "approve fail 404 because users without a role only see their claims", claim: claim(models.ClaimSubmitted), username: "dan", action: services.ActionApprove, want: helpers.NewNotFoundError()},
+		{name: "approve fail 404 because the claim does not exist", err: gorm.ErrRecordNotFound, username: "bob", action: services.ActionApprove, want: helpers.NewNotFoundError()},
+		{name: "reject fail 400 because there is no comment", claim: claim(models.ClaimSubmitted), username: "bob", action: services.ActionReject, want: helpers.NewBadRequestError("a rejection needs a comment")},
+		{name: "submit fail 400 because the claim has no expenses", claim: empty, username: "ann", action: services.ActionSubmit, want: helpers.NewBadRequestError("claim 3 has no expenses")},
+		{name: "submit fail 400 because an expense changed currency", claim: mixed, username: "ann", action: services.ActionSubmit, want: helpers.NewBadRequestError("a claim is in one currency, found THB and USD")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			//arrange
+			claimRepo := repositories.NewClaimRepositoryMock()
+			claimRepo.On("GetByID", "3").Return(tc.claim, tc.err)
+
+			claimService := services.NewClaimService(claimRepo, roles)
+
+			//act
+			_, err := claimService.Transition("3", tc.username, tc.action, requests.ClaimTransitionRequest{Comment: tc.comment})
+
+			//assert
+			assert.Equal(t, tc.want, err)
+			claimRepo.AssertNotCalled(t, "Transition", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("approve fail 409 because the claim was changed meanwhile", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetByID", "3").Return(claim(models.ClaimSubmitted), nil)
+		claimRepo.On("Transition", uint(3), models.ClaimSubmitted, mock.Anything).Return(false, nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		_, err := claimService.Transition("3", "bob", services.ActionApprove, requests.ClaimTransitionRequest{})
+
+		//assert
+		assert.Equal(t, helpers.NewConflictError("claim 3 was changed meanwhile"), err)
+	})
+}
+
+func TestGetEventsService(t *testing.T) {
+	t.Run("get events of a claimant success case", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetEvents", "dan", uint(4), 50).Return([]models.ClaimEvent{
+			{ID: 5, ClaimID: 3, From: models.ClaimSubmitted, To: models.ClaimRejected, Actor: "bob", Comment: "no receipt"},
+		}, nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		got, err := claimService.GetEvents("dan", requests.ClaimEventQuery{After: 4})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, models.ClaimRejected, got[0].To)
+			assert.Equal(t, "no receipt", got[0].Comment)
+		}
+	})
+}
+
+func TestValidateExpenseService(t *testing.T) {
+	t.Run("validate expense fail 409 because its claim was submitted", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetLocking", uint(1)).Return(claim(models.ClaimApproved), true, nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		err := claimService.ValidateExpense(models.Expense{ID: 1})
+
+		//assert
+		assert.Equal(t, helpers.NewConflictError("expense 1 is in claim 3 which is approved and cannot be changed"), err)
+	})
+
+	t.Run("validate expense success case", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetLocking", uint(1)).Return(models.Claim{}, false, nil)
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		err := claimService.ValidateExpense(models.Expense{ID: 1})
+
+		//assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("validate expense fail 500 because the repository fails", func(t *testing.T) {
+		//arrange
+		claimRepo := repositories.NewClaimRepositoryMock()
+		claimRepo.On("GetLocking", uint(1)).Return(models.Claim{}, false, errors.New("connection refused"))
+
+		claimService := services.NewClaimService(claimRepo, roles)
+
+		//act
+		err := claimService.ValidateExpense(models.Expense{ID: 1})
+
+		//assert
+		assert.Equal(t, helpers.NewInternalServerError(), err)
+	})
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type claimServiceMock struct {
+	mock.Mock
+}
+
+func NewClaimServiceMock() *claimServiceMock {
+	return &claimServiceMock{}
+}
+
+func (m *claimServiceMock) CreateClaim(username string, claimReq requests.ClaimRequest) (responses.ClaimResponse, error) {
+	args := m.Called(username, claimReq)
+	return args.Get(0).(responses.ClaimResponse), args.Error(1)
+}
+
+func (m *claimServiceMock) GetClaimByID(id string, username string) (responses.ClaimResponse, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(responses.ClaimResponse), args.Error(1)
+}
+
+func (m *claimServiceMock) GetClaims(username string, query requests.ClaimQuery) ([]responses.ClaimResponse, error) {
+	args := m.Called(username, query)
+	return args.Get(0).([]responses.ClaimResponse), args.Error(1)
+}
+
+func (m *claimServiceMock) UpdateClaimByID(id string, username string, claimReq requests.ClaimRequest) (responses.ClaimResponse, error) {
+	args := m.Called(id, username, claimReq)
+	return args.Get(0).(responses.ClaimResponse), args.Error(1)
+}
+
+func (m *claimServiceMock) DeleteClaimByID(id string, username string) error {
+	args := m.Called(id, username)
+	return args.Error(0)
+}
+
+func (m *claimServiceMock) Transition(id string, username string, action string, transitionReq requests.ClaimTransitionRequest) (responses.ClaimResponse, error) {
+	args := m.Called(id, username, action, transitionReq)
+	return args.Get(0).(responses.ClaimResponse), args.Error(1)
+}
+
+func (m *claimServiceMock) GetEvents(username string, query requests.ClaimEventQuery) ([]responses.ClaimEventResponse, error) {
+	args := m.Called(username, query)
+	return args.Get(0).([]responses.ClaimEventResponse), args.Error(1)
+}
+
+func (m *claimServiceMock) ProcessExpense(expense *models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
+
+func (m *claimServiceMock) ValidateExpense(expense models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/duplicate/services"
+)
+
+type duplicateHandler struct {
+	duplicateService services.DuplicateService
+}
+
+func NewDuplicateHandler(duplicateService services.DuplicateService) duplicateHandler {
+	return duplicateHandler{duplicateService: duplicateService}
+}
+
+func (h duplicateHandler) GetDuplicates(c *gin.Context) {
+	clustersResp, err := h.duplicateService.GetDuplicates()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, clustersResp)
+}
+
+func (h duplicateHandler) MergeExpenses(c *gin.Context) {
+	var mergeReq requests.MergeExpensesRequest
+	if err := c.ShouldBindJSON(&mergeReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	mergeResp, err := h.duplicateService.MergeExpenses(mergeReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, mergeResp)
+}
+
+func (h duplicateHandler) DismissDuplicates(c *gin.Context) {
+	var dismissReq requests.DismissDuplicatesRequest
+	if err := c.ShouldBindJSON(&dismissReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	if err := h.duplicateService.DismissDuplicates(dismissReq); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,107 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/duplicate/handlers"
+	services "github.com/wytquant/assessment/src/duplicate/services/mock"
+)
+
+func TestGetDuplicatesHandler(t *testing.T) {
+	t.Run("get duplicates success case", func(t *testing.T) {
+		//arrange
+		duplicateService := services.NewDuplicateServiceMock()
+		duplicateService.On("GetDuplicates").Return([]responses.DuplicateCluster{
+			{Score: 1, Expenses: []responses.ExpenseResponse{{ID: 1, Title: "coffee"}, {ID: 2, Title: "Coffee"}}},
+		}, nil)
+
+		duplicateHandler := handlers.NewDuplicateHandler(duplicateService)
+
+		r := gin.Default()
+		r.GET("/expenses/duplicates", duplicateHandler.GetDuplicates)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/duplicates", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		duplicateService.AssertExpectations(t)
+	})
+}
+
+func TestMergeExpensesHandler(t *testing.T) {
+	t.Run("merge expenses fail case because more than one expense is split", func(t *testing.T) {
+		//arrange
+		mergeReq := requests.MergeExpensesRequest{KeepID: 1, MergeIDs: []uint{2}}
+
+		duplicateService := services.NewDuplicateServiceMock()
+		duplicateService.On("MergeExpenses", mergeReq).Return(responses.MergeResponse{}, helpers.NewConflictError("only one of the merged expenses may be split"))
+
+		duplicateHandler := handlers.NewDuplicateHandler(duplicateService)
+
+		r := gin.Default()
+		r.POST("/expenses/merge", duplicateHandler.MergeExpenses)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/expenses/merge", bytes.NewBufferString(`{"keep_id": 1, "merge_ids": [2]}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"message": "only one of the merged expenses may be split"}`, w.Body.String())
+	})
+
+	t.Run("merge expenses fail bad request because nothing is merged", func(t *testing.T) {
+		//arrange
+		duplicateHandler := handlers.NewDuplicateHandler(services.NewDuplicateServiceMock())
+
+		r := gin.Default()
+		r.POST("/expenses/merge", duplicateHandler.MergeExpenses)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/expenses/merge", bytes.NewBufferString(`{"keep_id": 1, "merge_ids": []}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestDismissDuplicatesHandler(t *testing.T) {
+	t.Run("dismiss duplicates success case", func(t *testing.T) {
+		//arrange
+		duplicateService := services.NewDuplicateServiceMock()
+		duplicateService.On("DismissDuplicates", requests.DismissDuplicatesRequest{ExpenseIDs: []uint{4, 5}}).Return(nil)
+
+		duplicateHandler := handlers.NewDuplicateHandler(duplicateService)
+
+		r := gin.Default()
+		r.POST("/expenses/duplicates/dismiss", duplicateHandler.DismissDuplicates)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/expenses/duplicates/dismiss", bytes.NewBufferString(`{"expense_ids": [4, 5]}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/src/webhook/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type duplicateRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewDuplicateRepositoryDB(db *gorm.DB) DuplicateRepository {
+	return duplicateRepositoryDB{db: db}
+}
+
+func (r duplicateRepositoryDB) FindSimilar(expense models.Expense, days int) ([]models.Expense, error) {
+	var expenses []models.Expense
+
+	currency := expense.Currency
+	if currency == "" {
+		currency = helpers.BaseCurrency
+	}
+
+	err := r.db.Where("expenses.group_id IS NULL AND expenses.id <> ?", expense.ID).
+		Where("expenses.amount = ? AND expenses.currency = ?", expense.Amount, currency).
+		Where("expenses.date BETWEEN ?::date - ?::int AND ?::date + ?::int", expense.Date, days, expense.Date, days).
+		Order("expenses.id").
+		Find(&expenses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r duplicateRepositoryDB) FindPairs(days int) ([]ExpensePair, error) {
+	var pairs []ExpensePair
+
+	err := r.db.Raw(`SELECT a.id AS expense_id, a.title AS expense_title, b.id AS duplicate_id, b.title AS duplicate_title
+		FROM expenses AS a
+		JOIN expenses AS b ON b.id > a.id
+			AND b.amount = a.amount
+			AND b.currency = a.currency
+			AND ABS(b.date - a.date) <= ?
+		WHERE a.group_id IS NULL AND b.group_id IS NULL
+			AND a.deleted_at IS NULL AND b.deleted_at IS NULL
+		ORDER BY a.id, b.id`, days).Scan(&pairs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+func (r duplicateRepositoryDB) SaveCandidates(candidates []models.DuplicateCandidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&candidates).Error
+}
+
+func (r duplicateRepositoryDB) GetPendingCandidates() ([]models.DuplicateCandidate, error) {
+	var candidates []models.DuplicateCandidate
+
+	err := r.db.Where("duplicate_candidates.status = ?", models.DuplicatePending).
+		Where("EXISTS (SELECT 1 FROM expenses WHERE expenses.id = duplicate_candidates.expense_id AND expenses.deleted_at IS NULL)").
+		Where("EXISTS (SELECT 1 FROM expenses WHERE expenses.id = duplicate_candidates.duplicate_id AND expenses.deleted_at IS NULL)").
+		Order("duplicate_candidates.id").
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+func (r duplicateRepositoryDB) GetExpenses(ids []uint) ([]models.Expense, error) {
+	var expenses []models.Expense
+
+	if err := r.db.Where("expenses.group_id IS NULL AND expenses.id IN ?", ids).Order("expenses.id").Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r duplicateRepositoryDB) Dismiss(ids []uint) error {
+	return r.db.Model(&models.DuplicateCandidate{}).
+		Where("expense_id IN ? AND duplicate_id IN ?", ids, ids).
+		Update("status", models.DuplicateDismissed).Error
+}
+
+func (r duplicateRepositoryDB) Merge(keep models.Expense, merged []models.Expense, record *models.ExpenseMerge) (bool, error) {
+	ids := []uint{keep.ID}
+	mergedIDs := []uint{}
+	for _, expense := range merged {
+		ids = append(ids, expense.ID)
+		mergedIDs = append(mergedIDs, expense.ID)
+	}
+
+	merge := true
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var splits []models.ExpenseSplit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("expense_id IN ?", ids).Find(&splits).Error; err != nil {
+			return err
+		}
+		if len(splits) > 1 {
+			merge = false
+			return nil
+		}
+		if len(splits) == 1 && splits[0].ExpenseID != keep.ID {
+			if err := tx.Model(&splits[0]).Update("expense_id", keep.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		keep.SearchText = helpers.SearchText(keep.Title, keep.Note)
+		if err := tx.Model(&keep).Select("tags", "note", "search_text").Updates(keep).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Attachment{}).Where("expense_id IN ?", mergedIDs).Update("expense_id", keep.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Expense{}).Where("id IN ?", mergedIDs).Update("merged_into_id", keep.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", mergedIDs).Delete(&models.Expense{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.DuplicateCandidate{}).
+			Where("expense_id IN ? OR duplicate_id IN ?", mergedIDs, mergedIDs).
+			Update("status", models.DuplicateMerged).Error; err != nil {
+			return err
+		}
+		if err := outbox.Record(tx, models.EventExpenseUpdated, keep); err != nil {
+			return err
+		}
+		deleted := append([]models.Expense{}, merged...)
+		for i := range deleted {
+			deleted[i].MergedIntoID = &keep.ID
+		}
+		if err := outbox.Record(tx, models.EventExpenseDeleted, deleted...); err != nil {
+			return err
+		}
+
+		return tx.Create(record).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return merge, nil
+}
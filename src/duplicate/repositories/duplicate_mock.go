@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type duplicateRepositoryMock struct {
+	mock.Mock
+}
+
+func NewDuplicateRepositoryMock() *duplicateRepositoryMock {
+	return &duplicateRepositoryMock{}
+}
+
+func (m *duplicateRepositoryMock) FindSimilar(expense models.Expense, days int) ([]models.Expense, error) {
+	args := m.Called(expense, days)
+	return args.Get(0).([]models.Expense), args.Error(1)
+}
+
+func (m *duplicateRepositoryMock) FindPairs(days int) ([]ExpensePair, error) {
+	args := m.Called(days)
+	return args.Get(0).([]ExpensePair), args.Error(1)
+}
+
+func (m *duplicateRepositoryMock) SaveCandidates(candidates []models.DuplicateCandidate) error {
+	args := m.Called(candidates)
+	return args.Error(0)
+}
+
+func (m *duplicateRepositoryMock) GetPendingCandidates() ([]models.DuplicateCandidate, error) {
+	args := m.Called()
+	return args.Get(0).([]models.DuplicateCandidate), args.Error(1)
+}
+
+func (m *duplicateRepositoryMock) GetExpenses(ids []uint) ([]models.Expense, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.Expense), args.Error(1)
+}
+
+func (m *duplicateRepositoryMock) Dismiss(ids []uint) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *duplicateRepositoryMock) Merge(keep models.Expense, merged []models.Expense, record *models.ExpenseMerge) (bool, error) {
+	args := m.Called(keep, merged, record)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"github.com/wytquant/assessment/models"
+)
+
+type ExpensePair struct {
+	ExpenseID      uint
+	ExpenseTitle   string
+	DuplicateID    uint
+	DuplicateTitle string
+}
+
+// DuplicateRepository works on the personal ledger.
+type DuplicateRepository interface {
+	// FindSimilar returns the other expenses with the same amount and
+	// currency dated at most days apart from the expense.
+	FindSimilar(expense models.Expense, days int) ([]models.Expense, error)
+	// FindPairs returns every pair of expenses with the same amount and
+	// currency dated at most days apart, the lower id first.
+	FindPairs(days int) ([]ExpensePair, error)
+	// SaveCandidates stores new candidates, pairs known before keep their
+	// status.
+	SaveCandidates(candidates []models.DuplicateCandidate) error
+	GetPendingCandidates() ([]models.DuplicateCandidate, error)
+	GetExpenses(ids []uint) ([]models.Expense, error)
+	// Dismiss marks the candidates between the expenses as no duplicates.
+	Dismiss(ids []uint) error
+	// Merge saves the kept expense, soft deletes the merged ones and moves
+	// their attachments and split to it. It reports false when more than one
+	// of the expenses is split.
+	Merge(keep models.Expense, merged []models.Expense, record *models.ExpenseMerge) (bool, error)
+}
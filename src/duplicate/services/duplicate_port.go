@@ -0,0 +1,19 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// DuplicateService finds duplicate expenses in the personal ledger.
+type DuplicateService interface {
+	GetDuplicates() ([]responses.DuplicateCluster, error)
+	MergeExpenses(mergeReq requests.MergeExpensesRequest) (responses.MergeResponse, error)
+	DismissDuplicates(dismissReq requests.DismissDuplicatesRequest) error
+	// Scan flags the duplicates among all expenses and returns how many
+	// pairs it found.
+	Scan() (int, error)
+	ExpenseCreated(expense models.Expense)
+	ExpenseUpdated(expense models.Expense)
+}
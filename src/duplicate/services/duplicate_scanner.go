@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunScanner flags duplicates among all expenses right away and then every
+// interval until ctx is done, it catches expenses that were imported or
+// created without the expense service.
+func RunScanner(ctx context.Context, duplicateService DuplicateService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := duplicateService.Scan(); err != nil {
+			log.Println("fail to scan for duplicates:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/copier"
+	"github.com/lib/pq"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/duplicate/repositories"
+	expenseServices "github.com/wytquant/assessment/src/expense/services"
+)
+
+const (
+	// duplicateDays is how many days apart duplicates may be dated, a bank
+	// often books a card payment a day or two after it was made.
+	duplicateDays = 3
+	// duplicateScore is the title similarity from which expenses are flagged.
+	duplicateScore = 0.8
+)
+
+type duplicateService struct {
+	duplicateRepo repositories.DuplicateRepository
+	validators    []expenseServices.ExpenseValidator
+}
+
+// NewDuplicateService checks merges with the validators that lock expenses,
+// a merge changes the kept expense and deletes the others.
+func NewDuplicateService(duplicateRepo repositories.DuplicateRepository, validators ...expenseServices.ExpenseValidator) DuplicateService {
+	return duplicateService{duplicateRepo: duplicateRepo, validators: validators}
+}
+
+func (s duplicateService) ExpenseCreated(expense models.Expense) {
+	s.detect(expense)
+}
+
+func (s duplicateService) ExpenseUpdated(expense models.Expense) {
+	s.detect(expense)
+}
+
+func (s duplicateService) detect(expense models.Expense) {
+	// duplicates are looked for in the personal ledger only
+	if expense.GroupID != nil {
+		return
+	}
+
+	similar, err := s.duplicateRepo.FindSimilar(expense, duplicateDays)
+	if err != nil {
+		log.Println("fail to look for duplicates:", err)
+		return
+	}
+
+	var candidates []models.DuplicateCandidate
+	for _, other := range similar {
+		if score := helpers.Similarity(expense.Title, other.Title); score >= duplicateScore {
+			candidates = append(candidates, candidate(expense.ID, other.ID, score))
+		}
+	}
+
+	if err := s.duplicateRepo.SaveCandidates(candidates); err != nil {
+		log.Println("fail to save duplicates:", err)
+	}
+}
+
+func (s duplicateService) Scan() (int, error) {
+	pairs, err := s.duplicateRepo.FindPairs(duplicateDays)
+	if err != nil {
+		return 0, err
+	}
+
+	var candidates []models.DuplicateCandidate
+	for _, pair := range pairs {
+		if score := helpers.Similarity(pair.ExpenseTitle, pair.DuplicateTitle); score >= duplicateScore {
+			candidates = append(candidates, candidate(pair.ExpenseID, pair.DuplicateID, score))
+		}
+	}
+
+	if err := s.duplicateRepo.SaveCandidates(candidates); err != nil {
+		return 0, err
+	}
+
+	return len(candidates), nil
+}
+
+func candidate(id uint, otherID uint, score float64) models.DuplicateCandidate {
+	if otherID < id {
+		id, otherID = otherID, id
+	}
+
+	return models.DuplicateCandidate{ExpenseID: id, DuplicateID: otherID, Score: score, Status: models.DuplicatePending}
+}
+
+// GetDuplicates groups the pending candidates into clusters of expenses that
+// are linked by any pair, most likely duplicates first.
+func (s duplicateService) GetDuplicates() ([]responses.DuplicateCluster, error) {
+	clustersResp := []responses.DuplicateCluster{}
+
+	candidates, err := s.duplicateRepo.GetPendingCandidates()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+	if len(candidates) == 0 {
+		return clustersResp, nil
+	}
+
+	parent := map[uint]uint{}
+	var find func(id uint) uint
+	find = func(id uint) uint {
+		if _, ok := parent[id]; !ok {
+			parent[id] = id
+		}
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, candidate := range candidates {
+		a, b := find(candidate.ExpenseID), find(candidate.DuplicateID)
+		if a != b {
+			parent[b] = a
+		}
+	}
+
+	ids := make([]uint, 0, len(parent))
+	for id := range parent {
+		ids = append(ids, id)
+	}
+	expenses, err := s.duplicateRepo.GetExpenses(ids)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	clusters := map[uint]*responses.DuplicateCluster{}
+	var roots []uint
+	for _, expense := range expenses {
+		root := find(expense.ID)
+		if clusters[root] == nil {
+			clusters[root] = &responses.DuplicateCluster{}
+			roots = append(roots, root)
+		}
+		var expenseResp responses.ExpenseResponse
+		copier.Copy(&expenseResp, &expense)
+		clusters[root].Expenses = append(clusters[root].Expenses, expenseResp)
+	}
+	for _, candidate := range candidates {
+		if cluster := clusters[find(candidate.ExpenseID)]; cluster != nil && candidate.Score > cluster.Score {
+			cluster.Score = candidate.Score
+		}
+	}
+
+	for _, root := range roots {
+		if len(clusters[root].Expenses) > 1 {
+			clustersResp = append(clustersResp, *clusters[root])
+		}
+	}
+	sort.SliceStable(clustersResp, func(i, j int) bool {
+		return clustersResp[i].Score > clustersResp[j].Score
+	})
+
+	return clustersResp, nil
+}
+
+func (s duplicateService) DismissDuplicates(dismissReq requests.DismissDuplicatesRequest) error {
+	if err := s.duplicateRepo.Dismiss(dismissReq.ExpenseIDs); err != nil {
+		return helpers.NewInternalServerError()
+	}
+
+	return nil
+}
+
+// MergeExpenses keeps one expense with the tags and notes of all of them and
+// soft deletes the others.
+func (s duplicateService) MergeExpenses(mergeReq requests.MergeExpensesRequest) (responses.MergeResponse, error) {
+	ids := []uint{mergeReq.KeepID}
+	for _, id := range mergeReq.MergeIDs {
+		if !containsID(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 1 {
+		return responses.MergeResponse{}, helpers.NewBadRequestError("merge_ids must name other expenses than keep_id")
+	}
+
+	expenses, err := s.duplicateRepo.GetExpenses(ids)
+	if err != nil {
+		return responses.MergeResponse{}, helpers.NewInternalServerError()
+	}
+	if len(expenses) != len(ids) {
+		return responses.MergeResponse{}, helpers.NewNotFoundError()
+	}
+
+	var keep models.Expense
+	var merged []models.Expense
+	for _, expense := range expenses {
+		if expense.ID == mergeReq.KeepID {
+			keep = expense
+		} else {
+			merged = append(merged, expense)
+		}
+	}
+	for _, expense := range merged {
+		if expense.Type != keep.Type {
+			return responses.MergeResponse{}, helpers.NewBadRequestError("merged expenses must have the same type")
+		}
+		if expense.Amount != keep.Amount {
+			return responses.MergeResponse{}, helpers.NewBadRequestError("merged expenses must have the same amount")
+		}
+		if expense.Currency != keep.Currency {
+			return responses.MergeResponse{}, helpers.NewBadRequestError("merged expenses must have the same currency")
+		}
+	}
+	for _, expense := range expenses {
+		for _, validator := range s.validators {
+			if err := validator.ValidateExpense(expense); err != nil {
+				return responses.MergeResponse{}, err
+			}
+		}
+	}
+
+	record := models.ExpenseMerge{ExpenseID: keep.ID, NoteBefore: keep.Note, TagsBefore: keep.Tags}
+	notes := []string{}
+	if keep.Note != "" {
+		notes = append(notes, keep.Note)
+	}
+	tags := append(pq.StringArray{}, keep.Tags...)
+	for _, expense := range merged {
+		record.MergedIDs = append(record.MergedIDs, int64(expense.ID))
+		if expense.Note != "" && !containsString(notes, expense.Note) {
+			notes = append(notes, expense.Note)
+		}
+		for _, tag := range expense.Tags {
+			if !containsString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	keep.Tags = tags
+	keep.Note = strings.Join(notes, "\n")
+
+	ok, err := s.duplicateRepo.Merge(keep, merged, &record)
+	if err != nil {
+		return responses.MergeResponse{}, helpers.NewInternalServerError()
+	}
+	if !ok {
+		return responses.MergeResponse{}, helpers.NewConflictError("only one of the merged expenses may be split")
+	}
+
+	mergeResp := responses.MergeResponse{MergedIDs: ids[1:]}
+	copier.Copy(&mergeResp.Expense, &keep)
+
+	return mergeResp, nil
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
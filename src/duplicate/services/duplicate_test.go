@@ -0,0 +1,255 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	claimServices "github.com/wytquant/assessment/src/claim/services/mock"
+	"github.com/wytquant/assessment/src/duplicate/repositories"
+	"github.com/wytquant/assessment/src/duplicate/services"
+)
+
+func TestDetectDuplicatesService(t *testing.T) {
+	date := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("created expense is flagged with expenses of similar titles", func(t *testing.T) {
+		//arrange
+		expense := models.Expense{ID: 5, Title: "Starbucks Siam", Amount: 120, Currency: "THB", Date: date}
+
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+		duplicateRepo.On("FindSimilar", expense, 3).Return([]models.Expense{
+			{ID: 2, Title: "STARBUCKS SIAM"},
+			{ID: 3, Title: "Starbuck Siam"},
+			{ID: 7, Title: "Starbucks Siam Paragon"},
+			{ID: 9, Title: "Grab taxi"},
+		}, nil)
+		duplicateRepo.On("SaveCandidates", mock.Anything).Return(nil)
+
+		duplicateService := services.NewDuplicateService(duplicateRepo)
+
+		//act
+		duplicateService.ExpenseCreated(expense)
+
+		//assert
+		candidates := duplicateRepo.Calls[1].Arguments.Get(0).([]models.DuplicateCandidate)
+		if assert.Len(t, candidates, 3) {
+			assert.Equal(t, models.DuplicateCandidate{ExpenseID: 2, DuplicateID: 5, Score: 1, Status: models.DuplicatePending}, candidates[0])
+			assert.Equal(t, uint(3), candidates[1].ExpenseID)
+			assert.InDelta(t, 0.93, candidates[1].Score, 0.01)
+			assert.Equal(t, models.DuplicateCandidate{ExpenseID: 5, DuplicateID: 7, Score: 0.8, Status: models.DuplicatePending}, candidates[2])
+		}
+	})
+
+	t.Run("group expenses are not checked", func(t *testing.T) {
+		//arrange
+		groupID := uint(1)
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+
+		duplicateService := services.NewDuplicateService(duplicateRepo)
+
+		//act
+		duplicateService.ExpenseCreated(models.Expense{ID: 5, Title: "Starbucks", GroupID: &groupID})
+
+		//assert
+		duplicateRepo.AssertNumberOfCalls(t, "FindSimilar", 0)
+	})
+
+	t.Run("scan flags similar pairs", func(t *testing.T) {
+		//arrange
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+		duplicateRepo.On("FindPairs", 3).Return([]repositories.ExpensePair{
+			{ExpenseID: 1, ExpenseTitle: "ก๋วยเตี๋ยวเรือ", DuplicateID: 4, DuplicateTitle: "ก๋วยเตี๋ยวเรือ!"},
+			{ExpenseID: 2, ExpenseTitle: "rent", DuplicateID: 3, DuplicateTitle: "electricity"},
+		}, nil)
+		duplicateRepo.On("SaveCandidates", []models.DuplicateCandidate{
+			{ExpenseID: 1, DuplicateID: 4, Score: 1, Status: models.DuplicatePending},
+		}).Return(nil)
+
+		duplicateService := services.NewDuplicateService(duplicateRepo)
+
+		//act
+		got, err := duplicateService.Scan()
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got)
+		duplicateRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetDuplicatesService(t *testing.T) {
+	t.Run("get duplicates clusters linked pairs", func(t *testing.T) {
+		//arrange
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+		duplicateRepo.On("GetPendingCandidates").Return([]models.DuplicateCandidate{
+			{ExpenseID: 1, DuplicateID: 2, Score: 0.85},
+			{ExpenseID: 4, DuplicateID: 5, Score: 1},
+			{ExpenseID: 2, DuplicateID: 3, Score: 0.9},
+		}, nil)
+		duplicateRepo.On("GetExpenses", mock.Anything).Return([]models.Expense{
+			{ID: 1, Title: "coffee"}, {ID: 2, Title: "Coffee"}, {ID: 3, Title: "coffee."}, {ID: 4, Title: "taxi"}, {ID: 5, Title: "Taxi"},
+		}, nil)
+
+		duplicateService := services.NewDuplicateService(duplicateRepo)
+
+		//act
+		got, err := duplicateService.GetDuplicates()
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, 1.0, got[0].Score)
+			assert.Len(t, got[0].Expenses, 2)
+			assert.Equal(t, 0.9, got[1].Score)
+			assert.Len(t, got[1].Expenses, 3)
+		}
+		assert.ElementsMatch(t, []uint{1, 2, 3, 4, 5}, duplicateRepo.Calls[1].Arguments.Get(0))
+	})
+}
+
+func TestMergeExpensesService(t *testing.T) {
+	expenses := []models.Expense{
+		{ID: 1, Title: "coffee", Note: "latte", Tags: pq.StringArray{"food"}, Currency: "THB"},
+		{ID: 2, Title: "Coffee", Note: "imported from january.csv line 2", Tags: pq.StringArray{"food", "food/coffee"}, Currency: "THB"},
+		{ID: 3, Title: "coffee", Note: "latte", Currency: "THB"},
+	}
+
+	t.Run("merge expenses combines tags and notes", func(t *testing.T) {
+		//arrange
+		kept := models.Expense{ID: 1, Title: "coffee", Note: "latte\nimported from january.csv line 2", Tags: pq.StringArray{"food", "food/coffee"}, Currency: "THB"}
+		record := &models.ExpenseMerge{ExpenseID: 1, MergedIDs: pq.Int64Array{2, 3}, NoteBefore: "latte", TagsBefore: pq.StringArray{"food"}}
+
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+		duplicateRepo.On("GetExpenses", []uint{1, 2, 3}).Return(expenses, nil)
+		duplicateRepo.On("Merge", kept, expenses[1:], record).Return(true, nil)
+
+		duplicateService := services.NewDuplicateService(duplicateRepo)
+
+		//act
+		got, err := duplicateService.MergeExpenses(requests.MergeExpensesRequest{KeepID: 1, MergeIDs: []uint{2, 3, 2}})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, []uint{2, 3}, got.MergedIDs)
+		assert.Equal(t, kept.Note, got.Expense.Note)
+		assert.Equal(t, kept.Tags, got.Expense.Tags)
+		assert.Equal(t, pq.StringArray{"food"}, expenses[0].Tags)
+	})
+
+	t.Run("merge expenses fail case because more than one expense is split", func(t *testing.T) {
+		//arrange
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+		duplicateRepo.On("GetExpenses", []uint{1, 2}).Return(expenses[:2], nil)
+		duplicateRepo.On("Merge", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+		duplicateService := services.NewDuplicateService(duplicateRepo)
+
+		//act
+		_, err := duplicateService.MergeExpenses(requests.MergeExpensesRequest{KeepID: 1, MergeIDs: []uint{2}})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+		}
+	})
+
+	t.Run("merge expenses fail bad request because the expenses differ", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			merged  models.Expense
+			message string
+		}{
+			{name: "type", merged: models.Expense{ID: 2, Type: models.TypeIncome, Currency: "THB"}, message: "merged expenses must have the same type"},
+			{name: "amount", merged: models.Expense{ID: 2, Amount: 10, Currency: "THB"}, message: "merged expenses must have the same amount"},
+			{name: "currency", merged: models.Expense{ID: 2, Currency: "USD"}, message: "merged expenses must have the same currency"},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				//arrange
+				duplicateRepo := repositories.NewDuplicateRepositoryMock()
+				duplicateRepo.On("GetExpenses", []uint{1, 2}).Return([]models.Expense{expenses[0], tc.merged}, nil)
+
+				duplicateService := services.NewDuplicateService(duplicateRepo)
+
+				//act
+				_, err := duplicateService.MergeExpenses(requests.MergeExpensesRequest{KeepID: 1, MergeIDs: []uint{2}})
+
+				//assert
+				assert.Equal(t, helpers.NewBadRequestError(tc.message), err)
+				duplicateRepo.AssertNumberOfCalls(t, "Merge", 0)
+			})
+		}
+	})
+
+	t.Run("merge expenses fail case because a merged expense is locked", func(t *testing.T) {
+		//arrange
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+		duplicateRepo.On("GetExpenses", []uint{1, 2}).Return(expenses[:2], nil)
+
+		claimService := claimServices.NewClaimServiceMock()
+		claimService.On("ValidateExpense", expenses[0]).Return(nil)
+		claimService.On("ValidateExpense", expenses[1]).Return(helpers.NewConflictError("expense 2 is in claim 1 which is submitted and cannot be changed"))
+
+		duplicateService := services.NewDuplicateService(duplicateRepo, claimService)
+
+		//act
+		_, err := duplicateService.MergeExpenses(requests.MergeExpensesRequest{KeepID: 1, MergeIDs: []uint{2}})
+
+		//assert
+		assert.Equal(t, helpers.NewConflictError("expense 2 is in claim 1 which is submitted and cannot be changed"), err)
+		duplicateRepo.AssertNumberOfCalls(t, "Merge", 0)
+	})
+
+	t.Run("merge expenses fail case because an expense is not found", func(t *testing.T) {
+		//arrange
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+		duplicateRepo.On("GetExpenses", []uint{1, 8}).Return(expenses[:1], nil)
+
+		duplicateService := services.NewDuplicateService(duplicateRepo)
+
+		//act
+		_, err := duplicateService.MergeExpenses(requests.MergeExpensesRequest{KeepID: 1, MergeIDs: []uint{8}})
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+		duplicateRepo.AssertNumberOfCalls(t, "Merge", 0)
+	})
+
+	t.Run("merge expenses fail bad request because the kept expense is merged", func(t *testing.T) {
+		//arrange
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+		duplicateService := services.NewDuplicateService(duplicateRepo)
+
+		//act
+		_, err := duplicateService.MergeExpenses(requests.MergeExpensesRequest{KeepID: 1, MergeIDs: []uint{1}})
+
+		//assert
+		assert.Equal(t, helpers.NewBadRequestError("merge_ids must name other expenses than keep_id"), err)
+	})
+
+	t.Run("merge expenses fail internal server error", func(t *testing.T) {
+		//arrange
+		duplicateRepo := repositories.NewDuplicateRepositoryMock()
+		duplicateRepo.On("GetExpenses", []uint{1, 2}).Return([]models.Expense{}, errors.New("connection refused"))
+
+		duplicateService := services.NewDuplicateService(duplicateRepo)
+
+		//act
+		_, err := duplicateService.MergeExpenses(requests.MergeExpensesRequest{KeepID: 1, MergeIDs: []uint{2}})
+
+		//assert
+		assert.Equal(t, helpers.NewInternalServerError(), err)
+	})
+}
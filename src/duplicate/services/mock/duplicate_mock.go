@@ -0,0 +1,44 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type duplicateServiceMock struct {
+	mock.Mock
+}
+
+func NewDuplicateServiceMock() *duplicateServiceMock {
+	return &duplicateServiceMock{}
+}
+
+func (m *duplicateServiceMock) GetDuplicates() ([]responses.DuplicateCluster, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.DuplicateCluster), args.Error(1)
+}
+
+func (m *duplicateServiceMock) MergeExpenses(mergeReq requests.MergeExpensesRequest) (responses.MergeResponse, error) {
+	args := m.Called(mergeReq)
+	return args.Get(0).(responses.MergeResponse), args.Error(1)
+}
+
+func (m *duplicateServiceMock) DismissDuplicates(dismissReq requests.DismissDuplicatesRequest) error {
+	args := m.Called(dismissReq)
+	return args.Error(0)
+}
+
+func (m *duplicateServiceMock) Scan() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *duplicateServiceMock) ExpenseCreated(expense models.Expense) {
+	m.Called(expense)
+}
+
+func (m *duplicateServiceMock) ExpenseUpdated(expense models.Expense) {
+	m.Called(expense)
+}
@@ -0,0 +1,92 @@
+package exporters
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/wytquant/assessment/models"
+)
+
+// columns of the csv and xlsx exports, the amount is as stored and the type
+// tells whether it is spent or came in.
+var columns = []string{"id", "date", "type", "title", "amount", "currency", "account_id", "to_account_id", "refund_of_id", "note", "tags"}
+
+// typeOf is the type of the expense, expense when it has none.
+func typeOf(expense models.Expense) string {
+	if expense.Type == "" {
+		return models.TypeExpense
+	}
+	return expense.Type
+}
+
+// formatID writes an optional id, empty when there is none.
+func formatID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+type csvExporter struct {
+	buffer  *bufio.Writer
+	writer  *csv.Writer
+	options Options
+	err     error
+}
+
+// newCSVExporter writes a UTF-8 BOM so that Excel shows Thai text, and uses
+// semicolons for locales with a decimal comma as spreadsheets there expect.
+func newCSVExporter(w io.Writer, options Options) Exporter {
+	buffer := bufio.NewWriter(w)
+	writer := csv.NewWriter(buffer)
+	if options.Locale.Decimal == "," {
+		writer.Comma = ';'
+	}
+
+	e := &csvExporter{buffer: buffer, writer: writer, options: options}
+	if _, err := buffer.WriteString("\ufeff"); err != nil {
+		e.err = err
+	} else {
+		e.err = writer.Write(columns)
+	}
+
+	return e
+}
+
+func (e *csvExporter) Write(expense models.Expense) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	e.err = e.writer.Write([]string{
+		strconv.FormatUint(uint64(expense.ID), 10),
+		e.options.Locale.FormatDate(expense.Date),
+		typeOf(expense),
+		expense.Title,
+		e.options.Locale.FormatAmount(expense.Amount),
+		expense.Currency,
+		formatID(expense.AccountID),
+		formatID(expense.ToAccountID),
+		formatID(expense.RefundOfID),
+		expense.Note,
+		strings.Join(expense.Tags, ", "),
+	})
+
+	return e.err
+}
+
+func (e *csvExporter) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	e.writer.Flush()
+	if err := e.writer.Error(); err != nil {
+		return err
+	}
+
+	return e.buffer.Flush()
+}
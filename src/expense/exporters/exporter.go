@@ -0,0 +1,53 @@
+package exporters
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+// Exporter writes expenses one at a time, Close completes the file and must
+// be called even when nothing was written.
+type Exporter interface {
+	Write(expense models.Expense) error
+	Close() error
+}
+
+type Options struct {
+	Locale Locale
+	From   time.Time
+	To     time.Time
+}
+
+type Format struct {
+	ContentType string
+	Extension   string
+	New         func(w io.Writer, options Options) Exporter
+}
+
+var formats = map[string]Format{
+	"csv":   {ContentType: "text/csv; charset=utf-8", Extension: "csv", New: newCSVExporter},
+	"jsonl": {ContentType: "application/x-ndjson", Extension: "jsonl", New: newJSONLExporter},
+	"xlsx":  {ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Extension: "xlsx", New: newXLSXExporter},
+	"ofx":   {ContentType: "application/x-ofx", Extension: "ofx", New: newOFXExporter},
+}
+
+func ByFormat(format string) (Format, bool) {
+	f, ok := formats[format]
+	return f, ok
+}
+
+// Filename names the export after the filtered date range.
+func Filename(format Format, from time.Time, to time.Time) string {
+	name := "expenses"
+	if !from.IsZero() {
+		name += "-from-" + from.Format("20060102")
+	}
+	if !to.IsZero() {
+		name += "-to-" + to.Format("20060102")
+	}
+
+	return fmt.Sprintf("%s.%s", name, format.Extension)
+}
@@ -0,0 +1,166 @@
+//go:build unit
+
+package exporters_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/expense/exporters"
+	"github.com/wytquant/assessment/src/imports/parsers"
+)
+
+var accountID, toAccountID = uint(4), uint(5)
+
+var expenses = []models.Expense{
+	{ID: 1, Title: "coffee & cake", Amount: 1234.5, Note: "Siam", Tags: pq.StringArray{"food", "beverage"}, Date: time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), Currency: "THB"},
+	{ID: 2, Title: "train", Amount: 89.9, Date: time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC), Currency: "EUR"},
+}
+
+var transfer = models.Expense{ID: 3, Type: models.TypeTransfer, Title: "top up", Amount: 500, Date: time.Date(2023, 1, 17, 0, 0, 0, 0, time.UTC), Currency: "THB", AccountID: &accountID, ToAccountID: &toAccountID}
+
+func export(t *testing.T, name string, locale string) []byte {
+	format, ok := exporters.ByFormat(name)
+	assert.True(t, ok)
+	l, ok := exporters.LookupLocale(locale)
+	assert.True(t, ok)
+
+	var buffer bytes.Buffer
+	exporter := format.New(&buffer, exporters.Options{Locale: l})
+	for _, expense := range expenses {
+		assert.NoError(t, exporter.Write(expense))
+	}
+	assert.NoError(t, exporter.Close())
+
+	return buffer.Bytes()
+}
+
+func TestCSVExporter(t *testing.T) {
+	t.Run("use semicolons with a decimal comma", func(t *testing.T) {
+		//act
+		got := string(export(t, "csv", "de-DE"))
+
+		//assert
+		assert.True(t, strings.HasPrefix(got, "\ufeffid;date;type;title;amount;currency;account_id;to_account_id;refund_of_id;note;tags\n"))
+		assert.Contains(t, got, "1;15.01.2023;expense;coffee & cake;1.234,50;THB;;;;Siam;food, beverage\n")
+	})
+
+	t.Run("write plain values without locale", func(t *testing.T) {
+		//act
+		got := string(export(t, "csv", ""))
+
+		//assert
+		assert.Contains(t, got, "2,2023-01-16,expense,train,89.90,EUR,,,,,\n")
+	})
+
+	t.Run("write the type and accounts of a transfer", func(t *testing.T) {
+		//arrange
+		var buffer bytes.Buffer
+		format, _ := exporters.ByFormat("csv")
+		exporter := format.New(&buffer, exporters.Options{})
+
+		//act
+		err := exporter.Write(transfer)
+		exporter.Close()
+
+		//assert
+		assert.NoError(t, err)
+		assert.Contains(t, buffer.String(), "3,2023-01-17,transfer,top up,500.00,THB,4,5,,,\n")
+	})
+}
+
+func TestJSONLExporter(t *testing.T) {
+	//act
+	lines := strings.Split(strings.TrimSpace(string(export(t, "jsonl", "th"))), "\n")
+
+	//assert
+	if assert.Equal(t, 2, len(lines)) {
+		var got responses.ExpenseResponse
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &got))
+		assert.Equal(t, 1234.5, got.Amount)
+		assert.Equal(t, "coffee & cake", got.Title)
+	}
+}
+
+func TestXLSXExporter(t *testing.T) {
+	//arrange
+	data := export(t, "xlsx", "en-GB")
+
+	//act
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+
+	//assert
+	assert.NoError(t, err)
+	parts := map[string]string{}
+	for _, file := range archive.File {
+		r, err := file.Open()
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(r)
+		parts[file.Name] = string(content)
+	}
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts["xl/styles.xml"], `formatCode="dd/mm/yyyy"`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<c s="1"><v>44941</v></c>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<t xml:space="preserve">coffee &amp; cake</t>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<c s="2"><v>1234.5</v></c>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<t xml:space="preserve">expense</t>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<t xml:space="preserve">THB</t></is></c><c/><c/><c/>`)
+	assert.True(t, strings.HasSuffix(parts["xl/worksheets/sheet1.xml"], "</sheetData></worksheet>"))
+}
+
+func TestOFXExporter(t *testing.T) {
+	//arrange
+	data := export(t, "ofx", "")
+	parser, _ := parsers.ByFormat("ofx")
+
+	//act
+	got, err := parser.Parse(data, parsers.Options{})
+
+	//assert
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(got.Transactions)) {
+		assert.Equal(t, -1234.5, got.Transactions[0].Amount)
+		assert.Equal(t, "coffee & cake", got.Transactions[0].Description)
+		assert.Equal(t, "THB", got.Transactions[0].Currency)
+		assert.Equal(t, "EUR", got.Transactions[1].Currency)
+		assert.Equal(t, time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC), got.Transactions[1].Date)
+	}
+}
+
+func TestOFXExporterCredits(t *testing.T) {
+	//arrange
+	var buffer bytes.Buffer
+	format, _ := exporters.ByFormat("ofx")
+	exporter := format.New(&buffer, exporters.Options{})
+
+	//act
+	err := exporter.Write(models.Expense{ID: 3, Type: models.TypeRefund, Title: "returned shoes", Amount: 990, Date: time.Date(2023, 1, 20, 0, 0, 0, 0, time.UTC)})
+	exporter.Close()
+
+	//assert
+	assert.NoError(t, err)
+	assert.Contains(t, buffer.String(), "<TRNTYPE>CREDIT</TRNTYPE>")
+	assert.Contains(t, buffer.String(), "<TRNAMT>990.00</TRNAMT>")
+}
+
+func TestLocale(t *testing.T) {
+	th, _ := exporters.LookupLocale("th_TH")
+	fr, _ := exporters.LookupLocale("fr-FR")
+
+	assert.Equal(t, "-1,234,567.80", th.FormatAmount(-1234567.8))
+	assert.Equal(t, "999.00", th.FormatAmount(999))
+	assert.Equal(t, "1\u202f000,05", fr.FormatAmount(1000.05))
+	assert.Equal(t, "29/02/2567", th.FormatDate(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)))
+
+	_, ok := exporters.LookupLocale("xx")
+	assert.False(t, ok)
+}
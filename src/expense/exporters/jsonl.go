@@ -0,0 +1,34 @@
+package exporters
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/responses"
+)
+
+// jsonlExporter writes one expense per line in the shape of the API
+// responses, it is meant for machines and ignores the locale.
+type jsonlExporter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJSONLExporter(w io.Writer, options Options) Exporter {
+	buffer := bufio.NewWriter(w)
+	return &jsonlExporter{buffer: buffer, encoder: json.NewEncoder(buffer)}
+}
+
+func (e *jsonlExporter) Write(expense models.Expense) error {
+	var expenseResp responses.ExpenseResponse
+	copier.Copy(&expenseResp, &expense)
+
+	return e.encoder.Encode(expenseResp)
+}
+
+func (e *jsonlExporter) Close() error {
+	return e.buffer.Flush()
+}
@@ -0,0 +1,83 @@
+package exporters
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale formats numbers and dates in the human readable exports. The zero
+// Locale writes plain 1234.50 and ISO dates.
+type Locale struct {
+	Decimal   string
+	Thousands string
+	// DateLayout is a Go layout, BuddhistEra shifts its year by 543 as
+	// Thai documents do.
+	DateLayout  string
+	BuddhistEra bool
+}
+
+var locales = map[string]Locale{
+	"en-us": {Decimal: ".", Thousands: ",", DateLayout: "01/02/2006"},
+	"en-gb": {Decimal: ".", Thousands: ",", DateLayout: "02/01/2006"},
+	"th-th": {Decimal: ".", Thousands: ",", DateLayout: "02/01/2006", BuddhistEra: true},
+	"de-de": {Decimal: ",", Thousands: ".", DateLayout: "02.01.2006"},
+	"fr-fr": {Decimal: ",", Thousands: "\u202f", DateLayout: "02/01/2006"},
+}
+
+// languages resolve a bare language such as th to its main locale.
+var languages = map[string]string{"en": "en-us", "th": "th-th", "de": "de-de", "fr": "fr-fr"}
+
+// LookupLocale accepts tags such as th-TH, th_TH or th. An empty tag is the
+// zero Locale.
+func LookupLocale(tag string) (Locale, bool) {
+	if tag == "" {
+		return Locale{}, true
+	}
+
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if locale, ok := locales[tag]; ok {
+		return locale, true
+	}
+	if main, ok := languages[tag]; ok {
+		return locales[main], true
+	}
+
+	return Locale{}, false
+}
+
+func (l Locale) FormatAmount(amount float64) string {
+	formatted := strconv.FormatFloat(amount, 'f', 2, 64)
+	if l.Decimal == "" {
+		return formatted
+	}
+
+	sign := ""
+	if strings.HasPrefix(formatted, "-") {
+		sign, formatted = "-", formatted[1:]
+	}
+	integer, fraction := formatted[:len(formatted)-3], formatted[len(formatted)-2:]
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(l.Thousands)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return sign + grouped.String() + l.Decimal + fraction
+}
+
+func (l Locale) FormatDate(date time.Time) string {
+	if l.DateLayout == "" {
+		return date.Format("2006-01-02")
+	}
+	formatted := date.Format(l.DateLayout)
+	if l.BuddhistEra {
+		year := strconv.Itoa(date.Year())
+		formatted = strings.Replace(formatted, year, strconv.Itoa(date.Year()+543), 1)
+	}
+
+	return formatted
+}
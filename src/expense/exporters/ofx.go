@@ -0,0 +1,107 @@
+package exporters
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX><SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>1</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS><CURDEF>%s</CURDEF><BANKACCTFROM><BANKID>EXPENSES</BANKID><ACCTID>EXPENSES</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>
+<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>
+`
+
+const ofxFooter = `</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`
+
+const ofxCurrency = "THB"
+
+// ofxExporter writes an OFX 2 bank statement in which every expense is a
+// debit, so that the file can be imported by accounting software. Expenses
+// in another currency carry a CURRENCY aggregate, their amount stays in that
+// currency as no exchange rates are kept.
+type ofxExporter struct {
+	buffer *bufio.Writer
+	err    error
+}
+
+func newOFXExporter(w io.Writer, options Options) Exporter {
+	now := time.Now()
+	start, end := options.From, options.To
+	if start.IsZero() {
+		start = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	if end.IsZero() {
+		end = now
+	}
+
+	e := &ofxExporter{buffer: bufio.NewWriter(w)}
+	_, e.err = fmt.Fprintf(e.buffer, ofxHeader, now.UTC().Format("20060102150405"), ofxCurrency, start.Format("20060102"), end.Format("20060102"))
+
+	return e
+}
+
+func (e *ofxExporter) Write(expense models.Expense) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	// money coming in is a credit, everything else leaves the account
+	trnType, amount := "DEBIT", -expense.Amount
+	switch expense.Type {
+	case models.TypeIncome, models.TypeRefund:
+		trnType, amount = "CREDIT", expense.Amount
+	case models.TypeTransfer:
+		trnType = "XFER"
+	}
+
+	var transaction strings.Builder
+	fmt.Fprintf(&transaction, "<STMTTRN><TRNTYPE>%s</TRNTYPE>", trnType)
+	fmt.Fprintf(&transaction, "<DTPOSTED>%s</DTPOSTED>", expense.Date.Format("20060102"))
+	fmt.Fprintf(&transaction, "<TRNAMT>%s</TRNAMT>", strconv.FormatFloat(amount, 'f', 2, 64))
+	fmt.Fprintf(&transaction, "<FITID>%d</FITID>", expense.ID)
+	fmt.Fprintf(&transaction, "<NAME>%s</NAME>", ofxText(expense.Title, 32))
+	if expense.Note != "" {
+		fmt.Fprintf(&transaction, "<MEMO>%s</MEMO>", ofxText(expense.Note, 255))
+	}
+	if expense.Currency != "" && expense.Currency != ofxCurrency {
+		fmt.Fprintf(&transaction, "<CURRENCY><CURRATE>1</CURRATE><CURSYM>%s</CURSYM></CURRENCY>", expense.Currency)
+	}
+	transaction.WriteString("</STMTTRN>\n")
+
+	_, e.err = e.buffer.WriteString(transaction.String())
+
+	return e.err
+}
+
+func (e *ofxExporter) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	if _, err := e.buffer.WriteString(ofxFooter); err != nil {
+		return err
+	}
+
+	return e.buffer.Flush()
+}
+
+// ofxText escapes a value and cuts it to the length OFX allows for the field.
+func ofxText(value string, limit int) string {
+	if runes := []rune(value); len(runes) > limit {
+		value = string(runes[:limit])
+	}
+
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+
+	return escaped.String()
+}
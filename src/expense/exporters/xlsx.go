@@ -0,0 +1,149 @@
+package exporters
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+// xlsxParts are the static parts of a workbook with a single sheet. Style 1
+// formats dates and style 2 amounts, Excel localizes separators itself.
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Expenses" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+}
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><numFmts count="1"><numFmt numFmtId="164" formatCode="%s"/></numFmts><fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs></styleSheet>`
+
+// excelEpoch is day zero of the 1900 date system, dates are stored as the
+// number of days since.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+type xlsxExporter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	row     int
+	err     error
+}
+
+// newXLSXExporter streams the sheet as the last part of the archive, so rows
+// are compressed and written as they come.
+func newXLSXExporter(w io.Writer, options Options) Exporter {
+	e := &xlsxExporter{archive: zip.NewWriter(w)}
+
+	for _, part := range xlsxParts {
+		e.writePart(part.name, part.content)
+	}
+	e.writePart("xl/styles.xml", fmt.Sprintf(xlsxStyles, excelDateFormat(options.Locale)))
+
+	if e.err == nil {
+		var sheet io.Writer
+		sheet, e.err = e.archive.Create("xl/worksheets/sheet1.xml")
+		e.sheet = bufio.NewWriter(sheet)
+	}
+	e.printf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	e.row = 1
+	e.printf(`<row r="1">`)
+	for _, column := range columns {
+		e.writeString(column)
+	}
+	e.printf(`</row>`)
+
+	return e
+}
+
+func (e *xlsxExporter) writePart(name string, content string) {
+	if e.err != nil {
+		return
+	}
+
+	var part io.Writer
+	if part, e.err = e.archive.Create(name); e.err == nil {
+		_, e.err = io.WriteString(part, content)
+	}
+}
+
+func (e *xlsxExporter) printf(format string, a ...interface{}) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.sheet, format, a...)
+	}
+}
+
+func (e *xlsxExporter) writeString(value string) {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+	e.printf(`<c t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, escaped.String())
+}
+
+// writeID writes an optional id as a number, an empty cell when there is none.
+func (e *xlsxExporter) writeID(id *uint) {
+	if id == nil {
+		e.printf(`<c/>`)
+		return
+	}
+	e.printf(`<c><v>%d</v></c>`, *id)
+}
+
+func (e *xlsxExporter) Write(expense models.Expense) error {
+	e.row++
+	e.printf(`<row r="%d">`, e.row)
+	e.printf(`<c><v>%d</v></c>`, expense.ID)
+	e.printf(`<c s="1"><v>%d</v></c>`, excelDate(expense.Date))
+	e.writeString(typeOf(expense))
+	e.writeString(expense.Title)
+	e.printf(`<c s="2"><v>%s</v></c>`, strconv.FormatFloat(expense.Amount, 'f', -1, 64))
+	e.writeString(expense.Currency)
+	for _, id := range []*uint{expense.AccountID, expense.ToAccountID, expense.RefundOfID} {
+		e.writeID(id)
+	}
+	e.writeString(expense.Note)
+	e.writeString(strings.Join(expense.Tags, ", "))
+	e.printf(`</row>`)
+
+	return e.err
+}
+
+func (e *xlsxExporter) Close() error {
+	e.printf(`</sheetData></worksheet>`)
+	if e.err != nil {
+		return e.err
+	}
+	if err := e.sheet.Flush(); err != nil {
+		return err
+	}
+
+	return e.archive.Close()
+}
+
+func excelDate(date time.Time) int {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return int(day.Sub(excelEpoch).Hours() / 24)
+}
+
+// excelDateFormat turns the locale date layout into an Excel number format.
+func excelDateFormat(locale Locale) string {
+	if locale.DateLayout == "" {
+		return "yyyy-mm-dd"
+	}
+
+	return strings.NewReplacer("2006", "yyyy", "01", "mm", "02", "dd").Replace(locale.DateLayout)
+}
@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"mime"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/wytquant/assessment/helpers"
 	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/expense/exporters"
 	"github.com/wytquant/assessment/src/expense/services"
 )
 
@@ -17,6 +19,24 @@ func NewExpenseHandler(expenseService services.ExpenseService) expenseHandler {
 	return expenseHandler{expenseService: expenseService}
 }
 
+// service works on the ledger of the group the request was authorized for,
+// or on the personal ledger outside of a group.
+func (h expenseHandler) service(c *gin.Context) services.ExpenseService {
+	if groupID := c.GetUint(helpers.GroupIDKey); groupID != 0 {
+		return h.expenseService.InGroup(groupID)
+	}
+	return h.expenseService
+}
+
+// errorBody is the message of the error with the codes of the policies a
+// blocked expense violates.
+func errorBody(appErr *helpers.AppError) gin.H {
+	if len(appErr.Codes) > 0 {
+		return gin.H{"message": appErr.Message, "codes": appErr.Codes}
+	}
+	return gin.H{"message": appErr.Message}
+}
+
 func (h expenseHandler) CreateExpense(c *gin.Context) {
 	var expense requests.ExpenseRequest
 	if err := c.ShouldBindJSON(&expense); err != nil {
@@ -24,11 +44,11 @@ func (h expenseHandler) CreateExpense(c *gin.Context) {
 		return
 	}
 
-	expsResponse, err := h.expenseService.CreateExpense(expense)
+	expsResponse, err := h.service(c).CreateExpense(expense)
 	if err != nil {
 		appErr, ok := err.(*helpers.AppError)
 		if ok {
-			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+			c.JSON(appErr.StatusCode, errorBody(appErr))
 		}
 		return
 	}
@@ -37,7 +57,7 @@ func (h expenseHandler) CreateExpense(c *gin.Context) {
 }
 
 func (h expenseHandler) GetExpenseByID(c *gin.Context) {
-	expenseResp, err := h.expenseService.GetExpenseByID(c.Param("id"))
+	expenseResp, err := h.service(c).GetExpenseByID(c.Param("id"))
 	if err != nil {
 		appErr, ok := err.(*helpers.AppError)
 		if ok {
@@ -56,11 +76,11 @@ func (h expenseHandler) UpdateExpenseByID(c *gin.Context) {
 		return
 	}
 
-	expenseResp, err := h.expenseService.UpdateExpenseByID(c.Param("id"), expenseReq)
+	expenseResp, err := h.service(c).UpdateExpenseByID(c.Param("id"), expenseReq)
 	if err != nil {
 		appErr, ok := err.(*helpers.AppError)
 		if ok {
-			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+			c.JSON(appErr.StatusCode, errorBody(appErr))
 		}
 		return
 	}
@@ -69,7 +89,13 @@ func (h expenseHandler) UpdateExpenseByID(c *gin.Context) {
 }
 
 func (h expenseHandler) GetAllExpenses(c *gin.Context) {
-	expenseResp, err := h.expenseService.GetExpenses()
+	var query requests.ExpenseQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	expenseResp, err := h.service(c).GetExpenses(query)
 	if err != nil {
 		appErr, ok := err.(*helpers.AppError)
 		if ok {
@@ -80,3 +106,117 @@ func (h expenseHandler) GetAllExpenses(c *gin.Context) {
 
 	c.JSON(http.StatusOK, expenseResp)
 }
+
+func (h expenseHandler) GetSummary(c *gin.Context) {
+	var query requests.SummaryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	summaryResp, err := h.service(c).GetSummary(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, summaryResp)
+}
+
+func (h expenseHandler) GetCashFlow(c *gin.Context) {
+	var query requests.CashFlowQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	cashFlowResp, err := h.service(c).GetCashFlow(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, cashFlowResp)
+}
+
+func (h expenseHandler) SearchExpenses(c *gin.Context) {
+	var query requests.SearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	resultsResp, err := h.service(c).SearchExpenses(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resultsResp)
+}
+
+func (h expenseHandler) ExportExpenses(c *gin.Context) {
+	var query requests.ExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	format, _ := exporters.ByFormat(query.Format)
+	w := &attachmentWriter{c: c, contentType: format.ContentType, filename: exporters.Filename(format, query.From, query.To)}
+
+	if err := h.service(c).ExportExpenses(query, w); err != nil {
+		if w.started {
+			// the status is already sent, only the log can tell the file is cut short
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	if !w.started {
+		w.start()
+	}
+}
+
+// attachmentWriter sends the download headers with the first bytes, so that
+// errors found before anything is written can still be answered with JSON.
+type attachmentWriter struct {
+	c           *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *attachmentWriter) start() {
+	w.started = true
+	w.c.Header("Content-Type", w.contentType)
+	w.c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": w.filename}))
+	w.c.Status(http.StatusOK)
+	w.c.Writer.WriteHeaderNow()
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if !w.started {
+		w.start()
+	}
+
+	return w.c.Writer.Write(p)
+}
@@ -52,13 +52,15 @@ func TestIntegrationTestServer(t *testing.T) {
 		}
 
 		repo := repositories.NewExpenseRepositoryDB(db)
-		service := services.NewExpenseService(repo)
+		service := services.NewExpenseService(repo, nil)
 		handler := handlers.NewExpenseHandler(service)
 
 		r.GET("/expenses/:id", handler.GetExpenseByID)
 		r.GET("/expenses", handler.GetAllExpenses)
 		r.POST("/expenses", handler.CreateExpense)
 		r.PUT("/expenses/:id", handler.UpdateExpenseByID)
+		r.GET("/expenses/summary", handler.GetSummary)
+		r.GET("/expenses/export", handler.ExportExpenses)
 
 		r.Run(fmt.Sprintf(":%d", serverPort))
 
@@ -107,11 +109,14 @@ func TestIntegrationTestServer(t *testing.T) {
 
 		//assertion
 		want := responses.ExpenseResponse{
-			ID:     1,
-			Title:  "strawberry smoothie",
-			Amount: 79,
-			Note:   "night market promotion discount 10 bath",
-			Tags:   pq.StringArray{"food", "beverage"},
+			ID:       1,
+			Type:     "expense",
+			Title:    "strawberry smoothie",
+			Amount:   79,
+			Note:     "night market promotion discount 10 bath",
+			Tags:     pq.StringArray{"food", "beverage"},
+			Date:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			Currency: "THB",
 		}
 
 		if assert.NoError(t, err) {
@@ -128,7 +133,8 @@ func TestIntegrationTestServer(t *testing.T) {
 			"title": "strawberry smoothie",
 			"amount": 79,
 			"note": "night market promotion discount 10 bath",
-			"tags": ["food", "beverage"]
+			"tags": ["food", "beverage"],
+			"date": "2023-01-15T00:00:00Z"
 		}`)
 
 		//act
@@ -142,11 +148,14 @@ func TestIntegrationTestServer(t *testing.T) {
 
 		//assertion
 		want := responses.ExpenseResponse{
-			ID:     2,
-			Title:  "strawberry smoothie",
-			Amount: 79,
-			Note:   "night market promotion discount 10 bath",
-			Tags:   pq.StringArray{"food", "beverage"},
+			ID:       2,
+			Type:     "expense",
+			Title:    "strawberry smoothie",
+			Amount:   79,
+			Note:     "night market promotion discount 10 bath",
+			Tags:     pq.StringArray{"food", "beverage"},
+			Date:     time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC),
+			Currency: "THB",
 		}
 
 		if assert.NoError(t, err) {
@@ -178,11 +187,14 @@ func TestIntegrationTestServer(t *testing.T) {
 
 		//assertion
 		want := responses.ExpenseResponse{
-			ID:     1,
-			Title:  "strawberry smoothie",
-			Amount: 100,
-			Note:   "night market promotion discount 10 bath",
-			Tags:   pq.StringArray{"food"},
+			ID:       1,
+			Type:     "expense",
+			Title:    "strawberry smoothie",
+			Amount:   100,
+			Note:     "night market promotion discount 10 bath",
+			Tags:     pq.StringArray{"food"},
+			Date:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			Currency: "THB",
 		}
 
 		if assert.NoError(t, err) {
@@ -192,4 +204,38 @@ func TestIntegrationTestServer(t *testing.T) {
 			}
 		}
 	})
+	t.Run("get summary grouped by month", func(t *testing.T) {
+		//act
+		resp, err := createAndSendReq(http.MethodGet, fmt.Sprintf("http://localhost:%d/expenses/summary?group_by=month&from=2023-01-01&to=2023-01-31", serverPort), nil)
+		assert.NoError(t, err)
+
+		var got responses.SummaryResponse
+		err = json.NewDecoder(resp.Body).Decode(&got)
+		assert.NoError(t, err)
+		resp.Body.Close()
+
+		//assertion
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, int64(2), got.Overall.Count)
+			if assert.Equal(t, 1, len(got.Groups)) {
+				assert.Equal(t, "2023-01-01", got.Groups[0].Period)
+			}
+		}
+	})
+	t.Run("export expenses as csv", func(t *testing.T) {
+		//act
+		resp, err := createAndSendReq(http.MethodGet, fmt.Sprintf("http://localhost:%d/expenses/export?format=csv&tags=food&to=2023-01-31", serverPort), nil)
+		assert.NoError(t, err)
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		//assertion
+		if assert.NoError(t, err) {
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "attachment; filename=expenses-to-20230131.csv", resp.Header.Get("Content-Disposition"))
+			assert.Contains(t, string(body), "1,2023-01-01,strawberry smoothie,100.00,THB")
+		}
+	})
 }
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
@@ -111,6 +112,34 @@ func TestCreateExpenseHandler(t *testing.T) {
 		//assert
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
+
+	t.Run("create expense fail unprocessable entity because policies block it", func(t *testing.T) {
+		//arrange
+		expenseService := services.NewExpenseServiceMock()
+		expenseService.On("CreateExpense").Return(responses.ExpenseResponse{}, helpers.NewPolicyViolationError([]string{"MEALS", "NO-BARS"}))
+
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.POST("/expenses", expenseHandler.CreateExpense)
+
+		payload := strings.NewReader(`{
+			"title": "firerice",
+			"amount": 1500,
+			"note": "new dish",
+			"tags": ["food"]
+		}`)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/expenses", payload)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.JSONEq(t, `{"message": "the expense violates the policies MEALS, NO-BARS", "codes": ["MEALS", "NO-BARS"]}`, w.Body.String())
+	})
 }
 
 func TestGetExpenseByIDHandler(t *testing.T) {
@@ -285,7 +314,7 @@ func TestGetAllExpensesHandler(t *testing.T) {
 	t.Run("get all expenses success case", func(t *testing.T) {
 		//arrange
 		expenseService := services.NewExpenseServiceMock()
-		expenseService.On("GetExpenses").Return([]responses.ExpenseResponse{
+		expenseService.On("GetExpenses", requests.ExpenseQuery{}).Return([]responses.ExpenseResponse{
 			{
 				ID:     1,
 				Title:  "strawberry smoothie",
@@ -322,7 +351,7 @@ func TestGetAllExpensesHandler(t *testing.T) {
 	t.Run("get all expenses fail case because internal server error", func(t *testing.T) {
 		//arrange
 		expenseService := services.NewExpenseServiceMock()
-		expenseService.On("GetExpenses").Return([]responses.ExpenseResponse{}, helpers.NewInternalServerError())
+		expenseService.On("GetExpenses", requests.ExpenseQuery{}).Return([]responses.ExpenseResponse{}, helpers.NewInternalServerError())
 		expenseHandler := handlers.NewExpenseHandler(expenseService)
 
 		r := gin.Default()
@@ -340,4 +369,282 @@ func TestGetAllExpensesHandler(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		assert.Equal(t, 0, len(got))
 	})
+
+	t.Run("get all expenses of a group ledger", func(t *testing.T) {
+		//arrange
+		expenseService := services.NewExpenseServiceMock()
+		expenseService.On("InGroup", uint(7)).Return()
+		expenseService.On("GetExpenses", requests.ExpenseQuery{}).Return([]responses.ExpenseResponse{}, nil)
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.GET("/groups/:gid/expenses", func(c *gin.Context) {
+			c.Set(helpers.GroupIDKey, uint(7))
+		}, expenseHandler.GetAllExpenses)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/groups/7/expenses", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		expenseService.AssertExpectations(t)
+	})
+}
+
+func TestGetSummaryHandler(t *testing.T) {
+	t.Run("get summary success case", func(t *testing.T) {
+		//arrange
+		query := requests.SummaryQuery{
+			GroupBy: "month",
+			ByTag:   true,
+			From:    time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local),
+			Tags:    []string{"food"},
+		}
+		want := responses.SummaryResponse{
+			GroupBy: "month",
+			ByTag:   true,
+			Overall: responses.SummaryStats{Total: 79, Count: 1, Average: 79, Min: 79, Max: 79},
+			Groups: []responses.SummaryGroup{
+				{Period: "2023-01-01", Tag: "food", SummaryStats: responses.SummaryStats{Total: 79, Count: 1, Average: 79, Min: 79, Max: 79}},
+			},
+		}
+
+		expenseService := services.NewExpenseServiceMock()
+		expenseService.On("GetSummary", query).Return(want, nil)
+
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.GET("/expenses/summary", expenseHandler.GetSummary)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/summary?group_by=month&by_tag=true&from=2023-01-01&tags=food", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+		var got responses.SummaryResponse
+		json.NewDecoder(w.Body).Decode(&got)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		if !assert.ObjectsAreEqual(want, got) {
+			t.Errorf("not equal. want: %#v, got: %#v", want, got)
+		}
+	})
+
+	t.Run("get summary fail bad request because date is malformed", func(t *testing.T) {
+		//arrange
+		expenseService := services.NewExpenseServiceMock()
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.GET("/expenses/summary", expenseHandler.GetSummary)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/summary?from=01-2023", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get summary fail case because group_by is invalid", func(t *testing.T) {
+		//arrange
+		expenseService := services.NewExpenseServiceMock()
+		expenseService.On("GetSummary", requests.SummaryQuery{GroupBy: "hour"}).
+			Return(responses.SummaryResponse{}, helpers.NewBadRequestError("group_by must be one of day, week, month or year"))
+
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.GET("/expenses/summary", expenseHandler.GetSummary)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/summary?group_by=hour", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetCashFlowHandler(t *testing.T) {
+	t.Run("get cash flow success case", func(t *testing.T) {
+		//arrange
+		rate := 0.25
+		want := responses.CashFlowResponse{
+			GroupBy: "month",
+			Periods: []responses.CashFlow{{Period: "2023-01-01", Currency: "THB", Income: 40000, Expenses: 30000, Net: 10000, SavingsRate: &rate}},
+			Totals:  []responses.CashFlow{{Currency: "THB", Income: 40000, Expenses: 30000, Net: 10000, SavingsRate: &rate}},
+		}
+
+		expenseService := services.NewExpenseServiceMock()
+		expenseService.On("GetCashFlow", requests.CashFlowQuery{GroupBy: "month", From: time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)}).Return(want, nil)
+
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.GET("/expenses/cash-flow", expenseHandler.GetCashFlow)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/cash-flow?group_by=month&from=2023-01-01", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+		var got responses.CashFlowResponse
+		json.NewDecoder(w.Body).Decode(&got)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("get cash flow fail bad request because group_by is invalid", func(t *testing.T) {
+		//arrange
+		expenseHandler := handlers.NewExpenseHandler(services.NewExpenseServiceMock())
+
+		r := gin.Default()
+		r.GET("/expenses/cash-flow", expenseHandler.GetCashFlow)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/cash-flow?group_by=day", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSearchExpensesHandler(t *testing.T) {
+	t.Run("search expenses success case", func(t *testing.T) {
+		//arrange
+		expenseService := services.NewExpenseServiceMock()
+		expenseService.On("SearchExpenses", requests.SearchQuery{Q: "smoothie", Limit: 5}).Return([]responses.SearchResultResponse{
+			{
+				ExpenseResponse: responses.ExpenseResponse{ID: 1, Title: "strawberry smoothie"},
+				Rank:            0.6,
+				Highlight:       responses.SearchHighlight{Title: "strawberry <mark>smoothie</mark>"},
+			},
+		}, nil)
+
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.GET("/expenses/search", expenseHandler.SearchExpenses)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/search?q=smoothie&limit=5", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+		var got []responses.SearchResultResponse
+		json.NewDecoder(w.Body).Decode(&got)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, "strawberry <mark>smoothie</mark>", got[0].Highlight.Title)
+		}
+	})
+
+	t.Run("search expenses fail bad request because q is missing", func(t *testing.T) {
+		//arrange
+		expenseHandler := handlers.NewExpenseHandler(services.NewExpenseServiceMock())
+
+		r := gin.Default()
+		r.GET("/expenses/search", expenseHandler.SearchExpenses)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/search", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestExportExpensesHandler(t *testing.T) {
+	t.Run("export expenses success case", func(t *testing.T) {
+		//arrange
+		query := requests.ExportQuery{
+			ExpenseQuery: requests.ExpenseQuery{
+				From: time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local),
+				To:   time.Date(2023, 1, 31, 0, 0, 0, 0, time.Local),
+			},
+			Format: "csv",
+			Locale: "th-TH",
+		}
+
+		expenseService := services.NewExpenseServiceMock()
+		expenseService.On("ExportExpenses", query).Return("id,date,title\n", nil)
+
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.GET("/expenses/export", expenseHandler.ExportExpenses)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/export?format=csv&locale=th-TH&from=2023-01-01&to=2023-01-31", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=expenses-from-20230101-to-20230131.csv`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id,date,title\n", w.Body.String())
+	})
+
+	t.Run("export expenses fail bad request because format is unknown", func(t *testing.T) {
+		//arrange
+		expenseService := services.NewExpenseServiceMock()
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.GET("/expenses/export", expenseHandler.ExportExpenses)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/export?format=pdf", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("export expenses fail case answers json before anything is written", func(t *testing.T) {
+		//arrange
+		expenseService := services.NewExpenseServiceMock()
+		expenseService.On("ExportExpenses", requests.ExportQuery{Format: "xlsx", Locale: "xx"}).
+			Return("", helpers.NewBadRequestError(`locale "xx" is not supported`))
+
+		expenseHandler := handlers.NewExpenseHandler(expenseService)
+
+		r := gin.Default()
+		r.GET("/expenses/export", expenseHandler.ExportExpenses)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/export?format=xlsx&locale=xx", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	})
 }
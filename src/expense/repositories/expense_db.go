@@ -1,31 +1,78 @@
 package repositories
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/wytquant/assessment/helpers"
 	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/src/webhook/outbox"
 	"gorm.io/gorm"
 )
 
 type expenseRepositoryDB struct {
-	db *gorm.DB
+	db      *gorm.DB
+	groupID uint
 }
 
 func NewExpenseRepositoryDB(db *gorm.DB) ExpenseRepository {
 	return expenseRepositoryDB{db: db}
 }
 
+func (r expenseRepositoryDB) InGroup(groupID uint) ExpenseRepository {
+	return expenseRepositoryDB{db: r.db, groupID: groupID}
+}
+
+// ledger limits a query to the expenses of the repository's ledger.
+func (r expenseRepositoryDB) ledger(query *gorm.DB) *gorm.DB {
+	if r.groupID == 0 {
+		return query.Where("expenses.group_id IS NULL")
+	}
+	return query.Where("expenses.group_id = ?", r.groupID)
+}
+
+// prepare sets the ledger, type and search text of a new expense.
+func (r expenseRepositoryDB) prepare(expense *models.Expense) {
+	expense.SearchText = helpers.SearchText(expense.Title, expense.Note)
+	if expense.Type == "" {
+		expense.Type = models.TypeExpense
+	}
+	expense.GroupID = nil
+	if r.groupID != 0 {
+		groupID := r.groupID
+		expense.GroupID = &groupID
+	}
+}
+
 func (r expenseRepositoryDB) Create(expense *models.Expense) error {
-	query := r.db
-	if err := query.Create(expense).Error; err != nil {
-		return err
+	r.prepare(expense)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(expense).Error; err != nil {
+			return err
+		}
+
+		return outbox.Record(tx, models.EventExpenseCreated, *expense)
+	})
+}
+
+func (r expenseRepositoryDB) CreateMany(expenses []models.Expense) error {
+	for i := range expenses {
+		r.prepare(&expenses[i])
 	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.CreateInBatches(expenses, 100).Error; err != nil {
+			return err
+		}
 
-	return nil
+		return outbox.Record(tx, models.EventExpenseCreated, expenses...)
+	})
 }
 
 func (r expenseRepositoryDB) GetByID(id string) (models.Expense, error) {
 	var expense models.Expense
-	query := r.db
-	if err := query.Where("id = $1", id).First(&expense).Error; err != nil {
+	query := r.ledger(r.db)
+	if err := query.Where("expenses.id = ?", id).First(&expense).Error; err != nil {
 		return models.Expense{}, err
 	}
 
@@ -39,15 +86,30 @@ func (r expenseRepositoryDB) UpdateByID(id string, expense models.Expense) (mode
 		return models.Expense{}, err
 	}
 
-	if err := query.Model(&expenseDB).Updates(expense).Error; err != nil {
+	err = query.Transaction(func(tx *gorm.DB) error {
+		// an update never moves an expense to another ledger
+		if err := tx.Model(&expenseDB).Omit("group_id", "search_text").Updates(expense).Error; err != nil {
+			return err
+		}
+
+		searchText := helpers.SearchText(expenseDB.Title, expenseDB.Note)
+		if searchText != expenseDB.SearchText {
+			if err := tx.Model(&expenseDB).UpdateColumn("search_text", searchText).Error; err != nil {
+				return err
+			}
+		}
+
+		return outbox.Record(tx, models.EventExpenseUpdated, expenseDB)
+	})
+	if err != nil {
 		return models.Expense{}, err
 	}
 
 	return expenseDB, nil
 }
 
-func (r expenseRepositoryDB) GetAll() ([]models.Expense, error) {
-	query := r.db
+func (r expenseRepositoryDB) GetAll(filter ExpenseFilter) ([]models.Expense, error) {
+	query := applyExpenseFilter(r.ledger(r.db), filter)
 	var expenses []models.Expense
 
 	if err := query.Find(&expenses).Error; err != nil {
@@ -56,3 +118,177 @@ func (r expenseRepositoryDB) GetAll() ([]models.Expense, error) {
 
 	return expenses, nil
 }
+
+// FindInBatches walks the filtered expenses in id order without loading them
+// all, fn returning an error stops the walk.
+func (r expenseRepositoryDB) FindInBatches(filter ExpenseFilter, batchSize int, fn func(expenses []models.Expense) error) error {
+	var expenses []models.Expense
+	query := applyExpenseFilter(r.ledger(r.db), filter)
+
+	return query.FindInBatches(&expenses, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(expenses)
+	}).Error
+}
+
+func (r expenseRepositoryDB) Search(filter SearchFilter) ([]SearchRow, error) {
+	var rows []SearchRow
+	query := applyExpenseFilter(r.ledger(r.db.Model(&models.Expense{})), filter.ExpenseFilter)
+
+	err := query.Select("expenses.*, ts_rank(expenses.search_vector, to_tsquery('simple', ?)) AS rank", filter.Query).
+		Where("expenses.search_vector @@ to_tsquery('simple', ?)", filter.Query).
+		Order("rank DESC, expenses.date DESC, expenses.id DESC").
+		Limit(filter.Limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r expenseRepositoryDB) IndexSearchText(batchSize int) (int, error) {
+	var indexed int
+	for {
+		var expenses []models.Expense
+		if err := r.db.Select("id", "title", "note").Where("search_text IS NULL").Limit(batchSize).Find(&expenses).Error; err != nil {
+			return indexed, err
+		}
+
+		for _, expense := range expenses {
+			err := r.db.Model(&expense).UpdateColumn("search_text", helpers.SearchText(expense.Title, expense.Note)).Error
+			if err != nil {
+				return indexed, err
+			}
+		}
+		indexed += len(expenses)
+
+		if len(expenses) < batchSize {
+			return indexed, nil
+		}
+	}
+}
+
+func (r expenseRepositoryDB) Summarize(filter SummaryFilter) ([]SummaryRow, error) {
+	var rows []SummaryRow
+
+	columns := []string{
+		"COALESCE(SUM(" + models.SpentAmount + "), 0) AS total",
+		"COUNT(*) AS count",
+		"COALESCE(AVG(" + models.SpentAmount + "), 0) AS average",
+		"COALESCE(MIN(" + models.SpentAmount + "), 0) AS min",
+		"COALESCE(MAX(" + models.SpentAmount + "), 0) AS max",
+	}
+	var groups []string
+
+	query := r.ledger(r.db.Model(&models.Expense{})).Where(models.Spending)
+
+	if filter.GroupBy != "" {
+		// GroupBy is validated by the service against a fixed set of units
+		columns = append(columns, fmt.Sprintf("date_trunc('%s', expenses.date)::date AS period", filter.GroupBy))
+		groups = append(groups, "period")
+	}
+
+	if filter.ByAccount {
+		columns = append(columns, "expenses.account_id")
+		groups = append(groups, "account_id")
+	}
+
+	if filter.ByTag {
+		if filter.Rollup {
+			query = query.Joins("CROSS JOIN LATERAL (" + ancestorTags + ") AS rolled_up")
+		} else {
+			query = query.Joins("CROSS JOIN LATERAL unnest(expenses.tags) AS tag")
+		}
+		columns = append(columns, "tag")
+		groups = append(groups, "tag")
+		if len(filter.Tags) > 0 {
+			query = query.Where("tag IN ?", filter.Tags)
+		}
+	} else if len(filter.Tags) > 0 && filter.Rollup {
+		query = query.Where(`EXISTS (SELECT 1 FROM unnest(expenses.tags) AS t(name), unnest(?::text[]) AS f(name)
+			WHERE t.name = f.name OR left(t.name, length(f.name) + 1) = f.name || '/')`, pq.StringArray(filter.Tags))
+	} else if len(filter.Tags) > 0 {
+		query = query.Where("expenses.tags && ?", pq.StringArray(filter.Tags))
+	}
+
+	query = applyDateRange(applyAccount(query, filter.ExpenseFilter), filter.ExpenseFilter).Select(strings.Join(columns, ", "))
+
+	for _, group := range groups {
+		query = query.Group(group).Order(group)
+	}
+
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r expenseRepositoryDB) CashFlow(filter CashFlowFilter) ([]CashFlowRow, error) {
+	var rows []CashFlowRow
+
+	// GroupBy is validated by the request against a fixed set of units
+	query := applyExpenseFilter(r.ledger(r.db.Model(&models.Expense{})), filter.ExpenseFilter).
+		Select(fmt.Sprintf(`date_trunc('%s', expenses.date)::date AS period, expenses.currency,
+			COALESCE(SUM(expenses.amount) FILTER (WHERE expenses.type = 'income'), 0) AS income,
+			COALESCE(SUM(%s) FILTER (WHERE %s), 0) AS spent`, filter.GroupBy, models.SpentAmount, models.Spending)).
+		Where("expenses.type <> ?", models.TypeTransfer).
+		Group("period, expenses.currency").
+		Order("period, expenses.currency")
+
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r expenseRepositoryDB) GetRefunded(expenseID uint, exceptID uint) (float64, error) {
+	var refunded float64
+
+	err := r.ledger(r.db.Model(&models.Expense{})).
+		Where("expenses.type = ? AND expenses.refund_of_id = ? AND expenses.id <> ?", models.TypeRefund, expenseID, exceptID).
+		Select("COALESCE(SUM(expenses.amount), 0)").
+		Scan(&refunded).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return refunded, nil
+}
+
+// ancestorTags lists every tag of an expense with its ancestors once, e.g.
+// "food/coffee" gives "food" and "food/coffee".
+const ancestorTags = `SELECT DISTINCT array_to_string((string_to_array(t.name, '/'))[1:n.n], '/') AS tag
+	FROM unnest(expenses.tags) AS t(name)
+	CROSS JOIN LATERAL generate_series(1, array_length(string_to_array(t.name, '/'), 1)) AS n(n)`
+
+func applyExpenseFilter(query *gorm.DB, filter ExpenseFilter) *gorm.DB {
+	if len(filter.Tags) > 0 {
+		query = query.Where("expenses.tags && ?", pq.StringArray(filter.Tags))
+	}
+	if filter.Type != "" {
+		query = query.Where("expenses.type = ?", filter.Type)
+	}
+
+	return applyDateRange(applyAccount(query, filter), filter)
+}
+
+func applyAccount(query *gorm.DB, filter ExpenseFilter) *gorm.DB {
+	if filter.AccountID != 0 {
+		query = query.Where("(expenses.account_id = ? OR expenses.to_account_id = ?)", filter.AccountID, filter.AccountID)
+	}
+
+	return query
+}
+
+func applyDateRange(query *gorm.DB, filter ExpenseFilter) *gorm.DB {
+	if !filter.From.IsZero() {
+		query = query.Where("expenses.date >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("expenses.date <= ?", filter.To)
+	}
+
+	return query
+}
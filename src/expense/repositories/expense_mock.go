@@ -18,6 +18,11 @@ func (m *expenseRepositoryMock) Create(expense *models.Expense) error {
 	return args.Error(0)
 }
 
+func (m *expenseRepositoryMock) CreateMany(expenses []models.Expense) error {
+	args := m.Called(expenses)
+	return args.Error(0)
+}
+
 func (m *expenseRepositoryMock) GetByID(id string) (models.Expense, error) {
 	args := m.Called(id)
 	return args.Get(0).(models.Expense), args.Error(1)
@@ -28,7 +33,49 @@ func (m *expenseRepositoryMock) UpdateByID(id string, expense models.Expense) (m
 	return args.Get(0).(models.Expense), args.Error(1)
 }
 
-func (m *expenseRepositoryMock) GetAll() ([]models.Expense, error) {
-	args := m.Called()
+func (m *expenseRepositoryMock) GetAll(filter ExpenseFilter) ([]models.Expense, error) {
+	args := m.Called(filter)
 	return args.Get(0).([]models.Expense), args.Error(1)
 }
+
+// FindInBatches hands the batches given to On in order, then returns the error.
+func (m *expenseRepositoryMock) FindInBatches(filter ExpenseFilter, batchSize int, fn func(expenses []models.Expense) error) error {
+	args := m.Called(filter, batchSize)
+	for _, batch := range args.Get(0).([][]models.Expense) {
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *expenseRepositoryMock) Summarize(filter SummaryFilter) ([]SummaryRow, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]SummaryRow), args.Error(1)
+}
+
+func (m *expenseRepositoryMock) CashFlow(filter CashFlowFilter) ([]CashFlowRow, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]CashFlowRow), args.Error(1)
+}
+
+func (m *expenseRepositoryMock) GetRefunded(expenseID uint, exceptID uint) (float64, error) {
+	args := m.Called(expenseID, exceptID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *expenseRepositoryMock) Search(filter SearchFilter) ([]SearchRow, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]SearchRow), args.Error(1)
+}
+
+func (m *expenseRepositoryMock) IndexSearchText(batchSize int) (int, error) {
+	args := m.Called(batchSize)
+	return args.Int(0), args.Error(1)
+}
+
+// InGroup records the group and returns the same mock for the group ledger.
+func (m *expenseRepositoryMock) InGroup(groupID uint) ExpenseRepository {
+	m.Called(groupID)
+	return m
+}
@@ -1,10 +1,87 @@
 package repositories
 
-import "github.com/wytquant/assessment/models"
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+type ExpenseFilter struct {
+	From time.Time
+	To   time.Time
+	Tags []string
+	// AccountID limits the expenses to an account when it is not 0,
+	// including the transfers into it.
+	AccountID uint
+	Type      string
+}
+
+type SummaryFilter struct {
+	ExpenseFilter
+	GroupBy string
+	ByTag   bool
+	// ByAccount breaks the summary down by account.
+	ByAccount bool
+	// Rollup counts expenses under every ancestor of their tags, so that
+	// "food" includes "food/coffee".
+	Rollup bool
+}
+
+type CashFlowFilter struct {
+	ExpenseFilter
+	GroupBy string
+}
+
+type CashFlowRow struct {
+	Period   time.Time
+	Currency string
+	Income   float64
+	Spent    float64
+}
+
+type SearchFilter struct {
+	ExpenseFilter
+	// Query is a tsquery built by helpers.SearchQuery.
+	Query string
+	Limit int
+}
+
+type SearchRow struct {
+	models.Expense
+	Rank float64
+}
+
+type SummaryRow struct {
+	Period    *time.Time
+	Tag       string
+	AccountID *uint
+	Total     float64
+	Count     int64
+	Average   float64
+	Min       float64
+	Max       float64
+}
 
 type ExpenseRepository interface {
 	Create(*models.Expense) error
+	CreateMany(expenses []models.Expense) error
 	GetByID(id string) (models.Expense, error)
 	UpdateByID(id string, expense models.Expense) (models.Expense, error)
-	GetAll() ([]models.Expense, error)
+	GetAll(filter ExpenseFilter) ([]models.Expense, error)
+	FindInBatches(filter ExpenseFilter, batchSize int, fn func(expenses []models.Expense) error) error
+	// Summarize sums the spending, expenses less refunds.
+	Summarize(filter SummaryFilter) ([]SummaryRow, error)
+	// CashFlow sums income and spending per period and currency.
+	CashFlow(filter CashFlowFilter) ([]CashFlowRow, error)
+	// GetRefunded sums the refunds of an expense other than the one with
+	// exceptID.
+	GetRefunded(expenseID uint, exceptID uint) (float64, error)
+	// Search returns the expenses matching the query, best ranked first.
+	Search(filter SearchFilter) ([]SearchRow, error)
+	// IndexSearchText fills the search text of expenses stored before it
+	// existed, in every ledger, and returns how many it filled.
+	IndexSearchText(batchSize int) (int, error)
+	// InGroup returns the repository of a group's ledger, every query of the
+	// default repository is limited to the personal ledger.
+	InGroup(groupID uint) ExpenseRepository
 }
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"io"
+
+	"github.com/wytquant/assessment/models"
 	"github.com/wytquant/assessment/requests"
 	"github.com/wytquant/assessment/responses"
 )
@@ -9,5 +12,45 @@ type ExpenseService interface {
 	CreateExpense(requests.ExpenseRequest) (responses.ExpenseResponse, error)
 	GetExpenseByID(id string) (responses.ExpenseResponse, error)
 	UpdateExpenseByID(id string, expensReq requests.ExpenseRequest) (responses.ExpenseResponse, error)
-	GetExpenses() ([]responses.ExpenseResponse, error)
+	GetExpenses(query requests.ExpenseQuery) ([]responses.ExpenseResponse, error)
+	ExportExpenses(query requests.ExportQuery, w io.Writer) error
+	GetSummary(query requests.SummaryQuery) (responses.SummaryResponse, error)
+	GetCashFlow(query requests.CashFlowQuery) (responses.CashFlowResponse, error)
+	SearchExpenses(query requests.SearchQuery) ([]responses.SearchResultResponse, error)
+	// PrepareExpense runs the processors and policies on a new expense the
+	// way CreateExpense does, for expenses other services store themselves,
+	// and returns the warnings of the policies.
+	PrepareExpense(expense *models.Expense) ([]responses.PolicyViolation, error)
+	// NotifyCreated tells the observers about expenses other services
+	// stored after PrepareExpense.
+	NotifyCreated(expenses ...models.Expense)
+	// InGroup returns the service working on a group's ledger instead of
+	// the personal one.
+	InGroup(groupID uint) ExpenseService
+}
+
+// ExpenseProcessor may change a new expense before it is stored, an error
+// stops the expense from being created.
+type ExpenseProcessor interface {
+	ProcessExpense(expense *models.Expense) error
+}
+
+// ExpenseValidator is implemented by processors that also check changed
+// expenses, an error stops the change. The expense is the stored one with the
+// change applied.
+type ExpenseValidator interface {
+	ValidateExpense(expense models.Expense) error
+}
+
+// ExpensePolicy is implemented by processors that check new and changed
+// expenses once the processors and validators are done. An error stops the
+// expense, the violations returned only warn and are added to the response.
+type ExpensePolicy interface {
+	CheckExpense(expense models.Expense) ([]responses.PolicyViolation, error)
+}
+
+// ExpenseObserver is notified after an expense has been stored.
+type ExpenseObserver interface {
+	ExpenseCreated(expense models.Expense)
+	ExpenseUpdated(expense models.Expense)
 }
@@ -1,20 +1,38 @@
 package services
 
 import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
 	"github.com/jinzhu/copier"
 	"github.com/wytquant/assessment/helpers"
 	"github.com/wytquant/assessment/models"
 	"github.com/wytquant/assessment/requests"
 	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/expense/exporters"
 	"github.com/wytquant/assessment/src/expense/repositories"
 )
 
 type expenseService struct {
 	expenseRepo repositories.ExpenseRepository
+	processors  []ExpenseProcessor
+	observers   []ExpenseObserver
+	// groupID is the group whose ledger the service works on, 0 for the
+	// personal ledger.
+	groupID uint
+}
+
+func NewExpenseService(expenseRepo repositories.ExpenseRepository, processors []ExpenseProcessor, observers ...ExpenseObserver) ExpenseService {
+	return expenseService{expenseRepo: expenseRepo, processors: processors, observers: observers}
 }
 
-func NewExpenseService(expenseRepo repositories.ExpenseRepository) ExpenseService {
-	return expenseService{expenseRepo: expenseRepo}
+func (s expenseService) InGroup(groupID uint) ExpenseService {
+	s.expenseRepo = s.expenseRepo.InGroup(groupID)
+	s.groupID = groupID
+	return s
 }
 
 func (s expenseService) CreateExpense(expenseReq requests.ExpenseRequest) (responses.ExpenseResponse, error) {
@@ -23,15 +41,52 @@ func (s expenseService) CreateExpense(expenseReq requests.ExpenseRequest) (respo
 
 	copier.Copy(&expense, &expenseReq)
 
+	warnings, err := s.PrepareExpense(&expense)
+	if err != nil {
+		return responses.ExpenseResponse{}, err
+	}
+
 	if err := s.expenseRepo.Create(&expense); err != nil {
 		return responses.ExpenseResponse{}, helpers.NewInternalServerError()
 	}
 
+	s.NotifyCreated(expense)
+
 	copier.Copy(&expenseResp, &expense)
+	expenseResp.PolicyWarnings = warnings
 
 	return expenseResp, nil
 }
 
+func (s expenseService) PrepareExpense(expense *models.Expense) ([]responses.PolicyViolation, error) {
+	// the processors see the ledger the expense goes to
+	expense.GroupID = nil
+	if s.groupID != 0 {
+		groupID := s.groupID
+		expense.GroupID = &groupID
+	}
+
+	if err := s.checkType(*expense); err != nil {
+		return nil, err
+	}
+
+	for _, processor := range s.processors {
+		if err := processor.ProcessExpense(expense); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.checkPolicies(*expense)
+}
+
+func (s expenseService) NotifyCreated(expenses ...models.Expense) {
+	for _, expense := range expenses {
+		for _, observer := range s.observers {
+			observer.ExpenseCreated(expense)
+		}
+	}
+}
+
 func (s expenseService) GetExpenseByID(id string) (responses.ExpenseResponse, error) {
 	var expenseResp responses.ExpenseResponse
 
@@ -51,20 +106,148 @@ func (s expenseService) UpdateExpenseByID(id string, expensReq requests.ExpenseR
 
 	copier.Copy(&expense, &expensReq)
 
+	warnings, err := s.validate(id, expensReq)
+	if err != nil {
+		return responses.ExpenseResponse{}, err
+	}
+
 	updatedExpense, err := s.expenseRepo.UpdateByID(id, expense)
 	if err != nil {
 		return responses.ExpenseResponse{}, helpers.NewNotFoundError()
 	}
 
+	for _, observer := range s.observers {
+		observer.ExpenseUpdated(updatedExpense)
+	}
+
 	copier.Copy(&expenseResp, &updatedExpense)
+	expenseResp.PolicyWarnings = warnings
 
 	return expenseResp, nil
 }
 
-func (s expenseService) GetExpenses() ([]responses.ExpenseResponse, error) {
+// validate checks the stored expense with the request applied, only fields
+// set in the request are changed, and runs the processors that are
+// validators on it and then the policies, whose warnings it returns. The
+// type of an expense never changes.
+func (s expenseService) validate(id string, expensReq requests.ExpenseRequest) ([]responses.PolicyViolation, error) {
+	expense, err := s.expenseRepo.GetByID(id)
+	if err != nil {
+		return nil, helpers.NewNotFoundError()
+	}
+	if expensReq.Type != "" && expensReq.Type != expense.Type {
+		return nil, helpers.NewBadRequestError(fmt.Sprintf("%s %d cannot become %s", expense.Type, expense.ID, article(expensReq.Type)))
+	}
+	copier.CopyWithOption(&expense, &expensReq, copier.Option{IgnoreEmpty: true})
+
+	if err := s.checkType(expense); err != nil {
+		return nil, err
+	}
+
+	for _, processor := range s.processors {
+		if validator, ok := processor.(ExpenseValidator); ok {
+			if err := validator.ValidateExpense(expense); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.checkPolicies(expense)
+}
+
+// checkPolicies runs the processors that are policies on the expense and
+// returns their warnings.
+func (s expenseService) checkPolicies(expense models.Expense) ([]responses.PolicyViolation, error) {
+	var warnings []responses.PolicyViolation
+	for _, processor := range s.processors {
+		if policy, ok := processor.(ExpensePolicy); ok {
+			violations, err := policy.CheckExpense(expense)
+			if err != nil {
+				return nil, err
+			}
+			warnings = append(warnings, violations...)
+		}
+	}
+
+	return warnings, nil
+}
+
+// checkType checks the fields that belong to the type of the expense: the
+// two accounts of a transfer and the expense a refund is for.
+func (s expenseService) checkType(expense models.Expense) error {
+	if expense.Type == models.TypeTransfer {
+		if expense.AccountID == nil || expense.ToAccountID == nil {
+			return helpers.NewBadRequestError("a transfer needs account_id and to_account_id")
+		}
+		if *expense.AccountID == *expense.ToAccountID {
+			return helpers.NewBadRequestError("a transfer needs two different accounts")
+		}
+	} else if expense.ToAccountID != nil {
+		return helpers.NewBadRequestError("only a transfer has to_account_id")
+	}
+
+	if expense.Type == models.TypeRefund {
+		return s.checkRefund(expense)
+	}
+	if expense.RefundOfID != nil {
+		return helpers.NewBadRequestError("only a refund has refund_of_id")
+	}
+
+	return nil
+}
+
+// checkRefund checks that a refund is for an expense of the same ledger and
+// currency, and that its refunds do not add up to more than it cost.
+func (s expenseService) checkRefund(refund models.Expense) error {
+	if refund.RefundOfID == nil {
+		return helpers.NewBadRequestError("a refund needs refund_of_id")
+	}
+
+	original, err := s.expenseRepo.GetByID(strconv.FormatUint(uint64(*refund.RefundOfID), 10))
+	if err != nil {
+		return helpers.NewBadRequestError(fmt.Sprintf("expense %d does not exist", *refund.RefundOfID))
+	}
+	if original.Type != models.TypeExpense {
+		return helpers.NewBadRequestError(fmt.Sprintf("%s %d cannot be refunded, only an expense can", original.Type, original.ID))
+	}
+	if currencyOf(refund) != currencyOf(original) {
+		return helpers.NewBadRequestError(fmt.Sprintf("the refund is in %s but expense %d is in %s", currencyOf(refund), original.ID, currencyOf(original)))
+	}
+
+	refunded, err := s.expenseRepo.GetRefunded(original.ID, refund.ID)
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+	if refunded+refund.Amount > original.Amount+0.005 {
+		return helpers.NewBadRequestError(fmt.Sprintf("refunds of expense %d would add up to %.2f, more than its %.2f", original.ID, refunded+refund.Amount, original.Amount))
+	}
+
+	return nil
+}
+
+func currencyOf(expense models.Expense) string {
+	if expense.Currency == "" {
+		return helpers.BaseCurrency
+	}
+	return expense.Currency
+}
+
+func article(expenseType string) string {
+	if expenseType == models.TypeExpense || expenseType == models.TypeIncome {
+		return "an " + expenseType
+	}
+	return "a " + expenseType
+}
+
+func (s expenseService) GetExpenses(query requests.ExpenseQuery) ([]responses.ExpenseResponse, error) {
 	expensesResp := []responses.ExpenseResponse{}
 
-	expenses, err := s.expenseRepo.GetAll()
+	filter, err := expenseFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.expenseRepo.GetAll(filter)
 	if err != nil {
 		return nil, helpers.NewInternalServerError()
 	}
@@ -73,3 +256,205 @@ func (s expenseService) GetExpenses() ([]responses.ExpenseResponse, error) {
 
 	return expensesResp, nil
 }
+
+const exportBatchSize = 500
+
+// ExportExpenses streams the filtered expenses to w batch by batch. Errors
+// returned before anything is written are AppErrors, later ones mean a
+// truncated file.
+func (s expenseService) ExportExpenses(query requests.ExportQuery, w io.Writer) error {
+	format, ok := exporters.ByFormat(query.Format)
+	if !ok {
+		return helpers.NewBadRequestError("format must be one of csv, jsonl, xlsx or ofx")
+	}
+	locale, ok := exporters.LookupLocale(query.Locale)
+	if !ok {
+		return helpers.NewBadRequestError(fmt.Sprintf("locale %q is not supported", query.Locale))
+	}
+	filter, err := expenseFilter(query.ExpenseQuery)
+	if err != nil {
+		return err
+	}
+
+	exporter := format.New(w, exporters.Options{Locale: locale, From: query.From, To: query.To})
+	if err := s.expenseRepo.FindInBatches(filter, exportBatchSize, func(expenses []models.Expense) error {
+		for _, expense := range expenses {
+			if err := exporter.Write(expense); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return helpers.NewInternalServerError()
+	}
+
+	if err := exporter.Close(); err != nil {
+		return helpers.NewInternalServerError()
+	}
+
+	return nil
+}
+
+func expenseFilter(query requests.ExpenseQuery) (repositories.ExpenseFilter, error) {
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return repositories.ExpenseFilter{}, helpers.NewBadRequestError("to must not be before from")
+	}
+
+	return repositories.ExpenseFilter{From: query.From, To: query.To, Tags: query.Tags, AccountID: query.AccountID, Type: query.Type}, nil
+}
+
+const defaultSearchLimit = 20
+
+func (s expenseService) SearchExpenses(query requests.SearchQuery) ([]responses.SearchResultResponse, error) {
+	resultsResp := []responses.SearchResultResponse{}
+
+	searchQuery := helpers.SearchQuery(query.Q)
+	if searchQuery == "" {
+		return nil, helpers.NewBadRequestError("q must contain a word")
+	}
+	filter, err := expenseFilter(query.ExpenseQuery)
+	if err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	rows, err := s.expenseRepo.Search(repositories.SearchFilter{ExpenseFilter: filter, Query: searchQuery, Limit: limit})
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	words := helpers.SplitWords(query.Q)
+	for _, row := range rows {
+		result := responses.SearchResultResponse{
+			Rank: row.Rank,
+			Highlight: responses.SearchHighlight{
+				Title: highlight(row.Title, words, 0),
+				Note:  highlight(row.Note, words, snippetLength),
+			},
+		}
+		copier.Copy(&result.ExpenseResponse, &row.Expense)
+		resultsResp = append(resultsResp, result)
+	}
+
+	return resultsResp, nil
+}
+
+var summaryGroupUnits = map[string]bool{"day": true, "week": true, "month": true, "year": true}
+
+func (s expenseService) GetSummary(query requests.SummaryQuery) (responses.SummaryResponse, error) {
+	if query.GroupBy != "" && !summaryGroupUnits[query.GroupBy] {
+		return responses.SummaryResponse{}, helpers.NewBadRequestError("group_by must be one of day, week, month or year")
+	}
+	filter, err := expenseFilter(requests.ExpenseQuery{From: query.From, To: query.To, Tags: query.Tags, AccountID: query.AccountID})
+	if err != nil {
+		return responses.SummaryResponse{}, err
+	}
+
+	overall, err := s.expenseRepo.Summarize(repositories.SummaryFilter{ExpenseFilter: filter, Rollup: query.Rollup})
+	if err != nil {
+		return responses.SummaryResponse{}, helpers.NewInternalServerError()
+	}
+
+	summaryResp := responses.SummaryResponse{
+		GroupBy:   query.GroupBy,
+		ByTag:     query.ByTag,
+		ByAccount: query.ByAccount,
+		Groups:    []responses.SummaryGroup{},
+	}
+	if len(overall) > 0 {
+		summaryResp.Overall = toSummaryStats(overall[0])
+	}
+
+	if query.GroupBy == "" && !query.ByTag && !query.ByAccount {
+		return summaryResp, nil
+	}
+
+	rows, err := s.expenseRepo.Summarize(repositories.SummaryFilter{ExpenseFilter: filter, GroupBy: query.GroupBy, ByTag: query.ByTag, ByAccount: query.ByAccount, Rollup: query.Rollup})
+	if err != nil {
+		return responses.SummaryResponse{}, helpers.NewInternalServerError()
+	}
+
+	for _, row := range rows {
+		group := responses.SummaryGroup{Tag: row.Tag, AccountID: row.AccountID, SummaryStats: toSummaryStats(row)}
+		if row.Period != nil {
+			group.Period = row.Period.Format("2006-01-02")
+		}
+		summaryResp.Groups = append(summaryResp.Groups, group)
+	}
+
+	return summaryResp, nil
+}
+
+func toSummaryStats(row repositories.SummaryRow) responses.SummaryStats {
+	return responses.SummaryStats{
+		Total:   row.Total,
+		Count:   row.Count,
+		Average: row.Average,
+		Min:     row.Min,
+		Max:     row.Max,
+	}
+}
+
+// GetCashFlow reports per period and currency the income, the spending less
+// refunds and what is left, transfers between accounts move no money in or
+// out.
+func (s expenseService) GetCashFlow(query requests.CashFlowQuery) (responses.CashFlowResponse, error) {
+	groupBy := query.GroupBy
+	if groupBy == "" {
+		groupBy = "month"
+	}
+	filter, err := expenseFilter(requests.ExpenseQuery{From: query.From, To: query.To, AccountID: query.AccountID})
+	if err != nil {
+		return responses.CashFlowResponse{}, err
+	}
+
+	rows, err := s.expenseRepo.CashFlow(repositories.CashFlowFilter{ExpenseFilter: filter, GroupBy: groupBy})
+	if err != nil {
+		return responses.CashFlowResponse{}, helpers.NewInternalServerError()
+	}
+
+	cashFlowResp := responses.CashFlowResponse{GroupBy: groupBy, Periods: []responses.CashFlow{}, Totals: []responses.CashFlow{}}
+	totals := map[string]*responses.CashFlow{}
+	var currencies []string
+	for _, row := range rows {
+		cashFlowResp.Periods = append(cashFlowResp.Periods, cashFlow(row.Period.Format("2006-01-02"), row.Currency, row.Income, row.Spent))
+
+		total, ok := totals[row.Currency]
+		if !ok {
+			total = &responses.CashFlow{Currency: row.Currency}
+			totals[row.Currency] = total
+			currencies = append(currencies, row.Currency)
+		}
+		total.Income += row.Income
+		total.Expenses += row.Spent
+	}
+
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		cashFlowResp.Totals = append(cashFlowResp.Totals, cashFlow("", currency, totals[currency].Income, totals[currency].Expenses))
+	}
+
+	return cashFlowResp, nil
+}
+
+func cashFlow(period string, currency string, income float64, spent float64) responses.CashFlow {
+	flow := responses.CashFlow{
+		Period:   period,
+		Currency: currency,
+		Income:   round(income),
+		Expenses: round(spent),
+		Net:      round(income - spent),
+	}
+	if income > 0 {
+		rate := math.Round((income-spent)/income*10000) / 10000
+		flow.SavingsRate = &rate
+	}
+	return flow
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -5,23 +5,39 @@ package services_test
 import (
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/wytquant/assessment/helpers"
 	"github.com/wytquant/assessment/models"
 	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	accountServices "github.com/wytquant/assessment/src/account/services/mock"
+	alertServices "github.com/wytquant/assessment/src/alert/services/mock"
 	"github.com/wytquant/assessment/src/expense/repositories"
 	"github.com/wytquant/assessment/src/expense/services"
+	policyServices "github.com/wytquant/assessment/src/policy/services/mock"
+	ruleServices "github.com/wytquant/assessment/src/rule/services/mock"
+	suggestionServices "github.com/wytquant/assessment/src/suggestion/services/mock"
 )
 
+// isEqual compares the fields of the response got with the same fields of
+// the model want, fields only kept in the database and fields only in the
+// response are skipped.
 func isEqual(t *testing.T, want interface{}, got interface{}) {
 	wantValues := reflect.ValueOf(want)
 	gotValues := reflect.ValueOf(got)
 
-	for i := 0; i < wantValues.NumField(); i++ {
-		assert.Equal(t, wantValues.Field(i).Interface(), gotValues.Field(i).Interface())
+	for i := 0; i < gotValues.NumField(); i++ {
+		name := gotValues.Type().Field(i).Name
+		if !wantValues.FieldByName(name).IsValid() {
+			continue
+		}
+		assert.Equal(t, wantValues.FieldByName(name).Interface(), gotValues.Field(i).Interface(), name)
 	}
 }
 
@@ -31,7 +47,7 @@ func TestCreateExpenseService(t *testing.T) {
 		expenseRepo := repositories.NewExpenseReporitoryMock()
 		expenseRepo.On("Create").Return(nil)
 
-		expenseService := services.NewExpenseService(expenseRepo)
+		expenseService := services.NewExpenseService(expenseRepo, nil)
 
 		//act
 		_, err := expenseService.CreateExpense(requests.ExpenseRequest{})
@@ -45,7 +61,7 @@ func TestCreateExpenseService(t *testing.T) {
 		expenseRepo := repositories.NewExpenseReporitoryMock()
 		expenseRepo.On("Create").Return(helpers.NewInternalServerError())
 
-		expenseService := services.NewExpenseService(expenseRepo)
+		expenseService := services.NewExpenseService(expenseRepo, nil)
 
 		//act
 		_, err := expenseService.CreateExpense(requests.ExpenseRequest{})
@@ -53,6 +69,199 @@ func TestCreateExpenseService(t *testing.T) {
 		//assert
 		assert.EqualError(t, err, helpers.NewInternalServerError().Error())
 	})
+
+	t.Run("create expense notifies observers", func(t *testing.T) {
+		//Arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Create").Return(nil)
+
+		observer := alertServices.NewAlertServiceMock()
+		observer.On("ExpenseCreated", models.Expense{Title: "strawberry smoothie", Amount: 79}).Return()
+
+		expenseService := services.NewExpenseService(expenseRepo, nil, observer)
+
+		//act
+		_, err := expenseService.CreateExpense(requests.ExpenseRequest{Title: "strawberry smoothie", Amount: 79})
+
+		//assert
+		assert.NoError(t, err)
+		observer.AssertExpectations(t)
+	})
+
+	t.Run("create expense stores the expense changed by processors", func(t *testing.T) {
+		//Arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Create").Return(nil)
+
+		processor := ruleServices.NewRuleServiceMock()
+		processor.On("ProcessExpense", &models.Expense{Title: "Starbucks", Amount: 120}).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Expense).Category = "Coffee"
+		}).Return(nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, []services.ExpenseProcessor{processor})
+
+		//act
+		got, err := expenseService.CreateExpense(requests.ExpenseRequest{Title: "Starbucks", Amount: 120})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Coffee", got.Category)
+	})
+
+	t.Run("create expense of a group lets the processors see the group", func(t *testing.T) {
+		//Arrange
+		groupID := uint(7)
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("InGroup", groupID).Return()
+		expenseRepo.On("Create").Return(nil)
+
+		processor := ruleServices.NewRuleServiceMock()
+		processor.On("ProcessExpense", &models.Expense{Title: "Starbucks", Amount: 120, GroupID: &groupID}).Return(nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, []services.ExpenseProcessor{processor}).InGroup(groupID)
+
+		//act
+		_, err := expenseService.CreateExpense(requests.ExpenseRequest{Title: "Starbucks", Amount: 120})
+
+		//assert
+		assert.NoError(t, err)
+		processor.AssertExpectations(t)
+	})
+
+	t.Run("create expense fail case because a processor fails", func(t *testing.T) {
+		//Arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+
+		processor := ruleServices.NewRuleServiceMock()
+		processor.On("ProcessExpense", &models.Expense{Title: "Starbucks", Amount: 120}).Return(helpers.NewInternalServerError())
+
+		expenseService := services.NewExpenseService(expenseRepo, []services.ExpenseProcessor{processor})
+
+		//act
+		_, err := expenseService.CreateExpense(requests.ExpenseRequest{Title: "Starbucks", Amount: 120})
+
+		//assert
+		assert.EqualError(t, err, helpers.NewInternalServerError().Error())
+		expenseRepo.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("create expense returns the warnings of the policies", func(t *testing.T) {
+		//Arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Create").Return(nil)
+
+		warning := responses.PolicyViolation{Code: "NO-WEEKEND", Kind: models.PolicyWeekend, Action: models.PolicyWarn, Message: "spent on a Saturday"}
+		policy := policyServices.NewPolicyServiceMock()
+		policy.On("ProcessExpense", &models.Expense{Title: "Starbucks", Amount: 120}).Return(nil)
+		policy.On("CheckExpense", models.Expense{Title: "Starbucks", Amount: 120}).Return([]responses.PolicyViolation{warning}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, []services.ExpenseProcessor{policy})
+
+		//act
+		got, err := expenseService.CreateExpense(requests.ExpenseRequest{Title: "Starbucks", Amount: 120})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, []responses.PolicyViolation{warning}, got.PolicyWarnings)
+		expenseRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("create expense fail 422 because a policy blocks it", func(t *testing.T) {
+		//Arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+
+		policy := policyServices.NewPolicyServiceMock()
+		policy.On("ProcessExpense", &models.Expense{Title: "Starbucks", Amount: 1200}).Return(nil)
+		policy.On("CheckExpense", models.Expense{Title: "Starbucks", Amount: 1200}).Return([]responses.PolicyViolation(nil), helpers.NewPolicyViolationError([]string{"MEALS"}))
+
+		expenseService := services.NewExpenseService(expenseRepo, []services.ExpenseProcessor{policy})
+
+		//act
+		_, err := expenseService.CreateExpense(requests.ExpenseRequest{Title: "Starbucks", Amount: 1200})
+
+		//assert
+		assert.Equal(t, helpers.NewPolicyViolationError([]string{"MEALS"}), err)
+		expenseRepo.AssertNumberOfCalls(t, "Create", 0)
+	})
+
+	t.Run("create expense does not notify observers when it fails", func(t *testing.T) {
+		//Arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Create").Return(helpers.NewInternalServerError())
+
+		observer := alertServices.NewAlertServiceMock()
+
+		expenseService := services.NewExpenseService(expenseRepo, nil, observer)
+
+		//act
+		_, err := expenseService.CreateExpense(requests.ExpenseRequest{})
+
+		//assert
+		assert.Error(t, err)
+		observer.AssertNumberOfCalls(t, "ExpenseCreated", 0)
+	})
+}
+
+func TestCreateExpenseTypesService(t *testing.T) {
+	t.Run("create refund success case", func(t *testing.T) {
+		//arrange
+		originalID := uint(4)
+
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "4").Return(models.Expense{ID: 4, Type: models.TypeExpense, Title: "shoes", Amount: 990}, nil)
+		expenseRepo.On("GetRefunded", uint(4), uint(0)).Return(float64(500), nil)
+		expenseRepo.On("Create").Return(nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		got, err := expenseService.CreateExpense(requests.ExpenseRequest{Type: models.TypeRefund, Title: "returned shoes", Amount: 400, RefundOfID: &originalID})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, &originalID, got.RefundOfID)
+	})
+
+	t.Run("create refund fail bad request because refunds exceed the expense", func(t *testing.T) {
+		//arrange
+		originalID := uint(4)
+
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "4").Return(models.Expense{ID: 4, Type: models.TypeExpense, Title: "shoes", Amount: 990}, nil)
+		expenseRepo.On("GetRefunded", uint(4), uint(0)).Return(float64(700), nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		_, err := expenseService.CreateExpense(requests.ExpenseRequest{Type: models.TypeRefund, Title: "returned shoes", Amount: 400, RefundOfID: &originalID})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, "refunds of expense 4 would add up to 1100.00, more than its 990.00", appErr.Message)
+		}
+		expenseRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("create transfer fail bad request because both accounts are the same", func(t *testing.T) {
+		//arrange
+		accountID := uint(1)
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		_, err := expenseService.CreateExpense(requests.ExpenseRequest{Type: models.TypeTransfer, Title: "top up", Amount: 500, AccountID: &accountID, ToAccountID: &accountID})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, "a transfer needs two different accounts", appErr.Message)
+		}
+		expenseRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
 }
 
 func TestGetExpenseByIDService(t *testing.T) {
@@ -69,7 +278,7 @@ func TestGetExpenseByIDService(t *testing.T) {
 
 		expenseRepo := repositories.NewExpenseReporitoryMock()
 		expenseRepo.On("GetByID", id).Return(expenseReturn, nil)
-		expenseService := services.NewExpenseService(expenseRepo)
+		expenseService := services.NewExpenseService(expenseRepo, nil)
 
 		got, err := expenseService.GetExpenseByID(id)
 
@@ -83,7 +292,7 @@ func TestGetExpenseByIDService(t *testing.T) {
 		expenseRepo := repositories.NewExpenseReporitoryMock()
 		expenseRepo.On("GetByID", id).Return(models.Expense{}, helpers.NewNotFoundError())
 
-		expenseService := services.NewExpenseService(expenseRepo)
+		expenseService := services.NewExpenseService(expenseRepo, nil)
 
 		//act
 		_, err := expenseService.GetExpenseByID(id)
@@ -125,9 +334,10 @@ func TestUpdateExpenseByIDService(t *testing.T) {
 		}
 
 		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", id).Return(models.Expense{ID: 1, Type: models.TypeExpense, Title: "smoothie", Amount: 89}, nil)
 		expenseRepo.On("UpdateByID", id, updatedExpense).Return(expenseReturn, nil)
 
-		expenseService := services.NewExpenseService(expenseRepo)
+		expenseService := services.NewExpenseService(expenseRepo, nil)
 
 		//act
 		got, err := expenseService.UpdateExpenseByID(id, expenseReq)
@@ -137,16 +347,74 @@ func TestUpdateExpenseByIDService(t *testing.T) {
 		isEqual(t, expenseReturn, got)
 	})
 
-	t.Run("update expense by id fail case bacause expense was not found", func(t *testing.T) {
+	t.Run("update expense by id notifies observers", func(t *testing.T) {
 		//arrange
-		id := "1"
-		updatedExpense := models.Expense{
-			Title:  "strawberry smoothie",
-			Amount: 79,
-			Note:   "night market promotion discount 10 bath",
-			Tags:   pq.StringArray{"food", "beverage"},
+		expenseReturn := models.Expense{ID: 1, Title: "strawberry smoothie", Amount: 79, Tags: pq.StringArray{"beverage"}}
+
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1, Type: models.TypeExpense, Title: "smoothie", Amount: 89}, nil)
+		expenseRepo.On("UpdateByID", "1", models.Expense{Title: "strawberry smoothie", Amount: 79, Tags: pq.StringArray{"beverage"}}).Return(expenseReturn, nil)
+
+		observer := suggestionServices.NewSuggestionServiceMock()
+		observer.On("ExpenseUpdated", expenseReturn).Return()
+
+		expenseService := services.NewExpenseService(expenseRepo, nil, observer)
+
+		//act
+		_, err := expenseService.UpdateExpenseByID("1", requests.ExpenseRequest{Title: "strawberry smoothie", Amount: 79, Tags: pq.StringArray{"beverage"}})
+
+		//assert
+		assert.NoError(t, err)
+		observer.AssertExpectations(t)
+	})
+
+	t.Run("update expense by id checks the changed expense with validators", func(t *testing.T) {
+		//arrange
+		accountID := uint(2)
+		expenseReq := requests.ExpenseRequest{Title: "strawberry smoothie", Amount: 79, Tags: pq.StringArray{"beverage"}, AccountID: &accountID}
+
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1, Title: "smoothie", Amount: 3, Currency: "USD", Tags: pq.StringArray{"food"}}, nil)
+
+		validator := accountServices.NewAccountServiceMock()
+		validator.On("ValidateExpense", models.Expense{ID: 1, Title: "strawberry smoothie", Amount: 79, Currency: "USD", Tags: pq.StringArray{"beverage"}, AccountID: &accountID}).
+			Return(helpers.NewBadRequestError("the expense is in USD but account Visa is in THB"))
+
+		expenseService := services.NewExpenseService(expenseRepo, []services.ExpenseProcessor{validator})
+
+		//act
+		_, err := expenseService.UpdateExpenseByID("1", expenseReq)
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
 		}
+		expenseRepo.AssertNotCalled(t, "UpdateByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update expense by id fail bad request because the type changes", func(t *testing.T) {
+		//arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1, Type: models.TypeExpense, Title: "salary", Amount: 40000}, nil)
 
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		_, err := expenseService.UpdateExpenseByID("1", requests.ExpenseRequest{Type: models.TypeIncome, Title: "salary", Amount: 40000})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, "expense 1 cannot become an income", appErr.Message)
+		}
+		expenseRepo.AssertNotCalled(t, "UpdateByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update expense by id fail case bacause expense was not found", func(t *testing.T) {
+		//arrange
+		id := "1"
 		expenseReq := requests.ExpenseRequest{
 			Title:  "strawberry smoothie",
 			Amount: 79,
@@ -154,9 +422,9 @@ func TestUpdateExpenseByIDService(t *testing.T) {
 			Tags:   pq.StringArray{"food", "beverage"},
 		}
 		expenseRepo := repositories.NewExpenseReporitoryMock()
-		expenseRepo.On("UpdateByID", id, updatedExpense).Return(models.Expense{}, helpers.NewNotFoundError())
+		expenseRepo.On("GetByID", id).Return(models.Expense{}, helpers.NewNotFoundError())
 
-		expenseService := services.NewExpenseService(expenseRepo)
+		expenseService := services.NewExpenseService(expenseRepo, nil)
 
 		//act
 		_, err := expenseService.UpdateExpenseByID(id, expenseReq)
@@ -167,6 +435,7 @@ func TestUpdateExpenseByIDService(t *testing.T) {
 			assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
 		}
 		assert.EqualError(t, err, helpers.NewNotFoundError().Error())
+		expenseRepo.AssertNotCalled(t, "UpdateByID", mock.Anything, mock.Anything)
 	})
 }
 
@@ -174,7 +443,7 @@ func TestGetAllExpensesService(t *testing.T) {
 	t.Run("get all expenses success case", func(t *testing.T) {
 		//Arrange
 		expenseRepo := repositories.NewExpenseReporitoryMock()
-		expenseRepo.On("GetAll").Return([]models.Expense{
+		expenseRepo.On("GetAll", repositories.ExpenseFilter{}).Return([]models.Expense{
 			{
 				ID:     1,
 				Title:  "strawberry smoothie",
@@ -191,10 +460,10 @@ func TestGetAllExpensesService(t *testing.T) {
 			},
 		}, nil)
 
-		expenseService := services.NewExpenseService(expenseRepo)
+		expenseService := services.NewExpenseService(expenseRepo, nil)
 
 		//act
-		got, err := expenseService.GetExpenses()
+		got, err := expenseService.GetExpenses(requests.ExpenseQuery{})
 
 		//assert
 		assert.NoError(t, err)
@@ -204,12 +473,12 @@ func TestGetAllExpensesService(t *testing.T) {
 	t.Run("get all expenses fail case because internal server error", func(t *testing.T) {
 		//Arrange
 		expenseRepo := repositories.NewExpenseReporitoryMock()
-		expenseRepo.On("GetAll").Return([]models.Expense{}, helpers.NewInternalServerError())
+		expenseRepo.On("GetAll", repositories.ExpenseFilter{}).Return([]models.Expense{}, helpers.NewInternalServerError())
 
-		expenseService := services.NewExpenseService(expenseRepo)
+		expenseService := services.NewExpenseService(expenseRepo, nil)
 
 		//act
-		got, err := expenseService.GetExpenses()
+		got, err := expenseService.GetExpenses(requests.ExpenseQuery{})
 
 		//assert
 		appErr, ok := err.(*helpers.AppError)
@@ -219,4 +488,299 @@ func TestGetAllExpensesService(t *testing.T) {
 		assert.EqualError(t, err, helpers.NewInternalServerError().Error())
 		assert.Equal(t, 0, len(got))
 	})
+
+	t.Run("get all expenses of a group reads the group ledger", func(t *testing.T) {
+		//Arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("InGroup", uint(7)).Return()
+		expenseRepo.On("GetAll", repositories.ExpenseFilter{}).Return([]models.Expense{}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil).InGroup(7)
+
+		//act
+		_, err := expenseService.GetExpenses(requests.ExpenseQuery{})
+
+		//assert
+		assert.NoError(t, err)
+		expenseRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetSummaryService(t *testing.T) {
+	t.Run("get summary rolls child tags up into their parents", func(t *testing.T) {
+		//arrange
+		filter := repositories.ExpenseFilter{Tags: []string{"food"}}
+
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Summarize", repositories.SummaryFilter{ExpenseFilter: filter, Rollup: true}).
+			Return([]repositories.SummaryRow{{Total: 200, Count: 3}}, nil)
+		expenseRepo.On("Summarize", repositories.SummaryFilter{ExpenseFilter: filter, ByTag: true, Rollup: true}).
+			Return([]repositories.SummaryRow{{Tag: "food", Total: 200, Count: 3}}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		got, err := expenseService.GetSummary(requests.SummaryQuery{ByTag: true, Rollup: true, Tags: []string{"food"}})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, float64(200), got.Overall.Total)
+		expenseRepo.AssertExpectations(t)
+	})
+
+	t.Run("get summary by account success case", func(t *testing.T) {
+		//arrange
+		accountID := uint(2)
+		filter := repositories.ExpenseFilter{AccountID: accountID}
+
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Summarize", repositories.SummaryFilter{ExpenseFilter: filter}).
+			Return([]repositories.SummaryRow{{Total: 300, Count: 2}}, nil)
+		expenseRepo.On("Summarize", repositories.SummaryFilter{ExpenseFilter: filter, ByAccount: true}).
+			Return([]repositories.SummaryRow{{AccountID: &accountID, Total: 300, Count: 2}}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		got, err := expenseService.GetSummary(requests.SummaryQuery{AccountID: accountID, ByAccount: true})
+
+		//assert
+		assert.NoError(t, err)
+		assert.True(t, got.ByAccount)
+		if assert.Equal(t, 1, len(got.Groups)) {
+			assert.Equal(t, &accountID, got.Groups[0].AccountID)
+			assert.Equal(t, float64(300), got.Groups[0].Total)
+		}
+	})
+
+	t.Run("get summary grouped by month and tag success case", func(t *testing.T) {
+		//arrange
+		from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		filter := repositories.ExpenseFilter{From: from, Tags: []string{"food"}}
+		period := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Summarize", repositories.SummaryFilter{ExpenseFilter: filter}).
+			Return([]repositories.SummaryRow{{Total: 158, Count: 2, Average: 79, Min: 79, Max: 79}}, nil)
+		expenseRepo.On("Summarize", repositories.SummaryFilter{ExpenseFilter: filter, GroupBy: "month", ByTag: true}).
+			Return([]repositories.SummaryRow{{Period: &period, Tag: "food", Total: 158, Count: 2, Average: 79, Min: 79, Max: 79}}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		got, err := expenseService.GetSummary(requests.SummaryQuery{GroupBy: "month", ByTag: true, From: from, Tags: []string{"food"}})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, float64(158), got.Overall.Total)
+		if assert.Equal(t, 1, len(got.Groups)) {
+			assert.Equal(t, "2023-01-01", got.Groups[0].Period)
+			assert.Equal(t, "food", got.Groups[0].Tag)
+			assert.Equal(t, int64(2), got.Groups[0].Count)
+		}
+	})
+
+	t.Run("get summary without grouping returns only overall", func(t *testing.T) {
+		//arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Summarize", repositories.SummaryFilter{}).
+			Return([]repositories.SummaryRow{{Total: 79, Count: 1, Average: 79, Min: 79, Max: 79}}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		got, err := expenseService.GetSummary(requests.SummaryQuery{})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), got.Overall.Count)
+		assert.Equal(t, 0, len(got.Groups))
+		expenseRepo.AssertNumberOfCalls(t, "Summarize", 1)
+	})
+
+	t.Run("get summary fail case because group_by is invalid", func(t *testing.T) {
+		//arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		_, err := expenseService.GetSummary(requests.SummaryQuery{GroupBy: "hour"})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		}
+	})
+
+	t.Run("get summary fail case because internal server error", func(t *testing.T) {
+		//arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Summarize", repositories.SummaryFilter{}).
+			Return([]repositories.SummaryRow{}, helpers.NewInternalServerError())
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		_, err := expenseService.GetSummary(requests.SummaryQuery{})
+
+		//assert
+		assert.EqualError(t, err, helpers.NewInternalServerError().Error())
+	})
+}
+
+func TestGetCashFlowService(t *testing.T) {
+	t.Run("get cash flow per month with totals success case", func(t *testing.T) {
+		//arrange
+		january := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		february := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("CashFlow", repositories.CashFlowFilter{GroupBy: "month"}).Return([]repositories.CashFlowRow{
+			{Period: january, Currency: "THB", Income: 40000, Spent: 30000},
+			{Period: january, Currency: "USD", Spent: 120},
+			{Period: february, Currency: "THB", Income: 40000, Spent: 42000},
+		}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		got, err := expenseService.GetCashFlow(requests.CashFlowQuery{})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "month", got.GroupBy)
+		if assert.Len(t, got.Periods, 3) {
+			assert.Equal(t, "2023-01-01", got.Periods[0].Period)
+			assert.Equal(t, float64(10000), got.Periods[0].Net)
+			assert.Equal(t, 0.25, *got.Periods[0].SavingsRate)
+			assert.Nil(t, got.Periods[1].SavingsRate)
+			assert.Equal(t, float64(-2000), got.Periods[2].Net)
+		}
+		if assert.Len(t, got.Totals, 2) {
+			assert.Equal(t, "THB", got.Totals[0].Currency)
+			assert.Equal(t, float64(80000), got.Totals[0].Income)
+			assert.Equal(t, float64(72000), got.Totals[0].Expenses)
+			assert.Equal(t, 0.1, *got.Totals[0].SavingsRate)
+			assert.Equal(t, float64(-120), got.Totals[1].Net)
+		}
+	})
+}
+
+func TestSearchExpensesService(t *testing.T) {
+	t.Run("search expenses highlights the matches", func(t *testing.T) {
+		//arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Search", repositories.SearchFilter{Query: "smoo:* & (ตล <-> ลา <-> าด)", Limit: 20}).Return([]repositories.SearchRow{
+			{Expense: models.Expense{ID: 1, Title: "Strawberry SMOOTHIE", Note: "ตลาดนัดกลางคืน <night market>"}, Rank: 0.6},
+		}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		got, err := expenseService.SearchExpenses(requests.SearchQuery{Q: "smoo ตลาด"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, uint(1), got[0].ID)
+			assert.Equal(t, 0.6, got[0].Rank)
+			assert.Equal(t, "Strawberry <mark>SMOO</mark>THIE", got[0].Highlight.Title)
+			assert.Equal(t, "<mark>ตลาด</mark>นัดกลางคืน &lt;night market&gt;", got[0].Highlight.Note)
+		}
+	})
+
+	t.Run("search expenses cuts long notes around the first match", func(t *testing.T) {
+		//arrange
+		note := strings.Repeat("a ", 100) + "smoothie" + strings.Repeat(" b", 100)
+
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("Search", repositories.SearchFilter{Query: "smoothie:*", Limit: 5}).Return([]repositories.SearchRow{
+			{Expense: models.Expense{ID: 1, Title: "drink", Note: note}},
+		}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		got, err := expenseService.SearchExpenses(requests.SearchQuery{Q: "Smoothie", Limit: 5})
+
+		//assert
+		assert.NoError(t, err)
+		snippet := got[0].Highlight.Note
+		assert.True(t, strings.HasPrefix(snippet, "…"+strings.Repeat("a ", 20)+"<mark>smoothie</mark>"), snippet)
+		assert.True(t, strings.HasSuffix(snippet, "…"), snippet)
+	})
+
+	t.Run("search expenses fail bad request because query has no word", func(t *testing.T) {
+		//arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		_, err := expenseService.SearchExpenses(requests.SearchQuery{Q: "!?"})
+
+		//assert
+		assert.Equal(t, helpers.NewBadRequestError("q must contain a word"), err)
+		expenseRepo.AssertNumberOfCalls(t, "Search", 0)
+	})
+}
+
+func TestExportExpensesService(t *testing.T) {
+	t.Run("export streams every batch as csv", func(t *testing.T) {
+		//arrange
+		date := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("FindInBatches", repositories.ExpenseFilter{Tags: []string{"food"}}, 500).Return([][]models.Expense{
+			{{ID: 1, Title: "coffee", Amount: 1250, Date: date, Currency: "THB", Tags: pq.StringArray{"food"}}},
+			{{ID: 2, Title: "lunch", Amount: 120, Date: date, Currency: "THB", Tags: pq.StringArray{"food"}}},
+		}, nil)
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+		var w strings.Builder
+
+		//act
+		err := expenseService.ExportExpenses(requests.ExportQuery{
+			ExpenseQuery: requests.ExpenseQuery{Tags: []string{"food"}},
+			Format:       "csv",
+			Locale:       "th-TH",
+		}, &w)
+
+		//assert
+		assert.NoError(t, err)
+		assert.Contains(t, w.String(), `1,15/01/2566,expense,coffee,"1,250.00",THB,,,,,food`)
+		assert.Contains(t, w.String(), "2,15/01/2566,expense,lunch,120.00,THB,,,,,food")
+	})
+
+	t.Run("export fail bad request because locale is unknown", func(t *testing.T) {
+		//arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+		var w strings.Builder
+
+		//act
+		err := expenseService.ExportExpenses(requests.ExportQuery{Format: "csv", Locale: "xx-XX"}, &w)
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		}
+		assert.Empty(t, w.String())
+		expenseRepo.AssertNumberOfCalls(t, "FindInBatches", 0)
+	})
+
+	t.Run("export fail case because internal server error", func(t *testing.T) {
+		//arrange
+		expenseRepo := repositories.NewExpenseReporitoryMock()
+		expenseRepo.On("FindInBatches", repositories.ExpenseFilter{}, 500).
+			Return([][]models.Expense{}, helpers.NewInternalServerError())
+
+		expenseService := services.NewExpenseService(expenseRepo, nil)
+
+		//act
+		err := expenseService.ExportExpenses(requests.ExportQuery{Format: "jsonl"}, &strings.Builder{})
+
+		//assert
+		assert.EqualError(t, err, helpers.NewInternalServerError().Error())
+	})
 }
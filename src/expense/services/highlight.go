@@ -0,0 +1,91 @@
+package services
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	snippetLength  = 160
+	snippetContext = 40
+)
+
+// highlight escapes text for HTML and wraps the parts matching the words in
+// <mark>. Matching ignores case, a word matches the start of a longer one
+// and Thai words match anywhere as Thai has no spaces. With a maxLength the
+// text is cut around the first match.
+func highlight(text string, words []string, maxLength int) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, word := range words {
+		target := []rune(word)
+		for i := 0; i+len(target) <= len(lower); i++ {
+			if !hasPrefix(lower[i:], target) {
+				continue
+			}
+			if !unicode.Is(unicode.Thai, target[0]) && i > 0 && isWordRune(lower[i-1]) {
+				continue
+			}
+			for j := i; j < i+len(target); j++ {
+				marked[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+
+	start, end := 0, len(runes)
+	if maxLength > 0 && len(runes) > maxLength {
+		if first > snippetContext {
+			start = first - snippetContext
+		}
+		end = start + maxLength
+		if end > len(runes) {
+			end = len(runes)
+			start = end - maxLength
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && marked[j] == marked[i] {
+			j++
+		}
+		part := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			part = "<mark>" + part + "</mark>"
+		}
+		b.WriteString(part)
+		i = j
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+
+	return b.String()
+}
+
+func hasPrefix(text []rune, prefix []rune) bool {
+	for i, r := range prefix {
+		if text[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
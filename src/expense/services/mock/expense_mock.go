@@ -1,9 +1,13 @@
 package services
 
 import (
+	"io"
+
 	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
 	"github.com/wytquant/assessment/requests"
 	"github.com/wytquant/assessment/responses"
+	expenseServices "github.com/wytquant/assessment/src/expense/services"
 )
 
 type expenseServiceMock struct {
@@ -29,7 +33,47 @@ func (m *expenseServiceMock) UpdateExpenseByID(id string, expensReq requests.Exp
 	return args.Get(0).(responses.ExpenseResponse), args.Error(1)
 }
 
-func (m *expenseServiceMock) GetExpenses() ([]responses.ExpenseResponse, error) {
-	args := m.Called()
+func (m *expenseServiceMock) GetExpenses(query requests.ExpenseQuery) ([]responses.ExpenseResponse, error) {
+	args := m.Called(query)
 	return args.Get(0).([]responses.ExpenseResponse), args.Error(1)
 }
+
+// ExportExpenses writes the string given to On before returning the error.
+func (m *expenseServiceMock) ExportExpenses(query requests.ExportQuery, w io.Writer) error {
+	args := m.Called(query)
+	io.WriteString(w, args.String(0))
+	return args.Error(1)
+}
+
+func (m *expenseServiceMock) GetSummary(query requests.SummaryQuery) (responses.SummaryResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).(responses.SummaryResponse), args.Error(1)
+}
+
+func (m *expenseServiceMock) GetCashFlow(query requests.CashFlowQuery) (responses.CashFlowResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).(responses.CashFlowResponse), args.Error(1)
+}
+
+func (m *expenseServiceMock) SearchExpenses(query requests.SearchQuery) ([]responses.SearchResultResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).([]responses.SearchResultResponse), args.Error(1)
+}
+
+// PrepareExpense returns the warnings and error given to On, the expense is
+// left as it is.
+func (m *expenseServiceMock) PrepareExpense(expense *models.Expense) ([]responses.PolicyViolation, error) {
+	args := m.Called(*expense)
+	warnings, _ := args.Get(0).([]responses.PolicyViolation)
+	return warnings, args.Error(1)
+}
+
+func (m *expenseServiceMock) NotifyCreated(expenses ...models.Expense) {
+	m.Called(expenses)
+}
+
+// InGroup records the group and returns the same mock for the group ledger.
+func (m *expenseServiceMock) InGroup(groupID uint) expenseServices.ExpenseService {
+	m.Called(groupID)
+	return m
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/forecast/services"
+)
+
+type forecastHandler struct {
+	forecastService services.ForecastService
+}
+
+func NewForecastHandler(forecastService services.ForecastService) forecastHandler {
+	return forecastHandler{forecastService: forecastService}
+}
+
+func (h forecastHandler) GetForecast(c *gin.Context) {
+	var query requests.ForecastQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	forecastResp, err := h.forecastService.GetForecast(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, forecastResp)
+}
@@ -0,0 +1,62 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/forecast/handlers"
+	services "github.com/wytquant/assessment/src/forecast/services/mock"
+)
+
+func TestGetForecastHandler(t *testing.T) {
+	t.Run("get forecast success case", func(t *testing.T) {
+		//arrange
+		date := time.Date(2023, 4, 12, 0, 0, 0, 0, time.Local)
+
+		forecastService := services.NewForecastServiceMock()
+		forecastService.On("GetForecast", requests.ForecastQuery{Period: "yearly", Date: date}).Return(responses.ForecastResponse{
+			Period:    "yearly",
+			Forecasts: []responses.Forecast{{Tag: "food", Currency: "THB", Spent: 1800, Projected: 4500, Low: 4200, High: 4800}},
+		}, nil)
+
+		forecastHandler := handlers.NewForecastHandler(forecastService)
+
+		r := gin.Default()
+		r.GET("/forecast", forecastHandler.GetForecast)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/forecast?period=yearly&date=2023-04-12", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		forecastService.AssertExpectations(t)
+	})
+
+	t.Run("get forecast fail bad request because period is unknown", func(t *testing.T) {
+		//arrange
+		forecastHandler := handlers.NewForecastHandler(services.NewForecastServiceMock())
+
+		r := gin.Default()
+		r.GET("/forecast", forecastHandler.GetForecast)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/forecast?period=weekly", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+)
+
+type forecastRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewForecastRepositoryDB(db *gorm.DB) ForecastRepository {
+	return forecastRepositoryDB{db: db}
+}
+
+func (r forecastRepositoryDB) GetDailyTotals(from time.Time, to time.Time) ([]DailyTotal, error) {
+	var totals []DailyTotal
+
+	err := r.db.Raw(`SELECT t.tag, expenses.currency, expenses.date::date AS day, SUM(`+models.SpentAmount+`) AS total
+			FROM expenses
+			CROSS JOIN LATERAL unnest(expenses.tags) AS t(tag)
+			WHERE expenses.group_id IS NULL AND expenses.deleted_at IS NULL AND expenses.date >= @from AND expenses.date < @to
+				AND `+models.Spending+`
+			GROUP BY 1, 2, 3
+		UNION ALL
+		SELECT '' AS tag, expenses.currency, expenses.date::date AS day, SUM(`+models.SpentAmount+`) AS total
+			FROM expenses
+			WHERE expenses.group_id IS NULL AND expenses.deleted_at IS NULL AND expenses.date >= @from AND expenses.date < @to
+				AND `+models.Spending+`
+			GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3`, map[string]interface{}{"from": from, "to": to}).Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type forecastRepositoryMock struct {
+	mock.Mock
+}
+
+func NewForecastRepositoryMock() *forecastRepositoryMock {
+	return &forecastRepositoryMock{}
+}
+
+func (m *forecastRepositoryMock) GetDailyTotals(from time.Time, to time.Time) ([]DailyTotal, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]DailyTotal), args.Error(1)
+}
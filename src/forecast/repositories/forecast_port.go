@@ -0,0 +1,19 @@
+package repositories
+
+import "time"
+
+// DailyTotal is the spending of a tag on a day, an empty Tag stands for all
+// spending.
+type DailyTotal struct {
+	Tag      string
+	Currency string
+	Day      time.Time
+	Total    float64
+}
+
+// ForecastRepository works on the personal ledger.
+type ForecastRepository interface {
+	// GetDailyTotals sums the expenses dated from from until before to by
+	// tag, currency and day.
+	GetDailyTotals(from time.Time, to time.Time) ([]DailyTotal, error)
+}
@@ -0,0 +1,10 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type ForecastService interface {
+	GetForecast(query requests.ForecastQuery) (responses.ForecastResponse, error)
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/forecast/repositories"
+)
+
+// the model learns from whole weeks before the day of the forecast, a year
+// is projected from a longer history than a month
+const (
+	monthlyHistoryDays = 13 * 7
+	yearlyHistoryDays  = 52 * 7
+)
+
+type forecastService struct {
+	forecastRepo repositories.ForecastRepository
+	now          func() time.Time
+}
+
+func NewForecastService(forecastRepo repositories.ForecastRepository) ForecastService {
+	return forecastService{forecastRepo: forecastRepo, now: time.Now}
+}
+
+// GetForecast projects the spending at the end of the period containing the
+// day: what was spent until and including the day plus what the model
+// expects for the days left.
+func (s forecastService) GetForecast(query requests.ForecastQuery) (responses.ForecastResponse, error) {
+	period := query.Period
+	if period == "" {
+		period = models.PeriodMonthly
+	}
+	date := query.Date
+	if date.IsZero() {
+		date = s.now()
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	next := day.AddDate(0, 0, 1)
+	start, end := helpers.PeriodBounds(period, day)
+
+	historyDays := monthlyHistoryDays
+	if period == models.PeriodYearly {
+		historyDays = yearlyHistoryDays
+	}
+	historyFrom := day.AddDate(0, 0, -historyDays)
+
+	from := historyFrom
+	if start.Before(from) {
+		from = start
+	}
+
+	totals, err := s.forecastRepo.GetDailyTotals(from, next)
+	if err != nil {
+		return responses.ForecastResponse{}, helpers.NewInternalServerError()
+	}
+
+	forecastResp := responses.ForecastResponse{
+		Period:      period,
+		PeriodStart: start,
+		PeriodEnd:   end.AddDate(0, 0, -1),
+		Date:        day,
+		Forecasts:   []responses.Forecast{},
+	}
+
+	for _, series := range dailySeries(totals) {
+		history := make([]float64, historyDays)
+		for i := range history {
+			history[i] = series.days[historyFrom.AddDate(0, 0, i).Format("2006-01-02")]
+		}
+
+		var spent float64
+		for d := start; d.Before(next); d = d.AddDate(0, 0, 1) {
+			spent += series.days[d.Format("2006-01-02")]
+		}
+
+		model := fitSeasonal(history, historyFrom)
+		expected, spread := model.predict(next, end)
+
+		forecastResp.Forecasts = append(forecastResp.Forecasts, responses.Forecast{
+			Tag:          series.tag,
+			Currency:     series.currency,
+			Spent:        round(spent),
+			DailyAverage: round(model.level),
+			Projected:    round(spent + expected),
+			Low:          round(spent + math.Max(0, expected-spread)),
+			High:         round(spent + expected + spread),
+		})
+	}
+
+	return forecastResp, nil
+}
+
+type series struct {
+	tag      string
+	currency string
+	// days maps YYYY-MM-DD to the total of the day
+	days map[string]float64
+}
+
+func dailySeries(totals []repositories.DailyTotal) []*series {
+	var result []*series
+	byKey := map[string]*series{}
+
+	for _, total := range totals {
+		key := total.Tag + "\x00" + total.Currency
+		if byKey[key] == nil {
+			byKey[key] = &series{tag: total.Tag, currency: total.Currency, days: map[string]float64{}}
+			result = append(result, byKey[key])
+		}
+		byKey[key].days[total.Day.Format("2006-01-02")] += total.Total
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].tag != result[j].tag {
+			return result[i].tag < result[j].tag
+		}
+		return result[i].currency < result[j].currency
+	})
+
+	return result
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,125 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/forecast/repositories"
+	"github.com/wytquant/assessment/src/forecast/services"
+)
+
+// synthetic generates a daily total of the tag for every day from from
+// through to.
+func synthetic(tag string, from time.Time, to time.Time, amount func(day time.Time, i int) float64) []repositories.DailyTotal {
+	var totals []repositories.DailyTotal
+	for day, i := from, 0; !day.After(to); day, i = day.AddDate(0, 0, 1), i+1 {
+		totals = append(totals, repositories.DailyTotal{Tag: tag, Currency: "THB", Day: day, Total: amount(day, i)})
+	}
+	return totals
+}
+
+func weekendSpender(day time.Time, i int) float64 {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return 250
+	}
+	return 100
+}
+
+func TestGetForecastService(t *testing.T) {
+	// a Wednesday, the 91 days of history start on January 11th
+	date := time.Date(2023, 4, 12, 0, 0, 0, 0, time.UTC)
+	historyFrom := time.Date(2023, 1, 11, 0, 0, 0, 0, time.UTC)
+	next := date.AddDate(0, 0, 1)
+
+	t.Run("monthly forecast follows the weekly pattern success case", func(t *testing.T) {
+		//arrange
+		forecastRepo := repositories.NewForecastRepositoryMock()
+		forecastRepo.On("GetDailyTotals", historyFrom, next).Return(synthetic("food", historyFrom, date, weekendSpender), nil)
+
+		forecastService := services.NewForecastService(forecastRepo)
+
+		//act
+		got, err := forecastService.GetForecast(requests.ForecastQuery{Date: date})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, responses.ForecastResponse{
+			Period:      models.PeriodMonthly,
+			PeriodStart: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:   time.Date(2023, 4, 30, 0, 0, 0, 0, time.UTC),
+			Date:        date,
+			Forecasts: []responses.Forecast{
+				// 1800 spent until the 12th, 13 weekdays at 100 and 5 weekend days at 250 to come
+				{Tag: "food", Currency: "THB", Spent: 1800, DailyAverage: 142.86, Projected: 4500, Low: 4500, High: 4500},
+			},
+		}, got)
+	})
+
+	t.Run("monthly forecast of irregular spending has a range success case", func(t *testing.T) {
+		//arrange
+		forecastRepo := repositories.NewForecastRepositoryMock()
+		forecastRepo.On("GetDailyTotals", historyFrom, next).Return(synthetic("transport", historyFrom, date, func(day time.Time, i int) float64 {
+			return float64(40 + 20*(i%3))
+		}), nil)
+
+		forecastService := services.NewForecastService(forecastRepo)
+
+		//act
+		got, err := forecastService.GetForecast(requests.ForecastQuery{Date: date})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got.Forecasts, 1) {
+			forecast := got.Forecasts[0]
+			assert.Equal(t, "transport", forecast.Tag)
+			assert.InDelta(t, 60, forecast.DailyAverage, 0.5)
+			assert.InDelta(t, forecast.Spent+18*60, forecast.Projected, 20)
+			assert.Less(t, forecast.Low, forecast.Projected)
+			assert.Greater(t, forecast.High, forecast.Projected)
+			assert.GreaterOrEqual(t, forecast.Low, forecast.Spent)
+		}
+	})
+
+	t.Run("yearly forecast reads a year of history success case", func(t *testing.T) {
+		//arrange
+		forecastRepo := repositories.NewForecastRepositoryMock()
+		forecastRepo.On("GetDailyTotals", date.AddDate(0, 0, -364), next).Return([]repositories.DailyTotal{}, nil)
+
+		forecastService := services.NewForecastService(forecastRepo)
+
+		//act
+		got, err := forecastService.GetForecast(requests.ForecastQuery{Period: models.PeriodYearly, Date: date})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), got.PeriodStart)
+		assert.Equal(t, time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), got.PeriodEnd)
+		assert.Empty(t, got.Forecasts)
+	})
+
+	t.Run("get forecast fail internal server error because totals cannot be read", func(t *testing.T) {
+		//arrange
+		forecastRepo := repositories.NewForecastRepositoryMock()
+		forecastRepo.On("GetDailyTotals", historyFrom, next).Return([]repositories.DailyTotal{}, errors.New(""))
+
+		forecastService := services.NewForecastService(forecastRepo)
+
+		//act
+		_, err := forecastService.GetForecast(requests.ForecastQuery{Date: date})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusInternalServerError, appErr.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type forecastServiceMock struct {
+	mock.Mock
+}
+
+func NewForecastServiceMock() *forecastServiceMock {
+	return &forecastServiceMock{}
+}
+
+func (m *forecastServiceMock) GetForecast(query requests.ForecastQuery) (responses.ForecastResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).(responses.ForecastResponse), args.Error(1)
+}
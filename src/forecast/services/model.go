@@ -0,0 +1,71 @@
+package services
+
+import (
+	"math"
+	"time"
+)
+
+// z80 is the standard normal quantile bounding 80% of the outcomes.
+const z80 = 1.2816
+
+// seasonalModel is a moving average of the daily spending scaled by how much
+// is usually spent on each day of the week.
+type seasonalModel struct {
+	level   float64
+	factors [7]float64
+	// deviation is the standard deviation of a day around the model
+	deviation float64
+}
+
+// fitSeasonal fits the model on daily totals, days[i] being spent on first
+// plus i days. Days without spending must be included as zero.
+func fitSeasonal(days []float64, first time.Time) seasonalModel {
+	var model seasonalModel
+	if len(days) == 0 {
+		return model
+	}
+
+	var sums, counts [7]float64
+	for i, total := range days {
+		weekday := first.AddDate(0, 0, i).Weekday()
+		sums[weekday] += total
+		counts[weekday]++
+		model.level += total
+	}
+	model.level /= float64(len(days))
+
+	for weekday := range model.factors {
+		model.factors[weekday] = 1
+		if model.level > 0 && counts[weekday] > 0 {
+			model.factors[weekday] = sums[weekday] / counts[weekday] / model.level
+		}
+	}
+
+	if len(days) > 1 {
+		var squares float64
+		for i, total := range days {
+			residual := total - model.predictDay(first.AddDate(0, 0, i))
+			squares += residual * residual
+		}
+		model.deviation = math.Sqrt(squares / float64(len(days)-1))
+	}
+
+	return model
+}
+
+func (m seasonalModel) predictDay(day time.Time) float64 {
+	return m.level * m.factors[day.Weekday()]
+}
+
+// predict returns the spending expected from from until before to and how
+// far it may be off with 80% confidence, days being independent.
+func (m seasonalModel) predict(from time.Time, to time.Time) (float64, float64) {
+	var expected float64
+	var days int
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		expected += m.predictDay(day)
+		days++
+	}
+
+	return expected, z80 * m.deviation * math.Sqrt(float64(days))
+}
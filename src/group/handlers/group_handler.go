@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/group/services"
+)
+
+type groupHandler struct {
+	groupService services.GroupService
+}
+
+func NewGroupHandler(groupService services.GroupService) groupHandler {
+	return groupHandler{groupService: groupService}
+}
+
+// RequireRole lets the request through when the BasicAuth user has at least
+// role in the group :gid and stores the group id for the expense handlers.
+func (h groupHandler) RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		groupID, err := h.groupService.Authorize(c.Param("gid"), c.GetString(gin.AuthUserKey), role)
+		if err != nil {
+			c.Abort()
+			appErr, ok := err.(*helpers.AppError)
+			if ok {
+				c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+			}
+			return
+		}
+
+		c.Set(helpers.GroupIDKey, groupID)
+		c.Next()
+	}
+}
+
+func (h groupHandler) CreateGroup(c *gin.Context) {
+	var groupReq requests.GroupRequest
+	if err := c.ShouldBindJSON(&groupReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	groupResp, err := h.groupService.CreateGroup(c.GetString(gin.AuthUserKey), groupReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, groupResp)
+}
+
+func (h groupHandler) GetGroups(c *gin.Context) {
+	groupsResp, err := h.groupService.GetGroups(c.GetString(gin.AuthUserKey))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, groupsResp)
+}
+
+func (h groupHandler) GetGroupByID(c *gin.Context) {
+	groupResp, err := h.groupService.GetGroupByID(c.Param("gid"), c.GetString(gin.AuthUserKey))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, groupResp)
+}
+
+func (h groupHandler) CreateInvitation(c *gin.Context) {
+	var invitationReq requests.InvitationRequest
+	if err := c.ShouldBindJSON(&invitationReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	invitationResp, err := h.groupService.CreateInvitation(c.Param("gid"), c.GetString(gin.AuthUserKey), invitationReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitationResp)
+}
+
+func (h groupHandler) AcceptInvitation(c *gin.Context) {
+	groupResp, err := h.groupService.AcceptInvitation(c.Param("token"), c.GetString(gin.AuthUserKey))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, groupResp)
+}
+
+func (h groupHandler) UpdateMember(c *gin.Context) {
+	var memberReq requests.MemberRequest
+	if err := c.ShouldBindJSON(&memberReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	groupResp, err := h.groupService.UpdateMember(c.Param("gid"), c.GetString(gin.AuthUserKey), c.Param("username"), memberReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, groupResp)
+}
+
+func (h groupHandler) RemoveMember(c *gin.Context) {
+	if err := h.groupService.RemoveMember(c.Param("gid"), c.GetString(gin.AuthUserKey), c.Param("username")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
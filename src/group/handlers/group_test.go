@@ -0,0 +1,138 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/group/handlers"
+	services "github.com/wytquant/assessment/src/group/services/mock"
+)
+
+func TestRequireRoleHandler(t *testing.T) {
+	t.Run("require role stores the group for members", func(t *testing.T) {
+		//arrange
+		groupService := services.NewGroupServiceMock()
+		groupService.On("Authorize", "7", "ann", models.RoleEditor).Return(uint(7), nil)
+
+		groupHandler := handlers.NewGroupHandler(groupService)
+
+		var groupID uint
+		r := gin.Default()
+		r.POST("/groups/:gid/expenses", gin.BasicAuth(gin.Accounts{"ann": "secret"}), groupHandler.RequireRole(models.RoleEditor), func(c *gin.Context) {
+			groupID = c.GetUint(helpers.GroupIDKey)
+			c.Status(http.StatusCreated)
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/groups/7/expenses", nil)
+		req.SetBasicAuth("ann", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, uint(7), groupID)
+	})
+
+	t.Run("require role fail case because the role is too low", func(t *testing.T) {
+		//arrange
+		groupService := services.NewGroupServiceMock()
+		groupService.On("Authorize", "7", "bob", models.RoleEditor).Return(uint(0), helpers.NewForbiddenError("this requires the editor role in the group"))
+
+		groupHandler := handlers.NewGroupHandler(groupService)
+
+		called := false
+		r := gin.Default()
+		r.POST("/groups/:gid/expenses", gin.BasicAuth(gin.Accounts{"bob": "secret"}), groupHandler.RequireRole(models.RoleEditor), func(c *gin.Context) {
+			called = true
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/groups/7/expenses", nil)
+		req.SetBasicAuth("bob", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.False(t, called)
+	})
+}
+
+func TestCreateGroupHandler(t *testing.T) {
+	t.Run("create group success case", func(t *testing.T) {
+		//arrange
+		groupReq := requests.GroupRequest{Name: "Home", Kind: "household"}
+
+		groupService := services.NewGroupServiceMock()
+		groupService.On("CreateGroup", "ann", groupReq).Return(responses.GroupResponse{ID: 1, Name: "Home"}, nil)
+
+		groupHandler := handlers.NewGroupHandler(groupService)
+
+		r := gin.Default()
+		r.POST("/groups", gin.BasicAuth(gin.Accounts{"ann": "secret"}), groupHandler.CreateGroup)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/groups", bytes.NewBufferString(`{"name": "Home", "kind": "household"}`))
+		req.SetBasicAuth("ann", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		groupService.AssertExpectations(t)
+	})
+
+	t.Run("create group fail bad request because kind is unknown", func(t *testing.T) {
+		//arrange
+		groupHandler := handlers.NewGroupHandler(services.NewGroupServiceMock())
+
+		r := gin.Default()
+		r.POST("/groups", groupHandler.CreateGroup)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/groups", bytes.NewBufferString(`{"name": "Home", "kind": "club"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAcceptInvitationHandler(t *testing.T) {
+	t.Run("accept invitation fail case because the token is unknown", func(t *testing.T) {
+		//arrange
+		groupService := services.NewGroupServiceMock()
+		groupService.On("AcceptInvitation", "abc", "bob").Return(responses.GroupResponse{}, helpers.NewNotFoundError())
+
+		groupHandler := handlers.NewGroupHandler(groupService)
+
+		r := gin.Default()
+		r.POST("/invitations/:token/accept", gin.BasicAuth(gin.Accounts{"bob": "secret"}), groupHandler.AcceptInvitation)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/invitations/abc/accept", nil)
+		req.SetBasicAuth("bob", "secret")
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+)
+
+type groupRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewGroupRepositoryDB(db *gorm.DB) GroupRepository {
+	return groupRepositoryDB{db: db}
+}
+
+// Create stores the group together with its first members.
+func (r groupRepositoryDB) Create(group *models.Group) error {
+	query := r.db
+	if err := query.Create(group).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r groupRepositoryDB) GetByID(id string) (models.Group, error) {
+	var group models.Group
+	query := r.db
+	if err := query.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("id = ?", id).First(&group).Error; err != nil {
+		return models.Group{}, err
+	}
+
+	return group, nil
+}
+
+func (r groupRepositoryDB) GetAllByUsername(username string) ([]models.Group, error) {
+	var groups []models.Group
+	members := r.db.Model(&models.GroupMember{}).Select("group_id").Where("username = ?", username)
+
+	query := r.db
+	if err := query.Preload("Members", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("id IN (?)", members).Order("id").Find(&groups).Error; err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (r groupRepositoryDB) GetMember(groupID string, username string) (models.GroupMember, error) {
+	var member models.GroupMember
+	query := r.db
+	if err := query.Where("group_id = ? AND username = ?", groupID, username).First(&member).Error; err != nil {
+		return models.GroupMember{}, err
+	}
+
+	return member, nil
+}
+
+func (r groupRepositoryDB) UpdateMemberRole(groupID string, username string, role string) error {
+	result := r.db.Model(&models.GroupMember{}).Where("group_id = ? AND username = ?", groupID, username).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r groupRepositoryDB) DeleteMember(groupID string, username string) error {
+	result := r.db.Where("group_id = ? AND username = ?", groupID, username).Delete(&models.GroupMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r groupRepositoryDB) CountOwners(groupID string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.GroupMember{}).Where("group_id = ? AND role = ?", groupID, models.RoleOwner).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r groupRepositoryDB) CreateInvitation(invitation *models.GroupInvitation) error {
+	query := r.db
+	if err := query.Create(invitation).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r groupRepositoryDB) GetInvitationByTokenHash(tokenHash string) (models.GroupInvitation, error) {
+	var invitation models.GroupInvitation
+	query := r.db
+	if err := query.Where("token_hash = ?", tokenHash).First(&invitation).Error; err != nil {
+		return models.GroupInvitation{}, err
+	}
+
+	return invitation, nil
+}
+
+// AcceptInvitation uses up the invitation and adds the member, it reports
+// false when the invitation was used or expired in the meantime.
+func (r groupRepositoryDB) AcceptInvitation(invitation models.GroupInvitation, member *models.GroupMember) (bool, error) {
+	accepted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.GroupInvitation{}).
+			Where("id = ? AND accepted_at IS NULL AND expires_at > ?", invitation.ID, now).
+			Updates(map[string]interface{}{"accepted_by": member.Username, "accepted_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		accepted = true
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return accepted, nil
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type groupRepositoryMock struct {
+	mock.Mock
+}
+
+func NewGroupRepositoryMock() *groupRepositoryMock {
+	return &groupRepositoryMock{}
+}
+
+func (m *groupRepositoryMock) Create(group *models.Group) error {
+	args := m.Called(group)
+	return args.Error(0)
+}
+
+func (m *groupRepositoryMock) GetByID(id string) (models.Group, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Group), args.Error(1)
+}
+
+func (m *groupRepositoryMock) GetAllByUsername(username string) ([]models.Group, error) {
+	args := m.Called(username)
+	return args.Get(0).([]models.Group), args.Error(1)
+}
+
+func (m *groupRepositoryMock) GetMember(groupID string, username string) (models.GroupMember, error) {
+	args := m.Called(groupID, username)
+	return args.Get(0).(models.GroupMember), args.Error(1)
+}
+
+func (m *groupRepositoryMock) UpdateMemberRole(groupID string, username string, role string) error {
+	args := m.Called(groupID, username, role)
+	return args.Error(0)
+}
+
+func (m *groupRepositoryMock) DeleteMember(groupID string, username string) error {
+	args := m.Called(groupID, username)
+	return args.Error(0)
+}
+
+func (m *groupRepositoryMock) CountOwners(groupID string) (int64, error) {
+	args := m.Called(groupID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *groupRepositoryMock) CreateInvitation(invitation *models.GroupInvitation) error {
+	args := m.Called(invitation)
+	return args.Error(0)
+}
+
+func (m *groupRepositoryMock) GetInvitationByTokenHash(tokenHash string) (models.GroupInvitation, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(models.GroupInvitation), args.Error(1)
+}
+
+func (m *groupRepositoryMock) AcceptInvitation(invitation models.GroupInvitation, member *models.GroupMember) (bool, error) {
+	args := m.Called(invitation, member)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import "github.com/wytquant/assessment/models"
+
+type GroupRepository interface {
+	Create(*models.Group) error
+	GetByID(id string) (models.Group, error)
+	GetAllByUsername(username string) ([]models.Group, error)
+	GetMember(groupID string, username string) (models.GroupMember, error)
+	UpdateMemberRole(groupID string, username string, role string) error
+	DeleteMember(groupID string, username string) error
+	CountOwners(groupID string) (int64, error)
+	CreateInvitation(*models.GroupInvitation) error
+	GetInvitationByTokenHash(tokenHash string) (models.GroupInvitation, error)
+	AcceptInvitation(invitation models.GroupInvitation, member *models.GroupMember) (bool, error)
+}
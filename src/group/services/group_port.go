@@ -0,0 +1,19 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type GroupService interface {
+	CreateGroup(username string, groupReq requests.GroupRequest) (responses.GroupResponse, error)
+	GetGroups(username string) ([]responses.GroupResponse, error)
+	GetGroupByID(id string, username string) (responses.GroupResponse, error)
+	CreateInvitation(groupID string, username string, invitationReq requests.InvitationRequest) (responses.InvitationResponse, error)
+	AcceptInvitation(token string, username string) (responses.GroupResponse, error)
+	UpdateMember(groupID string, username string, member string, memberReq requests.MemberRequest) (responses.GroupResponse, error)
+	RemoveMember(groupID string, username string, member string) error
+	// Authorize returns the id of the group when username has at least the
+	// given role in it.
+	Authorize(groupID string, username string, role string) (uint, error)
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/group/repositories"
+)
+
+const defaultInvitationTTL = 7 * 24 * time.Hour
+
+// roleRanks orders the roles, a higher rank includes the lower ones.
+var roleRanks = map[string]int{
+	models.RoleViewer: 1,
+	models.RoleEditor: 2,
+	models.RoleOwner:  3,
+}
+
+type groupService struct {
+	groupRepo repositories.GroupRepository
+}
+
+func NewGroupService(groupRepo repositories.GroupRepository) GroupService {
+	return groupService{groupRepo: groupRepo}
+}
+
+func (s groupService) CreateGroup(username string, groupReq requests.GroupRequest) (responses.GroupResponse, error) {
+	group := models.Group{
+		Name:      groupReq.Name,
+		Kind:      groupReq.Kind,
+		CreatedBy: username,
+		Members:   []models.GroupMember{{Username: username, Role: models.RoleOwner}},
+	}
+
+	if err := s.groupRepo.Create(&group); err != nil {
+		return responses.GroupResponse{}, helpers.NewInternalServerError()
+	}
+
+	return toGroupResponse(group), nil
+}
+
+func (s groupService) GetGroups(username string) ([]responses.GroupResponse, error) {
+	groups, err := s.groupRepo.GetAllByUsername(username)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	groupsResp := []responses.GroupResponse{}
+	for _, group := range groups {
+		groupsResp = append(groupsResp, toGroupResponse(group))
+	}
+
+	return groupsResp, nil
+}
+
+func (s groupService) GetGroupByID(id string, username string) (responses.GroupResponse, error) {
+	if _, err := s.Authorize(id, username, models.RoleViewer); err != nil {
+		return responses.GroupResponse{}, err
+	}
+
+	group, err := s.groupRepo.GetByID(id)
+	if err != nil {
+		return responses.GroupResponse{}, helpers.NewNotFoundError()
+	}
+
+	return toGroupResponse(group), nil
+}
+
+func (s groupService) CreateInvitation(groupID string, username string, invitationReq requests.InvitationRequest) (responses.InvitationResponse, error) {
+	id, err := s.Authorize(groupID, username, models.RoleOwner)
+	if err != nil {
+		return responses.InvitationResponse{}, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return responses.InvitationResponse{}, helpers.NewInternalServerError()
+	}
+
+	ttl := defaultInvitationTTL
+	if invitationReq.ExpiresInHours > 0 {
+		ttl = time.Duration(invitationReq.ExpiresInHours) * time.Hour
+	}
+
+	invitation := models.GroupInvitation{
+		GroupID:   id,
+		TokenHash: hashToken(token),
+		Role:      invitationReq.Role,
+		CreatedBy: username,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.groupRepo.CreateInvitation(&invitation); err != nil {
+		return responses.InvitationResponse{}, helpers.NewInternalServerError()
+	}
+
+	return responses.InvitationResponse{
+		ID:        invitation.ID,
+		GroupID:   invitation.GroupID,
+		Role:      invitation.Role,
+		Token:     token,
+		ExpiresAt: invitation.ExpiresAt,
+	}, nil
+}
+
+func (s groupService) AcceptInvitation(token string, username string) (responses.GroupResponse, error) {
+	invitation, err := s.groupRepo.GetInvitationByTokenHash(hashToken(token))
+	if err != nil || invitation.AcceptedAt != nil || !time.Now().Before(invitation.ExpiresAt) {
+		return responses.GroupResponse{}, helpers.NewNotFoundError()
+	}
+
+	groupID := strconv.FormatUint(uint64(invitation.GroupID), 10)
+	if _, err := s.groupRepo.GetMember(groupID, username); err == nil {
+		return responses.GroupResponse{}, helpers.NewConflictError("you are already a member of this group")
+	}
+
+	member := models.GroupMember{GroupID: invitation.GroupID, Username: username, Role: invitation.Role}
+	accepted, err := s.groupRepo.AcceptInvitation(invitation, &member)
+	if err != nil {
+		return responses.GroupResponse{}, helpers.NewInternalServerError()
+	}
+	if !accepted {
+		return responses.GroupResponse{}, helpers.NewNotFoundError()
+	}
+
+	group, err := s.groupRepo.GetByID(groupID)
+	if err != nil {
+		return responses.GroupResponse{}, helpers.NewInternalServerError()
+	}
+
+	return toGroupResponse(group), nil
+}
+
+func (s groupService) UpdateMember(groupID string, username string, member string, memberReq requests.MemberRequest) (responses.GroupResponse, error) {
+	if _, err := s.Authorize(groupID, username, models.RoleOwner); err != nil {
+		return responses.GroupResponse{}, err
+	}
+
+	current, err := s.groupRepo.GetMember(groupID, member)
+	if err != nil {
+		return responses.GroupResponse{}, helpers.NewNotFoundError()
+	}
+	if current.Role == models.RoleOwner && memberReq.Role != models.RoleOwner {
+		if err := s.keepAnOwner(groupID); err != nil {
+			return responses.GroupResponse{}, err
+		}
+	}
+
+	if err := s.groupRepo.UpdateMemberRole(groupID, member, memberReq.Role); err != nil {
+		return responses.GroupResponse{}, helpers.NewNotFoundError()
+	}
+
+	group, err := s.groupRepo.GetByID(groupID)
+	if err != nil {
+		return responses.GroupResponse{}, helpers.NewInternalServerError()
+	}
+
+	return toGroupResponse(group), nil
+}
+
+// RemoveMember lets owners remove anyone and every member leave the group.
+func (s groupService) RemoveMember(groupID string, username string, member string) error {
+	role := models.RoleOwner
+	if member == username {
+		role = models.RoleViewer
+	}
+	if _, err := s.Authorize(groupID, username, role); err != nil {
+		return err
+	}
+
+	current, err := s.groupRepo.GetMember(groupID, member)
+	if err != nil {
+		return helpers.NewNotFoundError()
+	}
+	if current.Role == models.RoleOwner {
+		if err := s.keepAnOwner(groupID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.groupRepo.DeleteMember(groupID, member); err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	return nil
+}
+
+// Authorize answers not found to non-members so that they cannot tell which
+// groups exist.
+func (s groupService) Authorize(groupID string, username string, role string) (uint, error) {
+	member, err := s.groupRepo.GetMember(groupID, username)
+	if err != nil {
+		return 0, helpers.NewNotFoundError()
+	}
+	if roleRanks[member.Role] < roleRanks[role] {
+		return 0, helpers.NewForbiddenError("this requires the " + role + " role in the group")
+	}
+
+	return member.GroupID, nil
+}
+
+func (s groupService) keepAnOwner(groupID string) error {
+	owners, err := s.groupRepo.CountOwners(groupID)
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+	if owners <= 1 {
+		return helpers.NewConflictError("a group needs at least one owner")
+	}
+
+	return nil
+}
+
+func toGroupResponse(group models.Group) responses.GroupResponse {
+	groupResp := responses.GroupResponse{
+		ID:        group.ID,
+		Name:      group.Name,
+		Kind:      group.Kind,
+		CreatedBy: group.CreatedBy,
+		CreatedAt: group.CreatedAt,
+		Members:   []responses.MemberResponse{},
+	}
+	for _, member := range group.Members {
+		groupResp.Members = append(groupResp.Members, responses.MemberResponse{Username: member.Username, Role: member.Role, JoinedAt: member.JoinedAt})
+	}
+
+	return groupResp
+}
+
+func newToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,195 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/group/repositories"
+	"github.com/wytquant/assessment/src/group/services"
+	"gorm.io/gorm"
+)
+
+func TestCreateGroupService(t *testing.T) {
+	t.Run("create group makes the creator its owner", func(t *testing.T) {
+		//arrange
+		groupRepo := repositories.NewGroupRepositoryMock()
+		groupRepo.On("Create", mock.Anything).Return(nil)
+
+		groupService := services.NewGroupService(groupRepo)
+
+		//act
+		got, err := groupService.CreateGroup("ann", requests.GroupRequest{Name: "Chiang Mai trip", Kind: models.GroupTrip})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "ann", got.CreatedBy)
+		if assert.Len(t, got.Members, 1) {
+			assert.Equal(t, "ann", got.Members[0].Username)
+			assert.Equal(t, models.RoleOwner, got.Members[0].Role)
+		}
+	})
+}
+
+func TestAuthorizeService(t *testing.T) {
+	cases := []struct {
+		name   string
+		member models.GroupMember
+		err    error
+		role   string
+		want   error
+	}{
+		{name: "owner may edit", member: models.GroupMember{GroupID: 1, Role: models.RoleOwner}, role: models.RoleEditor},
+		{name: "viewer may view", member: models.GroupMember{GroupID: 1, Role: models.RoleViewer}, role: models.RoleViewer},
+		{name: "viewer may not edit", member: models.GroupMember{GroupID: 1, Role: models.RoleViewer}, role: models.RoleEditor, want: helpers.NewForbiddenError("this requires the editor role in the group")},
+		{name: "non-members do not see the group", err: gorm.ErrRecordNotFound, role: models.RoleViewer, want: helpers.NewNotFoundError()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			//arrange
+			groupRepo := repositories.NewGroupRepositoryMock()
+			groupRepo.On("GetMember", "1", "ann").Return(tc.member, tc.err)
+
+			groupService := services.NewGroupService(groupRepo)
+
+			//act
+			got, err := groupService.Authorize("1", "ann", tc.role)
+
+			//assert
+			assert.Equal(t, tc.want, err)
+			if tc.want == nil {
+				assert.Equal(t, uint(1), got)
+			}
+		})
+	}
+}
+
+func TestInvitationService(t *testing.T) {
+	t.Run("invitation token lets another user join", func(t *testing.T) {
+		//arrange
+		var stored models.GroupInvitation
+		groupRepo := repositories.NewGroupRepositoryMock()
+		groupRepo.On("GetMember", "1", "ann").Return(models.GroupMember{GroupID: 1, Username: "ann", Role: models.RoleOwner}, nil)
+		groupRepo.On("CreateInvitation", mock.Anything).Run(func(args mock.Arguments) {
+			stored = *args.Get(0).(*models.GroupInvitation)
+		}).Return(nil)
+
+		groupService := services.NewGroupService(groupRepo)
+
+		invitation, err := groupService.CreateInvitation("1", "ann", requests.InvitationRequest{Role: models.RoleEditor})
+		assert.NoError(t, err)
+		assert.NotEqual(t, invitation.Token, stored.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), invitation.ExpiresAt, time.Minute)
+
+		groupRepo.On("GetInvitationByTokenHash", stored.TokenHash).Return(stored, nil)
+		groupRepo.On("GetMember", "1", "bob").Return(models.GroupMember{}, gorm.ErrRecordNotFound)
+		groupRepo.On("AcceptInvitation", stored, &models.GroupMember{GroupID: 1, Username: "bob", Role: models.RoleEditor}).Return(true, nil)
+		groupRepo.On("GetByID", "1").Return(models.Group{ID: 1, Members: []models.GroupMember{{Username: "ann", Role: models.RoleOwner}, {Username: "bob", Role: models.RoleEditor}}}, nil)
+
+		//act
+		got, err := groupService.AcceptInvitation(invitation.Token, "bob")
+
+		//assert
+		assert.NoError(t, err)
+		assert.Len(t, got.Members, 2)
+		groupRepo.AssertExpectations(t)
+	})
+
+	t.Run("create invitation fail case because only owners invite", func(t *testing.T) {
+		//arrange
+		groupRepo := repositories.NewGroupRepositoryMock()
+		groupRepo.On("GetMember", "1", "bob").Return(models.GroupMember{GroupID: 1, Username: "bob", Role: models.RoleEditor}, nil)
+
+		groupService := services.NewGroupService(groupRepo)
+
+		//act
+		_, err := groupService.CreateInvitation("1", "bob", requests.InvitationRequest{Role: models.RoleOwner})
+
+		//assert
+		assert.Equal(t, helpers.NewForbiddenError("this requires the owner role in the group"), err)
+	})
+
+	t.Run("accept invitation fail case because it expired", func(t *testing.T) {
+		//arrange
+		groupRepo := repositories.NewGroupRepositoryMock()
+		groupRepo.On("GetInvitationByTokenHash", mock.Anything).Return(models.GroupInvitation{GroupID: 1, ExpiresAt: time.Now().Add(-time.Hour)}, nil)
+
+		groupService := services.NewGroupService(groupRepo)
+
+		//act
+		_, err := groupService.AcceptInvitation("token", "bob")
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+	})
+
+	t.Run("accept invitation fail case because the user is already a member", func(t *testing.T) {
+		//arrange
+		groupRepo := repositories.NewGroupRepositoryMock()
+		groupRepo.On("GetInvitationByTokenHash", mock.Anything).Return(models.GroupInvitation{GroupID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+		groupRepo.On("GetMember", "1", "bob").Return(models.GroupMember{GroupID: 1, Username: "bob"}, nil)
+
+		groupService := services.NewGroupService(groupRepo)
+
+		//act
+		_, err := groupService.AcceptInvitation("token", "bob")
+
+		//assert
+		assert.Equal(t, helpers.NewConflictError("you are already a member of this group"), err)
+	})
+}
+
+func TestRemoveMemberService(t *testing.T) {
+	t.Run("remove member fail case because the last owner cannot leave", func(t *testing.T) {
+		//arrange
+		groupRepo := repositories.NewGroupRepositoryMock()
+		groupRepo.On("GetMember", "1", "ann").Return(models.GroupMember{GroupID: 1, Username: "ann", Role: models.RoleOwner}, nil)
+		groupRepo.On("CountOwners", "1").Return(int64(1), nil)
+
+		groupService := services.NewGroupService(groupRepo)
+
+		//act
+		err := groupService.RemoveMember("1", "ann", "ann")
+
+		//assert
+		assert.Equal(t, helpers.NewConflictError("a group needs at least one owner"), err)
+	})
+
+	t.Run("remove member success case because members may leave", func(t *testing.T) {
+		//arrange
+		groupRepo := repositories.NewGroupRepositoryMock()
+		groupRepo.On("GetMember", "1", "bob").Return(models.GroupMember{GroupID: 1, Username: "bob", Role: models.RoleViewer}, nil)
+		groupRepo.On("DeleteMember", "1", "bob").Return(nil)
+
+		groupService := services.NewGroupService(groupRepo)
+
+		//act
+		err := groupService.RemoveMember("1", "bob", "bob")
+
+		//assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("remove member fail case because the repository fails", func(t *testing.T) {
+		//arrange
+		groupRepo := repositories.NewGroupRepositoryMock()
+		groupRepo.On("GetMember", "1", "bob").Return(models.GroupMember{GroupID: 1, Username: "bob", Role: models.RoleViewer}, nil)
+		groupRepo.On("DeleteMember", "1", "bob").Return(errors.New("db down"))
+
+		groupService := services.NewGroupService(groupRepo)
+
+		//act
+		err := groupService.RemoveMember("1", "bob", "bob")
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+	})
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type groupServiceMock struct {
+	mock.Mock
+}
+
+func NewGroupServiceMock() *groupServiceMock {
+	return &groupServiceMock{}
+}
+
+func (m *groupServiceMock) CreateGroup(username string, groupReq requests.GroupRequest) (responses.GroupResponse, error) {
+	args := m.Called(username, groupReq)
+	return args.Get(0).(responses.GroupResponse), args.Error(1)
+}
+
+func (m *groupServiceMock) GetGroups(username string) ([]responses.GroupResponse, error) {
+	args := m.Called(username)
+	return args.Get(0).([]responses.GroupResponse), args.Error(1)
+}
+
+func (m *groupServiceMock) GetGroupByID(id string, username string) (responses.GroupResponse, error) {
+	args := m.Called(id, username)
+	return args.Get(0).(responses.GroupResponse), args.Error(1)
+}
+
+func (m *groupServiceMock) CreateInvitation(groupID string, username string, invitationReq requests.InvitationRequest) (responses.InvitationResponse, error) {
+	args := m.Called(groupID, username, invitationReq)
+	return args.Get(0).(responses.InvitationResponse), args.Error(1)
+}
+
+func (m *groupServiceMock) AcceptInvitation(token string, username string) (responses.GroupResponse, error) {
+	args := m.Called(token, username)
+	return args.Get(0).(responses.GroupResponse), args.Error(1)
+}
+
+func (m *groupServiceMock) UpdateMember(groupID string, username string, member string, memberReq requests.MemberRequest) (responses.GroupResponse, error) {
+	args := m.Called(groupID, username, member, memberReq)
+	return args.Get(0).(responses.GroupResponse), args.Error(1)
+}
+
+func (m *groupServiceMock) RemoveMember(groupID string, username string, member string) error {
+	args := m.Called(groupID, username, member)
+	return args.Error(0)
+}
+
+func (m *groupServiceMock) Authorize(groupID string, username string, role string) (uint, error) {
+	args := m.Called(groupID, username, role)
+	return args.Get(0).(uint), args.Error(1)
+}
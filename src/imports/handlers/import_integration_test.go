@@ -0,0 +1,120 @@
+//go:build integration
+// +build integration
+
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/responses"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	expenseServices "github.com/wytquant/assessment/src/expense/services"
+	"github.com/wytquant/assessment/src/imports/handlers"
+	"github.com/wytquant/assessment/src/imports/repositories"
+	"github.com/wytquant/assessment/src/imports/services"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var serverPort = 2566
+
+// maxAmount blocks expenses above 10,000 like a max_amount policy.
+type maxAmount struct{}
+
+func (maxAmount) ProcessExpense(expense *models.Expense) error {
+	if expense.Amount > 10000 {
+		return helpers.NewPolicyViolationError([]string{"max_amount"})
+	}
+	return nil
+}
+
+func decode(t *testing.T, resp *http.Response, v interface{}) {
+	defer resp.Body.Close()
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+}
+
+func TestImportIntegrationTestServer(t *testing.T) {
+	//setup server
+	r := gin.Default()
+	go func(r *gin.Engine) {
+		db, err := gorm.Open(postgres.Open("postgres://root:root@db/go-integration-test-db?sslmode=disable"), &gorm.Config{})
+		if err != nil {
+			log.Fatalln("fail to connect the database")
+		}
+
+		expenseService := expenseServices.NewExpenseService(expenseRepositories.NewExpenseRepositoryDB(db), []expenseServices.ExpenseProcessor{maxAmount{}})
+		service := services.NewImportService(repositories.NewImportRepositoryDB(db), expenseService)
+		handler := handlers.NewImportHandler(service)
+
+		r.POST("/imports", handler.CreateImport)
+		r.GET("/imports/:id", handler.GetImportByID)
+		r.POST("/imports/:id/commit", handler.CommitImport)
+
+		r.Run(fmt.Sprintf(":%d", serverPort))
+
+	}(r)
+
+	for {
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", serverPort), 30*time.Second)
+		if err != nil {
+			log.Println(err)
+		}
+		if conn != nil {
+			conn.Close()
+			break
+		}
+	}
+	t.Run("get import after commit keeps the rows a policy blocked", func(t *testing.T) {
+		//arrange
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		file, _ := form.CreateFormFile("file", "statement.csv")
+		// no expense is created so that the ids the expense tests expect do
+		// not move
+		file.Write([]byte("date,description,amount\n2023-02-01,phone,-32000\n2023-02-02,laptop,-45000\n"))
+		form.Close()
+
+		resp, err := http.Post(fmt.Sprintf("http://localhost:%d/imports", serverPort), form.FormDataContentType(), &body)
+		assert.NoError(t, err)
+		var created responses.ImportResponse
+		decode(t, resp, &created)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, err = http.Post(fmt.Sprintf("http://localhost:%d/imports/%d/commit", serverPort, created.ID), "application/json", nil)
+		assert.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		//act
+		resp, err = http.Get(fmt.Sprintf("http://localhost:%d/imports/%d", serverPort, created.ID))
+		assert.NoError(t, err)
+
+		var got responses.ImportResponse
+		decode(t, resp, &got)
+
+		//assertion
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, models.ImportCommitted, got.Status)
+		assert.Equal(t, 2, got.TotalRows)
+		assert.Equal(t, 0, got.ValidRows)
+		assert.Equal(t, 0, got.ImportedRows)
+		if assert.Equal(t, 2, len(got.Rows)) {
+			for _, row := range got.Rows {
+				assert.Equal(t, "the expense violates the policies max_amount", row.Error)
+				assert.Nil(t, row.ExpenseID)
+			}
+		}
+	})
+}
@@ -0,0 +1,128 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/imports/handlers"
+	services "github.com/wytquant/assessment/src/imports/services/mock"
+)
+
+func multipartBody(t *testing.T, fields map[string]string, filename string, content string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		writer.WriteField(name, value)
+	}
+	if filename != "" {
+		part, err := writer.CreateFormFile("file", filename)
+		assert.NoError(t, err)
+		part.Write([]byte(content))
+	}
+	writer.Close()
+
+	return body, writer.FormDataContentType()
+}
+
+func TestCreateImportHandler(t *testing.T) {
+	t.Run("upload statement success case", func(t *testing.T) {
+		//arrange
+		content := "date,description,amount\n2023-01-15,coffee,-60\n"
+		importReq := requests.ImportRequest{Delimiter: "comma", DateFormat: "YYYY-MM-DD"}
+
+		importService := services.NewImportServiceMock()
+		importService.On("CreateImport", "january.csv", content, importReq).Return(responses.ImportResponse{ID: 1, Status: "pending", TotalRows: 1}, nil)
+
+		importHandler := handlers.NewImportHandler(importService)
+
+		r := gin.Default()
+		r.POST("/imports", importHandler.CreateImport)
+
+		body, contentType := multipartBody(t, map[string]string{"delimiter": "comma", "date_format": "YYYY-MM-DD"}, "january.csv", content)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/imports", body)
+		req.Header.Set("Content-Type", contentType)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		importService.AssertExpectations(t)
+	})
+
+	t.Run("upload statement fail bad request because file is missing", func(t *testing.T) {
+		//arrange
+		importService := services.NewImportServiceMock()
+		importHandler := handlers.NewImportHandler(importService)
+
+		r := gin.Default()
+		r.POST("/imports", importHandler.CreateImport)
+
+		body, contentType := multipartBody(t, map[string]string{"delimiter": "comma"}, "", "")
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/imports", body)
+		req.Header.Set("Content-Type", contentType)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("upload statement fail bad request because delimiter is unknown", func(t *testing.T) {
+		//arrange
+		importService := services.NewImportServiceMock()
+		importHandler := handlers.NewImportHandler(importService)
+
+		r := gin.Default()
+		r.POST("/imports", importHandler.CreateImport)
+
+		body, contentType := multipartBody(t, map[string]string{"delimiter": "colon"}, "january.csv", "date,amount\n")
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/imports", body)
+		req.Header.Set("Content-Type", contentType)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCommitImportHandler(t *testing.T) {
+	t.Run("commit import fail case because it was already committed", func(t *testing.T) {
+		//arrange
+		importService := services.NewImportServiceMock()
+		importService.On("CommitImport", "1").Return(responses.ImportResponse{}, helpers.NewConflictError("import was already committed"))
+
+		importHandler := handlers.NewImportHandler(importService)
+
+		r := gin.Default()
+		r.POST("/imports/:id/commit", importHandler.CommitImport)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/imports/1/commit", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
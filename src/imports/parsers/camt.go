@@ -0,0 +1,155 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type camtParser struct{}
+
+func (p camtParser) Format() string {
+	return "camt053"
+}
+
+func (p camtParser) Detect(filename string, data []byte) bool {
+	start := head(data)
+	return bytes.Contains(start, []byte("camt.053")) || bytes.Contains(start, []byte("BkToCstmrStmt"))
+}
+
+type camtDocument struct {
+	Statements []struct {
+		Account struct {
+			IBAN  string `xml:"Id>IBAN"`
+			Other string `xml:"Id>Othr>Id"`
+		} `xml:"Acct"`
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtEntry struct {
+	Amount struct {
+		Value    string `xml:",chardata"`
+		Currency string `xml:"Ccy,attr"`
+	} `xml:"Amt"`
+	CreditDebit     string `xml:"CdtDbtInd"`
+	BookingDate     string `xml:"BookgDt>Dt"`
+	BookingDateTime string `xml:"BookgDt>DtTm"`
+	ValueDate       string `xml:"ValDt>Dt"`
+	EntryReference  string `xml:"NtryRef"`
+	ServicerRef     string `xml:"AcctSvcrRef"`
+	AdditionalInfo  string `xml:"AddtlNtryInf"`
+	Details         []struct {
+		EndToEndID   string   `xml:"Refs>EndToEndId"`
+		Creditor     string   `xml:"RltdPties>Cdtr>Nm"`
+		Debtor       string   `xml:"RltdPties>Dbtr>Nm"`
+		Unstructured []string `xml:"RmtInf>Ustrd"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+// Parse reads the booked entries of an ISO 20022 camt.053 statement. Debits
+// become negative amounts, as in the other formats.
+func (p camtParser) Parse(data []byte, options Options) (Result, error) {
+	var document camtDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return Result{}, fmt.Errorf("invalid camt.053: %w", err)
+	}
+
+	var result Result
+	for _, statement := range document.Statements {
+		account := statement.Account.IBAN
+		if account == "" {
+			account = statement.Account.Other
+		}
+
+		for _, entry := range statement.Entries {
+			transaction := camtTransaction(len(result.Transactions)+1, entry)
+			if transaction.Currency == "" {
+				transaction.Currency = options.Currency
+			}
+			if transaction.ExternalID != "" {
+				transaction.ExternalID = fmt.Sprintf("camt053:%s:%s", account, transaction.ExternalID)
+			}
+			result.Transactions = append(result.Transactions, transaction)
+		}
+	}
+
+	if len(result.Transactions) == 0 {
+		return Result{}, errors.New("camt.053 file has no entries")
+	}
+	assignExternalIDs("camt053", result.Transactions)
+
+	return result, nil
+}
+
+func camtTransaction(line int, entry camtEntry) Transaction {
+	transaction := Transaction{
+		Line:        line,
+		Currency:    strings.ToUpper(entry.Amount.Currency),
+		Description: strings.TrimSpace(entry.AdditionalInfo),
+		ExternalID:  firstNonEmpty(entry.ServicerRef, entry.EntryReference),
+	}
+
+	if len(entry.Details) > 0 {
+		details := entry.Details[0]
+		counterparty := details.Debtor
+		if entry.CreditDebit == "DBIT" {
+			counterparty = details.Creditor
+		}
+		if description := firstNonEmpty(counterparty, strings.Join(details.Unstructured, " ")); description != "" {
+			transaction.Description = strings.TrimSpace(description)
+		}
+		if transaction.ExternalID == "" && details.EndToEndID != "NOTPROVIDED" {
+			transaction.ExternalID = strings.TrimSpace(details.EndToEndID)
+		}
+	}
+
+	var errs []string
+	if date, err := parseCAMTDate(firstNonEmpty(entry.BookingDate, entry.BookingDateTime, entry.ValueDate)); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		transaction.Date = date
+	}
+	if amount, err := ParseAmount(entry.Amount.Value); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		if entry.CreditDebit == "DBIT" {
+			amount = -amount
+		}
+		transaction.Amount = amount
+	}
+	if transaction.Description == "" {
+		errs = append(errs, "description is empty")
+	}
+	transaction.Error = strings.Join(errs, "; ")
+
+	return transaction
+}
+
+// parseCAMTDate reads the calendar day of an ISO date or date-time.
+func parseCAMTDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 10 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	date, err := time.Parse("2006-01-02", value[:10])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	return date, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return ""
+}
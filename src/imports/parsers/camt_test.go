@@ -0,0 +1,49 @@
+//go:build unit
+
+package parsers_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/src/imports/parsers"
+)
+
+func TestParseCAMT053(t *testing.T) {
+	t.Run("parse booked entries", func(t *testing.T) {
+		//arrange
+		data, err := os.ReadFile("testdata/statement.camt053.xml")
+		assert.NoError(t, err)
+		parser, _ := parsers.ByFormat("camt053")
+
+		//act
+		got, err := parser.Parse(data, parsers.Options{Currency: "THB"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 2, len(got.Transactions)) {
+			assert.Equal(t, time.Date(2023, 1, 16, 0, 0, 0, 0, time.UTC), got.Transactions[0].Date)
+			assert.Equal(t, -89.9, got.Transactions[0].Amount)
+			assert.Equal(t, "DEUTSCHE BAHN", got.Transactions[0].Description)
+			assert.Equal(t, "EUR", got.Transactions[0].Currency)
+			assert.Equal(t, "camt053:DE89370400440532013000:2023011600042", got.Transactions[0].ExternalID)
+			assert.Equal(t, time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), got.Transactions[1].Date)
+			assert.Equal(t, float64(3200), got.Transactions[1].Amount)
+			assert.Equal(t, "ACME GMBH", got.Transactions[1].Description)
+			assert.Equal(t, "camt053:DE89370400440532013000:PAYROLL-2023-01", got.Transactions[1].ExternalID)
+		}
+	})
+
+	t.Run("fail on malformed xml", func(t *testing.T) {
+		//arrange
+		parser, _ := parsers.ByFormat("camt053")
+
+		//act
+		_, err := parser.Parse([]byte("<Document><BkToCstmrStmt>"), parsers.Options{})
+
+		//assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,260 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var delimiterCandidates = []rune{',', ';', '\t', '|'}
+
+// columnAliases are the header names of each column. The amount column is
+// signed, debit and credit columns hold unsigned amounts of money going out
+// and coming in.
+var columnAliases = map[string][]string{
+	"date":        {"date", "transaction date", "posting date", "booking date", "value date", "วันที่", "วันที่ทำรายการ"},
+	"amount":      {"amount", "value", "จำนวนเงิน", "ยอดเงิน"},
+	"debit":       {"debit", "withdrawal", "ถอน"},
+	"credit":      {"credit", "deposit", "ฝาก"},
+	"description": {"description", "details", "memo", "narrative", "payee", "title", "รายการ", "รายละเอียด"},
+	"currency":    {"currency", "ccy", "สกุลเงิน"},
+}
+
+type csvParser struct{}
+
+func (p csvParser) Format() string {
+	return "csv"
+}
+
+// Detect accepts anything, csv is the fallback format.
+func (p csvParser) Detect(filename string, data []byte) bool {
+	return true
+}
+
+func (p csvParser) Parse(data []byte, options Options) (Result, error) {
+	return ParseCSV(data, options)
+}
+
+// ParseCSV reads a bank statement exported as CSV. Problems with single rows
+// are reported on the transaction, problems with the whole file as error.
+func ParseCSV(data []byte, options Options) (Result, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	delimiter := options.Delimiter
+	if delimiter == 0 {
+		delimiter = DetectDelimiter(data)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid csv: %w", err)
+	}
+
+	firstLine := 1
+	var header []string
+	if !options.NoHeader {
+		if len(records) == 0 {
+			return Result{}, errors.New("csv file is empty")
+		}
+		header, records = records[0], records[1:]
+		firstLine = 2
+	}
+	if len(records) == 0 {
+		return Result{}, errors.New("csv file has no rows")
+	}
+
+	columns := map[string]int{}
+	for _, field := range []struct {
+		name     string
+		option   string
+		fallback int
+		required bool
+	}{
+		{"date", options.DateColumn, 1, true},
+		{"amount", options.AmountColumn, 2, false},
+		{"debit", "", 0, false},
+		{"credit", "", 0, false},
+		{"description", options.DescriptionColumn, 3, true},
+		{"currency", options.CurrencyColumn, 0, false},
+	} {
+		index, err := resolveColumn(header, field.name, field.option, field.fallback)
+		if err != nil {
+			return Result{}, err
+		}
+		if index < 0 && field.required {
+			return Result{}, fmt.Errorf("cannot find the %s column, please map it explicitly", field.name)
+		}
+		columns[field.name] = index
+	}
+	if columns["amount"] < 0 && columns["debit"] < 0 && columns["credit"] < 0 {
+		return Result{}, errors.New("cannot find the amount column, please map it explicitly")
+	}
+
+	dateFormat := ToGoLayout(options.DateFormat)
+	if dateFormat == "" {
+		var dates []string
+		for _, record := range records {
+			dates = append(dates, column(record, columns["date"]))
+		}
+		dateFormat = DetectDateLayout(dates)
+	}
+
+	result := Result{Delimiter: delimiter, DateFormat: dateFormat}
+	for i, record := range records {
+		if isBlank(record) {
+			continue
+		}
+
+		transaction := Transaction{
+			Line:        firstLine + i,
+			Description: column(record, columns["description"]),
+			Currency:    strings.ToUpper(column(record, columns["currency"])),
+		}
+		if transaction.Currency == "" {
+			transaction.Currency = options.Currency
+		}
+
+		var errs []string
+		if date, err := time.Parse(dateFormat, column(record, columns["date"])); err != nil || dateFormat == "" {
+			errs = append(errs, fmt.Sprintf("invalid date %q", column(record, columns["date"])))
+		} else {
+			transaction.Date = date
+		}
+		if amount, err := rowAmount(record, columns, options.DebitAmounts); err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			transaction.Amount = amount
+		}
+		if transaction.Description == "" {
+			errs = append(errs, "description is empty")
+		}
+		transaction.Error = strings.Join(errs, "; ")
+
+		result.Transactions = append(result.Transactions, transaction)
+	}
+
+	assignExternalIDs("csv", result.Transactions)
+
+	return result, nil
+}
+
+// DetectDelimiter picks the candidate appearing the same, non zero, number
+// of times on the first lines, preferring the most frequent one.
+func DetectDelimiter(data []byte) rune {
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+		if len(lines) == 5 {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return ','
+	}
+
+	best, bestCount := ',', 0
+	for _, candidate := range delimiterCandidates {
+		count := strings.Count(lines[0], string(candidate))
+		if count <= bestCount {
+			continue
+		}
+
+		consistent := true
+		for _, line := range lines[1:] {
+			if strings.Count(line, string(candidate)) != count {
+				consistent = false
+				break
+			}
+		}
+		if consistent {
+			best, bestCount = candidate, count
+		}
+	}
+
+	return best
+}
+
+func resolveColumn(header []string, name string, option string, fallback int) (int, error) {
+	if header == nil {
+		if option == "" {
+			return fallback - 1, nil
+		}
+		index, err := strconv.Atoi(option)
+		if err != nil || index < 1 {
+			return -1, fmt.Errorf("%s column must be a 1-based column number when the file has no header", name)
+		}
+		return index - 1, nil
+	}
+
+	candidates := columnAliases[name]
+	if option != "" {
+		candidates = []string{option}
+	}
+
+	for _, candidate := range candidates {
+		for i, title := range header {
+			if strings.EqualFold(strings.TrimSpace(title), candidate) {
+				return i, nil
+			}
+		}
+	}
+
+	if option != "" {
+		return -1, fmt.Errorf("column %q not found in header", option)
+	}
+
+	return -1, nil
+}
+
+// rowAmount reads the signed amount of a row, money going out is negative.
+func rowAmount(record []string, columns map[string]int, debitAmounts bool) (float64, error) {
+	if columns["amount"] >= 0 {
+		amount, err := ParseAmount(column(record, columns["amount"]))
+		if debitAmounts {
+			amount = -math.Abs(amount)
+		}
+		return amount, err
+	}
+
+	// statements with both columns may fill the unused one with zero
+	if debit := column(record, columns["debit"]); debit != "" {
+		amount, err := ParseAmount(debit)
+		if err != nil || amount != 0 || columns["credit"] < 0 {
+			return -math.Abs(amount), err
+		}
+	}
+
+	amount, err := ParseAmount(column(record, columns["credit"]))
+	return math.Abs(amount), err
+}
+
+func column(record []string, index int) string {
+	if index < 0 || index >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[index])
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,114 @@
+//go:build unit
+
+package parsers_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/src/imports/parsers"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Run("detect delimiter, date format and decimal comma", func(t *testing.T) {
+		//arrange
+		data, err := os.ReadFile("testdata/statement_semicolon.csv")
+		assert.NoError(t, err)
+
+		//act
+		got, err := parsers.ParseCSV(data, parsers.Options{Currency: "THB"})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, ';', got.Delimiter)
+		if assert.Equal(t, 4, len(got.Transactions)) {
+			assert.Equal(t, time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), got.Transactions[0].Date)
+			assert.Equal(t, -1234.5, got.Transactions[0].Amount)
+			assert.Equal(t, "STARBUCKS SIAM", got.Transactions[0].Description)
+			assert.Equal(t, 2, got.Transactions[0].Line)
+			assert.NotEqual(t, got.Transactions[1].ExternalID, got.Transactions[2].ExternalID)
+			assert.Contains(t, got.Transactions[3].Error, "invalid date")
+		}
+	})
+
+	t.Run("use explicit mapping for files without header", func(t *testing.T) {
+		//arrange
+		data := []byte("coffee,2023-02-01,60\nlunch,2023-02-01,120\n")
+
+		//act
+		got, err := parsers.ParseCSV(data, parsers.Options{
+			NoHeader:          true,
+			DescriptionColumn: "1",
+			DateColumn:        "2",
+			AmountColumn:      "3",
+			DateFormat:        "YYYY-MM-DD",
+			Currency:          "THB",
+		})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 2, len(got.Transactions)) {
+			assert.Equal(t, "lunch", got.Transactions[1].Description)
+			assert.Equal(t, float64(120), got.Transactions[1].Amount)
+			assert.Equal(t, "THB", got.Transactions[1].Currency)
+		}
+	})
+
+	t.Run("unsigned debit and credit columns are signed", func(t *testing.T) {
+		//arrange
+		data := []byte("date,description,withdrawal,deposit\n2023-02-01,coffee,60.00,\n2023-02-25,salary,,45000.00\n2023-02-26,lunch,120.00,0.00\n")
+
+		//act
+		got, err := parsers.ParseCSV(data, parsers.Options{Currency: "THB"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 3, len(got.Transactions)) {
+			assert.Equal(t, float64(-60), got.Transactions[0].Amount)
+			assert.Equal(t, float64(45000), got.Transactions[1].Amount)
+			assert.Equal(t, float64(-120), got.Transactions[2].Amount)
+			assert.Empty(t, got.Transactions[0].Error)
+		}
+	})
+
+	t.Run("debit amounts option makes an unsigned amount column spending", func(t *testing.T) {
+		//arrange
+		data := []byte("coffee,2023-02-01,60\n")
+
+		//act
+		got, err := parsers.ParseCSV(data, parsers.Options{NoHeader: true, DescriptionColumn: "1", DateColumn: "2", AmountColumn: "3", DebitAmounts: true})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 1, len(got.Transactions)) {
+			assert.Equal(t, float64(-60), got.Transactions[0].Amount)
+		}
+	})
+
+	t.Run("fail when the amount column cannot be found", func(t *testing.T) {
+		//act
+		_, err := parsers.ParseCSV([]byte("date,description\n2023-02-01,coffee\n"), parsers.Options{})
+
+		//assert
+		assert.Error(t, err)
+	})
+}
+
+func TestParseAmount(t *testing.T) {
+	cases := map[string]float64{
+		"1,234.50":  1234.5,
+		"1.234,50":  1234.5,
+		"(99.00)":   -99,
+		"-85,00":    -85,
+		"฿ 2,500":   2500,
+		"1.000.000": 1000000,
+	}
+
+	for value, want := range cases {
+		got, err := parsers.ParseAmount(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+}
@@ -0,0 +1,134 @@
+package parsers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ofxTag matches both SGML (OFX 1.x, unclosed leaf elements) and XML
+// (OFX 2.x) markup, leaf values are the text up to the next tag.
+var ofxTag = regexp.MustCompile(`<(/?)([A-Za-z0-9.]+)>([^<]*)`)
+
+type ofxParser struct{}
+
+func (p ofxParser) Format() string {
+	return "ofx"
+}
+
+// Detect recognizes OFX and Quicken's QFX, which is OFX with extra tags.
+func (p ofxParser) Detect(filename string, data []byte) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == ".ofx" || ext == ".qfx" {
+		return true
+	}
+
+	start := bytes.ToUpper(head(data))
+	return bytes.Contains(start, []byte("OFXHEADER")) || bytes.Contains(start, []byte("<OFX>"))
+}
+
+// head returns the start of a file, enough to recognize its format.
+func head(data []byte) []byte {
+	if len(data) > 1024 {
+		return data[:1024]
+	}
+
+	return data
+}
+
+func (p ofxParser) Parse(data []byte, options Options) (Result, error) {
+	var result Result
+	var account, currency string
+	var transaction map[string]string
+
+	for _, match := range ofxTag.FindAllSubmatch(data, -1) {
+		closing := len(match[1]) > 0
+		name := strings.ToUpper(string(match[2]))
+		value := html.UnescapeString(strings.TrimSpace(string(match[3])))
+
+		switch {
+		case name == "STMTTRN" && !closing:
+			transaction = map[string]string{}
+		case name == "STMTTRN" && closing:
+			if transaction != nil {
+				result.Transactions = append(result.Transactions, ofxTransaction(len(result.Transactions)+1, transaction))
+			}
+			transaction = nil
+		case closing:
+		case transaction != nil && value != "":
+			transaction[name] = value
+		case name == "CURDEF":
+			currency = strings.ToUpper(value)
+		case name == "ACCTID":
+			account = value
+		}
+	}
+
+	if len(result.Transactions) == 0 {
+		return Result{}, errors.New("ofx file has no transactions")
+	}
+
+	if currency == "" {
+		currency = options.Currency
+	}
+	for i := range result.Transactions {
+		if result.Transactions[i].Currency == "" {
+			result.Transactions[i].Currency = currency
+		}
+		if result.Transactions[i].ExternalID != "" {
+			result.Transactions[i].ExternalID = fmt.Sprintf("ofx:%s:%s", account, result.Transactions[i].ExternalID)
+		}
+	}
+	assignExternalIDs("ofx", result.Transactions)
+
+	return result, nil
+}
+
+func ofxTransaction(line int, fields map[string]string) Transaction {
+	transaction := Transaction{
+		Line:        line,
+		Description: fields["NAME"],
+		ExternalID:  fields["FITID"],
+		Currency:    strings.ToUpper(fields["CURSYM"]),
+	}
+	if transaction.Description == "" {
+		transaction.Description = fields["MEMO"]
+	}
+
+	var errs []string
+	if date, err := parseOFXDate(fields["DTPOSTED"]); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		transaction.Date = date
+	}
+	if amount, err := ParseAmount(fields["TRNAMT"]); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		transaction.Amount = amount
+	}
+	if transaction.Description == "" {
+		errs = append(errs, "description is empty")
+	}
+	transaction.Error = strings.Join(errs, "; ")
+
+	return transaction
+}
+
+// parseOFXDate reads the calendar day of YYYYMMDD[HHMMSS[.XXX]][[offset:TZ]].
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,68 @@
+//go:build unit
+
+package parsers_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/src/imports/parsers"
+)
+
+func TestParseOFX(t *testing.T) {
+	t.Run("parse sgml statement", func(t *testing.T) {
+		//arrange
+		data, err := os.ReadFile("testdata/statement.ofx")
+		assert.NoError(t, err)
+		parser, _ := parsers.ByFormat("ofx")
+
+		//act
+		got, err := parser.Parse(data, parsers.Options{Currency: "USD"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 2, len(got.Transactions)) {
+			assert.Equal(t, time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), got.Transactions[0].Date)
+			assert.Equal(t, -120.5, got.Transactions[0].Amount)
+			assert.Equal(t, "STARBUCKS SIAM", got.Transactions[0].Description)
+			assert.Equal(t, "THB", got.Transactions[0].Currency)
+			assert.Equal(t, "ofx:1234567890:202301150001", got.Transactions[0].ExternalID)
+			assert.Equal(t, "SALARY JAN", got.Transactions[1].Description)
+			assert.Equal(t, float64(45000), got.Transactions[1].Amount)
+		}
+	})
+
+	t.Run("parse xml qfx statement", func(t *testing.T) {
+		//arrange
+		data, err := os.ReadFile("testdata/statement.qfx")
+		assert.NoError(t, err)
+		parser, _ := parsers.ByFormat("ofx")
+
+		//act
+		got, err := parser.Parse(data, parsers.Options{Currency: "THB"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 2, len(got.Transactions)) {
+			assert.Equal(t, time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC), got.Transactions[0].Date)
+			assert.Equal(t, "USD", got.Transactions[0].Currency)
+			assert.Equal(t, "ofx:4111111111111111:3000-0110-01", got.Transactions[0].ExternalID)
+			assert.Equal(t, "WHOLE FOODS", got.Transactions[1].Description)
+			assert.Equal(t, "EUR", got.Transactions[1].Currency)
+			assert.Empty(t, got.Transactions[1].Error)
+		}
+	})
+
+	t.Run("fail when there are no transactions", func(t *testing.T) {
+		//arrange
+		parser, _ := parsers.ByFormat("ofx")
+
+		//act
+		_, err := parser.Parse([]byte("<OFX></OFX>"), parsers.Options{})
+
+		//assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,191 @@
+package parsers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transaction is a statement line normalized into an expense candidate.
+// ExternalID identifies the line across imports, it comes from the bank when
+// the format carries one and is a fingerprint of the line otherwise.
+type Transaction struct {
+	Line        int
+	Date        time.Time
+	Amount      float64
+	Description string
+	Currency    string
+	ExternalID  string
+	Error       string
+}
+
+// Options configure parsing, formats ignore the options that do not apply
+// to them.
+type Options struct {
+	Delimiter         rune
+	DateFormat        string
+	DateColumn        string
+	AmountColumn      string
+	DescriptionColumn string
+	CurrencyColumn    string
+	NoHeader          bool
+	Currency          string
+	// DebitAmounts tells that the amount column holds unsigned amounts of
+	// money going out.
+	DebitAmounts bool
+}
+
+type Result struct {
+	Delimiter    rune
+	DateFormat   string
+	Transactions []Transaction
+}
+
+type StatementParser interface {
+	Format() string
+	Detect(filename string, data []byte) bool
+	Parse(data []byte, options Options) (Result, error)
+}
+
+// parsers are detected in order, csv accepts anything and has to stay last.
+var parsers = []StatementParser{ofxParser{}, camtParser{}, qifParser{}, csvParser{}}
+
+// Detect returns the parser for the statement based on its name and content.
+func Detect(filename string, data []byte) StatementParser {
+	for _, parser := range parsers {
+		if parser.Detect(filename, data) {
+			return parser
+		}
+	}
+
+	return csvParser{}
+}
+
+func ByFormat(format string) (StatementParser, bool) {
+	for _, parser := range parsers {
+		if parser.Format() == format {
+			return parser, true
+		}
+	}
+
+	return nil, false
+}
+
+// dateLayouts are tried in order, day-first layouts win over month-first ones
+// for ambiguous dates as that is what Thai banks export.
+var dateLayouts = []string{
+	"2006-01-02",
+	"02/01/2006",
+	"2/1/2006",
+	"01/02/2006",
+	"1/2/2006",
+	"02-01-2006",
+	"02.01.2006",
+	"2006/01/02",
+	"20060102",
+	"02 Jan 2006",
+	"2 Jan 2006",
+	"Jan 2, 2006",
+	"02/01/06",
+}
+
+// DetectDateLayout returns the known layout parsing most of the values, a
+// few malformed dates do not prevent detection. It returns an empty string
+// when no layout parses any value.
+func DetectDateLayout(values []string) string {
+	return detectDateLayout(values, dateLayouts)
+}
+
+func detectDateLayout(values []string, layouts []string) string {
+	best, bestCount := "", 0
+	for _, layout := range layouts {
+		count := 0
+		for _, value := range values {
+			if _, err := time.Parse(layout, value); err == nil {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = layout, count
+		}
+	}
+
+	return best
+}
+
+// ToGoLayout converts formats such as DD/MM/YYYY into Go layouts, values that
+// already are Go layouts are returned unchanged.
+func ToGoLayout(format string) string {
+	if format == "" || strings.ContainsAny(format, "0123456789") {
+		return format
+	}
+
+	return strings.NewReplacer("YYYY", "2006", "YY", "06", "MMM", "Jan", "MM", "01", "DD", "02", "M", "1", "D", "2").Replace(format)
+}
+
+// ParseAmount understands thousands separators, decimal commas, currency
+// symbols and negative amounts written as -100 or (100).
+func ParseAmount(value string) (float64, error) {
+	raw := strings.TrimSpace(value)
+	negative := strings.HasPrefix(raw, "-") || strings.HasSuffix(raw, "-") || (strings.HasPrefix(raw, "(") && strings.HasSuffix(raw, ")"))
+
+	digits := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' {
+			return r
+		}
+		return -1
+	}, raw)
+	if digits == "" {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+
+	lastDot, lastComma := strings.LastIndex(digits, "."), strings.LastIndex(digits, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0 && lastComma > lastDot:
+		digits = strings.ReplaceAll(strings.ReplaceAll(digits, ".", ""), ",", ".")
+	case lastDot >= 0 && lastComma >= 0:
+		digits = strings.ReplaceAll(digits, ",", "")
+	case lastComma >= 0 && strings.Count(digits, ",") == 1 && len(digits)-lastComma-1 <= 2:
+		digits = strings.ReplaceAll(digits, ",", ".")
+	case lastComma >= 0:
+		digits = strings.ReplaceAll(digits, ",", "")
+	case strings.Count(digits, ".") > 1:
+		digits = strings.ReplaceAll(digits, ".", "")
+	}
+
+	amount, err := strconv.ParseFloat(digits, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if amount == 0 {
+		return 0, errors.New("amount must not be zero")
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// assignExternalIDs fingerprints transactions without an id of their own.
+// Identical lines within one statement are numbered so that two equal
+// coffees on the same day both survive deduplication.
+func assignExternalIDs(format string, transactions []Transaction) {
+	seen := map[string]int{}
+	for i := range transactions {
+		if transactions[i].ExternalID != "" || transactions[i].Error != "" {
+			continue
+		}
+
+		key := fmt.Sprintf("%s|%.2f|%s|%s", transactions[i].Date.Format("2006-01-02"), transactions[i].Amount,
+			strings.ToLower(strings.Join(strings.Fields(transactions[i].Description), " ")), transactions[i].Currency)
+		seen[key]++
+
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", key, seen[key])))
+		transactions[i].ExternalID = format + ":" + hex.EncodeToString(sum[:16])
+	}
+}
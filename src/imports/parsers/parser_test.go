@@ -0,0 +1,29 @@
+//go:build unit
+
+package parsers_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/src/imports/parsers"
+)
+
+func TestDetect(t *testing.T) {
+	cases := map[string]string{
+		"statement.ofx":           "ofx",
+		"statement.qfx":           "ofx",
+		"statement.qif":           "qif",
+		"statement.camt053.xml":   "camt053",
+		"statement_semicolon.csv": "csv",
+	}
+
+	for filename, want := range cases {
+		data, err := os.ReadFile("testdata/" + filename)
+		assert.NoError(t, err)
+
+		assert.Equal(t, want, parsers.Detect(filename, data).Format(), filename)
+		assert.Equal(t, want, parsers.Detect("upload", data).Format(), filename)
+	}
+}
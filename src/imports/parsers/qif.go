@@ -0,0 +1,135 @@
+package parsers
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// qifDateLayouts prefer month-first dates, the convention of Quicken exports.
+var qifDateLayouts = []string{
+	"01/02/2006",
+	"1/2/2006",
+	"01/02/06",
+	"1/2/06",
+	"2006-01-02",
+	"02/01/2006",
+	"2/1/2006",
+	"02.01.2006",
+	"02/01/06",
+}
+
+type qifParser struct{}
+
+func (p qifParser) Format() string {
+	return "qif"
+}
+
+func (p qifParser) Detect(filename string, data []byte) bool {
+	if strings.ToLower(filepath.Ext(filename)) == ".qif" {
+		return true
+	}
+
+	return bytes.HasPrefix(bytes.TrimSpace(head(data)), []byte("!Type:"))
+}
+
+// Parse reads the bank and credit card sections of a QIF file, whose records
+// end with ^. QIF has no transaction ids, lines are fingerprinted instead.
+func (p qifParser) Parse(data []byte, options Options) (Result, error) {
+	type record struct {
+		line   int
+		fields map[byte]string
+	}
+
+	var records []record
+	current := record{fields: map[byte]string{}}
+	skip := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(text, "!"):
+			section := strings.ToLower(strings.TrimSpace(text))
+			skip = strings.HasPrefix(section, "!type:") && !strings.HasPrefix(section, "!type:bank") &&
+				!strings.HasPrefix(section, "!type:ccard") && !strings.HasPrefix(section, "!type:cash")
+		case text[0] == '^':
+			if !skip && len(current.fields) > 0 {
+				records = append(records, current)
+			}
+			current = record{fields: map[byte]string{}}
+		default:
+			if current.line == 0 {
+				current.line = line
+			}
+			current.fields[text[0]] = strings.TrimSpace(text[1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Result{}, fmt.Errorf("invalid qif: %w", err)
+	}
+	if len(records) == 0 {
+		return Result{}, errors.New("qif file has no transactions")
+	}
+
+	dateFormat := ToGoLayout(options.DateFormat)
+	if dateFormat == "" {
+		var dates []string
+		for _, r := range records {
+			dates = append(dates, normalizeQIFDate(r.fields['D']))
+		}
+		dateFormat = detectDateLayout(dates, qifDateLayouts)
+	}
+
+	result := Result{DateFormat: dateFormat}
+	for _, r := range records {
+		transaction := Transaction{
+			Line:        r.line,
+			Description: r.fields['P'],
+			Currency:    options.Currency,
+		}
+		if transaction.Description == "" {
+			transaction.Description = r.fields['M']
+		}
+
+		amount := r.fields['T']
+		if amount == "" {
+			amount = r.fields['U']
+		}
+
+		var errs []string
+		if date, err := time.Parse(dateFormat, normalizeQIFDate(r.fields['D'])); err != nil || dateFormat == "" {
+			errs = append(errs, fmt.Sprintf("invalid date %q", r.fields['D']))
+		} else {
+			transaction.Date = date
+		}
+		if value, err := ParseAmount(amount); err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			transaction.Amount = value
+		}
+		if transaction.Description == "" {
+			errs = append(errs, "description is empty")
+		}
+		transaction.Error = strings.Join(errs, "; ")
+
+		result.Transactions = append(result.Transactions, transaction)
+	}
+
+	assignExternalIDs("qif", result.Transactions)
+
+	return result, nil
+}
+
+// normalizeQIFDate turns Quicken's 1/15'23 and padded 1/ 5/23 into 1/15/23.
+func normalizeQIFDate(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(strings.TrimSpace(value), "'", "/"), " ", "")
+}
@@ -0,0 +1,63 @@
+//go:build unit
+
+package parsers_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/src/imports/parsers"
+)
+
+func TestParseQIF(t *testing.T) {
+	t.Run("parse bank records", func(t *testing.T) {
+		//arrange
+		data, err := os.ReadFile("testdata/statement.qif")
+		assert.NoError(t, err)
+		parser, _ := parsers.ByFormat("qif")
+
+		//act
+		got, err := parser.Parse(data, parsers.Options{Currency: "THB"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 4, len(got.Transactions)) {
+			assert.Equal(t, time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC), got.Transactions[0].Date)
+			assert.Equal(t, -120.5, got.Transactions[0].Amount)
+			assert.Equal(t, "STARBUCKS SIAM", got.Transactions[0].Description)
+			assert.Equal(t, "THB", got.Transactions[0].Currency)
+			assert.Equal(t, 2, got.Transactions[0].Line)
+			assert.NotEqual(t, got.Transactions[0].ExternalID, got.Transactions[1].ExternalID)
+			assert.Equal(t, "SALARY JAN", got.Transactions[2].Description)
+			assert.Equal(t, float64(45000), got.Transactions[2].Amount)
+			assert.Contains(t, got.Transactions[3].Error, "invalid date")
+		}
+	})
+
+	t.Run("use explicit date format", func(t *testing.T) {
+		//arrange
+		parser, _ := parsers.ByFormat("qif")
+
+		//act
+		got, err := parser.Parse([]byte("!Type:CCard\nD05/02/2023\nT-60\nPcoffee\n^\n"), parsers.Options{DateFormat: "DD/MM/YYYY"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Equal(t, 1, len(got.Transactions)) {
+			assert.Equal(t, time.Date(2023, 2, 5, 0, 0, 0, 0, time.UTC), got.Transactions[0].Date)
+		}
+	})
+
+	t.Run("skip investment sections", func(t *testing.T) {
+		//arrange
+		parser, _ := parsers.ByFormat("qif")
+
+		//act
+		_, err := parser.Parse([]byte("!Type:Invst\nD1/15/2023\nNBuy\nT-100\n^\n"), parsers.Options{})
+
+		//assert
+		assert.Error(t, err)
+	})
+}
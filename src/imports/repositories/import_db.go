@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type importRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewImportRepositoryDB(db *gorm.DB) ImportRepository {
+	return importRepositoryDB{db: db}
+}
+
+func (r importRepositoryDB) Create(importDB *models.Import) error {
+	query := r.db
+	if err := query.Create(importDB).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r importRepositoryDB) GetByID(id string) (models.Import, error) {
+	var importDB models.Import
+	query := r.db
+	if err := query.Preload("Rows", func(db *gorm.DB) *gorm.DB {
+		return db.Order("line")
+	}).Where("id = $1", id).First(&importDB).Error; err != nil {
+		return models.Import{}, err
+	}
+
+	return importDB, nil
+}
+
+func (r importRepositoryDB) GetAll() ([]models.Import, error) {
+	query := r.db
+	var imports []models.Import
+
+	if err := query.Order("id DESC").Find(&imports).Error; err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+func (r importRepositoryDB) GetImportedExternalIDs(externalIDs []string) (map[string]bool, error) {
+	imported := map[string]bool{}
+	if len(externalIDs) == 0 {
+		return imported, nil
+	}
+
+	var found []string
+	if err := r.db.Model(&models.ImportedTransaction{}).Where("external_id IN ?", externalIDs).
+		Pluck("external_id", &found).Error; err != nil {
+		return nil, err
+	}
+
+	for _, externalID := range found {
+		imported[externalID] = true
+	}
+
+	return imported, nil
+}
+
+// MarkCommitting moves a pending import to committing and reports false when
+// it was not pending anymore, so the same import is never committed twice.
+func (r importRepositoryDB) MarkCommitting(id string) (bool, error) {
+	result := r.db.Model(&models.Import{}).Where("id = ? AND status = ?", id, models.ImportPending).
+		Update("status", models.ImportCommitting)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Complete creates expenses[i] for rows[i], nil for the rows that are not
+// imported, and sets the ExpenseID of those rows. In the same transaction it
+// records the expenses on their rows, remembers their external ids and marks
+// the import committed, so a failure leaves nothing half imported.
+func (r importRepositoryDB) Complete(importDB models.Import, rows []models.ImportRow, expenses []*models.Expense) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var created []models.Expense
+		for _, expense := range expenses {
+			if expense != nil {
+				created = append(created, *expense)
+			}
+		}
+		if len(created) > 0 {
+			// the expense repository stores them in this transaction, with
+			// their search text and their events in the outbox
+			if err := expenseRepositories.NewExpenseRepositoryDB(tx).CreateMany(created); err != nil {
+				return err
+			}
+		}
+
+		next := 0
+		for i, expense := range expenses {
+			if expense != nil {
+				*expense = created[next]
+				rows[i].ExpenseID = &expense.ID
+				next++
+			}
+		}
+
+		var transactions []models.ImportedTransaction
+		for _, row := range rows {
+			if err := tx.Model(&row).Select("error", "duplicate", "expense_id").Updates(row).Error; err != nil {
+				return err
+			}
+			if row.ExpenseID != nil {
+				transactions = append(transactions, models.ImportedTransaction{ExternalID: row.ExternalID, ImportID: importDB.ID, ExpenseID: *row.ExpenseID})
+			}
+		}
+
+		if len(transactions) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&transactions).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		return tx.Model(&importDB).Select("status", "valid_rows", "duplicate_rows", "imported_rows", "committed_at").Updates(models.Import{
+			Status:        models.ImportCommitted,
+			ValidRows:     importDB.ValidRows,
+			DuplicateRows: importDB.DuplicateRows,
+			ImportedRows:  importDB.ImportedRows,
+			CommittedAt:   &now,
+		}).Error
+	})
+}
+
+func (r importRepositoryDB) Release(id string) error {
+	return r.db.Model(&models.Import{}).Where("id = ? AND status = ?", id, models.ImportCommitting).
+		Update("status", models.ImportPending).Error
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type importRepositoryMock struct {
+	mock.Mock
+}
+
+func NewImportRepositoryMock() *importRepositoryMock {
+	return &importRepositoryMock{}
+}
+
+func (m *importRepositoryMock) Create(importDB *models.Import) error {
+	args := m.Called(*importDB)
+	return args.Error(0)
+}
+
+func (m *importRepositoryMock) GetByID(id string) (models.Import, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Import), args.Error(1)
+}
+
+func (m *importRepositoryMock) GetAll() ([]models.Import, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Import), args.Error(1)
+}
+
+func (m *importRepositoryMock) GetImportedExternalIDs(externalIDs []string) (map[string]bool, error) {
+	args := m.Called(externalIDs)
+	return args.Get(0).(map[string]bool), args.Error(1)
+}
+
+func (m *importRepositoryMock) MarkCommitting(id string) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *importRepositoryMock) Complete(importDB models.Import, rows []models.ImportRow, expenses []*models.Expense) error {
+	args := m.Called(importDB, rows, expenses)
+	return args.Error(0)
+}
+
+func (m *importRepositoryMock) Release(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import "github.com/wytquant/assessment/models"
+
+type ImportRepository interface {
+	Create(*models.Import) error
+	GetByID(id string) (models.Import, error)
+	GetAll() ([]models.Import, error)
+	GetImportedExternalIDs(externalIDs []string) (map[string]bool, error)
+	MarkCommitting(id string) (bool, error)
+	Complete(importDB models.Import, rows []models.ImportRow, expenses []*models.Expense) error
+	Release(id string) error
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type ImportService interface {
+	CreateImport(filename string, content []byte, importReq requests.ImportRequest) (responses.ImportResponse, error)
+	GetImportByID(id string) (responses.ImportResponse, error)
+	GetImports() ([]responses.ImportResponse, error)
+	CommitImport(id string) (responses.ImportResponse, error)
+}
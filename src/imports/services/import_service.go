@@ -0,0 +1,244 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseServices "github.com/wytquant/assessment/src/expense/services"
+	"github.com/wytquant/assessment/src/imports/parsers"
+	"github.com/wytquant/assessment/src/imports/repositories"
+)
+
+const defaultCurrency = "THB"
+
+var delimiters = map[string]rune{"comma": ',', "semicolon": ';', "tab": '\t', "pipe": '|'}
+
+type importService struct {
+	importRepo     repositories.ImportRepository
+	expenseService expenseServices.ExpenseService
+}
+
+// NewImportService creates the service, every imported expense is prepared
+// by the expense service before it is stored, the same as expenses created
+// one by one.
+func NewImportService(importRepo repositories.ImportRepository, expenseService expenseServices.ExpenseService) ImportService {
+	return importService{importRepo: importRepo, expenseService: expenseService}
+}
+
+// CreateImport parses the uploaded statement, in the requested format or the
+// one detected from the file, and stores it as a pending import. Nothing is written to expenses until the import is committed, so
+// the response doubles as the dry-run preview.
+func (s importService) CreateImport(filename string, content []byte, importReq requests.ImportRequest) (responses.ImportResponse, error) {
+	var importResp responses.ImportResponse
+
+	currency := importReq.Currency
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	parser := parsers.Detect(filename, content)
+	if importReq.Format != "" {
+		parser, _ = parsers.ByFormat(importReq.Format)
+	}
+
+	result, err := parser.Parse(content, parsers.Options{
+		Delimiter:         delimiters[importReq.Delimiter],
+		DateFormat:        importReq.DateFormat,
+		DateColumn:        importReq.DateColumn,
+		AmountColumn:      importReq.AmountColumn,
+		DescriptionColumn: importReq.DescriptionColumn,
+		CurrencyColumn:    importReq.CurrencyColumn,
+		NoHeader:          importReq.NoHeader,
+		DebitAmounts:      importReq.DebitAmounts,
+		Currency:          currency,
+	})
+	if err != nil {
+		return responses.ImportResponse{}, helpers.NewBadRequestError(err.Error())
+	}
+
+	importDB := models.Import{
+		Filename:   filename,
+		Format:     parser.Format(),
+		Delimiter:  delimiterName(result.Delimiter),
+		DateFormat: result.DateFormat,
+		Tags:       importReq.Tags,
+		AccountID:  importReq.AccountID,
+		Status:     models.ImportPending,
+	}
+	for _, transaction := range result.Transactions {
+		row := models.ImportRow{
+			Line:        transaction.Line,
+			Amount:      transaction.Amount,
+			Description: transaction.Description,
+			Currency:    transaction.Currency,
+			ExternalID:  transaction.ExternalID,
+			Error:       transaction.Error,
+		}
+		if !transaction.Date.IsZero() {
+			date := transaction.Date
+			row.Date = &date
+		}
+		importDB.Rows = append(importDB.Rows, row)
+	}
+
+	if err := s.flagDuplicates(&importDB); err != nil {
+		return responses.ImportResponse{}, helpers.NewInternalServerError()
+	}
+
+	if err := s.importRepo.Create(&importDB); err != nil {
+		return responses.ImportResponse{}, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&importResp, &importDB)
+
+	return importResp, nil
+}
+
+func (s importService) GetImportByID(id string) (responses.ImportResponse, error) {
+	var importResp responses.ImportResponse
+
+	importDB, err := s.importRepo.GetByID(id)
+	if err != nil {
+		return responses.ImportResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&importResp, &importDB)
+
+	return importResp, nil
+}
+
+func (s importService) GetImports() ([]responses.ImportResponse, error) {
+	importsResp := []responses.ImportResponse{}
+
+	imports, err := s.importRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&importsResp, &imports)
+
+	return importsResp, nil
+}
+
+// CommitImport creates expenses for every valid row that was not imported
+// before, including by imports committed after this one was previewed, and
+// that no policy blocks.
+func (s importService) CommitImport(id string) (responses.ImportResponse, error) {
+	var importResp responses.ImportResponse
+
+	ok, err := s.importRepo.MarkCommitting(id)
+	if err != nil {
+		return responses.ImportResponse{}, helpers.NewInternalServerError()
+	}
+	if !ok {
+		if _, err := s.importRepo.GetByID(id); err != nil {
+			return responses.ImportResponse{}, helpers.NewNotFoundError()
+		}
+		return responses.ImportResponse{}, helpers.NewConflictError("import was already committed")
+	}
+
+	importDB, err := s.importRepo.GetByID(id)
+	if err != nil {
+		s.importRepo.Release(id)
+		return responses.ImportResponse{}, helpers.NewNotFoundError()
+	}
+
+	if err := s.flagDuplicates(&importDB); err != nil {
+		s.importRepo.Release(id)
+		return responses.ImportResponse{}, helpers.NewInternalServerError()
+	}
+
+	expenses := make([]*models.Expense, len(importDB.Rows))
+	importDB.ImportedRows = 0
+	for i, row := range importDB.Rows {
+		// rows reconciled with an expense entered by hand are not created
+		// again
+		if row.Error != "" || row.Duplicate || row.ExpenseID != nil {
+			continue
+		}
+		expense := models.Expense{
+			Title:     row.Description,
+			Amount:    math.Abs(row.Amount),
+			Note:      fmt.Sprintf("imported from %s line %d", importDB.Filename, row.Line),
+			Tags:      importDB.Tags,
+			Date:      *row.Date,
+			Currency:  row.Currency,
+			AccountID: importDB.AccountID,
+		}
+		// debits are spending, credits such as a salary are income
+		if row.Amount > 0 {
+			expense.Type = models.TypeIncome
+		}
+		if _, err := s.expenseService.PrepareExpense(&expense); err != nil {
+			// a row a policy blocks is not imported, the others are
+			appErr, ok := err.(*helpers.AppError)
+			if !ok || appErr.StatusCode >= http.StatusInternalServerError {
+				s.importRepo.Release(id)
+				return responses.ImportResponse{}, err
+			}
+			importDB.Rows[i].Error = appErr.Message
+			importDB.ValidRows--
+			continue
+		}
+		expenses[i] = &expense
+		importDB.ImportedRows++
+	}
+
+	if err := s.importRepo.Complete(importDB, importDB.Rows, expenses); err != nil {
+		s.importRepo.Release(id)
+		return responses.ImportResponse{}, helpers.NewInternalServerError()
+	}
+
+	importDB.Status = models.ImportCommitted
+	copier.Copy(&importResp, &importDB)
+
+	return importResp, nil
+}
+
+func (s importService) flagDuplicates(importDB *models.Import) error {
+	var externalIDs []string
+	for _, row := range importDB.Rows {
+		if row.ExternalID != "" {
+			externalIDs = append(externalIDs, row.ExternalID)
+		}
+	}
+
+	imported, err := s.importRepo.GetImportedExternalIDs(externalIDs)
+	if err != nil {
+		return err
+	}
+
+	importDB.TotalRows, importDB.ValidRows, importDB.DuplicateRows = len(importDB.Rows), 0, 0
+	for i := range importDB.Rows {
+		importDB.Rows[i].Duplicate = imported[importDB.Rows[i].ExternalID]
+		switch {
+		case importDB.Rows[i].Error != "":
+		case importDB.Rows[i].Duplicate:
+			importDB.DuplicateRows++
+		default:
+			importDB.ValidRows++
+		}
+	}
+
+	return nil
+}
+
+func delimiterName(delimiter rune) string {
+	if delimiter == 0 {
+		return ""
+	}
+
+	for name, r := range delimiters {
+		if r == delimiter {
+			return name
+		}
+	}
+
+	return string(delimiter)
+}
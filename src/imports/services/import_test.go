@@ -0,0 +1,304 @@
+//go:build unit
+
+package services_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	expenseServices "github.com/wytquant/assessment/src/expense/services/mock"
+	"github.com/wytquant/assessment/src/imports/parsers"
+	"github.com/wytquant/assessment/src/imports/repositories"
+	"github.com/wytquant/assessment/src/imports/services"
+)
+
+const statement = `date,description,amount
+2023-01-15,STARBUCKS SIAM,-120.00
+2023-01-16,GRAB TAXI,-89.50
+not a date,BROKEN,-1.00
+`
+
+func TestCreateImportService(t *testing.T) {
+	t.Run("preview flags rows already imported", func(t *testing.T) {
+		//arrange
+		parsed, err := parsers.ParseCSV([]byte(statement), parsers.Options{Currency: "THB"})
+		assert.NoError(t, err)
+
+		importRepo := repositories.NewImportRepositoryMock()
+		importRepo.On("GetImportedExternalIDs", []string{parsed.Transactions[0].ExternalID, parsed.Transactions[1].ExternalID}).
+			Return(map[string]bool{parsed.Transactions[0].ExternalID: true}, nil)
+		importRepo.On("Create", mock.Anything).Return(nil)
+
+		importService := services.NewImportService(importRepo, expenseServices.NewExpenseServiceMock())
+
+		//act
+		got, err := importService.CreateImport("january.csv", []byte(statement), requests.ImportRequest{Tags: []string{"imported"}})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.ImportPending, got.Status)
+		assert.Equal(t, "comma", got.Delimiter)
+		assert.Equal(t, "2006-01-02", got.DateFormat)
+		assert.Equal(t, 3, got.TotalRows)
+		assert.Equal(t, 1, got.ValidRows)
+		assert.Equal(t, 1, got.DuplicateRows)
+		if assert.Equal(t, 3, len(got.Rows)) {
+			assert.True(t, got.Rows[0].Duplicate)
+			assert.Equal(t, "THB", got.Rows[1].Currency)
+			assert.NotEmpty(t, got.Rows[2].Error)
+		}
+	})
+
+	t.Run("preview uses the requested format", func(t *testing.T) {
+		//arrange
+		qif := "!Type:Bank\nD1/15'23\nT-120.00\nPSTARBUCKS SIAM\n^\n"
+
+		importRepo := repositories.NewImportRepositoryMock()
+		importRepo.On("GetImportedExternalIDs", mock.Anything).Return(map[string]bool{}, nil)
+		importRepo.On("Create", mock.Anything).Return(nil)
+
+		importService := services.NewImportService(importRepo, expenseServices.NewExpenseServiceMock())
+
+		//act
+		got, err := importService.CreateImport("upload.txt", []byte(qif), requests.ImportRequest{Format: "qif"})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "qif", got.Format)
+		assert.Empty(t, got.Delimiter)
+		assert.Equal(t, 1, got.ValidRows)
+	})
+
+	t.Run("create import fail bad request because file is empty", func(t *testing.T) {
+		//arrange
+		importService := services.NewImportService(repositories.NewImportRepositoryMock(), expenseServices.NewExpenseServiceMock())
+
+		//act
+		_, err := importService.CreateImport("empty.csv", []byte(""), requests.ImportRequest{})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		}
+	})
+}
+
+func TestCommitImportService(t *testing.T) {
+	date := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+	pendingImport := func() models.Import {
+		return models.Import{
+			ID:       1,
+			Filename: "january.csv",
+			Status:   models.ImportCommitting,
+			Rows: []models.ImportRow{
+				{ID: 1, Line: 2, Date: &date, Amount: -120, Description: "STARBUCKS SIAM", Currency: "THB", ExternalID: "csv:a"},
+				{ID: 2, Line: 3, Date: &date, Amount: -89.5, Description: "GRAB TAXI", Currency: "THB", ExternalID: "csv:b"},
+				{ID: 3, Line: 4, Error: "invalid date"},
+			},
+		}
+	}
+
+	t.Run("commit creates expenses for valid rows not imported yet", func(t *testing.T) {
+		//arrange
+		importRepo := repositories.NewImportRepositoryMock()
+		importRepo.On("MarkCommitting", "1").Return(true, nil)
+		importRepo.On("GetByID", "1").Return(pendingImport(), nil)
+		importRepo.On("GetImportedExternalIDs", []string{"csv:a", "csv:b"}).Return(map[string]bool{"csv:b": true}, nil)
+		importRepo.On("Complete", mock.Anything, mock.Anything, []*models.Expense{{
+			Title:    "STARBUCKS SIAM",
+			Amount:   120,
+			Note:     "imported from january.csv line 2",
+			Date:     date,
+			Currency: "THB",
+		}, nil, nil}).Run(func(args mock.Arguments) {
+			expenseID := uint(42)
+			args.Get(1).([]models.ImportRow)[0].ExpenseID = &expenseID
+		}).Return(nil)
+
+		expenseService := expenseServices.NewExpenseServiceMock()
+		expenseService.On("PrepareExpense", mock.Anything).Return(nil, nil)
+
+		importService := services.NewImportService(importRepo, expenseService)
+
+		//act
+		got, err := importService.CommitImport("1")
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.ImportCommitted, got.Status)
+		assert.Equal(t, 1, got.ImportedRows)
+		assert.Equal(t, 1, got.DuplicateRows)
+		if assert.NotNil(t, got.Rows[0].ExpenseID) {
+			assert.Equal(t, uint(42), *got.Rows[0].ExpenseID)
+		}
+		assert.Nil(t, got.Rows[1].ExpenseID)
+		importRepo.AssertExpectations(t)
+	})
+
+	t.Run("commit creates income for credit lines", func(t *testing.T) {
+		//arrange
+		importDB := models.Import{
+			ID:       1,
+			Filename: "january.csv",
+			Status:   models.ImportPending,
+			Rows: []models.ImportRow{
+				{ID: 1, Line: 2, Date: &date, Description: "SALARY", Amount: 45000, Currency: "THB"},
+			},
+		}
+
+		importRepo := repositories.NewImportRepositoryMock()
+		importRepo.On("MarkCommitting", "1").Return(true, nil)
+		importRepo.On("GetByID", "1").Return(importDB, nil)
+		importRepo.On("GetImportedExternalIDs", []string(nil)).Return(map[string]bool{}, nil)
+		importRepo.On("Complete", mock.Anything, mock.Anything, []*models.Expense{{
+			Type:     models.TypeIncome,
+			Title:    "SALARY",
+			Amount:   45000,
+			Note:     "imported from january.csv line 2",
+			Date:     date,
+			Currency: "THB",
+		}}).Return(nil)
+
+		expenseService := expenseServices.NewExpenseServiceMock()
+		expenseService.On("PrepareExpense", mock.Anything).Return(nil, nil)
+
+		importService := services.NewImportService(importRepo, expenseService)
+
+		//act
+		got, err := importService.CommitImport("1")
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.ImportedRows)
+		importRepo.AssertExpectations(t)
+	})
+
+	t.Run("commit fail case because import was already committed", func(t *testing.T) {
+		//arrange
+		importRepo := repositories.NewImportRepositoryMock()
+		importRepo.On("MarkCommitting", "1").Return(false, nil)
+		importRepo.On("GetByID", "1").Return(pendingImport(), nil)
+
+		importService := services.NewImportService(importRepo, expenseServices.NewExpenseServiceMock())
+
+		//act
+		_, err := importService.CommitImport("1")
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+		}
+	})
+
+	t.Run("commit releases the import when it cannot be completed", func(t *testing.T) {
+		//arrange
+		importRepo := repositories.NewImportRepositoryMock()
+		importRepo.On("MarkCommitting", "1").Return(true, nil)
+		importRepo.On("GetByID", "1").Return(pendingImport(), nil)
+		importRepo.On("GetImportedExternalIDs", mock.Anything).Return(map[string]bool{}, nil)
+		importRepo.On("Complete", mock.Anything, mock.Anything, mock.Anything).Return(helpers.NewInternalServerError())
+		importRepo.On("Release", "1").Return(nil)
+
+		expenseService := expenseServices.NewExpenseServiceMock()
+		expenseService.On("PrepareExpense", mock.Anything).Return(nil, nil)
+
+		importService := services.NewImportService(importRepo, expenseService)
+
+		//act
+		_, err := importService.CommitImport("1")
+
+		//assert
+		assert.EqualError(t, err, helpers.NewInternalServerError().Error())
+		importRepo.AssertCalled(t, "Release", "1")
+	})
+
+	t.Run("commit prepares every new expense like one created by hand", func(t *testing.T) {
+		//arrange
+		importRepo := repositories.NewImportRepositoryMock()
+		importRepo.On("MarkCommitting", "1").Return(true, nil)
+		importRepo.On("GetByID", "1").Return(pendingImport(), nil)
+		importRepo.On("GetImportedExternalIDs", mock.Anything).Return(map[string]bool{}, nil)
+		importRepo.On("Complete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		expenseService := expenseServices.NewExpenseServiceMock()
+		expenseService.On("PrepareExpense", mock.Anything).Return(nil, nil)
+
+		importService := services.NewImportService(importRepo, expenseService)
+
+		//act
+		_, err := importService.CommitImport("1")
+
+		//assert
+		assert.NoError(t, err)
+		expenseService.AssertNumberOfCalls(t, "PrepareExpense", 2)
+		importRepo.AssertExpectations(t)
+	})
+
+	t.Run("commit does not import rows a policy blocks", func(t *testing.T) {
+		//arrange
+		importRepo := repositories.NewImportRepositoryMock()
+		importRepo.On("MarkCommitting", "1").Return(true, nil)
+		importRepo.On("GetByID", "1").Return(pendingImport(), nil)
+		importRepo.On("GetImportedExternalIDs", mock.Anything).Return(map[string]bool{}, nil)
+		importRepo.On("Complete", mock.Anything, mock.Anything, mock.MatchedBy(func(expenses []*models.Expense) bool {
+			return expenses[0] == nil && expenses[1].Title == "GRAB TAXI" && expenses[2] == nil
+		})).Return(nil)
+
+		expenseService := expenseServices.NewExpenseServiceMock()
+		expenseService.On("PrepareExpense", mock.MatchedBy(func(expense models.Expense) bool {
+			return expense.Title == "STARBUCKS SIAM"
+		})).Return(nil, helpers.NewPolicyViolationError([]string{"no-coffee"}))
+		expenseService.On("PrepareExpense", mock.Anything).Return(nil, nil)
+
+		importService := services.NewImportService(importRepo, expenseService)
+
+		//act
+		got, err := importService.CommitImport("1")
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.ImportedRows)
+		assert.Equal(t, 1, got.ValidRows)
+		assert.Equal(t, "the expense violates the policies no-coffee", got.Rows[0].Error)
+		importRepo.AssertExpectations(t)
+	})
+
+	t.Run("commit skips rows confirmed by a reconciliation", func(t *testing.T) {
+		//arrange
+		reconciled := pendingImport()
+		expenseID := uint(7)
+		reconciled.Rows[0].ExpenseID = &expenseID
+
+		importRepo := repositories.NewImportRepositoryMock()
+		importRepo.On("MarkCommitting", "1").Return(true, nil)
+		importRepo.On("GetByID", "1").Return(reconciled, nil)
+		importRepo.On("GetImportedExternalIDs", mock.Anything).Return(map[string]bool{}, nil)
+		importRepo.On("Complete", mock.Anything, mock.Anything, mock.MatchedBy(func(expenses []*models.Expense) bool {
+			return expenses[0] == nil && expenses[1].Title == "GRAB TAXI" && expenses[2] == nil
+		})).Return(nil)
+
+		expenseService := expenseServices.NewExpenseServiceMock()
+		expenseService.On("PrepareExpense", mock.Anything).Return(nil, nil)
+
+		importService := services.NewImportService(importRepo, expenseService)
+
+		//act
+		got, err := importService.CommitImport("1")
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.ImportedRows)
+		if assert.NotNil(t, got.Rows[0].ExpenseID) {
+			assert.Equal(t, uint(7), *got.Rows[0].ExpenseID)
+		}
+		importRepo.AssertExpectations(t)
+	})
+}
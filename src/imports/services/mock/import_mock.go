@@ -0,0 +1,35 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type importServiceMock struct {
+	mock.Mock
+}
+
+func NewImportServiceMock() *importServiceMock {
+	return &importServiceMock{}
+}
+
+func (m *importServiceMock) CreateImport(filename string, content []byte, importReq requests.ImportRequest) (responses.ImportResponse, error) {
+	args := m.Called(filename, string(content), importReq)
+	return args.Get(0).(responses.ImportResponse), args.Error(1)
+}
+
+func (m *importServiceMock) GetImportByID(id string) (responses.ImportResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.ImportResponse), args.Error(1)
+}
+
+func (m *importServiceMock) GetImports() ([]responses.ImportResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.ImportResponse), args.Error(1)
+}
+
+func (m *importServiceMock) CommitImport(id string) (responses.ImportResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.ImportResponse), args.Error(1)
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/insight/services"
+)
+
+type insightHandler struct {
+	insightService services.InsightService
+}
+
+func NewInsightHandler(insightService services.InsightService) insightHandler {
+	return insightHandler{insightService: insightService}
+}
+
+func (h insightHandler) GetInsights(c *gin.Context) {
+	var query requests.InsightQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	insightsResp, err := h.insightService.GetInsights(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, insightsResp)
+}
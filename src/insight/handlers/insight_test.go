@@ -0,0 +1,65 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/insight/handlers"
+	services "github.com/wytquant/assessment/src/insight/services/mock"
+)
+
+func TestGetInsightsHandler(t *testing.T) {
+	t.Run("get insights success case", func(t *testing.T) {
+		//arrange
+		insightService := services.NewInsightServiceMock()
+		insightService.On("GetInsights", requests.InsightQuery{Month: "2023-04"}).Return(responses.InsightsResponse{
+			Month:     "2023-04",
+			Insights:  []responses.Insight{},
+			Baselines: []responses.Baseline{},
+		}, nil)
+
+		insightHandler := handlers.NewInsightHandler(insightService)
+
+		r := gin.Default()
+		r.GET("/insights", insightHandler.GetInsights)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/insights?month=2023-04", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"month": "2023-04", "insights": [], "baselines": []}`, w.Body.String())
+	})
+
+	t.Run("get insights fail bad request because month is invalid", func(t *testing.T) {
+		//arrange
+		insightService := services.NewInsightServiceMock()
+		insightService.On("GetInsights", requests.InsightQuery{Month: "April"}).Return(responses.InsightsResponse{}, helpers.NewBadRequestError("month must be formatted as YYYY-MM"))
+
+		insightHandler := handlers.NewInsightHandler(insightService)
+
+		r := gin.Default()
+		r.GET("/insights", insightHandler.GetInsights)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/insights?month=April", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"message": "month must be formatted as YYYY-MM"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type insightRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewInsightRepositoryDB(db *gorm.DB) InsightRepository {
+	return insightRepositoryDB{db: db}
+}
+
+func (r insightRepositoryDB) GetMonthlyTotals(from time.Time, to time.Time) ([]MonthlyTotal, error) {
+	var totals []MonthlyTotal
+
+	err := r.db.Raw(`SELECT t.tag, expenses.currency, date_trunc('month', expenses.date)::date AS month, SUM(`+models.SpentAmount+`) AS total
+			FROM expenses
+			CROSS JOIN LATERAL unnest(expenses.tags) AS t(tag)
+			WHERE expenses.group_id IS NULL AND expenses.deleted_at IS NULL AND expenses.date >= @from AND expenses.date < @to
+				AND `+models.Spending+`
+			GROUP BY 1, 2, 3
+		UNION ALL
+		SELECT '' AS tag, expenses.currency, date_trunc('month', expenses.date)::date AS month, SUM(`+models.SpentAmount+`) AS total
+			FROM expenses
+			WHERE expenses.group_id IS NULL AND expenses.deleted_at IS NULL AND expenses.date >= @from AND expenses.date < @to
+				AND `+models.Spending+`
+			GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3`, map[string]interface{}{"from": from, "to": to}).Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+func (r insightRepositoryDB) GetAmounts(tag string, currency string, from time.Time, to time.Time, exceptID uint) ([]float64, error) {
+	var amounts []float64
+
+	err := r.db.Model(&models.Expense{}).
+		Where("group_id IS NULL AND type = ? AND id <> ? AND ? = ANY(tags) AND currency = ?", models.TypeExpense, exceptID, tag, currency).
+		Where("date >= ? AND date < ?", from, to).
+		Pluck("amount", &amounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return amounts, nil
+}
+
+func (r insightRepositoryDB) SaveAnomaly(anomaly *models.Anomaly) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(anomaly).Error
+}
+
+func (r insightRepositoryDB) GetAnomalies(from time.Time, to time.Time) ([]models.Anomaly, error) {
+	var anomalies []models.Anomaly
+
+	// anomalies of expenses merged or deleted since are left out
+	err := r.db.Where("date >= ? AND date < ?", from, to).
+		Where("EXISTS (SELECT 1 FROM expenses WHERE expenses.id = anomalies.expense_id AND expenses.deleted_at IS NULL)").
+		Order("ratio DESC, id").
+		Find(&anomalies).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return anomalies, nil
+}
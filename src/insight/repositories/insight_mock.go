@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type insightRepositoryMock struct {
+	mock.Mock
+}
+
+func NewInsightRepositoryMock() *insightRepositoryMock {
+	return &insightRepositoryMock{}
+}
+
+func (m *insightRepositoryMock) GetMonthlyTotals(from time.Time, to time.Time) ([]MonthlyTotal, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]MonthlyTotal), args.Error(1)
+}
+
+func (m *insightRepositoryMock) GetAmounts(tag string, currency string, from time.Time, to time.Time, exceptID uint) ([]float64, error) {
+	args := m.Called(tag, currency, from, to, exceptID)
+	return args.Get(0).([]float64), args.Error(1)
+}
+
+func (m *insightRepositoryMock) SaveAnomaly(anomaly *models.Anomaly) error {
+	args := m.Called(anomaly)
+	return args.Error(0)
+}
+
+func (m *insightRepositoryMock) GetAnomalies(from time.Time, to time.Time) ([]models.Anomaly, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]models.Anomaly), args.Error(1)
+}
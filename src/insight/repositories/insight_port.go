@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+// MonthlyTotal is the spending of a tag in a month, an empty Tag stands for
+// all spending.
+type MonthlyTotal struct {
+	Tag      string
+	Currency string
+	Month    time.Time
+	Total    float64
+}
+
+// InsightRepository works on the personal ledger.
+type InsightRepository interface {
+	// GetMonthlyTotals sums the expenses dated from from until before to by
+	// tag, currency and month.
+	GetMonthlyTotals(from time.Time, to time.Time) ([]MonthlyTotal, error)
+	// GetAmounts returns the amounts of the other expenses of the tag and
+	// currency dated from from until before to.
+	GetAmounts(tag string, currency string, from time.Time, to time.Time, exceptID uint) ([]float64, error)
+	SaveAnomaly(anomaly *models.Anomaly) error
+	GetAnomalies(from time.Time, to time.Time) ([]models.Anomaly, error)
+}
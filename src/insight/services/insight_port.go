@@ -0,0 +1,15 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// InsightService compares spending of the personal ledger with its usual
+// level.
+type InsightService interface {
+	GetInsights(query requests.InsightQuery) (responses.InsightsResponse, error)
+	ExpenseCreated(expense models.Expense)
+	ExpenseUpdated(expense models.Expense)
+}
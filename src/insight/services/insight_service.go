@@ -0,0 +1,257 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/insight/repositories"
+)
+
+const (
+	KindMonthToDate = "month_to_date"
+	KindExpense     = "expense"
+
+	// baselineMonths full months before the month make the monthly baseline,
+	// a tag needs minBaselineMonths of them since it was first used.
+	baselineMonths    = 6
+	minBaselineMonths = 3
+	// a month is flagged at monthRatio times the median month when it is also
+	// monthDeviations standard deviations above the mean
+	monthRatio      = 1.5
+	monthDeviations = 2.0
+
+	// an expense is compared with the expenses of its tags in the
+	// expenseWindowDays before it, of which there must be minSamples
+	expenseWindowDays = 180
+	minSamples        = 5
+	expenseRatio      = 2.0
+	expenseDeviations = 3.0
+)
+
+type insightService struct {
+	insightRepo repositories.InsightRepository
+	now         func() time.Time
+}
+
+func NewInsightService(insightRepo repositories.InsightRepository) InsightService {
+	return insightService{insightRepo: insightRepo, now: time.Now}
+}
+
+// GetInsights compares the spending of every tag in the month, so far for
+// the current month, with the months before and lists the expenses of the
+// month that were flagged when they were created.
+func (s insightService) GetInsights(query requests.InsightQuery) (responses.InsightsResponse, error) {
+	month := s.now()
+	if query.Month != "" {
+		var err error
+		if month, err = time.Parse("2006-01", query.Month); err != nil {
+			return responses.InsightsResponse{}, helpers.NewBadRequestError("month must be formatted as YYYY-MM")
+		}
+	}
+	start, next := helpers.PeriodBounds(models.PeriodMonthly, month)
+
+	totals, err := s.insightRepo.GetMonthlyTotals(start.AddDate(0, -baselineMonths, 0), next)
+	if err != nil {
+		return responses.InsightsResponse{}, helpers.NewInternalServerError()
+	}
+	anomalies, err := s.insightRepo.GetAnomalies(start, next)
+	if err != nil {
+		return responses.InsightsResponse{}, helpers.NewInternalServerError()
+	}
+
+	insightsResp := responses.InsightsResponse{
+		Month:     start.Format("2006-01"),
+		Insights:  []responses.Insight{},
+		Baselines: []responses.Baseline{},
+	}
+
+	for _, series := range monthlySeries(totals, start) {
+		baseline, ok := series.baseline()
+		if !ok {
+			continue
+		}
+		insightsResp.Baselines = append(insightsResp.Baselines, baseline)
+
+		if insight, ok := monthInsight(baseline, series.history); ok {
+			insightsResp.Insights = append(insightsResp.Insights, insight)
+		}
+	}
+
+	for _, anomaly := range anomalies {
+		expenseID := anomaly.ExpenseID
+		insightsResp.Insights = append(insightsResp.Insights, responses.Insight{
+			Kind:      KindExpense,
+			Tag:       anomaly.Tag,
+			Currency:  anomaly.Currency,
+			Amount:    anomaly.Amount,
+			Usual:     anomaly.Median,
+			Ratio:     anomaly.Ratio,
+			ExpenseID: &expenseID,
+			Message:   anomaly.Message,
+		})
+	}
+
+	sort.SliceStable(insightsResp.Insights, func(i, j int) bool {
+		return insightsResp.Insights[i].Ratio > insightsResp.Insights[j].Ratio
+	})
+
+	return insightsResp, nil
+}
+
+type series struct {
+	tag      string
+	currency string
+	// history holds the totals of the baseline months since the tag was
+	// first used, oldest first
+	history []float64
+	current float64
+}
+
+// monthlySeries splits the totals by tag and currency, months without
+// spending count as zero once a tag was used.
+func monthlySeries(totals []repositories.MonthlyTotal, start time.Time) []*series {
+	var result []*series
+	byKey := map[string]*series{}
+	byMonth := map[string]map[int]float64{}
+
+	for _, total := range totals {
+		key := total.Tag + "\x00" + total.Currency
+		if byKey[key] == nil {
+			byKey[key] = &series{tag: total.Tag, currency: total.Currency}
+			byMonth[key] = map[int]float64{}
+			result = append(result, byKey[key])
+		}
+		// months before start count from 1, start itself is 0
+		offset := (start.Year()-total.Month.Year())*12 + int(start.Month()) - int(total.Month.Month())
+		byMonth[key][offset] = total.Total
+	}
+
+	for key, s := range byKey {
+		first := 0
+		for offset := range byMonth[key] {
+			if offset > first {
+				first = offset
+			}
+		}
+		for offset := first; offset >= 1; offset-- {
+			s.history = append(s.history, byMonth[key][offset])
+		}
+		s.current = byMonth[key][0]
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].tag != result[j].tag {
+			return result[i].tag < result[j].tag
+		}
+		return result[i].currency < result[j].currency
+	})
+
+	return result
+}
+
+func (s series) baseline() (responses.Baseline, bool) {
+	if len(s.history) < minBaselineMonths {
+		return responses.Baseline{}, false
+	}
+
+	return responses.Baseline{
+		Tag:         s.tag,
+		Currency:    s.currency,
+		Months:      len(s.history),
+		Mean:        round(mean(s.history)),
+		Median:      round(median(s.history)),
+		StdDev:      round(stdDev(s.history)),
+		MonthToDate: round(s.current),
+	}, true
+}
+
+func monthInsight(baseline responses.Baseline, history []float64) (responses.Insight, bool) {
+	usual := baseline.Median
+	if usual == 0 {
+		usual = baseline.Mean
+	}
+	if usual <= 0 {
+		return responses.Insight{}, false
+	}
+
+	m, deviation := mean(history), stdDev(history)
+	ratio := baseline.MonthToDate / usual
+	if ratio < monthRatio || baseline.MonthToDate-m < monthDeviations*deviation {
+		return responses.Insight{}, false
+	}
+
+	subject := "spending"
+	if baseline.Tag != "" {
+		subject = baseline.Tag + " spending"
+	}
+
+	return responses.Insight{
+		Kind:     KindMonthToDate,
+		Tag:      baseline.Tag,
+		Currency: baseline.Currency,
+		Amount:   baseline.MonthToDate,
+		Usual:    usual,
+		Ratio:    round(ratio),
+		Message: fmt.Sprintf("Your %s this month is %.1fx your usual (%.2f %s so far, usually %.2f %s a month).",
+			subject, ratio, baseline.MonthToDate, baseline.Currency, usual, baseline.Currency),
+	}, true
+}
+
+// ExpenseCreated flags the expense when it is far above the usual expenses
+// of one of its tags.
+func (s insightService) ExpenseCreated(expense models.Expense) {
+	// insights cover the expenses of the personal ledger only
+	if expense.GroupID != nil || (expense.Type != "" && expense.Type != models.TypeExpense) {
+		return
+	}
+
+	currency := expense.Currency
+	if currency == "" {
+		currency = helpers.BaseCurrency
+	}
+	date := expense.Date
+	if date.IsZero() {
+		date = s.now()
+	}
+
+	for _, tag := range expense.Tags {
+		amounts, err := s.insightRepo.GetAmounts(tag, currency, date.AddDate(0, 0, -expenseWindowDays), date.AddDate(0, 0, 1), expense.ID)
+		if err != nil {
+			log.Println("fail to read usual expenses:", err)
+			return
+		}
+		if len(amounts) < minSamples {
+			continue
+		}
+
+		usual := median(amounts)
+		if usual <= 0 || expense.Amount < expenseRatio*usual || expense.Amount <= usual+expenseDeviations*mad(amounts) {
+			continue
+		}
+
+		ratio := expense.Amount / usual
+		anomaly := models.Anomaly{
+			ExpenseID: expense.ID,
+			Tag:       tag,
+			Date:      date,
+			Amount:    expense.Amount,
+			Currency:  currency,
+			Median:    round(usual),
+			Ratio:     round(ratio),
+			Message: fmt.Sprintf("%s (%.2f %s) is %.1fx your usual %s expense of %.2f %s.",
+				expense.Title, expense.Amount, currency, ratio, tag, usual, currency),
+		}
+		if err := s.insightRepo.SaveAnomaly(&anomaly); err != nil {
+			log.Println("fail to save anomaly:", err)
+		}
+	}
+}
+
+// ExpenseUpdated does nothing, only new expenses are flagged.
+func (s insightService) ExpenseUpdated(expense models.Expense) {}
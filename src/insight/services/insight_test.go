@@ -0,0 +1,192 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/insight/repositories"
+	"github.com/wytquant/assessment/src/insight/services"
+)
+
+func month(m time.Month) time.Time {
+	return time.Date(2023, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetInsightsService(t *testing.T) {
+	from, to := time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC), month(time.May)
+
+	t.Run("get insights success case", func(t *testing.T) {
+		//arrange
+		expenseID := uint(42)
+		insightRepo := repositories.NewInsightRepositoryMock()
+		insightRepo.On("GetMonthlyTotals", from, to).Return([]repositories.MonthlyTotal{
+			{Tag: "food", Currency: "THB", Month: month(time.January), Total: 500},
+			{Tag: "food", Currency: "THB", Month: month(time.February), Total: 600},
+			{Tag: "food", Currency: "THB", Month: month(time.March), Total: 550},
+			{Tag: "food", Currency: "THB", Month: month(time.April), Total: 560},
+			{Tag: "transport", Currency: "THB", Month: month(time.January), Total: 1000},
+			{Tag: "transport", Currency: "THB", Month: month(time.March), Total: 2000},
+			{Tag: "transport", Currency: "THB", Month: month(time.April), Total: 6000},
+			{Tag: "travel", Currency: "THB", Month: month(time.April), Total: 9000},
+		}, nil)
+		insightRepo.On("GetAnomalies", month(time.April), to).Return([]models.Anomaly{
+			{ExpenseID: 42, Tag: "food", Amount: 450, Currency: "THB", Median: 117.5, Ratio: 3.83, Message: "Omakase (450.00 THB) is 3.8x your usual food expense of 117.50 THB."},
+		}, nil)
+
+		insightService := services.NewInsightService(insightRepo)
+
+		//act
+		got, err := insightService.GetInsights(requests.InsightQuery{Month: "2023-04"})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, responses.InsightsResponse{
+			Month: "2023-04",
+			Insights: []responses.Insight{
+				{
+					Kind:     services.KindMonthToDate,
+					Tag:      "transport",
+					Currency: "THB",
+					Amount:   6000,
+					Usual:    1000,
+					Ratio:    6,
+					Message:  "Your transport spending this month is 6.0x your usual (6000.00 THB so far, usually 1000.00 THB a month).",
+				},
+				{
+					Kind:      services.KindExpense,
+					Tag:       "food",
+					Currency:  "THB",
+					Amount:    450,
+					Usual:     117.5,
+					Ratio:     3.83,
+					ExpenseID: &expenseID,
+					Message:   "Omakase (450.00 THB) is 3.8x your usual food expense of 117.50 THB.",
+				},
+			},
+			Baselines: []responses.Baseline{
+				{Tag: "food", Currency: "THB", Months: 3, Mean: 550, Median: 550, StdDev: 50, MonthToDate: 560},
+				{Tag: "transport", Currency: "THB", Months: 3, Mean: 1000, Median: 1000, StdDev: 1000, MonthToDate: 6000},
+			},
+		}, got)
+	})
+
+	t.Run("get insights fail bad request because month is invalid", func(t *testing.T) {
+		//arrange
+		insightService := services.NewInsightService(repositories.NewInsightRepositoryMock())
+
+		//act
+		_, err := insightService.GetInsights(requests.InsightQuery{Month: "04-2023"})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, "month must be formatted as YYYY-MM", appErr.Message)
+		}
+	})
+
+	t.Run("get insights fail internal server error because totals cannot be read", func(t *testing.T) {
+		//arrange
+		insightRepo := repositories.NewInsightRepositoryMock()
+		insightRepo.On("GetMonthlyTotals", from, to).Return([]repositories.MonthlyTotal{}, errors.New(""))
+
+		insightService := services.NewInsightService(insightRepo)
+
+		//act
+		_, err := insightService.GetInsights(requests.InsightQuery{Month: "2023-04"})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusInternalServerError, appErr.StatusCode)
+		}
+	})
+}
+
+func TestDetectAnomaliesService(t *testing.T) {
+	date := time.Date(2023, 4, 20, 0, 0, 0, 0, time.UTC)
+	from, to := date.AddDate(0, 0, -180), date.AddDate(0, 0, 1)
+	usual := []float64{100, 120, 110, 130, 120, 115}
+
+	t.Run("created expense far above the usual amount is flagged", func(t *testing.T) {
+		//arrange
+		expense := models.Expense{ID: 42, Title: "Omakase", Amount: 450, Currency: "THB", Date: date, Tags: pq.StringArray{"food"}}
+
+		insightRepo := repositories.NewInsightRepositoryMock()
+		insightRepo.On("GetAmounts", "food", "THB", from, to, uint(42)).Return(usual, nil)
+		insightRepo.On("SaveAnomaly", &models.Anomaly{
+			ExpenseID: 42,
+			Tag:       "food",
+			Date:      date,
+			Amount:    450,
+			Currency:  "THB",
+			Median:    117.5,
+			Ratio:     3.83,
+			Message:   "Omakase (450.00 THB) is 3.8x your usual food expense of 117.50 THB.",
+		}).Return(nil)
+
+		insightService := services.NewInsightService(insightRepo)
+
+		//act
+		insightService.ExpenseCreated(expense)
+
+		//assert
+		insightRepo.AssertExpectations(t)
+	})
+
+	t.Run("created expense within the usual amount is not flagged", func(t *testing.T) {
+		//arrange
+		expense := models.Expense{ID: 42, Title: "Lunch", Amount: 180, Currency: "THB", Date: date, Tags: pq.StringArray{"food"}}
+
+		insightRepo := repositories.NewInsightRepositoryMock()
+		insightRepo.On("GetAmounts", "food", "THB", from, to, uint(42)).Return(usual, nil)
+
+		insightService := services.NewInsightService(insightRepo)
+
+		//act
+		insightService.ExpenseCreated(expense)
+
+		//assert
+		insightRepo.AssertNotCalled(t, "SaveAnomaly")
+	})
+
+	t.Run("created expense of a rarely used tag is not flagged", func(t *testing.T) {
+		//arrange
+		expense := models.Expense{ID: 42, Title: "Flight", Amount: 9000, Currency: "THB", Date: date, Tags: pq.StringArray{"travel"}}
+
+		insightRepo := repositories.NewInsightRepositoryMock()
+		insightRepo.On("GetAmounts", "travel", "THB", from, to, uint(42)).Return([]float64{2000, 1500}, nil)
+
+		insightService := services.NewInsightService(insightRepo)
+
+		//act
+		insightService.ExpenseCreated(expense)
+
+		//assert
+		insightRepo.AssertNotCalled(t, "SaveAnomaly")
+	})
+
+	t.Run("group expenses are not checked", func(t *testing.T) {
+		//arrange
+		groupID := uint(1)
+		insightRepo := repositories.NewInsightRepositoryMock()
+
+		insightService := services.NewInsightService(insightRepo)
+
+		//act
+		insightService.ExpenseCreated(models.Expense{ID: 42, Amount: 9000, Tags: pq.StringArray{"food"}, GroupID: &groupID})
+
+		//assert
+		insightRepo.AssertNotCalled(t, "GetAmounts")
+	})
+}
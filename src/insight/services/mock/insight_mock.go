@@ -0,0 +1,29 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type insightServiceMock struct {
+	mock.Mock
+}
+
+func NewInsightServiceMock() *insightServiceMock {
+	return &insightServiceMock{}
+}
+
+func (m *insightServiceMock) GetInsights(query requests.InsightQuery) (responses.InsightsResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).(responses.InsightsResponse), args.Error(1)
+}
+
+func (m *insightServiceMock) ExpenseCreated(expense models.Expense) {
+	m.Called(expense)
+}
+
+func (m *insightServiceMock) ExpenseUpdated(expense models.Expense) {
+	m.Called(expense)
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"math"
+	"sort"
+)
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// stdDev is the sample standard deviation.
+func stdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	m := mean(values)
+	var sum float64
+	for _, value := range values {
+		sum += (value - m) * (value - m)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+// mad is the median absolute deviation scaled to estimate the standard
+// deviation of normally distributed values, unlike it a few outliers barely
+// move it.
+func mad(values []float64) float64 {
+	m := median(values)
+	deviations := make([]float64, len(values))
+	for i, value := range values {
+		deviations[i] = math.Abs(value - m)
+	}
+	return 1.4826 * median(deviations)
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/merchant/services"
+)
+
+type merchantHandler struct {
+	merchantService services.MerchantService
+}
+
+func NewMerchantHandler(merchantService services.MerchantService) merchantHandler {
+	return merchantHandler{merchantService: merchantService}
+}
+
+func (h merchantHandler) CreateMerchant(c *gin.Context) {
+	var merchantReq requests.MerchantRequest
+	if err := c.ShouldBindJSON(&merchantReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	merchantResp, err := h.merchantService.CreateMerchant(merchantReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, merchantResp)
+}
+
+func (h merchantHandler) GetMerchantByID(c *gin.Context) {
+	merchantResp, err := h.merchantService.GetMerchantByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, merchantResp)
+}
+
+func (h merchantHandler) UpdateMerchantByID(c *gin.Context) {
+	var merchantReq requests.MerchantRequest
+	if err := c.ShouldBindJSON(&merchantReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	merchantResp, err := h.merchantService.UpdateMerchantByID(c.Param("id"), merchantReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, merchantResp)
+}
+
+func (h merchantHandler) DeleteMerchantByID(c *gin.Context) {
+	if err := h.merchantService.DeleteMerchantByID(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h merchantHandler) GetAllMerchants(c *gin.Context) {
+	merchantsResp, err := h.merchantService.GetMerchants()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, merchantsResp)
+}
+
+func (h merchantHandler) AddAlias(c *gin.Context) {
+	var aliasReq requests.MerchantAliasRequest
+	if err := c.ShouldBindJSON(&aliasReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	aliasResp, err := h.merchantService.AddAlias(c.Param("id"), aliasReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, aliasResp)
+}
+
+func (h merchantHandler) DeleteAlias(c *gin.Context) {
+	if err := h.merchantService.DeleteAlias(c.Param("id"), c.Param("alias_id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h merchantHandler) GetStats(c *gin.Context) {
+	var query requests.MerchantStatsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	statsResp, err := h.merchantService.GetStats(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, statsResp)
+}
+
+func (h merchantHandler) MatchExpenses(c *gin.Context) {
+	matchResp, err := h.merchantService.MatchExpenses()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, matchResp)
+}
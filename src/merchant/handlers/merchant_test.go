@@ -0,0 +1,109 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/merchant/handlers"
+	services "github.com/wytquant/assessment/src/merchant/services/mock"
+)
+
+func TestCreateMerchantHandler(t *testing.T) {
+	t.Run("create merchant success case", func(t *testing.T) {
+		//arrange
+		merchantReq := requests.MerchantRequest{Name: "7-Eleven", Aliases: []string{"7-11"}}
+
+		merchantService := services.NewMerchantServiceMock()
+		merchantService.On("CreateMerchant", merchantReq).Return(responses.MerchantResponse{
+			ID:      1,
+			Name:    "7-Eleven",
+			Aliases: []responses.MerchantAliasResponse{{ID: 1, Alias: "7-11"}},
+		}, nil)
+
+		merchantHandler := handlers.NewMerchantHandler(merchantService)
+
+		r := gin.Default()
+		r.POST("/merchants", merchantHandler.CreateMerchant)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/merchants", bytes.NewBufferString(`{"name": "7-Eleven", "aliases": ["7-11"]}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.JSONEq(t, `{"id": 1, "name": "7-Eleven", "aliases": [{"id": 1, "alias": "7-11"}]}`, w.Body.String())
+	})
+
+	t.Run("create merchant fail bad request because name is missing", func(t *testing.T) {
+		//arrange
+		merchantHandler := handlers.NewMerchantHandler(services.NewMerchantServiceMock())
+
+		r := gin.Default()
+		r.POST("/merchants", merchantHandler.CreateMerchant)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/merchants", bytes.NewBufferString(`{"aliases": ["7-11"]}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAddAliasHandler(t *testing.T) {
+	t.Run("add alias fail conflict because the alias names another merchant", func(t *testing.T) {
+		//arrange
+		merchantService := services.NewMerchantServiceMock()
+		merchantService.On("AddAlias", "2", requests.MerchantAliasRequest{Alias: "7-11"}).Return(responses.MerchantAliasResponse{}, helpers.NewConflictError(`"7 11" already names merchant 7-Eleven`))
+
+		merchantHandler := handlers.NewMerchantHandler(merchantService)
+
+		r := gin.Default()
+		r.POST("/merchants/:id/aliases", merchantHandler.AddAlias)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/merchants/2/aliases", bytes.NewBufferString(`{"alias": "7-11"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"message": "\"7 11\" already names merchant 7-Eleven"}`, w.Body.String())
+	})
+}
+
+func TestDeleteMerchantHandler(t *testing.T) {
+	t.Run("delete merchant success case", func(t *testing.T) {
+		//arrange
+		merchantService := services.NewMerchantServiceMock()
+		merchantService.On("DeleteMerchantByID", "1").Return(nil)
+
+		merchantHandler := handlers.NewMerchantHandler(merchantService)
+
+		r := gin.Default()
+		r.DELETE("/merchants/:id", merchantHandler.DeleteMerchantByID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/merchants/1", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
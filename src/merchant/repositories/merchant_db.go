@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/src/webhook/outbox"
+	"gorm.io/gorm"
+)
+
+type merchantRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewMerchantRepositoryDB(db *gorm.DB) MerchantRepository {
+	return merchantRepositoryDB{db: db}
+}
+
+func (r merchantRepositoryDB) Create(merchant *models.Merchant) error {
+	query := r.db
+	if err := query.Create(merchant).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r merchantRepositoryDB) GetByID(id string) (models.Merchant, error) {
+	var merchant models.Merchant
+	query := r.db
+	if err := query.Preload("Aliases", orderByID).Where("id = ?", id).First(&merchant).Error; err != nil {
+		return models.Merchant{}, err
+	}
+
+	return merchant, nil
+}
+
+func (r merchantRepositoryDB) UpdateByID(id string, merchant models.Merchant) (models.Merchant, error) {
+	merchantDB, err := r.GetByID(id)
+	if err != nil {
+		return models.Merchant{}, err
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&merchantDB).Select("name", "category").Updates(merchant).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("merchant_id = ?", merchantDB.ID).Delete(&models.MerchantAlias{}).Error; err != nil {
+			return err
+		}
+
+		merchantDB.Aliases = merchant.Aliases
+		for i := range merchantDB.Aliases {
+			merchantDB.Aliases[i].MerchantID = merchantDB.ID
+		}
+		if len(merchantDB.Aliases) > 0 {
+			return tx.Create(&merchantDB.Aliases).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Merchant{}, err
+	}
+
+	return merchantDB, nil
+}
+
+func (r merchantRepositoryDB) DeleteByID(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var linked []uint
+		if err := tx.Model(&models.Expense{}).Where("merchant_id = ?", id).Pluck("id", &linked).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Expense{}).Unscoped().Where("merchant_id = ?", id).UpdateColumn("merchant_id", nil).Error; err != nil {
+			return err
+		}
+		if err := outbox.RecordChanged(tx, models.EventExpenseUpdated, linked...); err != nil {
+			return err
+		}
+		if err := tx.Where("merchant_id = ?", id).Delete(&models.MerchantAlias{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("merchant_id = ?", id).Delete(&models.Policy{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&models.Merchant{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+func (r merchantRepositoryDB) GetAll() ([]models.Merchant, error) {
+	query := r.db
+	var merchants []models.Merchant
+
+	if err := query.Preload("Aliases", orderByID).Order("name").Find(&merchants).Error; err != nil {
+		return nil, err
+	}
+
+	return merchants, nil
+}
+
+func (r merchantRepositoryDB) CreateAlias(alias *models.MerchantAlias) error {
+	query := r.db
+	if err := query.Create(alias).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r merchantRepositoryDB) DeleteAlias(merchantID string, aliasID string) error {
+	query := r.db
+	result := query.Where("id = ? AND merchant_id = ?", aliasID, merchantID).Delete(&models.MerchantAlias{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r merchantRepositoryDB) SetMerchant(expenseID uint, merchantID *uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Expense{ID: expenseID}).UpdateColumn("merchant_id", merchantID).Error; err != nil {
+			return err
+		}
+		return outbox.RecordChanged(tx, models.EventExpenseUpdated, expenseID)
+	})
+}
+
+func (r merchantRepositoryDB) GetUnmatched(afterID uint, limit int) ([]models.Expense, error) {
+	var expenses []models.Expense
+
+	err := r.db.Select("id", "title", "merchant_id").
+		Where("merchant_id IS NULL AND id > ?", afterID).
+		Order("id").
+		Limit(limit).
+		Find(&expenses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r merchantRepositoryDB) GetStats(filter StatsFilter) ([]MerchantStat, error) {
+	var stats []MerchantStat
+
+	query := r.db.Model(&models.Expense{}).
+		Select("merchants.id AS merchant_id, merchants.name, expenses.currency, SUM(" + models.SpentAmount + ") AS total, COUNT(*) FILTER (WHERE expenses.type = 'expense') AS visits, MIN(expenses.date) AS first_visit, MAX(expenses.date) AS last_visit").
+		Joins("JOIN merchants ON merchants.id = expenses.merchant_id").
+		Where("expenses.group_id IS NULL").
+		Where(models.Spending)
+	if !filter.From.IsZero() {
+		query = query.Where("expenses.date >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("expenses.date <= ?", filter.To)
+	}
+
+	err := query.Group("merchants.id, merchants.name, expenses.currency").
+		Order("total DESC, merchants.name, expenses.currency").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func orderByID(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
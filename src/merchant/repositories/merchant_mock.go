@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type merchantRepositoryMock struct {
+	mock.Mock
+}
+
+func NewMerchantRepositoryMock() *merchantRepositoryMock {
+	return &merchantRepositoryMock{}
+}
+
+func (m *merchantRepositoryMock) Create(merchant *models.Merchant) error {
+	args := m.Called(merchant)
+	return args.Error(0)
+}
+
+func (m *merchantRepositoryMock) GetByID(id string) (models.Merchant, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Merchant), args.Error(1)
+}
+
+func (m *merchantRepositoryMock) UpdateByID(id string, merchant models.Merchant) (models.Merchant, error) {
+	args := m.Called(id, merchant)
+	return args.Get(0).(models.Merchant), args.Error(1)
+}
+
+func (m *merchantRepositoryMock) DeleteByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *merchantRepositoryMock) GetAll() ([]models.Merchant, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Merchant), args.Error(1)
+}
+
+func (m *merchantRepositoryMock) CreateAlias(alias *models.MerchantAlias) error {
+	args := m.Called(alias)
+	return args.Error(0)
+}
+
+func (m *merchantRepositoryMock) DeleteAlias(merchantID string, aliasID string) error {
+	args := m.Called(merchantID, aliasID)
+	return args.Error(0)
+}
+
+func (m *merchantRepositoryMock) SetMerchant(expenseID uint, merchantID *uint) error {
+	args := m.Called(expenseID, merchantID)
+	return args.Error(0)
+}
+
+func (m *merchantRepositoryMock) GetUnmatched(afterID uint, limit int) ([]models.Expense, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]models.Expense), args.Error(1)
+}
+
+func (m *merchantRepositoryMock) GetStats(filter StatsFilter) ([]MerchantStat, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]MerchantStat), args.Error(1)
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+type StatsFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+type MerchantStat struct {
+	MerchantID uint
+	Name       string
+	Currency   string
+	Total      float64
+	Visits     int64
+	FirstVisit time.Time
+	LastVisit  time.Time
+}
+
+type MerchantRepository interface {
+	// Create stores the merchant with its aliases.
+	Create(*models.Merchant) error
+	GetByID(id string) (models.Merchant, error)
+	// UpdateByID replaces the merchant and its aliases.
+	UpdateByID(id string, merchant models.Merchant) (models.Merchant, error)
+	// DeleteByID deletes the merchant, its aliases and the policies
+	// forbidding it and unlinks its expenses.
+	DeleteByID(id string) error
+	GetAll() ([]models.Merchant, error)
+	CreateAlias(*models.MerchantAlias) error
+	DeleteAlias(merchantID string, aliasID string) error
+	SetMerchant(expenseID uint, merchantID *uint) error
+	// GetUnmatched returns up to limit expenses of every ledger without a
+	// merchant and with an id above afterID, in id order.
+	GetUnmatched(afterID uint, limit int) ([]models.Expense, error)
+	// GetStats sums the personal expenses by merchant and currency, largest
+	// total first.
+	GetStats(filter StatsFilter) ([]MerchantStat, error)
+}
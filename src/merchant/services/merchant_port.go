@@ -0,0 +1,27 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// MerchantService manages merchants and links expenses to them, it
+// processes new expenses and observes changed ones.
+type MerchantService interface {
+	CreateMerchant(merchantReq requests.MerchantRequest) (responses.MerchantResponse, error)
+	GetMerchantByID(id string) (responses.MerchantResponse, error)
+	UpdateMerchantByID(id string, merchantReq requests.MerchantRequest) (responses.MerchantResponse, error)
+	DeleteMerchantByID(id string) error
+	GetMerchants() ([]responses.MerchantResponse, error)
+	AddAlias(id string, aliasReq requests.MerchantAliasRequest) (responses.MerchantAliasResponse, error)
+	DeleteAlias(id string, aliasID string) error
+	GetStats(query requests.MerchantStatsQuery) ([]responses.MerchantStatsResponse, error)
+	// MatchExpenses links the expenses without a merchant that match one.
+	MatchExpenses() (responses.MerchantMatchResponse, error)
+	// MatchMerchant returns the merchant a title matches.
+	MatchMerchant(title string) (uint, bool)
+	ProcessExpense(expense *models.Expense) error
+	ExpenseCreated(expense models.Expense)
+	ExpenseUpdated(expense models.Expense)
+}
@@ -0,0 +1,363 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/merchant/repositories"
+)
+
+const (
+	matchBatchSize = 500
+	// merchantCacheTTL bounds how long merchants changed by another instance
+	// are not matched to new expenses.
+	merchantCacheTTL = time.Minute
+)
+
+type merchantCache struct {
+	mu       sync.Mutex
+	keys     []merchantKey
+	loadedAt time.Time
+}
+
+type merchantService struct {
+	merchantRepo repositories.MerchantRepository
+	cache        *merchantCache
+	now          func() time.Time
+}
+
+func NewMerchantService(merchantRepo repositories.MerchantRepository) MerchantService {
+	return merchantService{merchantRepo: merchantRepo, cache: &merchantCache{}, now: time.Now}
+}
+
+func (s merchantService) CreateMerchant(merchantReq requests.MerchantRequest) (responses.MerchantResponse, error) {
+	var merchantResp responses.MerchantResponse
+
+	merchant, err := s.merchant(0, merchantReq)
+	if err != nil {
+		return responses.MerchantResponse{}, err
+	}
+
+	if err := s.merchantRepo.Create(&merchant); err != nil {
+		return responses.MerchantResponse{}, helpers.NewInternalServerError()
+	}
+	s.invalidate()
+
+	copier.Copy(&merchantResp, &merchant)
+
+	return merchantResp, nil
+}
+
+func (s merchantService) GetMerchantByID(id string) (responses.MerchantResponse, error) {
+	var merchantResp responses.MerchantResponse
+
+	merchant, err := s.merchantRepo.GetByID(id)
+	if err != nil {
+		return responses.MerchantResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&merchantResp, &merchant)
+
+	return merchantResp, nil
+}
+
+func (s merchantService) UpdateMerchantByID(id string, merchantReq requests.MerchantRequest) (responses.MerchantResponse, error) {
+	var merchantResp responses.MerchantResponse
+
+	merchantDB, err := s.merchantRepo.GetByID(id)
+	if err != nil {
+		return responses.MerchantResponse{}, helpers.NewNotFoundError()
+	}
+
+	merchant, err := s.merchant(merchantDB.ID, merchantReq)
+	if err != nil {
+		return responses.MerchantResponse{}, err
+	}
+
+	updatedMerchant, err := s.merchantRepo.UpdateByID(id, merchant)
+	if err != nil {
+		return responses.MerchantResponse{}, helpers.NewInternalServerError()
+	}
+	s.invalidate()
+
+	copier.Copy(&merchantResp, &updatedMerchant)
+
+	return merchantResp, nil
+}
+
+func (s merchantService) DeleteMerchantByID(id string) error {
+	if err := s.merchantRepo.DeleteByID(id); err != nil {
+		return helpers.NewNotFoundError()
+	}
+	s.invalidate()
+
+	return nil
+}
+
+func (s merchantService) GetMerchants() ([]responses.MerchantResponse, error) {
+	merchantsResp := []responses.MerchantResponse{}
+
+	merchants, err := s.merchantRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&merchantsResp, &merchants)
+
+	return merchantsResp, nil
+}
+
+func (s merchantService) AddAlias(id string, aliasReq requests.MerchantAliasRequest) (responses.MerchantAliasResponse, error) {
+	var aliasResp responses.MerchantAliasResponse
+
+	merchant, err := s.merchantRepo.GetByID(id)
+	if err != nil {
+		return responses.MerchantAliasResponse{}, helpers.NewNotFoundError()
+	}
+
+	key := keyOf(aliasReq.Alias)
+	if key == "" {
+		return responses.MerchantAliasResponse{}, helpers.NewBadRequestError(fmt.Sprintf("alias %q has no letters or digits", aliasReq.Alias))
+	}
+	if err := s.checkConflicts(0, []string{key}); err != nil {
+		return responses.MerchantAliasResponse{}, err
+	}
+
+	alias := models.MerchantAlias{MerchantID: merchant.ID, Alias: aliasReq.Alias, Key: key}
+	if err := s.merchantRepo.CreateAlias(&alias); err != nil {
+		return responses.MerchantAliasResponse{}, helpers.NewInternalServerError()
+	}
+	s.invalidate()
+
+	copier.Copy(&aliasResp, &alias)
+
+	return aliasResp, nil
+}
+
+func (s merchantService) DeleteAlias(id string, aliasID string) error {
+	if err := s.merchantRepo.DeleteAlias(id, aliasID); err != nil {
+		return helpers.NewNotFoundError()
+	}
+	s.invalidate()
+
+	return nil
+}
+
+// merchant builds the merchant of a request, aliases repeating the name or
+// each other are dropped. A name or alias of another merchant is a conflict.
+func (s merchantService) merchant(id uint, merchantReq requests.MerchantRequest) (models.Merchant, error) {
+	merchant := models.Merchant{Name: merchantReq.Name, Category: merchantReq.Category, Aliases: []models.MerchantAlias{}}
+
+	nameKey := keyOf(merchantReq.Name)
+	if nameKey == "" {
+		return models.Merchant{}, helpers.NewBadRequestError(fmt.Sprintf("name %q has no letters or digits", merchantReq.Name))
+	}
+
+	keys := []string{nameKey}
+	seen := map[string]bool{nameKey: true}
+	for _, alias := range merchantReq.Aliases {
+		key := keyOf(alias)
+		if key == "" {
+			return models.Merchant{}, helpers.NewBadRequestError(fmt.Sprintf("alias %q has no letters or digits", alias))
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+		merchant.Aliases = append(merchant.Aliases, models.MerchantAlias{Alias: alias, Key: key})
+	}
+
+	if err := s.checkConflicts(id, keys); err != nil {
+		return models.Merchant{}, err
+	}
+
+	return merchant, nil
+}
+
+// checkConflicts fails when a key already names a merchant other than the
+// one with the id, 0 for a new merchant.
+func (s merchantService) checkConflicts(id uint, keys []string) error {
+	existing, err := s.loadKeys()
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+
+	for _, key := range existing {
+		if key.merchantID == id {
+			continue
+		}
+		for _, k := range keys {
+			if key.key == k {
+				return helpers.NewConflictError(fmt.Sprintf("%q already names merchant %s", k, key.merchant))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s merchantService) GetStats(query requests.MerchantStatsQuery) ([]responses.MerchantStatsResponse, error) {
+	statsResp := []responses.MerchantStatsResponse{}
+
+	stats, err := s.merchantRepo.GetStats(repositories.StatsFilter{From: query.From, To: query.To})
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	for _, stat := range stats {
+		var statResp responses.MerchantStatsResponse
+		copier.Copy(&statResp, &stat)
+
+		statResp.Total = round(stat.Total)
+		if stat.Visits > 0 {
+			statResp.Average = round(stat.Total / float64(stat.Visits))
+		}
+		statsResp = append(statsResp, statResp)
+	}
+
+	return statsResp, nil
+}
+
+func (s merchantService) MatchExpenses() (responses.MerchantMatchResponse, error) {
+	var matchResp responses.MerchantMatchResponse
+
+	// merchants just changed are matched, not the cached ones
+	keys, err := s.loadKeys()
+	if err != nil {
+		return responses.MerchantMatchResponse{}, helpers.NewInternalServerError()
+	}
+
+	var afterID uint
+	for {
+		expenses, err := s.merchantRepo.GetUnmatched(afterID, matchBatchSize)
+		if err != nil {
+			return responses.MerchantMatchResponse{}, helpers.NewInternalServerError()
+		}
+
+		for _, expense := range expenses {
+			matchResp.Scanned++
+			afterID = expense.ID
+
+			merchantID, ok := match(keys, expense.Title)
+			if !ok {
+				continue
+			}
+			if err := s.merchantRepo.SetMerchant(expense.ID, &merchantID); err != nil {
+				return responses.MerchantMatchResponse{}, helpers.NewInternalServerError()
+			}
+			matchResp.Matched++
+		}
+
+		if len(expenses) < matchBatchSize {
+			return matchResp, nil
+		}
+	}
+}
+
+func (s merchantService) MatchMerchant(title string) (uint, bool) {
+	keys, err := s.keys()
+	if err != nil {
+		log.Println("fail to load merchants:", err)
+		return 0, false
+	}
+
+	return match(keys, title)
+}
+
+// ProcessExpense links a new expense to the merchant its title matches.
+func (s merchantService) ProcessExpense(expense *models.Expense) error {
+	if expense.MerchantID != nil {
+		return nil
+	}
+
+	keys, err := s.keys()
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+
+	if merchantID, ok := match(keys, expense.Title); ok {
+		expense.MerchantID = &merchantID
+	}
+
+	return nil
+}
+
+// ExpenseCreated does nothing, new expenses are linked by ProcessExpense.
+func (s merchantService) ExpenseCreated(expense models.Expense) {}
+
+// ExpenseUpdated links the expense again as its title may have changed.
+func (s merchantService) ExpenseUpdated(expense models.Expense) {
+	keys, err := s.keys()
+	if err != nil {
+		log.Println("fail to load merchants:", err)
+		return
+	}
+
+	var merchantID *uint
+	if id, ok := match(keys, expense.Title); ok {
+		merchantID = &id
+	}
+	if sameMerchant(merchantID, expense.MerchantID) {
+		return
+	}
+
+	if err := s.merchantRepo.SetMerchant(expense.ID, merchantID); err != nil {
+		log.Println("fail to link merchant:", err)
+	}
+}
+
+func sameMerchant(a *uint, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// keys returns the cached merchant keys, loading them again once they are
+// older than merchantCacheTTL.
+func (s merchantService) keys() ([]merchantKey, error) {
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+
+	if !s.cache.loadedAt.IsZero() && s.now().Sub(s.cache.loadedAt) < merchantCacheTTL {
+		return s.cache.keys, nil
+	}
+
+	keys, err := s.loadKeys()
+	if err != nil {
+		return nil, err
+	}
+	s.cache.keys = keys
+	s.cache.loadedAt = s.now()
+
+	return keys, nil
+}
+
+func (s merchantService) loadKeys() ([]merchantKey, error) {
+	merchants, err := s.merchantRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return keysOf(merchants), nil
+}
+
+func (s merchantService) invalidate() {
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+
+	s.cache.loadedAt = time.Time{}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
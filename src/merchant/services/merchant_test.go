@@ -0,0 +1,265 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/merchant/repositories"
+	"github.com/wytquant/assessment/src/merchant/services"
+)
+
+func merchants() []models.Merchant {
+	return []models.Merchant{
+		{ID: 1, Name: "7-Eleven", Aliases: []models.MerchantAlias{
+			{ID: 1, MerchantID: 1, Alias: "7-11", Key: "7 11"},
+			{ID: 2, MerchantID: 1, Alias: "SEVEN ELEVEN", Key: "seven eleven"},
+		}},
+		{ID: 2, Name: "Starbucks"},
+		{ID: 3, Name: "Starbucks Reserve"},
+	}
+}
+
+func TestProcessExpenseService(t *testing.T) {
+	merchantID := func(id uint) *uint { return &id }
+
+	cases := []struct {
+		title    string
+		expected *uint
+	}{
+		{"7-11", merchantID(1)},
+		{"7-Eleven Sukhumvit", merchantID(1)},
+		{"SEVEN ELEVEN", merchantID(1)},
+		{"POS PURCHASE 7-ELEVEN BKK", merchantID(1)},
+		{"Starbuks", merchantID(2)},
+		{"Starbucks Reserve Chidlom", merchantID(3)},
+		{"Grab taxi", nil},
+	}
+
+	merchantRepo := repositories.NewMerchantRepositoryMock()
+	merchantRepo.On("GetAll").Return(merchants(), nil).Once()
+
+	merchantService := services.NewMerchantService(merchantRepo)
+
+	for _, c := range cases {
+		t.Run("title "+c.title+" is linked to its merchant", func(t *testing.T) {
+			//arrange
+			expense := models.Expense{Title: c.title}
+
+			//act
+			err := merchantService.ProcessExpense(&expense)
+
+			//assert
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, expense.MerchantID)
+		})
+	}
+
+	t.Run("merchants are loaded once for all expenses", func(t *testing.T) {
+		merchantRepo.AssertNumberOfCalls(t, "GetAll", 1)
+	})
+}
+
+func TestCreateMerchantService(t *testing.T) {
+	t.Run("create merchant success case", func(t *testing.T) {
+		//arrange
+		merchantRepo := repositories.NewMerchantRepositoryMock()
+		merchantRepo.On("GetAll").Return(merchants(), nil)
+		merchantRepo.On("Create", &models.Merchant{
+			Name:     "FamilyMart",
+			Category: "convenience",
+			Aliases: []models.MerchantAlias{
+				{Alias: "Family Mart", Key: "family mart"},
+			},
+		}).Return(nil).Run(func(args mock.Arguments) {
+			merchant := args.Get(0).(*models.Merchant)
+			merchant.ID = 4
+			merchant.Aliases[0].ID = 3
+		})
+
+		merchantService := services.NewMerchantService(merchantRepo)
+
+		//act
+		got, err := merchantService.CreateMerchant(requests.MerchantRequest{
+			Name:     "FamilyMart",
+			Category: "convenience",
+			Aliases:  []string{"Family Mart", "FAMILY-MART", "familymart"},
+		})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, responses.MerchantResponse{
+			ID:       4,
+			Name:     "FamilyMart",
+			Category: "convenience",
+			Aliases:  []responses.MerchantAliasResponse{{ID: 3, Alias: "Family Mart"}},
+		}, got)
+	})
+
+	t.Run("create merchant fail conflict because an alias names another merchant", func(t *testing.T) {
+		//arrange
+		merchantRepo := repositories.NewMerchantRepositoryMock()
+		merchantRepo.On("GetAll").Return(merchants(), nil)
+
+		merchantService := services.NewMerchantService(merchantRepo)
+
+		//act
+		_, err := merchantService.CreateMerchant(requests.MerchantRequest{Name: "Lawson", Aliases: []string{"7/11"}})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+			assert.Equal(t, `"7 11" already names merchant 7-Eleven`, appErr.Message)
+		}
+		merchantRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("create merchant fail bad request because an alias has no letters", func(t *testing.T) {
+		//arrange
+		merchantService := services.NewMerchantService(repositories.NewMerchantRepositoryMock())
+
+		//act
+		_, err := merchantService.CreateMerchant(requests.MerchantRequest{Name: "Lawson", Aliases: []string{"--"}})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+			assert.Equal(t, `alias "--" has no letters or digits`, appErr.Message)
+		}
+	})
+}
+
+func TestUpdateMerchantService(t *testing.T) {
+	t.Run("update merchant keeps its own aliases success case", func(t *testing.T) {
+		//arrange
+		merchant := models.Merchant{Name: "7-Eleven", Aliases: []models.MerchantAlias{{Alias: "7-11", Key: "7 11"}}}
+
+		merchantRepo := repositories.NewMerchantRepositoryMock()
+		merchantRepo.On("GetByID", "1").Return(merchants()[0], nil)
+		merchantRepo.On("GetAll").Return(merchants(), nil)
+		merchantRepo.On("UpdateByID", "1", merchant).Return(models.Merchant{ID: 1, Name: "7-Eleven", Aliases: []models.MerchantAlias{{ID: 5, MerchantID: 1, Alias: "7-11", Key: "7 11"}}}, nil)
+
+		merchantService := services.NewMerchantService(merchantRepo)
+
+		//act
+		got, err := merchantService.UpdateMerchantByID("1", requests.MerchantRequest{Name: "7-Eleven", Aliases: []string{"7-11"}})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, []responses.MerchantAliasResponse{{ID: 5, Alias: "7-11"}}, got.Aliases)
+	})
+
+	t.Run("update merchant fail not found", func(t *testing.T) {
+		//arrange
+		merchantRepo := repositories.NewMerchantRepositoryMock()
+		merchantRepo.On("GetByID", "9").Return(models.Merchant{}, errors.New(""))
+
+		merchantService := services.NewMerchantService(merchantRepo)
+
+		//act
+		_, err := merchantService.UpdateMerchantByID("9", requests.MerchantRequest{Name: "Lawson"})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+		}
+	})
+}
+
+func TestMatchExpensesService(t *testing.T) {
+	t.Run("match expenses links the unmatched expenses success case", func(t *testing.T) {
+		//arrange
+		merchantRepo := repositories.NewMerchantRepositoryMock()
+		merchantRepo.On("GetAll").Return(merchants(), nil)
+		merchantRepo.On("GetUnmatched", uint(0), 500).Return([]models.Expense{
+			{ID: 3, Title: "7-11 Silom"},
+			{ID: 8, Title: "Grab taxi"},
+			{ID: 9, Title: "Starbucks"},
+		}, nil)
+		merchantRepo.On("SetMerchant", uint(3), mock.Anything).Return(nil)
+		merchantRepo.On("SetMerchant", uint(9), mock.Anything).Return(nil)
+
+		merchantService := services.NewMerchantService(merchantRepo)
+
+		//act
+		got, err := merchantService.MatchExpenses()
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, responses.MerchantMatchResponse{Scanned: 3, Matched: 2}, got)
+		assert.Equal(t, uint(1), *merchantRepo.Calls[2].Arguments.Get(1).(*uint))
+		assert.Equal(t, uint(2), *merchantRepo.Calls[3].Arguments.Get(1).(*uint))
+	})
+}
+
+func TestExpenseUpdatedMerchantService(t *testing.T) {
+	t.Run("renamed expense is linked to its new merchant", func(t *testing.T) {
+		//arrange
+		oldMerchantID := uint(2)
+
+		merchantRepo := repositories.NewMerchantRepositoryMock()
+		merchantRepo.On("GetAll").Return(merchants(), nil)
+		merchantRepo.On("SetMerchant", uint(5), mock.Anything).Return(nil)
+
+		merchantService := services.NewMerchantService(merchantRepo)
+
+		//act
+		merchantService.ExpenseUpdated(models.Expense{ID: 5, Title: "7-11", MerchantID: &oldMerchantID})
+
+		//assert
+		assert.Equal(t, uint(1), *merchantRepo.Calls[1].Arguments.Get(1).(*uint))
+	})
+
+	t.Run("expense still at its merchant is left alone", func(t *testing.T) {
+		//arrange
+		merchantID := uint(1)
+
+		merchantRepo := repositories.NewMerchantRepositoryMock()
+		merchantRepo.On("GetAll").Return(merchants(), nil)
+
+		merchantService := services.NewMerchantService(merchantRepo)
+
+		//act
+		merchantService.ExpenseUpdated(models.Expense{ID: 5, Title: "7-Eleven Asok", MerchantID: &merchantID})
+
+		//assert
+		merchantRepo.AssertNotCalled(t, "SetMerchant", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetMerchantStatsService(t *testing.T) {
+	t.Run("get stats success case", func(t *testing.T) {
+		//arrange
+		from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		first := time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)
+		last := time.Date(2023, 1, 29, 0, 0, 0, 0, time.UTC)
+
+		merchantRepo := repositories.NewMerchantRepositoryMock()
+		merchantRepo.On("GetStats", repositories.StatsFilter{From: from}).Return([]repositories.MerchantStat{
+			{MerchantID: 1, Name: "7-Eleven", Currency: "THB", Total: 1000, Visits: 3, FirstVisit: first, LastVisit: last},
+		}, nil)
+
+		merchantService := services.NewMerchantService(merchantRepo)
+
+		//act
+		got, err := merchantService.GetStats(requests.MerchantStatsQuery{From: from})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, []responses.MerchantStatsResponse{
+			{MerchantID: 1, Name: "7-Eleven", Currency: "THB", Total: 1000, Visits: 3, Average: 333.33, FirstVisit: first, LastVisit: last},
+		}, got)
+	})
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type merchantServiceMock struct {
+	mock.Mock
+}
+
+func NewMerchantServiceMock() *merchantServiceMock {
+	return &merchantServiceMock{}
+}
+
+func (m *merchantServiceMock) CreateMerchant(merchantReq requests.MerchantRequest) (responses.MerchantResponse, error) {
+	args := m.Called(merchantReq)
+	return args.Get(0).(responses.MerchantResponse), args.Error(1)
+}
+
+func (m *merchantServiceMock) GetMerchantByID(id string) (responses.MerchantResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.MerchantResponse), args.Error(1)
+}
+
+func (m *merchantServiceMock) UpdateMerchantByID(id string, merchantReq requests.MerchantRequest) (responses.MerchantResponse, error) {
+	args := m.Called(id, merchantReq)
+	return args.Get(0).(responses.MerchantResponse), args.Error(1)
+}
+
+func (m *merchantServiceMock) DeleteMerchantByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *merchantServiceMock) GetMerchants() ([]responses.MerchantResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.MerchantResponse), args.Error(1)
+}
+
+func (m *merchantServiceMock) AddAlias(id string, aliasReq requests.MerchantAliasRequest) (responses.MerchantAliasResponse, error) {
+	args := m.Called(id, aliasReq)
+	return args.Get(0).(responses.MerchantAliasResponse), args.Error(1)
+}
+
+func (m *merchantServiceMock) DeleteAlias(id string, aliasID string) error {
+	args := m.Called(id, aliasID)
+	return args.Error(0)
+}
+
+func (m *merchantServiceMock) GetStats(query requests.MerchantStatsQuery) ([]responses.MerchantStatsResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).([]responses.MerchantStatsResponse), args.Error(1)
+}
+
+func (m *merchantServiceMock) MatchExpenses() (responses.MerchantMatchResponse, error) {
+	args := m.Called()
+	return args.Get(0).(responses.MerchantMatchResponse), args.Error(1)
+}
+
+func (m *merchantServiceMock) MatchMerchant(title string) (uint, bool) {
+	args := m.Called(title)
+	return args.Get(0).(uint), args.Bool(1)
+}
+
+func (m *merchantServiceMock) ProcessExpense(expense *models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
+
+func (m *merchantServiceMock) ExpenseCreated(expense models.Expense) {
+	m.Called(expense)
+}
+
+func (m *merchantServiceMock) ExpenseUpdated(expense models.Expense) {
+	m.Called(expense)
+}
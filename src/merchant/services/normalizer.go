@@ -0,0 +1,97 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+)
+
+// fuzzyScore is the similarity from which a title that contains no alias is
+// still taken for a misspelt one.
+const fuzzyScore = 0.85
+
+// merchantKey is a name or alias of a merchant as compared with titles.
+type merchantKey struct {
+	merchantID uint
+	merchant   string
+	key        string
+	words      []string
+}
+
+// keyOf reduces a name to lower case words, "7-Eleven" becomes "7 eleven".
+func keyOf(name string) string {
+	return strings.Join(helpers.SplitWords(name), " ")
+}
+
+func keysOf(merchants []models.Merchant) []merchantKey {
+	var keys []merchantKey
+	for _, merchant := range merchants {
+		keys = append(keys, newKey(merchant, keyOf(merchant.Name)))
+		for _, alias := range merchant.Aliases {
+			keys = append(keys, newKey(merchant, alias.Key))
+		}
+	}
+	return keys
+}
+
+func newKey(merchant models.Merchant, key string) merchantKey {
+	return merchantKey{merchantID: merchant.ID, merchant: merchant.Name, key: key, words: strings.Fields(key)}
+}
+
+// match finds the merchant of a title or of an import description. Titles
+// containing a name or alias as whole words, such as "7-Eleven Sukhumvit 55"
+// or "POS 7-11 BKK", match its merchant, the longest name winning. Otherwise
+// the closest name matches when it is similar enough to the title to be a
+// typo of it.
+func match(keys []merchantKey, title string) (uint, bool) {
+	words := helpers.SplitWords(title)
+	if len(words) == 0 {
+		return 0, false
+	}
+
+	var best merchantKey
+	for _, key := range keys {
+		if len(key.words) > len(best.words) && containsWords(words, key.words) {
+			best = key
+		}
+	}
+	if len(best.words) > 0 {
+		return best.merchantID, true
+	}
+
+	text := strings.Join(words, " ")
+	var bestScore float64
+	for _, key := range keys {
+		if score := helpers.Similarity(text, key.key); score >= fuzzyScore && score > bestScore {
+			best, bestScore = key, score
+		}
+	}
+	if len(best.words) > 0 {
+		return best.merchantID, true
+	}
+
+	return 0, false
+}
+
+// containsWords reports whether words holds part as a run of words.
+func containsWords(words []string, part []string) bool {
+	if len(part) == 0 {
+		return false
+	}
+
+	for i := 0; i+len(part) <= len(words); i++ {
+		found := true
+		for j := range part {
+			if words[i+j] != part[j] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/policy/services"
+)
+
+type policyHandler struct {
+	policyService services.PolicyService
+}
+
+func NewPolicyHandler(policyService services.PolicyService) policyHandler {
+	return policyHandler{policyService: policyService}
+}
+
+func (h policyHandler) CreatePolicy(c *gin.Context) {
+	var policyReq requests.PolicyRequest
+	if err := c.ShouldBindJSON(&policyReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	policyResp, err := h.policyService.CreatePolicy(policyReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, policyResp)
+}
+
+func (h policyHandler) GetPolicyByID(c *gin.Context) {
+	policyResp, err := h.policyService.GetPolicyByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, policyResp)
+}
+
+func (h policyHandler) UpdatePolicyByID(c *gin.Context) {
+	var policyReq requests.PolicyRequest
+	if err := c.ShouldBindJSON(&policyReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	policyResp, err := h.policyService.UpdatePolicyByID(c.Param("id"), policyReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, policyResp)
+}
+
+func (h policyHandler) DeletePolicyByID(c *gin.Context) {
+	if err := h.policyService.DeletePolicyByID(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h policyHandler) GetAllPolicies(c *gin.Context) {
+	policiesResp, err := h.policyService.GetPolicies()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, policiesResp)
+}
+
+func (h policyHandler) GetViolations(c *gin.Context) {
+	var query requests.PolicyViolationQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	violationsResp, err := h.policyService.GetViolations(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, violationsResp)
+}
@@ -0,0 +1,91 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/policy/handlers"
+	services "github.com/wytquant/assessment/src/policy/services/mock"
+)
+
+func TestCreatePolicyHandler(t *testing.T) {
+	t.Run("create policy success case", func(t *testing.T) {
+		//arrange
+		policyReq := requests.PolicyRequest{Code: "MEALS", Name: "meals up to 1000 THB", Kind: models.PolicyMaxAmount, Action: models.PolicyBlock, Tag: "food", Amount: 1000}
+		policyService := services.NewPolicyServiceMock()
+		policyService.On("CreatePolicy", policyReq).Return(responses.PolicyResponse{ID: 1, Code: "MEALS"}, nil)
+
+		policyHandler := handlers.NewPolicyHandler(policyService)
+
+		r := gin.Default()
+		r.POST("/policies", policyHandler.CreatePolicy)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/policies", bytes.NewBufferString(`{
+			"code": "MEALS",
+			"name": "meals up to 1000 THB",
+			"kind": "max_amount",
+			"action": "block",
+			"tag": "food",
+			"amount": 1000
+		}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		policyService.AssertExpectations(t)
+	})
+
+	t.Run("create policy fail bad request because the action is unknown", func(t *testing.T) {
+		//arrange
+		policyHandler := handlers.NewPolicyHandler(services.NewPolicyServiceMock())
+
+		r := gin.Default()
+		r.POST("/policies", policyHandler.CreatePolicy)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/policies", bytes.NewBufferString(`{"code": "WKND", "name": "weekends", "kind": "weekend", "action": "fine"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetViolationsHandler(t *testing.T) {
+	t.Run("get violations success case", func(t *testing.T) {
+		//arrange
+		query := requests.PolicyViolationQuery{From: time.Date(2023, 3, 1, 0, 0, 0, 0, time.Local), Code: "WKND"}
+		policyService := services.NewPolicyServiceMock()
+		policyService.On("GetViolations", query).Return([]responses.ExpenseViolationsResponse{}, nil)
+
+		policyHandler := handlers.NewPolicyHandler(policyService)
+
+		r := gin.Default()
+		r.GET("/policies/violations", policyHandler.GetViolations)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/policies/violations?from=2023-03-01&code=WKND", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		policyService.AssertExpectations(t)
+	})
+}
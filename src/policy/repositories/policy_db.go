@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+)
+
+type policyRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewPolicyRepositoryDB(db *gorm.DB) PolicyRepository {
+	return policyRepositoryDB{db: db}
+}
+
+func (r policyRepositoryDB) Create(policy *models.Policy) error {
+	query := r.db
+	if err := query.Create(policy).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r policyRepositoryDB) GetByID(id string) (models.Policy, error) {
+	var policy models.Policy
+	query := r.db
+	if err := query.Where("id = ?", id).First(&policy).Error; err != nil {
+		return models.Policy{}, err
+	}
+
+	return policy, nil
+}
+
+func (r policyRepositoryDB) GetAll() ([]models.Policy, error) {
+	query := r.db
+	var policies []models.Policy
+
+	if err := query.Order("code").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (r policyRepositoryDB) UpdateByID(id string, policy models.Policy) (models.Policy, error) {
+	policyDB, err := r.GetByID(id)
+	if err != nil {
+		return models.Policy{}, err
+	}
+
+	// every field is replaced, a policy of another kind clears the others
+	if err := r.db.Model(&policyDB).
+		Select("code", "name", "kind", "action", "tag", "amount", "currency", "merchant_id").
+		Updates(policy).Error; err != nil {
+		return models.Policy{}, err
+	}
+
+	return r.GetByID(id)
+}
+
+func (r policyRepositoryDB) DeleteByID(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&models.Policy{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r policyRepositoryDB) ExistsCode(code string, exceptID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Policy{}).Where("code = ? AND id <> ?", code, exceptID).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r policyRepositoryDB) MerchantExists(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Merchant{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r policyRepositoryDB) GetExpenses(from time.Time, to time.Time) ([]models.Expense, error) {
+	var expenses []models.Expense
+	query := r.db.Model(&models.Expense{}).Where("group_id IS NULL AND type = ?", models.TypeExpense)
+	if !from.IsZero() {
+		query = query.Where("date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("date <= ?", to)
+	}
+
+	if err := query.Order("date, id").Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r policyRepositoryDB) CountReceipts(expenseIDs []uint) (map[uint]int64, error) {
+	counts := map[uint]int64{}
+	if len(expenseIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ExpenseID uint
+		Count     int64
+	}
+	if err := r.db.Model(&models.Attachment{}).Select("expense_id, COUNT(*) AS count").
+		Where("expense_id IN ?", expenseIDs).Group("expense_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ExpenseID] = row.Count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type policyRepositoryMock struct {
+	mock.Mock
+}
+
+func NewPolicyRepositoryMock() *policyRepositoryMock {
+	return &policyRepositoryMock{}
+}
+
+func (m *policyRepositoryMock) Create(policy *models.Policy) error {
+	args := m.Called(policy)
+	return args.Error(0)
+}
+
+func (m *policyRepositoryMock) GetByID(id string) (models.Policy, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Policy), args.Error(1)
+}
+
+func (m *policyRepositoryMock) GetAll() ([]models.Policy, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Policy), args.Error(1)
+}
+
+func (m *policyRepositoryMock) UpdateByID(id string, policy models.Policy) (models.Policy, error) {
+	args := m.Called(id, policy)
+	return args.Get(0).(models.Policy), args.Error(1)
+}
+
+func (m *policyRepositoryMock) DeleteByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *policyRepositoryMock) ExistsCode(code string, exceptID uint) (bool, error) {
+	args := m.Called(code, exceptID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *policyRepositoryMock) MerchantExists(id uint) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *policyRepositoryMock) GetExpenses(from time.Time, to time.Time) ([]models.Expense, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]models.Expense), args.Error(1)
+}
+
+func (m *policyRepositoryMock) CountReceipts(expenseIDs []uint) (map[uint]int64, error) {
+	args := m.Called(expenseIDs)
+	return args.Get(0).(map[uint]int64), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+type PolicyRepository interface {
+	Create(*models.Policy) error
+	GetByID(id string) (models.Policy, error)
+	// GetAll returns the policies by code.
+	GetAll() ([]models.Policy, error)
+	UpdateByID(id string, policy models.Policy) (models.Policy, error)
+	DeleteByID(id string) error
+	// ExistsCode tells whether a policy other than exceptID has the code.
+	ExistsCode(code string, exceptID uint) (bool, error)
+	MerchantExists(id uint) (bool, error)
+	// GetExpenses returns the expenses of the personal ledger dated from
+	// from through to, zero dates leave the range open. Income, transfers
+	// and refunds are left out.
+	GetExpenses(from time.Time, to time.Time) ([]models.Expense, error)
+	// CountReceipts returns how many attachments each of the expenses has,
+	// expenses without one are missing.
+	CountReceipts(expenseIDs []uint) (map[uint]int64, error)
+}
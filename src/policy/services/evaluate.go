@@ -0,0 +1,92 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/responses"
+)
+
+// evaluate returns the violation of the policy by the expense, false when the
+// expense complies or the policy does not apply to it. receipts is the number
+// of files attached to the expense. Amounts in another currency than the
+// policy are converted with the rates, an expense that cannot be converted
+// violates the policy as it cannot be checked.
+func evaluate(policy models.Policy, expense models.Expense, receipts int64, rates helpers.ExchangeRates) (responses.PolicyViolation, bool) {
+	if !applies(policy, expense) {
+		return responses.PolicyViolation{}, false
+	}
+
+	var message string
+	currency := currencyOf(expense)
+	switch policy.Kind {
+	case models.PolicyMaxAmount:
+		amount, ok := rates.Convert(expense.Amount, currency, policy.Currency)
+		if !ok {
+			message = fmt.Sprintf("no exchange rate from %s to check the limit of %.2f %s", currency, policy.Amount, policy.Currency)
+			break
+		}
+		if amount <= policy.Amount {
+			return responses.PolicyViolation{}, false
+		}
+		message = fmt.Sprintf("%.2f %s is above the limit of %.2f %s", expense.Amount, currency, policy.Amount, policy.Currency)
+	case models.PolicyWeekend:
+		// a new expense without a date is dated today by the database
+		date := expense.Date
+		if date.IsZero() {
+			date = time.Now()
+		}
+		if date.Weekday() != time.Saturday && date.Weekday() != time.Sunday {
+			return responses.PolicyViolation{}, false
+		}
+		message = fmt.Sprintf("spent on a %s", date.Weekday())
+	case models.PolicyReceipt:
+		if receipts > 0 {
+			return responses.PolicyViolation{}, false
+		}
+		amount, ok := rates.Convert(expense.Amount, currency, policy.Currency)
+		if !ok {
+			message = fmt.Sprintf("no exchange rate from %s to check the receipt needed above %.2f %s", currency, policy.Amount, policy.Currency)
+			break
+		}
+		if amount <= policy.Amount {
+			return responses.PolicyViolation{}, false
+		}
+		message = fmt.Sprintf("a receipt is needed above %.2f %s", policy.Amount, policy.Currency)
+	case models.PolicyMerchant:
+		if expense.MerchantID == nil || policy.MerchantID == nil || *expense.MerchantID != *policy.MerchantID {
+			return responses.PolicyViolation{}, false
+		}
+		message = fmt.Sprintf("merchant %d is forbidden", *policy.MerchantID)
+	default:
+		return responses.PolicyViolation{}, false
+	}
+
+	return responses.PolicyViolation{Code: policy.Code, Kind: policy.Kind, Action: policy.Action, Message: message}, true
+}
+
+// applies tells whether the policy covers the expense, policies are about
+// spending and a policy with a tag only covers the expenses with it.
+func applies(policy models.Policy, expense models.Expense) bool {
+	if expense.Type != "" && expense.Type != models.TypeExpense {
+		return false
+	}
+	if policy.Tag == "" {
+		return true
+	}
+	for _, tag := range expense.Tags {
+		if tag == policy.Tag {
+			return true
+		}
+	}
+	return false
+}
+
+func currencyOf(expense models.Expense) string {
+	if expense.Currency == "" {
+		return helpers.BaseCurrency
+	}
+	return expense.Currency
+}
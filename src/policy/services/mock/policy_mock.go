@@ -0,0 +1,56 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type policyServiceMock struct {
+	mock.Mock
+}
+
+func NewPolicyServiceMock() *policyServiceMock {
+	return &policyServiceMock{}
+}
+
+func (m *policyServiceMock) CreatePolicy(policyReq requests.PolicyRequest) (responses.PolicyResponse, error) {
+	args := m.Called(policyReq)
+	return args.Get(0).(responses.PolicyResponse), args.Error(1)
+}
+
+func (m *policyServiceMock) GetPolicyByID(id string) (responses.PolicyResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.PolicyResponse), args.Error(1)
+}
+
+func (m *policyServiceMock) GetPolicies() ([]responses.PolicyResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.PolicyResponse), args.Error(1)
+}
+
+func (m *policyServiceMock) UpdatePolicyByID(id string, policyReq requests.PolicyRequest) (responses.PolicyResponse, error) {
+	args := m.Called(id, policyReq)
+	return args.Get(0).(responses.PolicyResponse), args.Error(1)
+}
+
+func (m *policyServiceMock) DeletePolicyByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *policyServiceMock) GetViolations(query requests.PolicyViolationQuery) ([]responses.ExpenseViolationsResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).([]responses.ExpenseViolationsResponse), args.Error(1)
+}
+
+func (m *policyServiceMock) ProcessExpense(expense *models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
+
+func (m *policyServiceMock) CheckExpense(expense models.Expense) ([]responses.PolicyViolation, error) {
+	args := m.Called(expense)
+	return args.Get(0).([]responses.PolicyViolation), args.Error(1)
+}
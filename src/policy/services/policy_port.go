@@ -0,0 +1,23 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// PolicyService manages the spending policies of the company. It is a
+// processor of the expense service that checks every new and changed
+// expense against them.
+type PolicyService interface {
+	CreatePolicy(policyReq requests.PolicyRequest) (responses.PolicyResponse, error)
+	GetPolicyByID(id string) (responses.PolicyResponse, error)
+	GetPolicies() ([]responses.PolicyResponse, error)
+	UpdatePolicyByID(id string, policyReq requests.PolicyRequest) (responses.PolicyResponse, error)
+	DeletePolicyByID(id string) error
+	// GetViolations checks the existing expenses of the personal ledger and
+	// returns the ones violating a policy.
+	GetViolations(query requests.PolicyViolationQuery) ([]responses.ExpenseViolationsResponse, error)
+	ProcessExpense(expense *models.Expense) error
+	CheckExpense(expense models.Expense) ([]responses.PolicyViolation, error)
+}
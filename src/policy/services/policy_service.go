@@ -0,0 +1,246 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/policy/repositories"
+)
+
+type policyService struct {
+	policyRepo repositories.PolicyRepository
+	rates      helpers.ExchangeRates
+}
+
+// NewPolicyService creates the service, the rates convert expenses to the
+// currency of the amount policies.
+func NewPolicyService(policyRepo repositories.PolicyRepository, rates helpers.ExchangeRates) PolicyService {
+	return policyService{policyRepo: policyRepo, rates: rates}
+}
+
+func (s policyService) CreatePolicy(policyReq requests.PolicyRequest) (responses.PolicyResponse, error) {
+	var policy models.Policy
+	var policyResp responses.PolicyResponse
+
+	copier.Copy(&policy, &policyReq)
+
+	if err := s.checkPolicy(&policy, 0); err != nil {
+		return responses.PolicyResponse{}, err
+	}
+
+	if err := s.policyRepo.Create(&policy); err != nil {
+		return responses.PolicyResponse{}, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&policyResp, &policy)
+
+	return policyResp, nil
+}
+
+func (s policyService) GetPolicyByID(id string) (responses.PolicyResponse, error) {
+	var policyResp responses.PolicyResponse
+
+	policy, err := s.policyRepo.GetByID(id)
+	if err != nil {
+		return responses.PolicyResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&policyResp, &policy)
+
+	return policyResp, nil
+}
+
+func (s policyService) GetPolicies() ([]responses.PolicyResponse, error) {
+	policiesResp := []responses.PolicyResponse{}
+
+	policies, err := s.policyRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	for _, policy := range policies {
+		var policyResp responses.PolicyResponse
+		copier.Copy(&policyResp, &policy)
+		policiesResp = append(policiesResp, policyResp)
+	}
+
+	return policiesResp, nil
+}
+
+func (s policyService) UpdatePolicyByID(id string, policyReq requests.PolicyRequest) (responses.PolicyResponse, error) {
+	var policy models.Policy
+	var policyResp responses.PolicyResponse
+
+	stored, err := s.policyRepo.GetByID(id)
+	if err != nil {
+		return responses.PolicyResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&policy, &policyReq)
+
+	if err := s.checkPolicy(&policy, stored.ID); err != nil {
+		return responses.PolicyResponse{}, err
+	}
+
+	updatedPolicy, err := s.policyRepo.UpdateByID(id, policy)
+	if err != nil {
+		return responses.PolicyResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&policyResp, &updatedPolicy)
+
+	return policyResp, nil
+}
+
+func (s policyService) DeletePolicyByID(id string) error {
+	if err := s.policyRepo.DeleteByID(id); err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	return nil
+}
+
+// checkPolicy checks that the policy has the fields of its kind and no
+// others, amounts are in BaseCurrency unless it says otherwise.
+func (s policyService) checkPolicy(policy *models.Policy, id uint) error {
+	amountKind := policy.Kind == models.PolicyMaxAmount || policy.Kind == models.PolicyReceipt
+	if policy.Kind == models.PolicyMaxAmount && policy.Amount == 0 {
+		return helpers.NewBadRequestError("a max_amount policy needs an amount")
+	}
+	if !amountKind && (policy.Amount != 0 || policy.Currency != "") {
+		return helpers.NewBadRequestError("only max_amount and receipt policies have an amount and currency")
+	}
+	if amountKind && policy.Currency == "" {
+		policy.Currency = helpers.BaseCurrency
+	}
+
+	if policy.Kind == models.PolicyMerchant {
+		if policy.MerchantID == nil {
+			return helpers.NewBadRequestError("a merchant policy needs merchant_id")
+		}
+		exists, err := s.policyRepo.MerchantExists(*policy.MerchantID)
+		if err != nil {
+			return helpers.NewInternalServerError()
+		}
+		if !exists {
+			return helpers.NewBadRequestError(fmt.Sprintf("merchant %d does not exist", *policy.MerchantID))
+		}
+	} else if policy.MerchantID != nil {
+		return helpers.NewBadRequestError("only a merchant policy has merchant_id")
+	}
+
+	exists, err := s.policyRepo.ExistsCode(policy.Code, id)
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+	if exists {
+		return helpers.NewConflictError(fmt.Sprintf("policy %s already exists", policy.Code))
+	}
+
+	return nil
+}
+
+func (s policyService) GetViolations(query requests.PolicyViolationQuery) ([]responses.ExpenseViolationsResponse, error) {
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return nil, helpers.NewBadRequestError("to is before from")
+	}
+
+	policies, err := s.policyRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	expenses, err := s.policyRepo.GetExpenses(query.From, query.To)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	ids := make([]uint, 0, len(expenses))
+	for _, expense := range expenses {
+		ids = append(ids, expense.ID)
+	}
+	receipts, err := s.policyRepo.CountReceipts(ids)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	violationsResp := []responses.ExpenseViolationsResponse{}
+	for _, expense := range expenses {
+		var violations []responses.PolicyViolation
+		for _, policy := range policies {
+			if query.Code != "" && policy.Code != query.Code {
+				continue
+			}
+			if violation, ok := evaluate(policy, expense, receipts[expense.ID], s.rates); ok {
+				violations = append(violations, violation)
+			}
+		}
+		if len(violations) == 0 {
+			continue
+		}
+
+		var expenseResp responses.ExpenseResponse
+		copier.Copy(&expenseResp, &expense)
+		violationsResp = append(violationsResp, responses.ExpenseViolationsResponse{Expense: expenseResp, Violations: violations})
+	}
+
+	return violationsResp, nil
+}
+
+// ProcessExpense does nothing, the policies are checked once every
+// processor has run.
+func (s policyService) ProcessExpense(expense *models.Expense) error {
+	return nil
+}
+
+// CheckExpense returns the violated policies that warn, or blocks the
+// expense with the codes of the ones that block. A new expense cannot have
+// a receipt yet, receipt policies only warn about it until it is changed.
+func (s policyService) CheckExpense(expense models.Expense) ([]responses.PolicyViolation, error) {
+	policies, err := s.policyRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	var receipts int64
+	if expense.ID != 0 && hasKind(policies, models.PolicyReceipt) {
+		counts, err := s.policyRepo.CountReceipts([]uint{expense.ID})
+		if err != nil {
+			return nil, helpers.NewInternalServerError()
+		}
+		receipts = counts[expense.ID]
+	}
+
+	var warnings []responses.PolicyViolation
+	var blocked []string
+	for _, policy := range policies {
+		violation, ok := evaluate(policy, expense, receipts, s.rates)
+		if !ok {
+			continue
+		}
+		if violation.Action == models.PolicyBlock && (expense.ID != 0 || policy.Kind != models.PolicyReceipt) {
+			blocked = append(blocked, violation.Code)
+			continue
+		}
+		violation.Action = models.PolicyWarn
+		warnings = append(warnings, violation)
+	}
+	if len(blocked) > 0 {
+		return nil, helpers.NewPolicyViolationError(blocked)
+	}
+
+	return warnings, nil
+}
+
+func hasKind(policies []models.Policy, kind string) bool {
+	for _, policy := range policies {
+		if policy.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+//go:build unit
+
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/policy/repositories"
+	"github.com/wytquant/assessment/src/policy/services"
+)
+
+var bar = uint(9)
+
+// rates has a dollar at 35 baht and no rate for XYZ.
+var rates = helpers.ExchangeRates{"USD": 35}
+
+func policies() []models.Policy {
+	return []models.Policy{
+		{ID: 1, Code: "MEALS", Kind: models.PolicyMaxAmount, Action: models.PolicyBlock, Tag: "food", Amount: 1000, Currency: "THB"},
+		{ID: 2, Code: "NO-BARS", Kind: models.PolicyMerchant, Action: models.PolicyBlock, MerchantID: &bar},
+		{ID: 3, Code: "RECEIPT", Kind: models.PolicyReceipt, Action: models.PolicyBlock, Amount: 500, Currency: "THB"},
+		{ID: 4, Code: "WKND", Kind: models.PolicyWeekend, Action: models.PolicyWarn},
+	}
+}
+
+// monday and saturday are dates in March 2023.
+var (
+	monday   = time.Date(2023, 3, 6, 0, 0, 0, 0, time.UTC)
+	saturday = time.Date(2023, 3, 11, 0, 0, 0, 0, time.UTC)
+)
+
+func TestCheckExpenseService(t *testing.T) {
+	cases := []struct {
+		name     string
+		expense  models.Expense
+		receipts map[uint]int64
+		want     []string
+		blocked  []string
+	}{
+		{name: "expense within the policies passes", expense: models.Expense{ID: 1, Amount: 300, Currency: "THB", Tags: pq.StringArray{"food"}, Date: monday}},
+		{name: "meal above the limit is blocked", expense: models.Expense{ID: 1, Amount: 1200, Currency: "THB", Tags: pq.StringArray{"food"}, Date: monday}, receipts: map[uint]int64{1: 1}, blocked: []string{"MEALS"}},
+		{name: "limit only covers its tag", expense: models.Expense{ID: 1, Amount: 1200, Currency: "THB", Tags: pq.StringArray{"hotel"}, Date: monday}, receipts: map[uint]int64{1: 1}},
+		{name: "meal in another currency within the converted limit passes", expense: models.Expense{ID: 1, Amount: 20, Currency: "USD", Tags: pq.StringArray{"food"}, Date: monday}, receipts: map[uint]int64{1: 1}},
+		{name: "meal in another currency above the converted limit is blocked", expense: models.Expense{ID: 1, Amount: 40, Currency: "USD", Tags: pq.StringArray{"food"}, Date: monday}, receipts: map[uint]int64{1: 1}, blocked: []string{"MEALS"}},
+		{name: "meal without an exchange rate is blocked", expense: models.Expense{ID: 1, Amount: 10, Currency: "XYZ", Tags: pq.StringArray{"food"}, Date: monday}, receipts: map[uint]int64{1: 1}, blocked: []string{"MEALS"}},
+		{name: "receipt above the converted amount is needed", expense: models.Expense{Amount: 20, Currency: "USD", Date: monday}, want: []string{"RECEIPT"}},
+		{name: "weekend spending warns", expense: models.Expense{ID: 1, Amount: 300, Currency: "THB", Date: saturday}, want: []string{"WKND"}},
+		{name: "forbidden merchant is blocked", expense: models.Expense{ID: 1, Amount: 300, Currency: "THB", Date: saturday, MerchantID: &bar}, blocked: []string{"NO-BARS"}},
+		{name: "changed expense without a receipt is blocked", expense: models.Expense{ID: 1, Amount: 800, Currency: "THB", Date: monday}, blocked: []string{"RECEIPT"}},
+		{name: "new expense without a receipt only warns", expense: models.Expense{Amount: 800, Currency: "THB", Date: monday}, want: []string{"RECEIPT"}},
+		{name: "income is not spending", expense: models.Expense{ID: 1, Type: models.TypeIncome, Amount: 5000, Currency: "THB", Date: saturday}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			//arrange
+			receipts := tc.receipts
+			if receipts == nil {
+				receipts = map[uint]int64{}
+			}
+			policyRepo := repositories.NewPolicyRepositoryMock()
+			policyRepo.On("GetAll").Return(policies(), nil)
+			policyRepo.On("CountReceipts", []uint{1}).Return(receipts, nil)
+
+			policyService := services.NewPolicyService(policyRepo, rates)
+
+			//act
+			got, err := policyService.CheckExpense(tc.expense)
+
+			//assert
+			if tc.blocked != nil {
+				assert.Equal(t, helpers.NewPolicyViolationError(tc.blocked), err)
+				return
+			}
+			assert.NoError(t, err)
+			var codes []string
+			for _, violation := range got {
+				assert.Equal(t, models.PolicyWarn, violation.Action)
+				codes = append(codes, violation.Code)
+			}
+			assert.Equal(t, tc.want, codes)
+		})
+	}
+}
+
+func TestCreatePolicyService(t *testing.T) {
+	t.Run("create policy defaults the currency success case", func(t *testing.T) {
+		//arrange
+		policyRepo := repositories.NewPolicyRepositoryMock()
+		policyRepo.On("ExistsCode", "MEALS", uint(0)).Return(false, nil)
+		policyRepo.On("Create", &models.Policy{Code: "MEALS", Name: "meals", Kind: models.PolicyMaxAmount, Action: models.PolicyBlock, Tag: "food", Amount: 1000, Currency: "THB"}).Return(nil)
+
+		policyService := services.NewPolicyService(policyRepo, rates)
+
+		//act
+		got, err := policyService.CreatePolicy(requests.PolicyRequest{Code: "MEALS", Name: "meals", Kind: models.PolicyMaxAmount, Action: models.PolicyBlock, Tag: "food", Amount: 1000})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "THB", got.Currency)
+		policyRepo.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name      string
+		policyReq requests.PolicyRequest
+		exists    bool
+		want      error
+	}{
+		{
+			name:      "create policy fail 400 because a limit needs an amount",
+			policyReq: requests.PolicyRequest{Code: "MEALS", Kind: models.PolicyMaxAmount, Action: models.PolicyBlock},
+			want:      helpers.NewBadRequestError("a max_amount policy needs an amount"),
+		},
+		{
+			name:      "create policy fail 400 because a weekend policy has no amount",
+			policyReq: requests.PolicyRequest{Code: "WKND", Kind: models.PolicyWeekend, Action: models.PolicyWarn, Amount: 100},
+			want:      helpers.NewBadRequestError("only max_amount and receipt policies have an amount and currency"),
+		},
+		{
+			name:      "create policy fail 400 because a merchant policy needs a merchant",
+			policyReq: requests.PolicyRequest{Code: "NO-BARS", Kind: models.PolicyMerchant, Action: models.PolicyBlock},
+			want:      helpers.NewBadRequestError("a merchant policy needs merchant_id"),
+		},
+		{
+			name:      "create policy fail 400 because the merchant does not exist",
+			policyReq: requests.PolicyRequest{Code: "NO-BARS", Kind: models.PolicyMerchant, Action: models.PolicyBlock, MerchantID: &bar},
+			want:      helpers.NewBadRequestError("merchant 9 does not exist"),
+		},
+		{
+			name:      "create policy fail 400 because only a merchant policy has a merchant",
+			policyReq: requests.PolicyRequest{Code: "WKND", Kind: models.PolicyWeekend, Action: models.PolicyWarn, MerchantID: &bar},
+			want:      helpers.NewBadRequestError("only a merchant policy has merchant_id"),
+		},
+		{
+			name:      "create policy fail 409 because the code is taken",
+			policyReq: requests.PolicyRequest{Code: "WKND", Kind: models.PolicyWeekend, Action: models.PolicyWarn},
+			exists:    true,
+			want:      helpers.NewConflictError("policy WKND already exists"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			//arrange
+			policyRepo := repositories.NewPolicyRepositoryMock()
+			policyRepo.On("MerchantExists", bar).Return(false, nil)
+			policyRepo.On("ExistsCode", tc.policyReq.Code, uint(0)).Return(tc.exists, nil)
+
+			policyService := services.NewPolicyService(policyRepo, rates)
+
+			//act
+			_, err := policyService.CreatePolicy(tc.policyReq)
+
+			//assert
+			assert.Equal(t, tc.want, err)
+			policyRepo.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestUpdatePolicyService(t *testing.T) {
+	t.Run("update policy keeps its own code success case", func(t *testing.T) {
+		//arrange
+		policy := models.Policy{Code: "WKND", Name: "weekends", Kind: models.PolicyWeekend, Action: models.PolicyBlock}
+		policyRepo := repositories.NewPolicyRepositoryMock()
+		policyRepo.On("GetByID", "4").Return(policies()[3], nil)
+		policyRepo.On("ExistsCode", "WKND", uint(4)).Return(false, nil)
+		policyRepo.On("UpdateByID", "4", policy).Return(models.Policy{ID: 4, Code: "WKND", Kind: models.PolicyWeekend, Action: models.PolicyBlock}, nil)
+
+		policyService := services.NewPolicyService(policyRepo, rates)
+
+		//act
+		got, err := policyService.UpdatePolicyByID("4", requests.PolicyRequest{Code: "WKND", Name: "weekends", Kind: models.PolicyWeekend, Action: models.PolicyBlock})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.PolicyBlock, got.Action)
+	})
+}
+
+func TestGetViolationsService(t *testing.T) {
+	t.Run("get violations lists the expenses violating a policy success case", func(t *testing.T) {
+		//arrange
+		expenses := []models.Expense{
+			{ID: 1, Title: "lunch", Amount: 300, Currency: "THB", Tags: pq.StringArray{"food"}, Date: monday},
+			{ID: 2, Title: "team dinner", Amount: 1800, Currency: "THB", Tags: pq.StringArray{"food"}, Date: saturday},
+			{ID: 3, Title: "taxi", Amount: 600, Currency: "THB", Date: monday},
+		}
+		policyRepo := repositories.NewPolicyRepositoryMock()
+		policyRepo.On("GetAll").Return(policies(), nil)
+		policyRepo.On("GetExpenses", time.Time{}, time.Time{}).Return(expenses, nil)
+		policyRepo.On("CountReceipts", []uint{1, 2, 3}).Return(map[uint]int64{2: 1}, nil)
+
+		policyService := services.NewPolicyService(policyRepo, rates)
+
+		//act
+		got, err := policyService.GetViolations(requests.PolicyViolationQuery{})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got, 2) {
+			assert.Equal(t, uint(2), got[0].Expense.ID)
+			assert.Equal(t, []responses.PolicyViolation{
+				{Code: "MEALS", Kind: models.PolicyMaxAmount, Action: models.PolicyBlock, Message: "1800.00 THB is above the limit of 1000.00 THB"},
+				{Code: "WKND", Kind: models.PolicyWeekend, Action: models.PolicyWarn, Message: "spent on a Saturday"},
+			}, got[0].Violations)
+			assert.Equal(t, uint(3), got[1].Expense.ID)
+			assert.Equal(t, "RECEIPT", got[1].Violations[0].Code)
+		}
+	})
+
+	t.Run("get violations filters by code success case", func(t *testing.T) {
+		//arrange
+		expenses := []models.Expense{{ID: 2, Amount: 1800, Currency: "THB", Tags: pq.StringArray{"food"}, Date: saturday}}
+		policyRepo := repositories.NewPolicyRepositoryMock()
+		policyRepo.On("GetAll").Return(policies(), nil)
+		policyRepo.On("GetExpenses", monday, saturday).Return(expenses, nil)
+		policyRepo.On("CountReceipts", []uint{2}).Return(map[uint]int64{}, nil)
+
+		policyService := services.NewPolicyService(policyRepo, rates)
+
+		//act
+		got, err := policyService.GetViolations(requests.PolicyViolationQuery{From: monday, To: saturday, Code: "WKND"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Len(t, got[0].Violations, 1)
+		}
+	})
+
+	t.Run("get violations fail 400 because to is before from", func(t *testing.T) {
+		//arrange
+		policyService := services.NewPolicyService(repositories.NewPolicyRepositoryMock(), rates)
+
+		//act
+		_, err := policyService.GetViolations(requests.PolicyViolationQuery{From: saturday, To: monday})
+
+		//assert
+		assert.Equal(t, helpers.NewBadRequestError("to is before from"), err)
+	})
+}
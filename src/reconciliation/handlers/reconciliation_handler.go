@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/reconciliation/services"
+)
+
+type reconciliationHandler struct {
+	reconciliationService services.ReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService services.ReconciliationService) reconciliationHandler {
+	return reconciliationHandler{reconciliationService: reconciliationService}
+}
+
+func (h reconciliationHandler) CreateReconciliation(c *gin.Context) {
+	var reconciliationReq requests.ReconciliationRequest
+	if err := c.ShouldBindJSON(&reconciliationReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	reconciliationResp, err := h.reconciliationService.CreateReconciliation(reconciliationReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, reconciliationResp)
+}
+
+func (h reconciliationHandler) GetReconciliationByID(c *gin.Context) {
+	reconciliationResp, err := h.reconciliationService.GetReconciliationByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliationResp)
+}
+
+func (h reconciliationHandler) GetAllReconciliations(c *gin.Context) {
+	reconciliationsResp, err := h.reconciliationService.GetReconciliations()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliationsResp)
+}
+
+func (h reconciliationHandler) ConfirmMatch(c *gin.Context) {
+	reconciliationResp, err := h.reconciliationService.ConfirmMatch(c.Param("id"), c.Param("match_id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliationResp)
+}
+
+func (h reconciliationHandler) RejectMatch(c *gin.Context) {
+	reconciliationResp, err := h.reconciliationService.RejectMatch(c.Param("id"), c.Param("match_id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliationResp)
+}
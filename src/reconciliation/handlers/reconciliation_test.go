@@ -0,0 +1,104 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/reconciliation/handlers"
+	services "github.com/wytquant/assessment/src/reconciliation/services/mock"
+)
+
+func TestCreateReconciliationHandler(t *testing.T) {
+	t.Run("create reconciliation success case", func(t *testing.T) {
+		//arrange
+		days := 5
+		reconciliationService := services.NewReconciliationServiceMock()
+		reconciliationService.On("CreateReconciliation", requests.ReconciliationRequest{ImportID: 1, Days: &days}).
+			Return(responses.ReconciliationResponse{ID: 1, ImportID: 1, Days: 5, Suggested: 2}, nil)
+
+		reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)
+
+		r := gin.Default()
+		r.POST("/reconciliations", reconciliationHandler.CreateReconciliation)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/reconciliations", bytes.NewBufferString(`{"import_id": 1, "days": 5}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		reconciliationService.AssertExpectations(t)
+	})
+
+	t.Run("create reconciliation fail bad request because days is too many", func(t *testing.T) {
+		//arrange
+		reconciliationHandler := handlers.NewReconciliationHandler(services.NewReconciliationServiceMock())
+
+		r := gin.Default()
+		r.POST("/reconciliations", reconciliationHandler.CreateReconciliation)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/reconciliations", bytes.NewBufferString(`{"import_id": 1, "days": 30}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestConfirmMatchHandler(t *testing.T) {
+	t.Run("confirm match success case", func(t *testing.T) {
+		//arrange
+		reconciliationService := services.NewReconciliationServiceMock()
+		reconciliationService.On("ConfirmMatch", "1", "2").Return(responses.ReconciliationResponse{ID: 1, Confirmed: 1}, nil)
+
+		reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)
+
+		r := gin.Default()
+		r.POST("/reconciliations/:id/matches/:match_id/confirm", reconciliationHandler.ConfirmMatch)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/reconciliations/1/matches/2/confirm", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		reconciliationService.AssertExpectations(t)
+	})
+
+	t.Run("confirm match fail conflict because expense is already reconciled", func(t *testing.T) {
+		//arrange
+		reconciliationService := services.NewReconciliationServiceMock()
+		reconciliationService.On("ConfirmMatch", "1", "2").
+			Return(responses.ReconciliationResponse{}, helpers.NewConflictError("expense 7 or its statement line is already reconciled"))
+
+		reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)
+
+		r := gin.Default()
+		r.POST("/reconciliations/:id/matches/:match_id/confirm", reconciliationHandler.ConfirmMatch)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/reconciliations/1/matches/2/confirm", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/src/webhook/outbox"
+	"gorm.io/gorm"
+)
+
+var errTaken = errors.New("expense or line already reconciled")
+
+type reconciliationRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepositoryDB(db *gorm.DB) ReconciliationRepository {
+	return reconciliationRepositoryDB{db: db}
+}
+
+func (r reconciliationRepositoryDB) GetImport(id uint) (models.Import, error) {
+	var importDB models.Import
+	query := r.db
+	if err := query.Preload("Rows", func(db *gorm.DB) *gorm.DB {
+		return db.Order("line")
+	}).Where("id = ?", id).First(&importDB).Error; err != nil {
+		return models.Import{}, err
+	}
+
+	return importDB, nil
+}
+
+func (r reconciliationRepositoryDB) Create(reconciliation *models.Reconciliation) error {
+	query := r.db
+	if err := query.Create(reconciliation).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r reconciliationRepositoryDB) GetByID(id string) (models.Reconciliation, error) {
+	var reconciliation models.Reconciliation
+	query := r.db
+	if err := query.Preload("Matches", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("id = ?", id).First(&reconciliation).Error; err != nil {
+		return models.Reconciliation{}, err
+	}
+
+	return reconciliation, nil
+}
+
+func (r reconciliationRepositoryDB) GetAll() ([]models.Reconciliation, error) {
+	query := r.db
+	var reconciliations []models.Reconciliation
+
+	if err := query.Preload("Matches").Order("id DESC").Find(&reconciliations).Error; err != nil {
+		return nil, err
+	}
+
+	return reconciliations, nil
+}
+
+func (r reconciliationRepositoryDB) ExistsForImport(importID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.Reconciliation{}).Where("import_id = ?", importID).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r reconciliationRepositoryDB) GetCandidates(from time.Time, to time.Time, accountID *uint) ([]models.Expense, error) {
+	var expenses []models.Expense
+	query := r.db.Model(&models.Expense{}).
+		Where("group_id IS NULL AND reconciled_at IS NULL AND date BETWEEN ? AND ?", from, to)
+	if accountID != nil {
+		query = query.Where("account_id = ? OR account_id IS NULL", *accountID)
+	}
+
+	if err := query.Order("date, id").Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r reconciliationRepositoryDB) GetExpenses(ids []uint) ([]models.Expense, error) {
+	var expenses []models.Expense
+	if len(ids) == 0 {
+		return expenses, nil
+	}
+
+	if err := r.db.Model(&models.Expense{}).Where("id IN ?", ids).Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r reconciliationRepositoryDB) GetMatch(id string, matchID string) (models.ReconciliationMatch, error) {
+	var match models.ReconciliationMatch
+	query := r.db
+	if err := query.Where("id = ? AND reconciliation_id = ?", matchID, id).First(&match).Error; err != nil {
+		return models.ReconciliationMatch{}, err
+	}
+
+	return match, nil
+}
+
+func (r reconciliationRepositoryDB) Confirm(match models.ReconciliationMatch) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Expense{}).Where("id = ? AND reconciled_at IS NULL", match.ExpenseID).
+			Update("reconciled_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errTaken
+		}
+
+		result = tx.Model(&models.ImportRow{}).Where("id = ? AND expense_id IS NULL", match.ImportRowID).
+			Update("expense_id", match.ExpenseID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errTaken
+		}
+
+		if err := tx.Model(&match).Update("status", models.MatchConfirmed).Error; err != nil {
+			return err
+		}
+		return outbox.RecordChanged(tx, models.EventExpenseUpdated, match.ExpenseID)
+	})
+	if errors.Is(err, errTaken) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r reconciliationRepositoryDB) Reject(match models.ReconciliationMatch) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if match.Status == models.MatchConfirmed {
+			if err := tx.Model(&models.Expense{}).Where("id = ?", match.ExpenseID).
+				Update("reconciled_at", nil).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.ImportRow{}).Where("id = ? AND expense_id = ?", match.ImportRowID, match.ExpenseID).
+				Update("expense_id", nil).Error; err != nil {
+				return err
+			}
+			if err := outbox.RecordChanged(tx, models.EventExpenseUpdated, match.ExpenseID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&match).Update("status", models.MatchRejected).Error
+	})
+}
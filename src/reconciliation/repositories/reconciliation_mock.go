@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type reconciliationRepositoryMock struct {
+	mock.Mock
+}
+
+func NewReconciliationRepositoryMock() *reconciliationRepositoryMock {
+	return &reconciliationRepositoryMock{}
+}
+
+func (m *reconciliationRepositoryMock) GetImport(id uint) (models.Import, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Import), args.Error(1)
+}
+
+func (m *reconciliationRepositoryMock) Create(reconciliation *models.Reconciliation) error {
+	args := m.Called(reconciliation)
+	return args.Error(0)
+}
+
+func (m *reconciliationRepositoryMock) GetByID(id string) (models.Reconciliation, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Reconciliation), args.Error(1)
+}
+
+func (m *reconciliationRepositoryMock) GetAll() ([]models.Reconciliation, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Reconciliation), args.Error(1)
+}
+
+func (m *reconciliationRepositoryMock) ExistsForImport(importID uint) (bool, error) {
+	args := m.Called(importID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *reconciliationRepositoryMock) GetCandidates(from time.Time, to time.Time, accountID *uint) ([]models.Expense, error) {
+	args := m.Called(from, to, accountID)
+	return args.Get(0).([]models.Expense), args.Error(1)
+}
+
+func (m *reconciliationRepositoryMock) GetExpenses(ids []uint) ([]models.Expense, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.Expense), args.Error(1)
+}
+
+func (m *reconciliationRepositoryMock) GetMatch(id string, matchID string) (models.ReconciliationMatch, error) {
+	args := m.Called(id, matchID)
+	return args.Get(0).(models.ReconciliationMatch), args.Error(1)
+}
+
+func (m *reconciliationRepositoryMock) Confirm(match models.ReconciliationMatch) (bool, error) {
+	args := m.Called(match)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *reconciliationRepositoryMock) Reject(match models.ReconciliationMatch) error {
+	args := m.Called(match)
+	return args.Error(0)
+}
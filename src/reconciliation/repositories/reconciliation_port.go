@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+// ReconciliationRepository reads the personal ledger only, group expenses
+// are never on a personal bank statement.
+type ReconciliationRepository interface {
+	// GetImport returns the import with its rows in line order.
+	GetImport(id uint) (models.Import, error)
+	Create(*models.Reconciliation) error
+	GetByID(id string) (models.Reconciliation, error)
+	GetAll() ([]models.Reconciliation, error)
+	ExistsForImport(importID uint) (bool, error)
+	// GetCandidates returns the expenses dated from from through to that are
+	// not reconciled yet. With an account only its expenses and the ones
+	// without an account are returned.
+	GetCandidates(from time.Time, to time.Time, accountID *uint) ([]models.Expense, error)
+	GetExpenses(ids []uint) ([]models.Expense, error)
+	GetMatch(id string, matchID string) (models.ReconciliationMatch, error)
+	// Confirm marks the expense reconciled and records it on the statement
+	// line, it reports false when either was already taken.
+	Confirm(match models.ReconciliationMatch) (bool, error)
+	// Reject rejects the match and undoes it when it was confirmed.
+	Reject(match models.ReconciliationMatch) error
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+)
+
+// A match starts at baseScore for the same amount and currency, the date adds
+// up to dateWeight and the merchant or title up to textWeight.
+const (
+	baseScore  = 0.5
+	dateWeight = 0.3
+	textWeight = 0.2
+)
+
+type candidate struct {
+	row     int
+	expense int
+	score   float64
+}
+
+// match pairs every line with at most one expense and every expense with at
+// most one line, the best scoring pairs first.
+func (s reconciliationService) match(rows []models.ImportRow, expenses []models.Expense, days int) []models.ReconciliationMatch {
+	var candidates []candidate
+	for i, row := range rows {
+		merchantID, hasMerchant := s.merchants.MatchMerchant(row.Description)
+		for j, expense := range expenses {
+			sameMerchant := hasMerchant && expense.MerchantID != nil && *expense.MerchantID == merchantID
+			if score, ok := scoreOf(row, expense, days, sameMerchant); ok {
+				candidates = append(candidates, candidate{row: i, expense: j, score: score})
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	matchedRows := map[int]bool{}
+	matchedExpenses := map[int]bool{}
+	var chosen []candidate
+	for _, c := range candidates {
+		if matchedRows[c.row] || matchedExpenses[c.expense] {
+			continue
+		}
+		matchedRows[c.row] = true
+		matchedExpenses[c.expense] = true
+		chosen = append(chosen, c)
+	}
+	sort.Slice(chosen, func(i, j int) bool {
+		return chosen[i].row < chosen[j].row
+	})
+
+	matches := []models.ReconciliationMatch{}
+	for _, c := range chosen {
+		matches = append(matches, models.ReconciliationMatch{
+			ImportRowID: rows[c.row].ID,
+			ExpenseID:   expenses[c.expense].ID,
+			Score:       c.score,
+			Status:      models.MatchSuggested,
+		})
+	}
+
+	return matches
+}
+
+// scoreOf scores a line against an expense, they only match with the same
+// amount and currency and dates at most days apart. Statements show
+// payments as negative amounts.
+func scoreOf(row models.ImportRow, expense models.Expense, days int, sameMerchant bool) (float64, bool) {
+	if math.Abs(math.Abs(row.Amount)-expense.Amount) >= 0.005 {
+		return 0, false
+	}
+	if !strings.EqualFold(row.Currency, expense.Currency) {
+		return 0, false
+	}
+
+	apart := math.Abs(math.Round(row.Date.Sub(expense.Date).Hours() / 24))
+	if apart > float64(days) {
+		return 0, false
+	}
+	dateScore := 1 - apart/float64(days+1)
+
+	textScore := 1.0
+	if !sameMerchant {
+		textScore = helpers.Similarity(row.Description, expense.Title)
+	}
+
+	return round(baseScore + dateWeight*dateScore + textWeight*textScore), true
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
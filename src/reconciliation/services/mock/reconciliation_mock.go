@@ -0,0 +1,51 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type reconciliationServiceMock struct {
+	mock.Mock
+}
+
+func NewReconciliationServiceMock() *reconciliationServiceMock {
+	return &reconciliationServiceMock{}
+}
+
+func (m *reconciliationServiceMock) CreateReconciliation(reconciliationReq requests.ReconciliationRequest) (responses.ReconciliationResponse, error) {
+	args := m.Called(reconciliationReq)
+	return args.Get(0).(responses.ReconciliationResponse), args.Error(1)
+}
+
+func (m *reconciliationServiceMock) GetReconciliationByID(id string) (responses.ReconciliationResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.ReconciliationResponse), args.Error(1)
+}
+
+func (m *reconciliationServiceMock) GetReconciliations() ([]responses.ReconciliationResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.ReconciliationResponse), args.Error(1)
+}
+
+func (m *reconciliationServiceMock) ConfirmMatch(id string, matchID string) (responses.ReconciliationResponse, error) {
+	args := m.Called(id, matchID)
+	return args.Get(0).(responses.ReconciliationResponse), args.Error(1)
+}
+
+func (m *reconciliationServiceMock) RejectMatch(id string, matchID string) (responses.ReconciliationResponse, error) {
+	args := m.Called(id, matchID)
+	return args.Get(0).(responses.ReconciliationResponse), args.Error(1)
+}
+
+func (m *reconciliationServiceMock) ProcessExpense(expense *models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
+
+func (m *reconciliationServiceMock) ValidateExpense(expense models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
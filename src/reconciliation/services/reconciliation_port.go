@@ -0,0 +1,25 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// ReconciliationService matches the lines of an imported statement with the
+// expenses of the personal ledger. It is a processor of the expense service
+// so that reconciled expenses cannot be changed.
+type ReconciliationService interface {
+	CreateReconciliation(reconciliationReq requests.ReconciliationRequest) (responses.ReconciliationResponse, error)
+	GetReconciliationByID(id string) (responses.ReconciliationResponse, error)
+	GetReconciliations() ([]responses.ReconciliationResponse, error)
+	ConfirmMatch(id string, matchID string) (responses.ReconciliationResponse, error)
+	RejectMatch(id string, matchID string) (responses.ReconciliationResponse, error)
+	ProcessExpense(expense *models.Expense) error
+	ValidateExpense(expense models.Expense) error
+}
+
+// MerchantMatcher finds the merchant of a statement line.
+type MerchantMatcher interface {
+	MatchMerchant(title string) (uint, bool)
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/reconciliation/repositories"
+)
+
+// defaultDays is how far apart the dates of a line and its expense may be
+// unless the request says otherwise, card payments often post days later.
+const defaultDays = 3
+
+type reconciliationService struct {
+	reconciliationRepo repositories.ReconciliationRepository
+	merchants          MerchantMatcher
+}
+
+func NewReconciliationService(reconciliationRepo repositories.ReconciliationRepository, merchants MerchantMatcher) ReconciliationService {
+	return reconciliationService{reconciliationRepo: reconciliationRepo, merchants: merchants}
+}
+
+// CreateReconciliation suggests a match for the lines of a pending import,
+// the lines confirmed later are skipped when the import is committed.
+func (s reconciliationService) CreateReconciliation(reconciliationReq requests.ReconciliationRequest) (responses.ReconciliationResponse, error) {
+	days := defaultDays
+	if reconciliationReq.Days != nil {
+		days = *reconciliationReq.Days
+	}
+
+	importDB, err := s.reconciliationRepo.GetImport(reconciliationReq.ImportID)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewBadRequestError(fmt.Sprintf("import %d does not exist", reconciliationReq.ImportID))
+	}
+	if importDB.Status != models.ImportPending {
+		return responses.ReconciliationResponse{}, helpers.NewConflictError(fmt.Sprintf("import %d was already committed", importDB.ID))
+	}
+
+	exists, err := s.reconciliationRepo.ExistsForImport(importDB.ID)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewInternalServerError()
+	}
+	if exists {
+		return responses.ReconciliationResponse{}, helpers.NewConflictError(fmt.Sprintf("import %d is already reconciled", importDB.ID))
+	}
+
+	rows := validRows(importDB.Rows)
+	if len(rows) == 0 {
+		return responses.ReconciliationResponse{}, helpers.NewBadRequestError(fmt.Sprintf("import %d has no valid lines", importDB.ID))
+	}
+
+	from, to := *rows[0].Date, *rows[0].Date
+	for _, row := range rows {
+		if row.Date.Before(from) {
+			from = *row.Date
+		}
+		if row.Date.After(to) {
+			to = *row.Date
+		}
+	}
+	reconciliation := models.Reconciliation{
+		ImportID: importDB.ID,
+		Days:     days,
+		From:     from.AddDate(0, 0, -days),
+		To:       to.AddDate(0, 0, days),
+	}
+
+	candidates, err := s.reconciliationRepo.GetCandidates(reconciliation.From, reconciliation.To, importDB.AccountID)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewInternalServerError()
+	}
+	reconciliation.Matches = s.match(rows, candidates, days)
+
+	if err := s.reconciliationRepo.Create(&reconciliation); err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewInternalServerError()
+	}
+
+	return report(reconciliation, importDB.Rows, candidates, candidates), nil
+}
+
+func (s reconciliationService) GetReconciliationByID(id string) (responses.ReconciliationResponse, error) {
+	reconciliation, err := s.reconciliationRepo.GetByID(id)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewNotFoundError()
+	}
+
+	importDB, err := s.reconciliationRepo.GetImport(reconciliation.ImportID)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewInternalServerError()
+	}
+
+	candidates, err := s.reconciliationRepo.GetCandidates(reconciliation.From, reconciliation.To, importDB.AccountID)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewInternalServerError()
+	}
+
+	// confirmed expenses are reconciled and no longer candidates
+	var expenseIDs []uint
+	for _, match := range reconciliation.Matches {
+		expenseIDs = append(expenseIDs, match.ExpenseID)
+	}
+	matched, err := s.reconciliationRepo.GetExpenses(expenseIDs)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewInternalServerError()
+	}
+
+	return report(reconciliation, importDB.Rows, matched, candidates), nil
+}
+
+func (s reconciliationService) GetReconciliations() ([]responses.ReconciliationResponse, error) {
+	reconciliationsResp := []responses.ReconciliationResponse{}
+
+	reconciliations, err := s.reconciliationRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	for _, reconciliation := range reconciliations {
+		reconciliationsResp = append(reconciliationsResp, summary(reconciliation))
+	}
+
+	return reconciliationsResp, nil
+}
+
+func (s reconciliationService) ConfirmMatch(id string, matchID string) (responses.ReconciliationResponse, error) {
+	match, err := s.reconciliationRepo.GetMatch(id, matchID)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewNotFoundError()
+	}
+	if match.Status == models.MatchConfirmed {
+		return responses.ReconciliationResponse{}, helpers.NewConflictError("match is already confirmed")
+	}
+	if err := s.checkPending(match); err != nil {
+		return responses.ReconciliationResponse{}, err
+	}
+
+	ok, err := s.reconciliationRepo.Confirm(match)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewInternalServerError()
+	}
+	if !ok {
+		return responses.ReconciliationResponse{}, helpers.NewConflictError(fmt.Sprintf("expense %d or its statement line is already reconciled", match.ExpenseID))
+	}
+
+	return s.GetReconciliationByID(id)
+}
+
+func (s reconciliationService) RejectMatch(id string, matchID string) (responses.ReconciliationResponse, error) {
+	match, err := s.reconciliationRepo.GetMatch(id, matchID)
+	if err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewNotFoundError()
+	}
+	if match.Status == models.MatchRejected {
+		return responses.ReconciliationResponse{}, helpers.NewConflictError("match is already rejected")
+	}
+	// a committed import skipped the confirmed lines, undoing them would
+	// leave the lines without an expense
+	if match.Status == models.MatchConfirmed {
+		if err := s.checkPending(match); err != nil {
+			return responses.ReconciliationResponse{}, err
+		}
+	}
+
+	if err := s.reconciliationRepo.Reject(match); err != nil {
+		return responses.ReconciliationResponse{}, helpers.NewInternalServerError()
+	}
+
+	return s.GetReconciliationByID(id)
+}
+
+// checkPending fails when the import of the reconciliation was committed.
+func (s reconciliationService) checkPending(match models.ReconciliationMatch) error {
+	reconciliation, err := s.reconciliationRepo.GetByID(strconv.FormatUint(uint64(match.ReconciliationID), 10))
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+	importDB, err := s.reconciliationRepo.GetImport(reconciliation.ImportID)
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+	if importDB.Status != models.ImportPending {
+		return helpers.NewConflictError(fmt.Sprintf("import %d was already committed", importDB.ID))
+	}
+
+	return nil
+}
+
+// ProcessExpense does nothing, new expenses are never reconciled.
+func (s reconciliationService) ProcessExpense(expense *models.Expense) error {
+	return nil
+}
+
+// ValidateExpense locks reconciled expenses, they agree with the statement.
+func (s reconciliationService) ValidateExpense(expense models.Expense) error {
+	if expense.ReconciledAt != nil {
+		return helpers.NewConflictError(fmt.Sprintf("expense %d is reconciled and cannot be changed", expense.ID))
+	}
+
+	return nil
+}
+
+func validRows(rows []models.ImportRow) []models.ImportRow {
+	var valid []models.ImportRow
+	for _, row := range rows {
+		if row.Error == "" && row.Date != nil {
+			valid = append(valid, row)
+		}
+	}
+	return valid
+}
+
+func summary(reconciliation models.Reconciliation) responses.ReconciliationResponse {
+	reconciliationResp := responses.ReconciliationResponse{
+		ID:        reconciliation.ID,
+		ImportID:  reconciliation.ImportID,
+		Days:      reconciliation.Days,
+		From:      reconciliation.From,
+		To:        reconciliation.To,
+		CreatedAt: reconciliation.CreatedAt,
+	}
+	for _, match := range reconciliation.Matches {
+		switch match.Status {
+		case models.MatchSuggested:
+			reconciliationResp.Suggested++
+		case models.MatchConfirmed:
+			reconciliationResp.Confirmed++
+		case models.MatchRejected:
+			reconciliationResp.Rejected++
+		}
+	}
+	return reconciliationResp
+}
+
+// report lists the matches with their line and expense, the valid lines
+// without a suggested or confirmed match and the candidates without one.
+// Lines already imported as an expense and those expenses are left out.
+func report(reconciliation models.Reconciliation, rows []models.ImportRow, matched []models.Expense, candidates []models.Expense) responses.ReconciliationResponse {
+	reconciliationResp := summary(reconciliation)
+
+	rowByID := map[uint]models.ImportRow{}
+	for _, row := range rows {
+		rowByID[row.ID] = row
+	}
+	expenseByID := map[uint]models.Expense{}
+	for _, expense := range matched {
+		expenseByID[expense.ID] = expense
+	}
+
+	matchedRows := map[uint]bool{}
+	matchedExpenses := map[uint]bool{}
+	for _, match := range reconciliation.Matches {
+		var matchResp responses.ReconciliationMatchResponse
+		copier.Copy(&matchResp.Line, rowByID[match.ImportRowID])
+		expense, ok := expenseByID[match.ExpenseID]
+		if !ok {
+			expense = models.Expense{ID: match.ExpenseID}
+		}
+		copier.Copy(&matchResp.Expense, expense)
+		matchResp.ID = match.ID
+		matchResp.Score = match.Score
+		matchResp.Status = match.Status
+		reconciliationResp.Matches = append(reconciliationResp.Matches, matchResp)
+
+		if match.Status != models.MatchRejected {
+			matchedRows[match.ImportRowID] = true
+			matchedExpenses[match.ExpenseID] = true
+		}
+	}
+
+	for _, row := range validRows(rows) {
+		if row.ExpenseID != nil {
+			matchedExpenses[*row.ExpenseID] = true
+			continue
+		}
+		if matchedRows[row.ID] {
+			continue
+		}
+		var rowResp responses.ImportRowResponse
+		copier.Copy(&rowResp, row)
+		reconciliationResp.UnmatchedLines = append(reconciliationResp.UnmatchedLines, rowResp)
+	}
+
+	for _, expense := range candidates {
+		if matchedExpenses[expense.ID] {
+			continue
+		}
+		var expenseResp responses.ExpenseResponse
+		copier.Copy(&expenseResp, expense)
+		reconciliationResp.UnmatchedExpenses = append(reconciliationResp.UnmatchedExpenses, expenseResp)
+	}
+
+	return reconciliationResp
+}
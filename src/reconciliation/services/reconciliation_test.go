@@ -0,0 +1,139 @@
+//go:build unit
+
+package services_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	merchantServices "github.com/wytquant/assessment/src/merchant/services/mock"
+	"github.com/wytquant/assessment/src/reconciliation/repositories"
+	"github.com/wytquant/assessment/src/reconciliation/services"
+)
+
+func day(d int) *time.Time {
+	date := time.Date(2023, 3, d, 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+func statement() models.Import {
+	return models.Import{ID: 1, Status: models.ImportPending, Rows: []models.ImportRow{
+		{ID: 11, ImportID: 1, Line: 1, Date: day(2), Amount: -120, Description: "GRAB *FOOD BKK", Currency: "THB"},
+		{ID: 12, ImportID: 1, Line: 2, Date: day(3), Amount: -45, Description: "7-ELEVEN 1234", Currency: "THB"},
+		{ID: 13, ImportID: 1, Line: 3, Amount: -60, Description: "MRT", Currency: "THB", Error: "invalid date"},
+	}}
+}
+
+func TestCreateReconciliationService(t *testing.T) {
+	t.Run("create reconciliation matches lines with expenses success case", func(t *testing.T) {
+		//arrange
+		merchantID := uint(5)
+		candidates := []models.Expense{
+			{ID: 1, Title: "grab food", Amount: 120, Currency: "THB", Date: *day(2), MerchantID: &merchantID},
+			{ID: 2, Title: "coffee", Amount: 80, Currency: "THB", Date: *day(3)},
+			{ID: 3, Title: "grab", Amount: 120, Currency: "THB", Date: *day(4)},
+		}
+		reconciliationRepo := repositories.NewReconciliationRepositoryMock()
+		reconciliationRepo.On("GetImport", uint(1)).Return(statement(), nil)
+		reconciliationRepo.On("ExistsForImport", uint(1)).Return(false, nil)
+		reconciliationRepo.On("GetCandidates", day(2).AddDate(0, 0, -3), day(3).AddDate(0, 0, 3), (*uint)(nil)).Return(candidates, nil)
+		reconciliationRepo.On("Create", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Reconciliation).ID = 1
+		})
+		merchantService := merchantServices.NewMerchantServiceMock()
+		merchantService.On("MatchMerchant", "GRAB *FOOD BKK").Return(uint(5), true)
+		merchantService.On("MatchMerchant", "7-ELEVEN 1234").Return(uint(0), false)
+
+		reconciliationService := services.NewReconciliationService(reconciliationRepo, merchantService)
+
+		//act
+		got, err := reconciliationService.CreateReconciliation(requests.ReconciliationRequest{ImportID: 1})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), got.ID)
+		assert.Equal(t, 3, got.Days)
+		assert.Equal(t, 1, got.Suggested)
+		if assert.Len(t, got.Matches, 1) {
+			assert.Equal(t, 1, got.Matches[0].Line.Line)
+			assert.Equal(t, uint(1), got.Matches[0].Expense.ID)
+			assert.Equal(t, 1.0, got.Matches[0].Score)
+			assert.Equal(t, models.MatchSuggested, got.Matches[0].Status)
+		}
+		if assert.Len(t, got.UnmatchedLines, 1) {
+			assert.Equal(t, 2, got.UnmatchedLines[0].Line)
+		}
+		if assert.Len(t, got.UnmatchedExpenses, 2) {
+			assert.Equal(t, uint(2), got.UnmatchedExpenses[0].ID)
+			assert.Equal(t, uint(3), got.UnmatchedExpenses[1].ID)
+		}
+	})
+
+	t.Run("create reconciliation fail conflict because the import was committed", func(t *testing.T) {
+		//arrange
+		importDB := statement()
+		importDB.Status = models.ImportCommitted
+		reconciliationRepo := repositories.NewReconciliationRepositoryMock()
+		reconciliationRepo.On("GetImport", uint(1)).Return(importDB, nil)
+
+		reconciliationService := services.NewReconciliationService(reconciliationRepo, merchantServices.NewMerchantServiceMock())
+
+		//act
+		_, err := reconciliationService.CreateReconciliation(requests.ReconciliationRequest{ImportID: 1})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+			assert.Equal(t, "import 1 was already committed", appErr.Message)
+		}
+		reconciliationRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestConfirmMatchService(t *testing.T) {
+	t.Run("confirm match fail conflict because the expense is already reconciled", func(t *testing.T) {
+		//arrange
+		match := models.ReconciliationMatch{ID: 2, ReconciliationID: 1, ImportRowID: 11, ExpenseID: 7, Score: 0.9, Status: models.MatchSuggested}
+		reconciliationRepo := repositories.NewReconciliationRepositoryMock()
+		reconciliationRepo.On("GetMatch", "1", "2").Return(match, nil)
+		reconciliationRepo.On("GetByID", "1").Return(models.Reconciliation{ID: 1, ImportID: 1}, nil)
+		reconciliationRepo.On("GetImport", uint(1)).Return(statement(), nil)
+		reconciliationRepo.On("Confirm", match).Return(false, nil)
+
+		reconciliationService := services.NewReconciliationService(reconciliationRepo, merchantServices.NewMerchantServiceMock())
+
+		//act
+		_, err := reconciliationService.ConfirmMatch("1", "2")
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+			assert.Equal(t, "expense 7 or its statement line is already reconciled", appErr.Message)
+		}
+	})
+}
+
+func TestValidateExpenseService(t *testing.T) {
+	t.Run("validate expense fail conflict because it is reconciled", func(t *testing.T) {
+		//arrange
+		reconciliationService := services.NewReconciliationService(repositories.NewReconciliationRepositoryMock(), merchantServices.NewMerchantServiceMock())
+
+		//act
+		err := reconciliationService.ValidateExpense(models.Expense{ID: 4, Title: "grab food", ReconciledAt: day(5)})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+			assert.Equal(t, "expense 4 is reconciled and cannot be changed", appErr.Message)
+		}
+	})
+}
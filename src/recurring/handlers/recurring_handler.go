@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/recurring/services"
+)
+
+type recurringExpenseHandler struct {
+	recurringService services.RecurringExpenseService
+}
+
+func NewRecurringExpenseHandler(recurringService services.RecurringExpenseService) recurringExpenseHandler {
+	return recurringExpenseHandler{recurringService: recurringService}
+}
+
+func (h recurringExpenseHandler) CreateRecurringExpense(c *gin.Context) {
+	var recurringReq requests.RecurringExpenseRequest
+	if err := c.ShouldBindJSON(&recurringReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	recurringResp, err := h.recurringService.CreateRecurringExpense(recurringReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, recurringResp)
+}
+
+func (h recurringExpenseHandler) GetRecurringExpenseByID(c *gin.Context) {
+	recurringResp, err := h.recurringService.GetRecurringExpenseByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringResp)
+}
+
+func (h recurringExpenseHandler) UpdateRecurringExpenseByID(c *gin.Context) {
+	var recurringReq requests.RecurringExpenseRequest
+	if err := c.ShouldBindJSON(&recurringReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	recurringResp, err := h.recurringService.UpdateRecurringExpenseByID(c.Param("id"), recurringReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringResp)
+}
+
+func (h recurringExpenseHandler) DeleteRecurringExpenseByID(c *gin.Context) {
+	if err := h.recurringService.DeleteRecurringExpenseByID(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h recurringExpenseHandler) GetAllRecurringExpenses(c *gin.Context) {
+	recurringsResp, err := h.recurringService.GetRecurringExpenses()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringsResp)
+}
+
+func (h recurringExpenseHandler) PauseRecurringExpense(c *gin.Context) {
+	h.changeSchedule(c, h.recurringService.PauseRecurringExpense)
+}
+
+func (h recurringExpenseHandler) ResumeRecurringExpense(c *gin.Context) {
+	h.changeSchedule(c, h.recurringService.ResumeRecurringExpense)
+}
+
+func (h recurringExpenseHandler) SkipNextOccurrence(c *gin.Context) {
+	h.changeSchedule(c, h.recurringService.SkipNextOccurrence)
+}
+
+func (h recurringExpenseHandler) changeSchedule(c *gin.Context, change func(id string) (responses.RecurringExpenseResponse, error)) {
+	recurringResp, err := change(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, recurringResp)
+}
+
+func (h recurringExpenseHandler) PreviewOccurrences(c *gin.Context) {
+	var query requests.RecurringPreviewQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	previewResp, err := h.recurringService.PreviewOccurrences(c.Param("id"), query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, previewResp)
+}
@@ -0,0 +1,112 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/recurring/handlers"
+	services "github.com/wytquant/assessment/src/recurring/services/mock"
+)
+
+func TestCreateRecurringExpenseHandler(t *testing.T) {
+	t.Run("create recurring expense success case", func(t *testing.T) {
+		//arrange
+		recurringService := services.NewRecurringExpenseServiceMock()
+		recurringService.On("CreateRecurringExpense").Return(responses.RecurringExpenseResponse{ID: 1, Title: "rent"}, nil)
+
+		recurringHandler := handlers.NewRecurringExpenseHandler(recurringService)
+
+		r := gin.Default()
+		r.POST("/recurring-expenses", recurringHandler.CreateRecurringExpense)
+
+		payload := strings.NewReader(`{"title": "rent", "amount": 12000, "frequency": "monthly", "day_of_month": 1}`)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/recurring-expenses", payload)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("create recurring expense fail bad request because frequency is unknown", func(t *testing.T) {
+		//arrange
+		recurringService := services.NewRecurringExpenseServiceMock()
+		recurringHandler := handlers.NewRecurringExpenseHandler(recurringService)
+
+		r := gin.Default()
+		r.POST("/recurring-expenses", recurringHandler.CreateRecurringExpense)
+
+		payload := strings.NewReader(`{"title": "rent", "amount": 12000, "frequency": "hourly"}`)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/recurring-expenses", payload)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPreviewOccurrencesHandler(t *testing.T) {
+	t.Run("preview occurrences success case", func(t *testing.T) {
+		//arrange
+		want := responses.RecurringPreviewResponse{ID: 1, Occurrences: []string{"2023-01-31", "2023-02-28"}}
+
+		recurringService := services.NewRecurringExpenseServiceMock()
+		recurringService.On("PreviewOccurrences", "1", requests.RecurringPreviewQuery{Count: 2}).Return(want, nil)
+
+		recurringHandler := handlers.NewRecurringExpenseHandler(recurringService)
+
+		r := gin.Default()
+		r.GET("/recurring-expenses/:id/preview", recurringHandler.PreviewOccurrences)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/recurring-expenses/1/preview?count=2", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+		var got responses.RecurringPreviewResponse
+		json.NewDecoder(w.Body).Decode(&got)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, want, got)
+	})
+}
+
+func TestPauseRecurringExpenseHandler(t *testing.T) {
+	t.Run("pause recurring expense fail case record not found", func(t *testing.T) {
+		//arrange
+		recurringService := services.NewRecurringExpenseServiceMock()
+		recurringService.On("PauseRecurringExpense", "9").Return(responses.RecurringExpenseResponse{}, helpers.NewNotFoundError())
+
+		recurringHandler := handlers.NewRecurringExpenseHandler(recurringService)
+
+		r := gin.Default()
+		r.POST("/recurring-expenses/:id/pause", recurringHandler.PauseRecurringExpense)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/recurring-expenses/9/pause", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
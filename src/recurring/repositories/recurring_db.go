@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type recurringExpenseRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewRecurringExpenseRepositoryDB(db *gorm.DB) RecurringExpenseRepository {
+	return recurringExpenseRepositoryDB{db: db}
+}
+
+func (r recurringExpenseRepositoryDB) Create(recurring *models.RecurringExpense) error {
+	query := r.db
+	if err := query.Create(recurring).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r recurringExpenseRepositoryDB) GetByID(id string) (models.RecurringExpense, error) {
+	var recurring models.RecurringExpense
+	query := r.db
+	if err := query.Where("id = $1", id).First(&recurring).Error; err != nil {
+		return models.RecurringExpense{}, err
+	}
+
+	return recurring, nil
+}
+
+func (r recurringExpenseRepositoryDB) UpdateByID(id string, recurring models.RecurringExpense) (models.RecurringExpense, error) {
+	query := r.db
+	recurringDB, err := r.GetByID(id)
+	if err != nil {
+		return models.RecurringExpense{}, err
+	}
+
+	if err := query.Model(&recurringDB).Select(
+		"title", "amount", "note", "tags", "frequency", "interval", "weekday", "day_of_month",
+		"last_business_day", "start_date", "end_date", "next_run_date",
+	).Updates(recurring).Error; err != nil {
+		return models.RecurringExpense{}, err
+	}
+
+	return recurringDB, nil
+}
+
+func (r recurringExpenseRepositoryDB) DeleteByID(id string) error {
+	query := r.db
+	result := query.Where("id = $1", id).Delete(&models.RecurringExpense{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r recurringExpenseRepositoryDB) GetAll() ([]models.RecurringExpense, error) {
+	query := r.db
+	var recurrings []models.RecurringExpense
+
+	if err := query.Order("id").Find(&recurrings).Error; err != nil {
+		return nil, err
+	}
+
+	return recurrings, nil
+}
+
+func (r recurringExpenseRepositoryDB) SetSchedule(id string, paused bool, nextRunDate time.Time) (models.RecurringExpense, error) {
+	query := r.db
+	recurringDB, err := r.GetByID(id)
+	if err != nil {
+		return models.RecurringExpense{}, err
+	}
+
+	if err := query.Model(&recurringDB).Select("paused", "next_run_date").
+		Updates(models.RecurringExpense{Paused: paused, NextRunDate: nextRunDate}).Error; err != nil {
+		return models.RecurringExpense{}, err
+	}
+
+	return recurringDB, nil
+}
+
+func (r recurringExpenseRepositoryDB) GetDue(today time.Time, limit int) ([]models.RecurringExpense, error) {
+	query := r.db
+	var recurrings []models.RecurringExpense
+
+	if err := query.Where("paused = false AND next_run_date <= ?", today).
+		Where("end_date IS NULL OR next_run_date <= end_date").
+		Order("next_run_date").Limit(limit).Find(&recurrings).Error; err != nil {
+		return nil, err
+	}
+
+	return recurrings, nil
+}
+
+// Materialize stores the expense of the template's current NextRunDate and
+// advances the schedule in one transaction, a nil expense skips the
+// occurrence. The template row is locked and re-checked, and occurrences are
+// unique per date, so replicas racing on the same template or a restart
+// mid-run can never create the expense twice. It reports false when another
+// worker already handled the occurrence.
+func (r recurringExpenseRepositoryDB) Materialize(recurring models.RecurringExpense, expense *models.Expense, nextRunDate time.Time) (bool, error) {
+	created := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current models.RecurringExpense
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", recurring.ID).First(&current).Error; err != nil {
+			return err
+		}
+		if current.Paused || !current.NextRunDate.Equal(recurring.NextRunDate) {
+			return nil
+		}
+
+		occurrence := models.RecurringOccurrence{RecurringExpenseID: current.ID, Date: current.NextRunDate}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&occurrence)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// an existing occurrence means the date was materialized before the
+		// schedule got moved back, only the schedule has to catch up
+		if result.RowsAffected > 0 && expense != nil {
+			// the expense repository stores it in this transaction, with
+			// its search text and its event in the outbox
+			if err := expenseRepositories.NewExpenseRepositoryDB(tx).Create(expense); err != nil {
+				return err
+			}
+			if err := tx.Model(&occurrence).Update("expense_id", expense.ID).Error; err != nil {
+				return err
+			}
+			created = true
+		}
+
+		return tx.Model(&current).Update("next_run_date", nextRunDate).Error
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type recurringExpenseRepositoryMock struct {
+	mock.Mock
+}
+
+func NewRecurringExpenseRepositoryMock() *recurringExpenseRepositoryMock {
+	return &recurringExpenseRepositoryMock{}
+}
+
+func (m *recurringExpenseRepositoryMock) Create(recurring *models.RecurringExpense) error {
+	args := m.Called(*recurring)
+	return args.Error(0)
+}
+
+func (m *recurringExpenseRepositoryMock) GetByID(id string) (models.RecurringExpense, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.RecurringExpense), args.Error(1)
+}
+
+func (m *recurringExpenseRepositoryMock) UpdateByID(id string, recurring models.RecurringExpense) (models.RecurringExpense, error) {
+	args := m.Called(id, recurring)
+	return args.Get(0).(models.RecurringExpense), args.Error(1)
+}
+
+func (m *recurringExpenseRepositoryMock) DeleteByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *recurringExpenseRepositoryMock) GetAll() ([]models.RecurringExpense, error) {
+	args := m.Called()
+	return args.Get(0).([]models.RecurringExpense), args.Error(1)
+}
+
+func (m *recurringExpenseRepositoryMock) SetSchedule(id string, paused bool, nextRunDate time.Time) (models.RecurringExpense, error) {
+	args := m.Called(id, paused, nextRunDate)
+	return args.Get(0).(models.RecurringExpense), args.Error(1)
+}
+
+func (m *recurringExpenseRepositoryMock) GetDue(today time.Time, limit int) ([]models.RecurringExpense, error) {
+	args := m.Called(today)
+	return args.Get(0).([]models.RecurringExpense), args.Error(1)
+}
+
+func (m *recurringExpenseRepositoryMock) Materialize(recurring models.RecurringExpense, expense *models.Expense, nextRunDate time.Time) (bool, error) {
+	args := m.Called(recurring.ID, recurring.NextRunDate, expense, nextRunDate)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+type RecurringExpenseRepository interface {
+	Create(*models.RecurringExpense) error
+	GetByID(id string) (models.RecurringExpense, error)
+	UpdateByID(id string, recurring models.RecurringExpense) (models.RecurringExpense, error)
+	DeleteByID(id string) error
+	GetAll() ([]models.RecurringExpense, error)
+	SetSchedule(id string, paused bool, nextRunDate time.Time) (models.RecurringExpense, error)
+	GetDue(today time.Time, limit int) ([]models.RecurringExpense, error)
+	Materialize(recurring models.RecurringExpense, expense *models.Expense, nextRunDate time.Time) (bool, error)
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type recurringExpenseServiceMock struct {
+	mock.Mock
+}
+
+func NewRecurringExpenseServiceMock() *recurringExpenseServiceMock {
+	return &recurringExpenseServiceMock{}
+}
+
+func (m *recurringExpenseServiceMock) CreateRecurringExpense(recurringReq requests.RecurringExpenseRequest) (responses.RecurringExpenseResponse, error) {
+	args := m.Called()
+	return args.Get(0).(responses.RecurringExpenseResponse), args.Error(1)
+}
+
+func (m *recurringExpenseServiceMock) GetRecurringExpenseByID(id string) (responses.RecurringExpenseResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.RecurringExpenseResponse), args.Error(1)
+}
+
+func (m *recurringExpenseServiceMock) UpdateRecurringExpenseByID(id string, recurringReq requests.RecurringExpenseRequest) (responses.RecurringExpenseResponse, error) {
+	args := m.Called(id, recurringReq)
+	return args.Get(0).(responses.RecurringExpenseResponse), args.Error(1)
+}
+
+func (m *recurringExpenseServiceMock) DeleteRecurringExpenseByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *recurringExpenseServiceMock) GetRecurringExpenses() ([]responses.RecurringExpenseResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.RecurringExpenseResponse), args.Error(1)
+}
+
+func (m *recurringExpenseServiceMock) PauseRecurringExpense(id string) (responses.RecurringExpenseResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.RecurringExpenseResponse), args.Error(1)
+}
+
+func (m *recurringExpenseServiceMock) ResumeRecurringExpense(id string) (responses.RecurringExpenseResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.RecurringExpenseResponse), args.Error(1)
+}
+
+func (m *recurringExpenseServiceMock) SkipNextOccurrence(id string) (responses.RecurringExpenseResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.RecurringExpenseResponse), args.Error(1)
+}
+
+func (m *recurringExpenseServiceMock) PreviewOccurrences(id string, query requests.RecurringPreviewQuery) (responses.RecurringPreviewResponse, error) {
+	args := m.Called(id, query)
+	return args.Get(0).(responses.RecurringPreviewResponse), args.Error(1)
+}
+
+func (m *recurringExpenseServiceMock) MaterializeDue(now time.Time) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
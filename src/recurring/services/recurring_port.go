@@ -0,0 +1,21 @@
+package services
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type RecurringExpenseService interface {
+	CreateRecurringExpense(recurringReq requests.RecurringExpenseRequest) (responses.RecurringExpenseResponse, error)
+	GetRecurringExpenseByID(id string) (responses.RecurringExpenseResponse, error)
+	UpdateRecurringExpenseByID(id string, recurringReq requests.RecurringExpenseRequest) (responses.RecurringExpenseResponse, error)
+	DeleteRecurringExpenseByID(id string) error
+	GetRecurringExpenses() ([]responses.RecurringExpenseResponse, error)
+	PauseRecurringExpense(id string) (responses.RecurringExpenseResponse, error)
+	ResumeRecurringExpense(id string) (responses.RecurringExpenseResponse, error)
+	SkipNextOccurrence(id string) (responses.RecurringExpenseResponse, error)
+	PreviewOccurrences(id string, query requests.RecurringPreviewQuery) (responses.RecurringPreviewResponse, error)
+	MaterializeDue(now time.Time) (int, error)
+}
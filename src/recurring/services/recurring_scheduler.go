@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunScheduler materializes due recurring expenses right away and then every
+// interval until ctx is done.
+func RunScheduler(ctx context.Context, recurringService RecurringExpenseService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for now := time.Now(); ; {
+		if _, err := recurringService.MaterializeDue(now); err != nil {
+			log.Println("fail to materialize recurring expenses:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case now = <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseServices "github.com/wytquant/assessment/src/expense/services"
+	"github.com/wytquant/assessment/src/recurring/repositories"
+)
+
+const materializeBatchSize = 100
+
+type recurringExpenseService struct {
+	recurringRepo  repositories.RecurringExpenseRepository
+	expenseService expenseServices.ExpenseService
+}
+
+// NewRecurringExpenseService creates the service, the expense service
+// processes, checks and announces every materialized expense the same as
+// expenses created one by one.
+func NewRecurringExpenseService(recurringRepo repositories.RecurringExpenseRepository, expenseService expenseServices.ExpenseService) RecurringExpenseService {
+	return recurringExpenseService{recurringRepo: recurringRepo, expenseService: expenseService}
+}
+
+func (s recurringExpenseService) CreateRecurringExpense(recurringReq requests.RecurringExpenseRequest) (responses.RecurringExpenseResponse, error) {
+	var recurring models.RecurringExpense
+	var recurringResp responses.RecurringExpenseResponse
+
+	copier.Copy(&recurring, &recurringReq)
+	if err := normalizeSchedule(&recurring); err != nil {
+		return responses.RecurringExpenseResponse{}, err
+	}
+	recurring.NextRunDate = firstOccurrence(recurring)
+
+	if err := s.recurringRepo.Create(&recurring); err != nil {
+		return responses.RecurringExpenseResponse{}, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&recurringResp, &recurring)
+
+	return recurringResp, nil
+}
+
+func (s recurringExpenseService) GetRecurringExpenseByID(id string) (responses.RecurringExpenseResponse, error) {
+	var recurringResp responses.RecurringExpenseResponse
+
+	recurring, err := s.recurringRepo.GetByID(id)
+	if err != nil {
+		return responses.RecurringExpenseResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&recurringResp, &recurring)
+
+	return recurringResp, nil
+}
+
+func (s recurringExpenseService) UpdateRecurringExpenseByID(id string, recurringReq requests.RecurringExpenseRequest) (responses.RecurringExpenseResponse, error) {
+	var recurring models.RecurringExpense
+	var recurringResp responses.RecurringExpenseResponse
+
+	current, err := s.recurringRepo.GetByID(id)
+	if err != nil {
+		return responses.RecurringExpenseResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&recurring, &recurringReq)
+	if recurring.StartDate.IsZero() {
+		recurring.StartDate = current.StartDate
+	}
+	if err := normalizeSchedule(&recurring); err != nil {
+		return responses.RecurringExpenseResponse{}, err
+	}
+	// never go back before the pending occurrence, earlier dates were already materialized
+	recurring.NextRunDate = occurrenceOnOrAfter(recurring, current.NextRunDate)
+
+	updatedRecurring, err := s.recurringRepo.UpdateByID(id, recurring)
+	if err != nil {
+		return responses.RecurringExpenseResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&recurringResp, &updatedRecurring)
+
+	return recurringResp, nil
+}
+
+func (s recurringExpenseService) DeleteRecurringExpenseByID(id string) error {
+	if err := s.recurringRepo.DeleteByID(id); err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func (s recurringExpenseService) GetRecurringExpenses() ([]responses.RecurringExpenseResponse, error) {
+	recurringsResp := []responses.RecurringExpenseResponse{}
+
+	recurrings, err := s.recurringRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&recurringsResp, &recurrings)
+
+	return recurringsResp, nil
+}
+
+func (s recurringExpenseService) PauseRecurringExpense(id string) (responses.RecurringExpenseResponse, error) {
+	return s.reschedule(id, func(recurring models.RecurringExpense) (bool, time.Time) {
+		return true, recurring.NextRunDate
+	})
+}
+
+// ResumeRecurringExpense continues from today, occurrences missed while the
+// template was paused are not materialized.
+func (s recurringExpenseService) ResumeRecurringExpense(id string) (responses.RecurringExpenseResponse, error) {
+	return s.reschedule(id, func(recurring models.RecurringExpense) (bool, time.Time) {
+		from := truncateDay(time.Now())
+		if recurring.NextRunDate.After(from) {
+			from = recurring.NextRunDate
+		}
+		return false, occurrenceOnOrAfter(recurring, from)
+	})
+}
+
+func (s recurringExpenseService) SkipNextOccurrence(id string) (responses.RecurringExpenseResponse, error) {
+	return s.reschedule(id, func(recurring models.RecurringExpense) (bool, time.Time) {
+		return recurring.Paused, nextOccurrence(recurring, recurring.NextRunDate)
+	})
+}
+
+func (s recurringExpenseService) reschedule(id string, schedule func(models.RecurringExpense) (bool, time.Time)) (responses.RecurringExpenseResponse, error) {
+	var recurringResp responses.RecurringExpenseResponse
+
+	recurring, err := s.recurringRepo.GetByID(id)
+	if err != nil {
+		return responses.RecurringExpenseResponse{}, helpers.NewNotFoundError()
+	}
+
+	paused, nextRunDate := schedule(recurring)
+
+	updatedRecurring, err := s.recurringRepo.SetSchedule(id, paused, nextRunDate)
+	if err != nil {
+		return responses.RecurringExpenseResponse{}, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&recurringResp, &updatedRecurring)
+
+	return recurringResp, nil
+}
+
+func (s recurringExpenseService) PreviewOccurrences(id string, query requests.RecurringPreviewQuery) (responses.RecurringPreviewResponse, error) {
+	recurring, err := s.recurringRepo.GetByID(id)
+	if err != nil {
+		return responses.RecurringPreviewResponse{}, helpers.NewNotFoundError()
+	}
+
+	count := query.Count
+	if count == 0 {
+		count = 5
+	}
+
+	previewResp := responses.RecurringPreviewResponse{ID: recurring.ID, Occurrences: []string{}}
+	for occurrence := recurring.NextRunDate; len(previewResp.Occurrences) < count && !isEnded(recurring, occurrence); occurrence = nextOccurrence(recurring, occurrence) {
+		previewResp.Occurrences = append(previewResp.Occurrences, occurrence.Format("2006-01-02"))
+	}
+
+	return previewResp, nil
+}
+
+// MaterializeDue creates the expenses of every occurrence due up to now and
+// returns how many were created. An occurrence whose expense is rejected,
+// by a policy that blocks it for instance, is skipped.
+func (s recurringExpenseService) MaterializeDue(now time.Time) (int, error) {
+	today := truncateDay(now)
+
+	recurrings, err := s.recurringRepo.GetDue(today, materializeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for _, recurring := range recurrings {
+		for !recurring.NextRunDate.After(today) && !isEnded(recurring, recurring.NextRunDate) {
+			nextRunDate := nextOccurrence(recurring, recurring.NextRunDate)
+
+			expense, err := s.occurrenceExpense(recurring)
+			if err != nil {
+				return created, err
+			}
+
+			ok, err := s.recurringRepo.Materialize(recurring, expense, nextRunDate)
+			if err != nil {
+				return created, err
+			}
+			if ok {
+				created++
+				s.expenseService.NotifyCreated(*expense)
+			}
+
+			recurring.NextRunDate = nextRunDate
+		}
+	}
+
+	return created, nil
+}
+
+// occurrenceExpense returns the expense of the template's next occurrence
+// once the expense service prepared it, or nil when it was rejected.
+func (s recurringExpenseService) occurrenceExpense(recurring models.RecurringExpense) (*models.Expense, error) {
+	expense := models.Expense{
+		Title:  recurring.Title,
+		Amount: recurring.Amount,
+		Note:   recurring.Note,
+		Tags:   recurring.Tags,
+		Date:   recurring.NextRunDate,
+	}
+
+	if _, err := s.expenseService.PrepareExpense(&expense); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if !ok || appErr.StatusCode >= http.StatusInternalServerError {
+			return nil, err
+		}
+		log.Println("fail to materialize recurring expense, the occurrence is skipped:", recurring.ID, appErr.Message)
+		return nil, nil
+	}
+
+	return &expense, nil
+}
+
+func normalizeSchedule(recurring *models.RecurringExpense) error {
+	if recurring.Interval == 0 {
+		recurring.Interval = 1
+	}
+	if recurring.StartDate.IsZero() {
+		recurring.StartDate = time.Now()
+	}
+	recurring.StartDate = truncateDay(recurring.StartDate)
+
+	if recurring.EndDate != nil && recurring.EndDate.Before(recurring.StartDate) {
+		return helpers.NewBadRequestError("end_date must not be before start_date")
+	}
+	if recurring.Frequency != models.FrequencyWeekly && recurring.Weekday != nil {
+		return helpers.NewBadRequestError("weekday is only allowed for weekly schedules")
+	}
+	if recurring.Frequency != models.FrequencyMonthly && (recurring.DayOfMonth != 0 || recurring.LastBusinessDay) {
+		return helpers.NewBadRequestError("day_of_month and last_business_day are only allowed for monthly schedules")
+	}
+	if recurring.DayOfMonth != 0 && recurring.LastBusinessDay {
+		return helpers.NewBadRequestError("day_of_month and last_business_day cannot be combined")
+	}
+
+	return nil
+}
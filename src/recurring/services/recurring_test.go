@@ -0,0 +1,257 @@
+//go:build unit
+
+package services_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	expenseServices "github.com/wytquant/assessment/src/expense/services/mock"
+	"github.com/wytquant/assessment/src/recurring/repositories"
+	"github.com/wytquant/assessment/src/recurring/services"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestCreateRecurringExpenseService(t *testing.T) {
+	t.Run("create monthly template on the last business day", func(t *testing.T) {
+		//arrange
+		recurringRepo := repositories.NewRecurringExpenseRepositoryMock()
+		recurringRepo.On("Create", mock.Anything).Return(nil)
+
+		recurringService := services.NewRecurringExpenseService(recurringRepo, expenseServices.NewExpenseServiceMock())
+
+		//act
+		got, err := recurringService.CreateRecurringExpense(requests.RecurringExpenseRequest{
+			Title:           "rent",
+			Amount:          12000,
+			Frequency:       models.FrequencyMonthly,
+			LastBusinessDay: true,
+			StartDate:       date(2023, 9, 5),
+		})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.Interval)
+		// 30 September 2023 is a Saturday
+		assert.Equal(t, date(2023, 9, 29), got.NextRunDate)
+	})
+
+	t.Run("create weekly template starts on the next matching weekday", func(t *testing.T) {
+		//arrange
+		recurringRepo := repositories.NewRecurringExpenseRepositoryMock()
+		recurringRepo.On("Create", mock.Anything).Return(nil)
+
+		recurringService := services.NewRecurringExpenseService(recurringRepo, expenseServices.NewExpenseServiceMock())
+		friday := int(time.Friday)
+
+		//act
+		got, err := recurringService.CreateRecurringExpense(requests.RecurringExpenseRequest{
+			Title:     "badminton court",
+			Amount:    150,
+			Frequency: models.FrequencyWeekly,
+			Weekday:   &friday,
+			StartDate: date(2023, 1, 2),
+		})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, date(2023, 1, 6), got.NextRunDate)
+	})
+
+	t.Run("create template fail case because day_of_month is used on a daily schedule", func(t *testing.T) {
+		//arrange
+		recurringService := services.NewRecurringExpenseService(repositories.NewRecurringExpenseRepositoryMock(), expenseServices.NewExpenseServiceMock())
+
+		//act
+		_, err := recurringService.CreateRecurringExpense(requests.RecurringExpenseRequest{
+			Title:      "coffee",
+			Amount:     60,
+			Frequency:  models.FrequencyDaily,
+			DayOfMonth: 5,
+		})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		}
+	})
+}
+
+func TestPreviewOccurrencesService(t *testing.T) {
+	t.Run("preview monthly occurrences clamps to short months", func(t *testing.T) {
+		//arrange
+		recurring := models.RecurringExpense{
+			ID:          1,
+			Frequency:   models.FrequencyMonthly,
+			Interval:    1,
+			DayOfMonth:  31,
+			StartDate:   date(2023, 1, 31),
+			NextRunDate: date(2023, 1, 31),
+		}
+
+		recurringRepo := repositories.NewRecurringExpenseRepositoryMock()
+		recurringRepo.On("GetByID", "1").Return(recurring, nil)
+
+		recurringService := services.NewRecurringExpenseService(recurringRepo, expenseServices.NewExpenseServiceMock())
+
+		//act
+		got, err := recurringService.PreviewOccurrences("1", requests.RecurringPreviewQuery{Count: 4})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2023-01-31", "2023-02-28", "2023-03-31", "2023-04-30"}, got.Occurrences)
+	})
+
+	t.Run("preview stops at the end date", func(t *testing.T) {
+		//arrange
+		endDate := date(2023, 1, 15)
+		recurring := models.RecurringExpense{
+			ID:          2,
+			Frequency:   models.FrequencyWeekly,
+			Interval:    1,
+			StartDate:   date(2023, 1, 2),
+			EndDate:     &endDate,
+			NextRunDate: date(2023, 1, 2),
+		}
+
+		recurringRepo := repositories.NewRecurringExpenseRepositoryMock()
+		recurringRepo.On("GetByID", "2").Return(recurring, nil)
+
+		recurringService := services.NewRecurringExpenseService(recurringRepo, expenseServices.NewExpenseServiceMock())
+
+		//act
+		got, err := recurringService.PreviewOccurrences("2", requests.RecurringPreviewQuery{})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2023-01-02", "2023-01-09"}, got.Occurrences)
+	})
+}
+
+func TestSkipNextOccurrenceService(t *testing.T) {
+	t.Run("skip next moves the schedule one occurrence ahead", func(t *testing.T) {
+		//arrange
+		recurring := models.RecurringExpense{
+			ID:          1,
+			Frequency:   models.FrequencyMonthly,
+			Interval:    1,
+			DayOfMonth:  5,
+			StartDate:   date(2023, 1, 5),
+			NextRunDate: date(2023, 3, 5),
+		}
+
+		recurringRepo := repositories.NewRecurringExpenseRepositoryMock()
+		recurringRepo.On("GetByID", "1").Return(recurring, nil)
+		recurringRepo.On("SetSchedule", "1", false, date(2023, 4, 5)).Return(recurring, nil)
+
+		recurringService := services.NewRecurringExpenseService(recurringRepo, expenseServices.NewExpenseServiceMock())
+
+		//act
+		_, err := recurringService.SkipNextOccurrence("1")
+
+		//assert
+		assert.NoError(t, err)
+		recurringRepo.AssertExpectations(t)
+	})
+
+	t.Run("pause fail case because template was not found", func(t *testing.T) {
+		//arrange
+		recurringRepo := repositories.NewRecurringExpenseRepositoryMock()
+		recurringRepo.On("GetByID", "9").Return(models.RecurringExpense{}, helpers.NewNotFoundError())
+
+		recurringService := services.NewRecurringExpenseService(recurringRepo, expenseServices.NewExpenseServiceMock())
+
+		//act
+		_, err := recurringService.PauseRecurringExpense("9")
+
+		//assert
+		assert.EqualError(t, err, helpers.NewNotFoundError().Error())
+	})
+}
+
+func TestMaterializeDueService(t *testing.T) {
+	t.Run("materialize every missed occurrence up to today", func(t *testing.T) {
+		//arrange
+		recurring := models.RecurringExpense{
+			ID:          1,
+			Title:       "phone bill",
+			Amount:      599,
+			Frequency:   models.FrequencyDaily,
+			Interval:    2,
+			StartDate:   date(2023, 1, 1),
+			NextRunDate: date(2023, 1, 1),
+		}
+
+		first := models.Expense{Title: "phone bill", Amount: 599, Date: date(2023, 1, 1)}
+		second := models.Expense{Title: "phone bill", Amount: 599, Date: date(2023, 1, 3)}
+
+		recurringRepo := repositories.NewRecurringExpenseRepositoryMock()
+		recurringRepo.On("GetDue", date(2023, 1, 4)).Return([]models.RecurringExpense{recurring}, nil)
+		recurringRepo.On("Materialize", uint(1), date(2023, 1, 1), &first, date(2023, 1, 3)).Return(true, nil)
+		recurringRepo.On("Materialize", uint(1), date(2023, 1, 3), &second, date(2023, 1, 5)).Return(false, nil)
+
+		expenseService := expenseServices.NewExpenseServiceMock()
+		expenseService.On("PrepareExpense", first).Return(nil, nil)
+		expenseService.On("PrepareExpense", second).Return(nil, nil)
+		expenseService.On("NotifyCreated", []models.Expense{first}).Return()
+
+		recurringService := services.NewRecurringExpenseService(recurringRepo, expenseService)
+
+		//act
+		got, err := recurringService.MaterializeDue(time.Date(2023, 1, 4, 8, 30, 0, 0, time.UTC))
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got)
+		recurringRepo.AssertExpectations(t)
+		expenseService.AssertNumberOfCalls(t, "NotifyCreated", 1)
+	})
+
+	t.Run("materialize skips the occurrence a policy blocks", func(t *testing.T) {
+		//arrange
+		recurring := models.RecurringExpense{ID: 1, Title: "bar tab", Amount: 900, Frequency: models.FrequencyDaily, Interval: 1, StartDate: date(2023, 1, 4), NextRunDate: date(2023, 1, 4)}
+		expense := models.Expense{Title: "bar tab", Amount: 900, Date: date(2023, 1, 4)}
+
+		recurringRepo := repositories.NewRecurringExpenseRepositoryMock()
+		recurringRepo.On("GetDue", date(2023, 1, 4)).Return([]models.RecurringExpense{recurring}, nil)
+		recurringRepo.On("Materialize", uint(1), date(2023, 1, 4), (*models.Expense)(nil), date(2023, 1, 5)).Return(false, nil)
+
+		expenseService := expenseServices.NewExpenseServiceMock()
+		expenseService.On("PrepareExpense", expense).Return(nil, helpers.NewPolicyViolationError([]string{"NO-BARS"}))
+
+		recurringService := services.NewRecurringExpenseService(recurringRepo, expenseService)
+
+		//act
+		got, err := recurringService.MaterializeDue(date(2023, 1, 4))
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, 0, got)
+		recurringRepo.AssertExpectations(t)
+		expenseService.AssertNotCalled(t, "NotifyCreated", mock.Anything)
+	})
+
+	t.Run("materialize fail case because repository error", func(t *testing.T) {
+		//arrange
+		recurringRepo := repositories.NewRecurringExpenseRepositoryMock()
+		recurringRepo.On("GetDue", date(2023, 1, 4)).Return([]models.RecurringExpense{}, helpers.NewInternalServerError())
+
+		recurringService := services.NewRecurringExpenseService(recurringRepo, expenseServices.NewExpenseServiceMock())
+
+		//act
+		_, err := recurringService.MaterializeDue(date(2023, 1, 4))
+
+		//assert
+		assert.Error(t, err)
+	})
+}
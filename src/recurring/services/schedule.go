@@ -0,0 +1,86 @@
+package services
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// firstOccurrence returns the first scheduled date on or after the start date.
+func firstOccurrence(recurring models.RecurringExpense) time.Time {
+	start := truncateDay(recurring.StartDate)
+
+	switch recurring.Frequency {
+	case models.FrequencyWeekly:
+		weekday := int(start.Weekday())
+		if recurring.Weekday != nil {
+			weekday = *recurring.Weekday
+		}
+		return start.AddDate(0, 0, (weekday-int(start.Weekday())+7)%7)
+	case models.FrequencyMonthly:
+		first := monthlyOccurrence(recurring, start.Year(), start.Month())
+		if first.Before(start) {
+			return nextOccurrence(recurring, first)
+		}
+		return first
+	default:
+		return start
+	}
+}
+
+// nextOccurrence returns the scheduled date following occurrence.
+func nextOccurrence(recurring models.RecurringExpense, occurrence time.Time) time.Time {
+	interval := recurring.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch recurring.Frequency {
+	case models.FrequencyWeekly:
+		return occurrence.AddDate(0, 0, 7*interval)
+	case models.FrequencyMonthly:
+		month := time.Date(occurrence.Year(), occurrence.Month()+time.Month(interval), 1, 0, 0, 0, 0, time.UTC)
+		return monthlyOccurrence(recurring, month.Year(), month.Month())
+	default:
+		return occurrence.AddDate(0, 0, interval)
+	}
+}
+
+// occurrenceOnOrAfter returns the first scheduled date not before day.
+func occurrenceOnOrAfter(recurring models.RecurringExpense, day time.Time) time.Time {
+	occurrence := firstOccurrence(recurring)
+	for occurrence.Before(truncateDay(day)) {
+		occurrence = nextOccurrence(recurring, occurrence)
+	}
+
+	return occurrence
+}
+
+func monthlyOccurrence(recurring models.RecurringExpense, year int, month time.Month) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+
+	if recurring.LastBusinessDay {
+		for lastDay.Weekday() == time.Saturday || lastDay.Weekday() == time.Sunday {
+			lastDay = lastDay.AddDate(0, 0, -1)
+		}
+		return lastDay
+	}
+
+	day := recurring.DayOfMonth
+	if day == 0 {
+		day = recurring.StartDate.Day()
+	}
+	if day < 0 || day > lastDay.Day() {
+		return lastDay
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func isEnded(recurring models.RecurringExpense, occurrence time.Time) bool {
+	return recurring.EndDate != nil && occurrence.After(truncateDay(*recurring.EndDate))
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/report/renderers"
+	"github.com/wytquant/assessment/src/report/services"
+)
+
+type reportHandler struct {
+	reportService services.ReportService
+}
+
+func NewReportHandler(reportService services.ReportService) reportHandler {
+	return reportHandler{reportService: reportService}
+}
+
+// GetStatement answers with a PDF by default and with the statement data
+// for format=json.
+func (h reportHandler) GetStatement(c *gin.Context) {
+	var query requests.StatementQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	statementResp, err := h.reportService.GetStatement(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	if query.Format == "json" {
+		c.JSON(http.StatusOK, statementResp)
+		return
+	}
+
+	var pdf bytes.Buffer
+	if err := renderers.RenderStatementPDF(&pdf, statementResp); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": helpers.NewInternalServerError().Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("statement-%s.pdf", statementResp.Month)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Data(http.StatusOK, "application/pdf", pdf.Bytes())
+}
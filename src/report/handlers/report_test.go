@@ -0,0 +1,109 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/report/handlers"
+	services "github.com/wytquant/assessment/src/report/services/mock"
+)
+
+func TestGetStatementHandler(t *testing.T) {
+	t.Run("get statement as pdf success case", func(t *testing.T) {
+		//arrange
+		reportService := services.NewReportServiceMock()
+		reportService.On("GetStatement", requests.StatementQuery{Month: "2023-01"}).
+			Return(responses.StatementResponse{Month: "2023-01", Currency: "THB"}, nil)
+
+		reportHandler := handlers.NewReportHandler(reportService)
+
+		r := gin.Default()
+		r.GET("/reports/statement", reportHandler.GetStatement)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/reports/statement?month=2023-01", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=statement-2023-01.pdf", w.Header().Get("Content-Disposition"))
+		assert.True(t, strings.HasPrefix(w.Body.String(), "%PDF-"))
+	})
+
+	t.Run("get statement as json success case", func(t *testing.T) {
+		//arrange
+		want := responses.StatementResponse{Month: "2023-01", Currency: "EUR", Total: 12.5, Count: 1}
+
+		reportService := services.NewReportServiceMock()
+		reportService.On("GetStatement", requests.StatementQuery{Month: "2023-01", Format: "json", Currency: "EUR"}).Return(want, nil)
+
+		reportHandler := handlers.NewReportHandler(reportService)
+
+		r := gin.Default()
+		r.GET("/reports/statement", reportHandler.GetStatement)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/reports/statement?month=2023-01&format=json&currency=EUR", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+		var got responses.StatementResponse
+		json.NewDecoder(w.Body).Decode(&got)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, want.Total, got.Total)
+		assert.Equal(t, want.Currency, got.Currency)
+	})
+
+	t.Run("get statement fail bad request because month is missing", func(t *testing.T) {
+		//arrange
+		reportHandler := handlers.NewReportHandler(services.NewReportServiceMock())
+
+		r := gin.Default()
+		r.GET("/reports/statement", reportHandler.GetStatement)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/reports/statement?format=pdf", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("get statement fail case because service returns bad request", func(t *testing.T) {
+		//arrange
+		reportService := services.NewReportServiceMock()
+		reportService.On("GetStatement", requests.StatementQuery{Month: "2023-13"}).
+			Return(responses.StatementResponse{}, helpers.NewBadRequestError("month must be formatted as YYYY-MM"))
+
+		reportHandler := handlers.NewReportHandler(reportService)
+
+		r := gin.Default()
+		r.GET("/reports/statement", reportHandler.GetStatement)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/reports/statement?month=2023-13", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,240 @@
+package renderers
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/expense/exporters"
+)
+
+// statementFont covers Latin and Thai, the PDF core fonts only know Latin-1.
+//
+//go:embed fonts/FreeSerif.ttf
+var statementFont []byte
+
+const (
+	fontFamily = "FreeSerif"
+	margin     = 15.0
+	lineHeight = 6.0
+)
+
+// transactionColumns add up to the printable width of an A4 page.
+var transactionColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Date", 20, "L"},
+	{"Description", 72, "L"},
+	{"Tags", 30, "L"},
+	{"Amount", 32, "R"},
+	{"", 26, "R"},
+}
+
+var tagColumns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Tag", 110, "L"},
+	{"Expenses", 30, "R"},
+	{"", 40, "R"},
+}
+
+var locale, _ = exporters.LookupLocale("en-GB")
+
+// RenderStatementPDF writes the statement as an A4 PDF, the transaction table
+// repeats its header on every page.
+func RenderStatementPDF(w io.Writer, statement responses.StatementResponse) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.SetCreationDate(statement.GeneratedAt)
+	pdf.SetTitle("Expense statement "+statement.Month, true)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", statementFont)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-margin + 5)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	writeHeader(pdf, statement)
+	writeTagTotals(pdf, statement)
+	writeTransactions(pdf, statement)
+
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+
+	return pdf.Output(w)
+}
+
+func writeHeader(pdf *fpdf.Fpdf, statement responses.StatementResponse) {
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont(fontFamily, "", 20)
+	pdf.CellFormat(0, 10, "Expense statement", "", 1, "L", false, 0, "")
+
+	pdf.SetFont(fontFamily, "", 12)
+	pdf.CellFormat(0, lineHeight, statement.PeriodStart.Format("January 2006"), "", 1, "L", false, 0, "")
+
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.CellFormat(0, 5, fmt.Sprintf("Period %s to %s, amounts in %s", locale.FormatDate(statement.PeriodStart),
+		locale.FormatDate(statement.PeriodEnd), statement.Currency), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, "Generated "+statement.GeneratedAt.Format("02/01/2006 15:04"), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFillColor(235, 240, 245)
+	pdf.SetFont(fontFamily, "", 12)
+	pdf.CellFormat(90, 10, fmt.Sprintf("  %d expenses", statement.Count), "", 0, "L", true, 0, "")
+	pdf.CellFormat(90, 10, fmt.Sprintf("Total %s %s  ", locale.FormatAmount(statement.Total), statement.Currency), "", 1, "R", true, 0, "")
+
+	pdf.SetFont(fontFamily, "", 9)
+	for _, total := range statement.Unconverted {
+		pdf.SetTextColor(160, 60, 0)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Not in the totals, no exchange rate: %s %s (%d expenses)",
+			locale.FormatAmount(total.Total), total.Currency, total.Count), "", 1, "L", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(4)
+}
+
+func writeTagTotals(pdf *fpdf.Fpdf, statement responses.StatementResponse) {
+	if len(statement.Tags) == 0 {
+		return
+	}
+
+	writeSectionTitle(pdf, "Subtotals by tag")
+	header := func() {
+		writeTableHeader(pdf, []string{tagColumns[0].title, tagColumns[1].title, statement.Currency},
+			[]float64{tagColumns[0].width, tagColumns[1].width, tagColumns[2].width},
+			[]string{tagColumns[0].align, tagColumns[1].align, tagColumns[2].align})
+	}
+	header()
+
+	pdf.SetFont(fontFamily, "", 10)
+	for _, total := range statement.Tags {
+		ensureSpace(pdf, lineHeight, header)
+		pdf.CellFormat(tagColumns[0].width, lineHeight, fit(pdf, total.Tag, tagColumns[0].width), "B", 0, tagColumns[0].align, false, 0, "")
+		pdf.CellFormat(tagColumns[1].width, lineHeight, fmt.Sprint(total.Count), "B", 0, tagColumns[1].align, false, 0, "")
+		pdf.CellFormat(tagColumns[2].width, lineHeight, locale.FormatAmount(total.Total), "B", 1, tagColumns[2].align, false, 0, "")
+	}
+
+	pdf.SetFont(fontFamily, "", 8)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.CellFormat(0, 5, "Expenses with several tags count under each of them.", "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(4)
+}
+
+func writeTransactions(pdf *fpdf.Fpdf, statement responses.StatementResponse) {
+	writeSectionTitle(pdf, "Transactions")
+
+	var titles, aligns []string
+	var widths []float64
+	for _, column := range transactionColumns {
+		titles = append(titles, column.title)
+		widths = append(widths, column.width)
+		aligns = append(aligns, column.align)
+	}
+	titles[len(titles)-1] = statement.Currency
+	header := func() { writeTableHeader(pdf, titles, widths, aligns) }
+	header()
+
+	if len(statement.Transactions) == 0 {
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.CellFormat(0, lineHeight, "No expenses in this month.", "", 1, "L", false, 0, "")
+		return
+	}
+
+	pdf.SetFont(fontFamily, "", 9)
+	for _, transaction := range statement.Transactions {
+		ensureSpace(pdf, lineHeight, header)
+
+		description := transaction.Title
+		if transaction.Note != "" {
+			description += " — " + transaction.Note
+		}
+		reporting := "n/a"
+		if transaction.ReportingAmount != nil {
+			reporting = locale.FormatAmount(*transaction.ReportingAmount)
+		}
+
+		values := []string{
+			locale.FormatDate(transaction.Date),
+			description,
+			strings.Join(transaction.Tags, ", "),
+			fmt.Sprintf("%s %s", locale.FormatAmount(transaction.Amount), transaction.Currency),
+			reporting,
+		}
+		for i, value := range values {
+			ln := 0
+			if i == len(values)-1 {
+				ln = 1
+			}
+			pdf.CellFormat(widths[i], lineHeight, fit(pdf, value, widths[i]), "B", ln, aligns[i], false, 0, "")
+		}
+	}
+
+	pdf.SetFont(fontFamily, "", 10)
+	ensureSpace(pdf, lineHeight, header)
+	pdf.CellFormat(widths[0]+widths[1]+widths[2]+widths[3], lineHeight+1, "Total", "", 0, "R", false, 0, "")
+	pdf.CellFormat(widths[4], lineHeight+1, locale.FormatAmount(statement.Total), "", 1, "R", false, 0, "")
+}
+
+func writeSectionTitle(pdf *fpdf.Fpdf, title string) {
+	ensureSpace(pdf, 3*lineHeight, func() {})
+	pdf.SetFont(fontFamily, "", 13)
+	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+}
+
+func writeTableHeader(pdf *fpdf.Fpdf, titles []string, widths []float64, aligns []string) {
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.SetFillColor(60, 70, 90)
+	pdf.SetTextColor(255, 255, 255)
+	for i, title := range titles {
+		ln := 0
+		if i == len(titles)-1 {
+			ln = 1
+		}
+		pdf.CellFormat(widths[i], lineHeight, title, "", ln, aligns[i], true, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// ensureSpace starts a new page, with the table header, when the next h
+// millimetres do not fit on the current one.
+func ensureSpace(pdf *fpdf.Fpdf, h float64, header func()) {
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+h <= pageHeight-margin {
+		return
+	}
+
+	size, _ := pdf.GetFontSize()
+	pdf.AddPage()
+	header()
+	pdf.SetFont(fontFamily, "", size)
+}
+
+// fit shortens text with an ellipsis to fit a cell of the given width.
+func fit(pdf *fpdf.Fpdf, text string, width float64) string {
+	available := width - 2*pdf.GetCellMargin()
+	if pdf.GetStringWidth(text) <= available {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"…") > available {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "…"
+}
@@ -0,0 +1,64 @@
+//go:build unit
+
+package renderers_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/report/renderers"
+)
+
+func TestRenderStatementPDF(t *testing.T) {
+	t.Run("render thai text over several pages", func(t *testing.T) {
+		//arrange
+		amount := 79.0
+		statement := responses.StatementResponse{
+			Month:       "2023-01",
+			PeriodStart: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:   time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			Currency:    "THB",
+			GeneratedAt: time.Date(2023, 2, 1, 9, 0, 0, 0, time.UTC),
+			Tags:        []responses.StatementTagTotal{{Tag: "อาหาร", Count: 100, Total: 7900}},
+			Unconverted: []responses.StatementCurrencyTotal{{Currency: "JPY", Count: 1, Total: 500}},
+		}
+		for i := 0; i < 100; i++ {
+			statement.Transactions = append(statement.Transactions, responses.StatementTransaction{
+				ID:              uint(i + 1),
+				Date:            statement.PeriodStart,
+				Title:           "น้ำปั่นสตรอเบอร์รี่",
+				Note:            "ตลาดนัดกลางคืน ลดราคา 10 บาท",
+				Tags:            []string{"อาหาร"},
+				Amount:          amount,
+				Currency:        "THB",
+				ReportingAmount: &amount,
+			})
+		}
+		var w bytes.Buffer
+
+		//act
+		err := renderers.RenderStatementPDF(&w, statement)
+
+		//assert
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(w.String(), "%PDF-"))
+		assert.Contains(t, w.String(), "/FontFile2")
+		assert.Contains(t, w.String(), "/Count 3")
+	})
+
+	t.Run("render an empty month", func(t *testing.T) {
+		//arrange
+		var w bytes.Buffer
+
+		//act
+		err := renderers.RenderStatementPDF(&w, responses.StatementResponse{Month: "2023-02", Currency: "THB"})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Contains(t, w.String(), "/Count 1")
+	})
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type reportServiceMock struct {
+	mock.Mock
+}
+
+func NewReportServiceMock() *reportServiceMock {
+	return &reportServiceMock{}
+}
+
+func (m *reportServiceMock) GetStatement(query requests.StatementQuery) (responses.StatementResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).(responses.StatementResponse), args.Error(1)
+}
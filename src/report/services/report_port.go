@@ -0,0 +1,10 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type ReportService interface {
+	GetStatement(query requests.StatementQuery) (responses.StatementResponse, error)
+}
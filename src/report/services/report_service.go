@@ -0,0 +1,154 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+)
+
+const untagged = "untagged"
+
+type reportService struct {
+	expenseRepo expenseRepositories.ExpenseRepository
+	rates       helpers.ExchangeRates
+}
+
+func NewReportService(expenseRepo expenseRepositories.ExpenseRepository, rates helpers.ExchangeRates) ReportService {
+	return reportService{expenseRepo: expenseRepo, rates: rates}
+}
+
+// GetStatement gathers the expenses dated in the month, converted to the
+// reporting currency (THB unless asked otherwise) with the configured rates.
+// Refunds are listed as negative amounts, income and transfers are left out.
+func (s reportService) GetStatement(query requests.StatementQuery) (responses.StatementResponse, error) {
+	month, err := time.Parse("2006-01", query.Month)
+	if err != nil {
+		return responses.StatementResponse{}, helpers.NewBadRequestError("month must be formatted as YYYY-MM")
+	}
+
+	currency := query.Currency
+	if currency == "" {
+		currency = helpers.BaseCurrency
+	}
+	if _, ok := s.rates.Convert(1, currency, helpers.BaseCurrency); !ok {
+		return responses.StatementResponse{}, helpers.NewBadRequestError("no exchange rate for currency " + currency)
+	}
+
+	start, next := helpers.PeriodBounds(models.PeriodMonthly, month)
+	end := next.AddDate(0, 0, -1)
+
+	expenses, err := s.expenseRepo.GetAll(expenseRepositories.ExpenseFilter{From: start, To: end})
+	if err != nil {
+		return responses.StatementResponse{}, helpers.NewInternalServerError()
+	}
+	sort.SliceStable(expenses, func(i, j int) bool {
+		if !expenses[i].Date.Equal(expenses[j].Date) {
+			return expenses[i].Date.Before(expenses[j].Date)
+		}
+		return expenses[i].ID < expenses[j].ID
+	})
+
+	statement := responses.StatementResponse{
+		Month:        month.Format("2006-01"),
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Currency:     currency,
+		GeneratedAt:  time.Now(),
+		Tags:         []responses.StatementTagTotal{},
+		Unconverted:  []responses.StatementCurrencyTotal{},
+		Transactions: []responses.StatementTransaction{},
+	}
+
+	tagTotals := map[string]*responses.StatementTagTotal{}
+	unconverted := map[string]*responses.StatementCurrencyTotal{}
+
+	for _, expense := range expenses {
+		spent, ok := spentAmount(expense)
+		if !ok {
+			continue
+		}
+		transaction := responses.StatementTransaction{
+			ID:       expense.ID,
+			Date:     expense.Date,
+			Title:    expense.Title,
+			Note:     expense.Note,
+			Tags:     expense.Tags,
+			Amount:   spent,
+			Currency: expense.Currency,
+		}
+
+		amount, ok := s.rates.Convert(spent, expense.Currency, currency)
+		if !ok {
+			if unconverted[expense.Currency] == nil {
+				unconverted[expense.Currency] = &responses.StatementCurrencyTotal{Currency: expense.Currency}
+			}
+			unconverted[expense.Currency].Count++
+			unconverted[expense.Currency].Total += spent
+			statement.Transactions = append(statement.Transactions, transaction)
+			continue
+		}
+
+		amount = roundCents(amount)
+		transaction.ReportingAmount = &amount
+		statement.Total += amount
+
+		tags := expense.Tags
+		if len(tags) == 0 {
+			tags = []string{untagged}
+		}
+		for _, tag := range tags {
+			if tagTotals[tag] == nil {
+				tagTotals[tag] = &responses.StatementTagTotal{Tag: tag}
+			}
+			tagTotals[tag].Count++
+			tagTotals[tag].Total += amount
+		}
+
+		statement.Transactions = append(statement.Transactions, transaction)
+	}
+
+	statement.Count = len(statement.Transactions)
+	statement.Total = roundCents(statement.Total)
+	for _, total := range tagTotals {
+		total.Total = roundCents(total.Total)
+		statement.Tags = append(statement.Tags, *total)
+	}
+	sort.Slice(statement.Tags, func(i, j int) bool {
+		if statement.Tags[i].Total != statement.Tags[j].Total {
+			return statement.Tags[i].Total > statement.Tags[j].Total
+		}
+		return statement.Tags[i].Tag < statement.Tags[j].Tag
+	})
+	for _, total := range unconverted {
+		total.Total = roundCents(total.Total)
+		statement.Unconverted = append(statement.Unconverted, *total)
+	}
+	sort.Slice(statement.Unconverted, func(i, j int) bool {
+		return statement.Unconverted[i].Currency < statement.Unconverted[j].Currency
+	})
+
+	return statement, nil
+}
+
+// spentAmount is what the record adds to spending, only expenses and refunds
+// count.
+func spentAmount(expense models.Expense) (float64, bool) {
+	switch expense.Type {
+	case models.TypeIncome, models.TypeTransfer:
+		return 0, false
+	case models.TypeRefund:
+		return -expense.Amount, true
+	default:
+		return expense.Amount, true
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,129 @@
+//go:build unit
+
+package services_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	"github.com/wytquant/assessment/src/report/services"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+var january = expenseRepositories.ExpenseFilter{From: date(2023, 1, 1), To: date(2023, 1, 31)}
+
+func TestGetStatementService(t *testing.T) {
+	t.Run("get statement with tag subtotals in the reporting currency", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetAll", january).Return([]models.Expense{
+			{ID: 3, Title: "train", Amount: 10, Currency: "EUR", Date: date(2023, 1, 20), Tags: pq.StringArray{"travel"}},
+			{ID: 1, Title: "ข้าวมันไก่", Amount: 50, Currency: "THB", Date: date(2023, 1, 5), Tags: pq.StringArray{"food"}},
+			{ID: 2, Title: "smoothie", Amount: 79, Currency: "THB", Date: date(2023, 1, 5), Tags: pq.StringArray{"food", "beverage"}},
+			{ID: 4, Title: "book", Amount: 20, Currency: "JPY", Date: date(2023, 1, 21)},
+			{ID: 5, Title: "parking", Amount: 40, Currency: "THB", Date: date(2023, 1, 22)},
+		}, nil)
+
+		reportService := services.NewReportService(expenseRepo, helpers.ExchangeRates{"EUR": 38})
+
+		//act
+		got, err := reportService.GetStatement(requests.StatementQuery{Month: "2023-01"})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "THB", got.Currency)
+		assert.Equal(t, date(2023, 1, 31), got.PeriodEnd)
+		assert.Equal(t, 5, got.Count)
+		assert.Equal(t, float64(549), got.Total)
+		if assert.Equal(t, 5, len(got.Transactions)) {
+			assert.Equal(t, "ข้าวมันไก่", got.Transactions[0].Title)
+			assert.Equal(t, uint(3), got.Transactions[2].ID)
+			assert.Equal(t, float64(380), *got.Transactions[2].ReportingAmount)
+			assert.Nil(t, got.Transactions[3].ReportingAmount)
+		}
+		assert.Equal(t, []string{"travel", "food", "beverage", "untagged"}, tagNames(got.Tags))
+		assert.Equal(t, float64(129), got.Tags[1].Total)
+		if assert.Equal(t, 1, len(got.Unconverted)) {
+			assert.Equal(t, "JPY", got.Unconverted[0].Currency)
+			assert.Equal(t, float64(20), got.Unconverted[0].Total)
+		}
+	})
+
+	t.Run("get statement converts into the requested currency", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetAll", january).Return([]models.Expense{
+			{ID: 1, Title: "lunch", Amount: 76, Currency: "THB", Date: date(2023, 1, 5)},
+		}, nil)
+
+		reportService := services.NewReportService(expenseRepo, helpers.ExchangeRates{"EUR": 38})
+
+		//act
+		got, err := reportService.GetStatement(requests.StatementQuery{Month: "2023-01", Currency: "EUR"})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, float64(2), got.Total)
+	})
+
+	t.Run("get statement fail bad request because month is malformed", func(t *testing.T) {
+		//arrange
+		reportService := services.NewReportService(expenseRepositories.NewExpenseReporitoryMock(), helpers.ExchangeRates{})
+
+		//act
+		_, err := reportService.GetStatement(requests.StatementQuery{Month: "01/2023"})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		}
+	})
+
+	t.Run("get statement fail bad request because reporting currency has no rate", func(t *testing.T) {
+		//arrange
+		reportService := services.NewReportService(expenseRepositories.NewExpenseReporitoryMock(), helpers.ExchangeRates{})
+
+		//act
+		_, err := reportService.GetStatement(requests.StatementQuery{Month: "2023-01", Currency: "USD"})
+
+		//assert
+		appErr, ok := err.(*helpers.AppError)
+		if assert.True(t, ok) {
+			assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		}
+	})
+
+	t.Run("get statement fail case because internal server error", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetAll", january).Return([]models.Expense{}, helpers.NewInternalServerError())
+
+		reportService := services.NewReportService(expenseRepo, helpers.ExchangeRates{})
+
+		//act
+		_, err := reportService.GetStatement(requests.StatementQuery{Month: "2023-01"})
+
+		//assert
+		assert.EqualError(t, err, helpers.NewInternalServerError().Error())
+	})
+}
+
+func tagNames(totals []responses.StatementTagTotal) []string {
+	var names []string
+	for _, total := range totals {
+		names = append(names, total.Tag)
+	}
+	return names
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/rule/services"
+)
+
+type ruleHandler struct {
+	ruleService services.RuleService
+}
+
+func NewRuleHandler(ruleService services.RuleService) ruleHandler {
+	return ruleHandler{ruleService: ruleService}
+}
+
+func (h ruleHandler) CreateRule(c *gin.Context) {
+	var ruleReq requests.RuleRequest
+	if err := c.ShouldBindJSON(&ruleReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	ruleResp, err := h.ruleService.CreateRule(ruleReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, ruleResp)
+}
+
+func (h ruleHandler) GetRuleByID(c *gin.Context) {
+	ruleResp, err := h.ruleService.GetRuleByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ruleResp)
+}
+
+func (h ruleHandler) UpdateRuleByID(c *gin.Context) {
+	var ruleReq requests.RuleRequest
+	if err := c.ShouldBindJSON(&ruleReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	ruleResp, err := h.ruleService.UpdateRuleByID(c.Param("id"), ruleReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, ruleResp)
+}
+
+func (h ruleHandler) DeleteRuleByID(c *gin.Context) {
+	if err := h.ruleService.DeleteRuleByID(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h ruleHandler) GetAllRules(c *gin.Context) {
+	rulesResp, err := h.ruleService.GetRules()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, rulesResp)
+}
+
+// DryRun tries a rule from the body without saving it.
+func (h ruleHandler) DryRun(c *gin.Context) {
+	var ruleReq requests.RuleRequest
+	if err := c.ShouldBindJSON(&ruleReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	dryRunResp, err := h.ruleService.DryRun(ruleReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dryRunResp)
+}
+
+func (h ruleHandler) DryRunByID(c *gin.Context) {
+	dryRunResp, err := h.ruleService.DryRunByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dryRunResp)
+}
+
+// StartRun queues applying the rules to every existing expense.
+func (h ruleHandler) StartRun(c *gin.Context) {
+	runResp, err := h.ruleService.StartRun()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, runResp)
+}
+
+func (h ruleHandler) GetRunByID(c *gin.Context) {
+	runResp, err := h.ruleService.GetRunByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, runResp)
+}
@@ -0,0 +1,117 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/rule/handlers"
+	services "github.com/wytquant/assessment/src/rule/services/mock"
+)
+
+func TestCreateRuleHandler(t *testing.T) {
+	t.Run("create rule success case", func(t *testing.T) {
+		//arrange
+		ruleReq := requests.RuleRequest{Name: "coffee", Priority: 1, TitlePattern: "starbucks", AddTags: pq.StringArray{"food/coffee"}}
+
+		ruleService := services.NewRuleServiceMock()
+		ruleService.On("CreateRule", ruleReq).Return(responses.RuleResponse{ID: 1, Name: "coffee"}, nil)
+
+		ruleHandler := handlers.NewRuleHandler(ruleService)
+
+		r := gin.Default()
+		r.POST("/rules", ruleHandler.CreateRule)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/rules", bytes.NewBufferString(`{"name": "coffee", "priority": 1, "title_pattern": "starbucks", "add_tags": ["food/coffee"]}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		ruleService.AssertExpectations(t)
+	})
+
+	t.Run("create rule fail case because pattern is invalid", func(t *testing.T) {
+		//arrange
+		ruleReq := requests.RuleRequest{Name: "coffee", TitlePattern: "caf(e", SetCategory: "Coffee"}
+
+		ruleService := services.NewRuleServiceMock()
+		ruleService.On("CreateRule", ruleReq).Return(responses.RuleResponse{}, helpers.NewBadRequestError("invalid title_pattern"))
+
+		ruleHandler := handlers.NewRuleHandler(ruleService)
+
+		r := gin.Default()
+		r.POST("/rules", ruleHandler.CreateRule)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/rules", bytes.NewBufferString(`{"name": "coffee", "title_pattern": "caf(e", "set_category": "Coffee"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"message": "invalid title_pattern"}`, w.Body.String())
+	})
+}
+
+func TestDryRunHandler(t *testing.T) {
+	t.Run("dry run by id success case", func(t *testing.T) {
+		//arrange
+		ruleService := services.NewRuleServiceMock()
+		ruleService.On("DryRunByID", "1").Return(responses.DryRunResponse{
+			Scanned: 2,
+			Matched: 1,
+			Changes: []responses.RuleChange{{ExpenseID: 4, Title: "cafe", CategoryAfter: "Coffee"}},
+		}, nil)
+
+		ruleHandler := handlers.NewRuleHandler(ruleService)
+
+		r := gin.Default()
+		r.GET("/rules/:id/dry-run", ruleHandler.DryRunByID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/rules/1/dry-run", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"scanned": 2, "matched": 1, "changes": [{"expense_id": 4, "title": "cafe", "category_after": "Coffee"}]}`, w.Body.String())
+	})
+}
+
+func TestStartRunHandler(t *testing.T) {
+	t.Run("start run success case", func(t *testing.T) {
+		//arrange
+		ruleService := services.NewRuleServiceMock()
+		ruleService.On("StartRun").Return(responses.RuleRunResponse{ID: 3, Status: "pending"}, nil)
+
+		ruleHandler := handlers.NewRuleHandler(ruleService)
+
+		r := gin.Default()
+		r.POST("/rules/apply", ruleHandler.StartRun)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/rules/apply", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		ruleService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+)
+
+type ruleRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewRuleRepositoryDB(db *gorm.DB) RuleRepository {
+	return ruleRepositoryDB{db: db}
+}
+
+func (r ruleRepositoryDB) Create(rule *models.Rule) error {
+	query := r.db
+	if err := query.Create(rule).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r ruleRepositoryDB) GetByID(id string) (models.Rule, error) {
+	var rule models.Rule
+	query := r.db
+	if err := query.Where("id = $1", id).First(&rule).Error; err != nil {
+		return models.Rule{}, err
+	}
+
+	return rule, nil
+}
+
+func (r ruleRepositoryDB) UpdateByID(id string, rule models.Rule) (models.Rule, error) {
+	query := r.db
+	ruleDB, err := r.GetByID(id)
+	if err != nil {
+		return models.Rule{}, err
+	}
+
+	// a rule is replaced as a whole so that conditions can be removed
+	if err := query.Model(&ruleDB).Select("name", "priority", "disabled", "stop_processing", "title_pattern", "note_pattern",
+		"min_amount", "max_amount", "merchant", "currency", "add_tags", "set_category", "set_note").Updates(rule).Error; err != nil {
+		return models.Rule{}, err
+	}
+
+	return ruleDB, nil
+}
+
+func (r ruleRepositoryDB) DeleteByID(id string) error {
+	query := r.db
+	result := query.Where("id = $1", id).Delete(&models.Rule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r ruleRepositoryDB) GetAll() ([]models.Rule, error) {
+	query := r.db
+	var rules []models.Rule
+
+	if err := query.Order("priority, id").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r ruleRepositoryDB) CreateRun(run *models.RuleRun) error {
+	query := r.db
+	if err := query.Create(run).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r ruleRepositoryDB) GetRunByID(id string) (models.RuleRun, error) {
+	var run models.RuleRun
+	query := r.db
+	if err := query.Where("id = $1", id).First(&run).Error; err != nil {
+		return models.RuleRun{}, err
+	}
+
+	return run, nil
+}
+
+func (r ruleRepositoryDB) ClaimPendingRun() (models.RuleRun, bool, error) {
+	var runs []models.RuleRun
+
+	err := r.db.Raw(`UPDATE rule_runs SET status = ?, started_at = ?
+		WHERE id IN (
+			SELECT id FROM rule_runs
+			WHERE status = ?
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, models.RuleRunRunning, time.Now(), models.RuleRunPending).Scan(&runs).Error
+	if err != nil {
+		return models.RuleRun{}, false, err
+	}
+	if len(runs) == 0 {
+		return models.RuleRun{}, false, nil
+	}
+
+	return runs[0], true, nil
+}
+
+func (r ruleRepositoryDB) FinishRun(run models.RuleRun) error {
+	return r.db.Model(&run).Select("status", "scanned", "changed", "error", "finished_at").Updates(run).Error
+}
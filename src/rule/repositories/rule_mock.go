@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type ruleRepositoryMock struct {
+	mock.Mock
+}
+
+func NewRuleRepositoryMock() *ruleRepositoryMock {
+	return &ruleRepositoryMock{}
+}
+
+func (m *ruleRepositoryMock) Create(rule *models.Rule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *ruleRepositoryMock) GetByID(id string) (models.Rule, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.Rule), args.Error(1)
+}
+
+func (m *ruleRepositoryMock) UpdateByID(id string, rule models.Rule) (models.Rule, error) {
+	args := m.Called(id, rule)
+	return args.Get(0).(models.Rule), args.Error(1)
+}
+
+func (m *ruleRepositoryMock) DeleteByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *ruleRepositoryMock) GetAll() ([]models.Rule, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Rule), args.Error(1)
+}
+
+func (m *ruleRepositoryMock) CreateRun(run *models.RuleRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *ruleRepositoryMock) GetRunByID(id string) (models.RuleRun, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.RuleRun), args.Error(1)
+}
+
+func (m *ruleRepositoryMock) ClaimPendingRun() (models.RuleRun, bool, error) {
+	args := m.Called()
+	return args.Get(0).(models.RuleRun), args.Bool(1), args.Error(2)
+}
+
+func (m *ruleRepositoryMock) FinishRun(run models.RuleRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import "github.com/wytquant/assessment/models"
+
+type RuleRepository interface {
+	Create(*models.Rule) error
+	GetByID(id string) (models.Rule, error)
+	UpdateByID(id string, rule models.Rule) (models.Rule, error)
+	DeleteByID(id string) error
+	// GetAll returns the rules in the order they run.
+	GetAll() ([]models.Rule, error)
+	CreateRun(*models.RuleRun) error
+	GetRunByID(id string) (models.RuleRun, error)
+	// ClaimPendingRun marks the oldest pending run as running, it reports
+	// false when there is none.
+	ClaimPendingRun() (models.RuleRun, bool, error)
+	FinishRun(run models.RuleRun) error
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+)
+
+type compiledRule struct {
+	models.Rule
+	title *regexp.Regexp
+	note  *regexp.Regexp
+}
+
+// compile checks the rule and prepares its patterns, patterns never care
+// about case.
+func compile(rule models.Rule) (compiledRule, error) {
+	compiled := compiledRule{Rule: rule}
+
+	if rule.TitlePattern == "" && rule.NotePattern == "" && rule.MinAmount == nil && rule.MaxAmount == nil &&
+		rule.Merchant == "" && rule.Currency == "" {
+		return compiledRule{}, helpers.NewBadRequestError("rule needs at least one condition")
+	}
+	if len(rule.AddTags) == 0 && rule.SetCategory == "" && rule.SetNote == "" {
+		return compiledRule{}, helpers.NewBadRequestError("rule needs at least one action")
+	}
+	if rule.MinAmount != nil && rule.MaxAmount != nil && *rule.MinAmount > *rule.MaxAmount {
+		return compiledRule{}, helpers.NewBadRequestError("min_amount must not be greater than max_amount")
+	}
+
+	var err error
+	if rule.TitlePattern != "" {
+		if compiled.title, err = regexp.Compile("(?i)" + rule.TitlePattern); err != nil {
+			return compiledRule{}, helpers.NewBadRequestError("invalid title_pattern: " + err.Error())
+		}
+	}
+	if rule.NotePattern != "" {
+		if compiled.note, err = regexp.Compile("(?i)" + rule.NotePattern); err != nil {
+			return compiledRule{}, helpers.NewBadRequestError("invalid note_pattern: " + err.Error())
+		}
+	}
+
+	return compiled, nil
+}
+
+// matches reports whether the expense meets every condition of the rule.
+// Until expenses know their merchant, the merchant is looked up in the title.
+func (r compiledRule) matches(expense models.Expense) bool {
+	if r.title != nil && !r.title.MatchString(expense.Title) {
+		return false
+	}
+	if r.note != nil && !r.note.MatchString(expense.Note) {
+		return false
+	}
+	if r.MinAmount != nil && expense.Amount < *r.MinAmount {
+		return false
+	}
+	if r.MaxAmount != nil && expense.Amount > *r.MaxAmount {
+		return false
+	}
+	if r.Merchant != "" && !strings.Contains(strings.ToLower(expense.Title), strings.ToLower(r.Merchant)) {
+		return false
+	}
+	if r.Currency != "" {
+		currency := expense.Currency
+		if currency == "" {
+			currency = helpers.BaseCurrency
+		}
+		if currency != r.Currency {
+			return false
+		}
+	}
+
+	return true
+}
+
+// apply runs the rules in order on the expense. Tags of every matching rule
+// are added while the category and note are set by the first rule setting
+// them. It reports how many rules matched.
+func apply(rules []compiledRule, expense *models.Expense) int {
+	var matched int
+	var categorySet, noteSet bool
+
+	for _, rule := range rules {
+		if rule.Disabled || !rule.matches(*expense) {
+			continue
+		}
+		matched++
+
+		expense.Tags = addTags(expense.Tags, rule.AddTags)
+		if rule.SetCategory != "" && !categorySet {
+			expense.Category = rule.SetCategory
+			categorySet = true
+		}
+		if rule.SetNote != "" && !noteSet {
+			expense.Note = rule.SetNote
+			noteSet = true
+		}
+
+		if rule.StopProcessing {
+			break
+		}
+	}
+
+	return matched
+}
+
+// addTags returns a new slice so that expenses sharing their tags are not
+// changed together.
+func addTags(tags pq.StringArray, added []string) pq.StringArray {
+	result := append(pq.StringArray{}, tags...)
+	for _, tag := range added {
+		if !contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
+
+func contains(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
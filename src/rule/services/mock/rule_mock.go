@@ -0,0 +1,71 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type ruleServiceMock struct {
+	mock.Mock
+}
+
+func NewRuleServiceMock() *ruleServiceMock {
+	return &ruleServiceMock{}
+}
+
+func (m *ruleServiceMock) CreateRule(ruleReq requests.RuleRequest) (responses.RuleResponse, error) {
+	args := m.Called(ruleReq)
+	return args.Get(0).(responses.RuleResponse), args.Error(1)
+}
+
+func (m *ruleServiceMock) GetRuleByID(id string) (responses.RuleResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.RuleResponse), args.Error(1)
+}
+
+func (m *ruleServiceMock) UpdateRuleByID(id string, ruleReq requests.RuleRequest) (responses.RuleResponse, error) {
+	args := m.Called(id, ruleReq)
+	return args.Get(0).(responses.RuleResponse), args.Error(1)
+}
+
+func (m *ruleServiceMock) DeleteRuleByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *ruleServiceMock) GetRules() ([]responses.RuleResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.RuleResponse), args.Error(1)
+}
+
+func (m *ruleServiceMock) DryRun(ruleReq requests.RuleRequest) (responses.DryRunResponse, error) {
+	args := m.Called(ruleReq)
+	return args.Get(0).(responses.DryRunResponse), args.Error(1)
+}
+
+func (m *ruleServiceMock) DryRunByID(id string) (responses.DryRunResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.DryRunResponse), args.Error(1)
+}
+
+func (m *ruleServiceMock) StartRun() (responses.RuleRunResponse, error) {
+	args := m.Called()
+	return args.Get(0).(responses.RuleRunResponse), args.Error(1)
+}
+
+func (m *ruleServiceMock) GetRunByID(id string) (responses.RuleRunResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.RuleRunResponse), args.Error(1)
+}
+
+func (m *ruleServiceMock) RunPending() (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *ruleServiceMock) ProcessExpense(expense *models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type RuleService interface {
+	CreateRule(ruleReq requests.RuleRequest) (responses.RuleResponse, error)
+	GetRuleByID(id string) (responses.RuleResponse, error)
+	UpdateRuleByID(id string, ruleReq requests.RuleRequest) (responses.RuleResponse, error)
+	DeleteRuleByID(id string) error
+	GetRules() ([]responses.RuleResponse, error)
+	// DryRun lists the personal expenses the rule would change without
+	// changing them.
+	DryRun(ruleReq requests.RuleRequest) (responses.DryRunResponse, error)
+	DryRunByID(id string) (responses.DryRunResponse, error)
+	StartRun() (responses.RuleRunResponse, error)
+	GetRunByID(id string) (responses.RuleRunResponse, error)
+	// RunPending applies the rules to every personal expense for the oldest
+	// pending run, it reports false when there was nothing to run.
+	RunPending() (bool, error)
+	// ProcessExpense applies the enabled rules to a new expense.
+	ProcessExpense(expense *models.Expense) error
+}
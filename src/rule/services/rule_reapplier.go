@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunReapplier works off the pending rule runs right away and then every
+// interval until ctx is done.
+func RunReapplier(ctx context.Context, ruleService RuleService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			ran, err := ruleService.RunPending()
+			if err != nil {
+				log.Println("fail to apply rules:", err)
+			}
+			if !ran || err != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	"github.com/wytquant/assessment/src/rule/repositories"
+)
+
+const (
+	ruleBatchSize = 500
+	// ruleCacheTTL bounds how long rules changed by another instance are
+	// not applied to new expenses.
+	ruleCacheTTL = time.Minute
+)
+
+type ruleCache struct {
+	mu       sync.Mutex
+	rules    []compiledRule
+	loadedAt time.Time
+}
+
+type ruleService struct {
+	ruleRepo    repositories.RuleRepository
+	expenseRepo expenseRepositories.ExpenseRepository
+	cache       *ruleCache
+	now         func() time.Time
+}
+
+func NewRuleService(ruleRepo repositories.RuleRepository, expenseRepo expenseRepositories.ExpenseRepository) RuleService {
+	return ruleService{ruleRepo: ruleRepo, expenseRepo: expenseRepo, cache: &ruleCache{}, now: time.Now}
+}
+
+func (s ruleService) CreateRule(ruleReq requests.RuleRequest) (responses.RuleResponse, error) {
+	var rule models.Rule
+	var ruleResp responses.RuleResponse
+
+	copier.Copy(&rule, &ruleReq)
+
+	if _, err := compile(rule); err != nil {
+		return responses.RuleResponse{}, err
+	}
+
+	if err := s.ruleRepo.Create(&rule); err != nil {
+		return responses.RuleResponse{}, helpers.NewInternalServerError()
+	}
+	s.invalidate()
+
+	copier.Copy(&ruleResp, &rule)
+
+	return ruleResp, nil
+}
+
+func (s ruleService) GetRuleByID(id string) (responses.RuleResponse, error) {
+	var ruleResp responses.RuleResponse
+
+	rule, err := s.ruleRepo.GetByID(id)
+	if err != nil {
+		return responses.RuleResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&ruleResp, &rule)
+
+	return ruleResp, nil
+}
+
+func (s ruleService) UpdateRuleByID(id string, ruleReq requests.RuleRequest) (responses.RuleResponse, error) {
+	var rule models.Rule
+	var ruleResp responses.RuleResponse
+
+	copier.Copy(&rule, &ruleReq)
+
+	if _, err := compile(rule); err != nil {
+		return responses.RuleResponse{}, err
+	}
+
+	updatedRule, err := s.ruleRepo.UpdateByID(id, rule)
+	if err != nil {
+		return responses.RuleResponse{}, helpers.NewNotFoundError()
+	}
+	s.invalidate()
+
+	copier.Copy(&ruleResp, &updatedRule)
+
+	return ruleResp, nil
+}
+
+func (s ruleService) DeleteRuleByID(id string) error {
+	if err := s.ruleRepo.DeleteByID(id); err != nil {
+		return helpers.NewNotFoundError()
+	}
+	s.invalidate()
+
+	return nil
+}
+
+func (s ruleService) GetRules() ([]responses.RuleResponse, error) {
+	rulesResp := []responses.RuleResponse{}
+
+	rules, err := s.ruleRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&rulesResp, &rules)
+
+	return rulesResp, nil
+}
+
+func (s ruleService) DryRun(ruleReq requests.RuleRequest) (responses.DryRunResponse, error) {
+	var rule models.Rule
+
+	copier.Copy(&rule, &ruleReq)
+
+	compiled, err := compile(rule)
+	if err != nil {
+		return responses.DryRunResponse{}, err
+	}
+	// an unsaved rule is tried even when it would be saved disabled
+	compiled.Disabled = false
+
+	return s.dryRun(compiled)
+}
+
+func (s ruleService) DryRunByID(id string) (responses.DryRunResponse, error) {
+	rule, err := s.ruleRepo.GetByID(id)
+	if err != nil {
+		return responses.DryRunResponse{}, helpers.NewNotFoundError()
+	}
+
+	compiled, err := compile(rule)
+	if err != nil {
+		return responses.DryRunResponse{}, helpers.NewInternalServerError()
+	}
+	compiled.Disabled = false
+
+	return s.dryRun(compiled)
+}
+
+func (s ruleService) dryRun(rule compiledRule) (responses.DryRunResponse, error) {
+	dryRunResp := responses.DryRunResponse{Changes: []responses.RuleChange{}}
+
+	err := s.expenseRepo.FindInBatches(expenseRepositories.ExpenseFilter{}, ruleBatchSize, func(expenses []models.Expense) error {
+		for _, expense := range expenses {
+			dryRunResp.Scanned++
+
+			changed := expense
+			if apply([]compiledRule{rule}, &changed) == 0 {
+				continue
+			}
+			dryRunResp.Matched++
+
+			if change, ok := diff(expense, changed); ok {
+				dryRunResp.Changes = append(dryRunResp.Changes, change)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return responses.DryRunResponse{}, helpers.NewInternalServerError()
+	}
+
+	return dryRunResp, nil
+}
+
+// diff describes what changed between the expense and its changed copy, it
+// reports false when nothing did.
+func diff(before, after models.Expense) (responses.RuleChange, bool) {
+	change := responses.RuleChange{ExpenseID: before.ID, Title: before.Title}
+	changed := false
+
+	for _, tag := range after.Tags {
+		if !contains(before.Tags, tag) {
+			change.AddedTags = append(change.AddedTags, tag)
+			changed = true
+		}
+	}
+	if before.Category != after.Category {
+		change.CategoryBefore, change.CategoryAfter = before.Category, after.Category
+		changed = true
+	}
+	if before.Note != after.Note {
+		change.NoteBefore, change.NoteAfter = before.Note, after.Note
+		changed = true
+	}
+
+	return change, changed
+}
+
+func (s ruleService) StartRun() (responses.RuleRunResponse, error) {
+	var runResp responses.RuleRunResponse
+
+	run := models.RuleRun{Status: models.RuleRunPending}
+	if err := s.ruleRepo.CreateRun(&run); err != nil {
+		return responses.RuleRunResponse{}, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&runResp, &run)
+
+	return runResp, nil
+}
+
+func (s ruleService) GetRunByID(id string) (responses.RuleRunResponse, error) {
+	var runResp responses.RuleRunResponse
+
+	run, err := s.ruleRepo.GetRunByID(id)
+	if err != nil {
+		return responses.RuleRunResponse{}, helpers.NewNotFoundError()
+	}
+
+	copier.Copy(&runResp, &run)
+
+	return runResp, nil
+}
+
+func (s ruleService) RunPending() (bool, error) {
+	run, ok, err := s.ruleRepo.ClaimPendingRun()
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+
+	// the run loads the rules itself so that it never works on a stale cache
+	rules, err := s.loadRules()
+	if err == nil {
+		err = s.expenseRepo.FindInBatches(expenseRepositories.ExpenseFilter{}, ruleBatchSize, func(expenses []models.Expense) error {
+			for _, expense := range expenses {
+				run.Scanned++
+
+				changed := expense
+				if apply(rules, &changed) == 0 {
+					continue
+				}
+				if _, ok := diff(expense, changed); !ok {
+					continue
+				}
+				if _, err := s.expenseRepo.UpdateByID(strconv.FormatUint(uint64(expense.ID), 10), changed); err != nil {
+					return err
+				}
+				run.Changed++
+			}
+			return nil
+		})
+	}
+
+	finishedAt := s.now()
+	run.FinishedAt = &finishedAt
+	run.Status = models.RuleRunCompleted
+	if err != nil {
+		run.Status = models.RuleRunFailed
+		run.Error = err.Error()
+	}
+
+	if err := s.ruleRepo.FinishRun(run); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// ProcessExpense applies the rules to a new or changed expense of the
+// personal ledger, rules are personal and leave group ledgers alone.
+func (s ruleService) ProcessExpense(expense *models.Expense) error {
+	if expense.GroupID != nil {
+		return nil
+	}
+
+	rules, err := s.rules()
+	if err != nil {
+		return helpers.NewInternalServerError()
+	}
+
+	apply(rules, expense)
+
+	return nil
+}
+
+// rules returns the cached rules, loading them again once they are older
+// than ruleCacheTTL.
+func (s ruleService) rules() ([]compiledRule, error) {
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+
+	if !s.cache.loadedAt.IsZero() && s.now().Sub(s.cache.loadedAt) < ruleCacheTTL {
+		return s.cache.rules, nil
+	}
+
+	rules, err := s.loadRules()
+	if err != nil {
+		return nil, err
+	}
+	s.cache.rules = rules
+	s.cache.loadedAt = s.now()
+
+	return rules, nil
+}
+
+func (s ruleService) loadRules() ([]compiledRule, error) {
+	rules, err := s.ruleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Disabled {
+			continue
+		}
+		c, err := compile(rule)
+		if err != nil {
+			return nil, errors.New("rule " + rule.Name + " is invalid")
+		}
+		compiled = append(compiled, c)
+	}
+
+	return compiled, nil
+}
+
+func (s ruleService) invalidate() {
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+
+	s.cache.loadedAt = time.Time{}
+}
@@ -0,0 +1,265 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	"github.com/wytquant/assessment/src/rule/repositories"
+	"github.com/wytquant/assessment/src/rule/services"
+)
+
+func amount(v float64) *float64 {
+	return &v
+}
+
+var rules = []models.Rule{
+	{ID: 1, Name: "coffee", Priority: 1, TitlePattern: `starbucks|cafe`, AddTags: pq.StringArray{"food/coffee"}, SetCategory: "Coffee"},
+	{ID: 2, Name: "small food", Priority: 2, MaxAmount: amount(200), Currency: "THB", AddTags: pq.StringArray{"food"}, SetCategory: "Food"},
+	{ID: 3, Name: "grab", Priority: 3, Merchant: "grab", AddTags: pq.StringArray{"travel"}, StopProcessing: true},
+	{ID: 4, Name: "after grab", Priority: 4, MinAmount: amount(0), SetNote: "checked"},
+	{ID: 5, Name: "off", Priority: 5, Disabled: true, TitlePattern: `.`, AddTags: pq.StringArray{"never"}},
+}
+
+func TestProcessExpenseService(t *testing.T) {
+	t.Run("process expense applies matching rules by priority", func(t *testing.T) {
+		//arrange
+		ruleRepo := repositories.NewRuleRepositoryMock()
+		ruleRepo.On("GetAll").Return(rules, nil).Once()
+
+		ruleService := services.NewRuleService(ruleRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		coffee := models.Expense{Title: "STARBUCKS Siam", Amount: 150, Note: "latte", Tags: pq.StringArray{"food"}}
+		ride := models.Expense{Title: "Grab ride", Amount: 320, Currency: "THB", Note: "office"}
+
+		//act
+		errCoffee := ruleService.ProcessExpense(&coffee)
+		errRide := ruleService.ProcessExpense(&ride)
+
+		//assert
+		assert.NoError(t, errCoffee)
+		assert.NoError(t, errRide)
+		assert.Equal(t, pq.StringArray{"food", "food/coffee"}, coffee.Tags)
+		assert.Equal(t, "Coffee", coffee.Category)
+		assert.Equal(t, "checked", coffee.Note)
+		assert.Equal(t, pq.StringArray{"travel"}, ride.Tags)
+		assert.Equal(t, "office", ride.Note)
+		ruleRepo.AssertNumberOfCalls(t, "GetAll", 1)
+	})
+
+	t.Run("process expense does not change tags shared with another expense", func(t *testing.T) {
+		//arrange
+		ruleRepo := repositories.NewRuleRepositoryMock()
+		ruleRepo.On("GetAll").Return(rules[:1], nil)
+
+		ruleService := services.NewRuleService(ruleRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		shared := make(pq.StringArray, 1, 2)
+		shared[0] = "imported"
+		expenses := []models.Expense{{Title: "cafe", Tags: shared}, {Title: "rent", Tags: shared}}
+
+		//act
+		err := ruleService.ProcessExpense(&expenses[0])
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, pq.StringArray{"imported", "food/coffee"}, expenses[0].Tags)
+		assert.Equal(t, pq.StringArray{"imported"}, expenses[1].Tags)
+	})
+
+	t.Run("process expense leaves group expenses alone", func(t *testing.T) {
+		//arrange
+		ruleRepo := repositories.NewRuleRepositoryMock()
+		ruleService := services.NewRuleService(ruleRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		groupID := uint(3)
+		expense := models.Expense{Title: "cafe", GroupID: &groupID}
+
+		//act
+		err := ruleService.ProcessExpense(&expense)
+
+		//assert
+		assert.NoError(t, err)
+		assert.Empty(t, expense.Tags)
+		ruleRepo.AssertNotCalled(t, "GetAll")
+	})
+
+	t.Run("process expense fail case because rules cannot be loaded", func(t *testing.T) {
+		//arrange
+		ruleRepo := repositories.NewRuleRepositoryMock()
+		ruleRepo.On("GetAll").Return([]models.Rule{}, errors.New("connection refused"))
+
+		ruleService := services.NewRuleService(ruleRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		//act
+		err := ruleService.ProcessExpense(&models.Expense{Title: "cafe"})
+
+		//assert
+		assert.Equal(t, helpers.NewInternalServerError(), err)
+	})
+}
+
+func TestCreateRuleService(t *testing.T) {
+	t.Run("create rule reloads the cached rules", func(t *testing.T) {
+		//arrange
+		ruleRepo := repositories.NewRuleRepositoryMock()
+		ruleRepo.On("GetAll").Return([]models.Rule{}, nil).Once()
+		ruleRepo.On("Create", mock.Anything).Return(nil)
+		ruleRepo.On("GetAll").Return(rules[:1], nil).Once()
+
+		ruleService := services.NewRuleService(ruleRepo, expenseRepositories.NewExpenseReporitoryMock())
+		ruleService.ProcessExpense(&models.Expense{Title: "cafe"})
+
+		expense := models.Expense{Title: "cafe"}
+
+		//act
+		_, err := ruleService.CreateRule(requests.RuleRequest{Name: "coffee", TitlePattern: "cafe", AddTags: pq.StringArray{"food/coffee"}})
+		ruleService.ProcessExpense(&expense)
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, pq.StringArray{"food/coffee"}, expense.Tags)
+	})
+
+	tests := []struct {
+		name    string
+		ruleReq requests.RuleRequest
+		message string
+	}{
+		{"without condition", requests.RuleRequest{Name: "all", SetCategory: "Other"}, "rule needs at least one condition"},
+		{"without action", requests.RuleRequest{Name: "none", TitlePattern: "cafe"}, "rule needs at least one action"},
+		{"with inverted amount range", requests.RuleRequest{Name: "range", MinAmount: amount(100), MaxAmount: amount(10), SetCategory: "Other"}, "min_amount must not be greater than max_amount"},
+		{"with invalid pattern", requests.RuleRequest{Name: "bad", TitlePattern: "caf(e", SetCategory: "Other"}, "invalid title_pattern: error parsing regexp: missing closing ): `(?i)caf(e`"},
+	}
+	for _, tt := range tests {
+		t.Run("create rule fail case because rule is "+tt.name, func(t *testing.T) {
+			//arrange
+			ruleRepo := repositories.NewRuleRepositoryMock()
+			ruleService := services.NewRuleService(ruleRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+			//act
+			_, err := ruleService.CreateRule(tt.ruleReq)
+
+			//assert
+			assert.Equal(t, helpers.NewBadRequestError(tt.message), err)
+			ruleRepo.AssertNumberOfCalls(t, "Create", 0)
+		})
+	}
+}
+
+func TestDryRunService(t *testing.T) {
+	t.Run("dry run lists the expenses the rule would change", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("FindInBatches", expenseRepositories.ExpenseFilter{}, 500).Return([][]models.Expense{
+			{{ID: 1, Title: "Cafe Amazon", Note: "latte", Tags: pq.StringArray{"food"}}, {ID: 2, Title: "rent", Note: "may"}},
+			{{ID: 3, Title: "cafe", Note: "mocha", Tags: pq.StringArray{"food/coffee"}, Category: "Coffee"}},
+		}, nil)
+
+		ruleService := services.NewRuleService(repositories.NewRuleRepositoryMock(), expenseRepo)
+
+		//act
+		got, err := ruleService.DryRun(requests.RuleRequest{Name: "coffee", Disabled: true, TitlePattern: "cafe", AddTags: pq.StringArray{"food/coffee"}, SetCategory: "Coffee"})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, responses.DryRunResponse{
+			Scanned: 3,
+			Matched: 2,
+			Changes: []responses.RuleChange{
+				{ExpenseID: 1, Title: "Cafe Amazon", AddedTags: pq.StringArray{"food/coffee"}, CategoryAfter: "Coffee"},
+			},
+		}, got)
+	})
+
+	t.Run("dry run by id fail case because rule is not found", func(t *testing.T) {
+		//arrange
+		ruleRepo := repositories.NewRuleRepositoryMock()
+		ruleRepo.On("GetByID", "9").Return(models.Rule{}, errors.New("record not found"))
+
+		ruleService := services.NewRuleService(ruleRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		//act
+		_, err := ruleService.DryRunByID("9")
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+	})
+}
+
+func TestRunPendingService(t *testing.T) {
+	t.Run("run pending updates the changed expenses", func(t *testing.T) {
+		//arrange
+		ruleRepo := repositories.NewRuleRepositoryMock()
+		ruleRepo.On("ClaimPendingRun").Return(models.RuleRun{ID: 7, Status: models.RuleRunRunning}, true, nil)
+		ruleRepo.On("GetAll").Return(rules[:1], nil)
+		ruleRepo.On("FinishRun", mock.MatchedBy(func(run models.RuleRun) bool {
+			return run.ID == 7 && run.Status == models.RuleRunCompleted && run.Scanned == 2 && run.Changed == 1 && run.FinishedAt != nil
+		})).Return(nil)
+
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("FindInBatches", expenseRepositories.ExpenseFilter{}, 500).Return([][]models.Expense{
+			{{ID: 1, Title: "cafe"}, {ID: 2, Title: "cafe", Tags: pq.StringArray{"food/coffee"}, Category: "Coffee"}},
+		}, nil)
+		expenseRepo.On("UpdateByID", "1", models.Expense{ID: 1, Title: "cafe", Tags: pq.StringArray{"food/coffee"}, Category: "Coffee"}).Return(models.Expense{}, nil)
+
+		ruleService := services.NewRuleService(ruleRepo, expenseRepo)
+
+		//act
+		ran, err := ruleService.RunPending()
+
+		//assert
+		assert.True(t, ran)
+		assert.NoError(t, err)
+		ruleRepo.AssertExpectations(t)
+		expenseRepo.AssertNumberOfCalls(t, "UpdateByID", 1)
+	})
+
+	t.Run("run pending records the failure of the run", func(t *testing.T) {
+		//arrange
+		ruleRepo := repositories.NewRuleRepositoryMock()
+		ruleRepo.On("ClaimPendingRun").Return(models.RuleRun{ID: 7, Status: models.RuleRunRunning}, true, nil)
+		ruleRepo.On("GetAll").Return(rules[:1], nil)
+		ruleRepo.On("FinishRun", mock.MatchedBy(func(run models.RuleRun) bool {
+			return run.Status == models.RuleRunFailed && run.Error == "connection refused"
+		})).Return(nil)
+
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("FindInBatches", expenseRepositories.ExpenseFilter{}, 500).Return([][]models.Expense{}, errors.New("connection refused"))
+
+		ruleService := services.NewRuleService(ruleRepo, expenseRepo)
+
+		//act
+		ran, err := ruleService.RunPending()
+
+		//assert
+		assert.True(t, ran)
+		assert.NoError(t, err)
+		ruleRepo.AssertExpectations(t)
+	})
+
+	t.Run("run pending does nothing without pending run", func(t *testing.T) {
+		//arrange
+		ruleRepo := repositories.NewRuleRepositoryMock()
+		ruleRepo.On("ClaimPendingRun").Return(models.RuleRun{}, false, nil)
+
+		ruleService := services.NewRuleService(ruleRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		//act
+		ran, err := ruleService.RunPending()
+
+		//assert
+		assert.False(t, ran)
+		assert.NoError(t, err)
+		ruleRepo.AssertNumberOfCalls(t, "GetAll", 0)
+	})
+}
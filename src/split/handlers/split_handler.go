@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/split/services"
+)
+
+type splitHandler struct {
+	splitService services.SplitService
+}
+
+func NewSplitHandler(splitService services.SplitService) splitHandler {
+	return splitHandler{splitService: splitService}
+}
+
+func (h splitHandler) SplitExpense(c *gin.Context) {
+	var splitReq requests.SplitRequest
+	if err := c.ShouldBindJSON(&splitReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	splitResp, err := h.splitService.SplitExpense(c.Param("id"), splitReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, splitResp)
+}
+
+func (h splitHandler) GetSplit(c *gin.Context) {
+	splitResp, err := h.splitService.GetSplit(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, splitResp)
+}
+
+func (h splitHandler) DeleteSplit(c *gin.Context) {
+	if err := h.splitService.DeleteSplit(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h splitHandler) CreateSettlement(c *gin.Context) {
+	var settlementReq requests.SettlementRequest
+	if err := c.ShouldBindJSON(&settlementReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	settlementResp, err := h.splitService.CreateSettlement(settlementReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, settlementResp)
+}
+
+func (h splitHandler) GetSettlements(c *gin.Context) {
+	settlementsResp, err := h.splitService.GetSettlements()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, settlementsResp)
+}
+
+func (h splitHandler) DeleteSettlementByID(c *gin.Context) {
+	if err := h.splitService.DeleteSettlementByID(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h splitHandler) GetBalances(c *gin.Context) {
+	var query requests.BalanceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	balancesResp, err := h.splitService.GetBalances(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, balancesResp)
+}
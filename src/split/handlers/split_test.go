@@ -0,0 +1,132 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/split/handlers"
+	services "github.com/wytquant/assessment/src/split/services/mock"
+)
+
+func TestSplitExpenseHandler(t *testing.T) {
+	t.Run("split expense success case", func(t *testing.T) {
+		//arrange
+		splitReq := requests.SplitRequest{PaidBy: "ann", Method: "equal", Shares: []requests.ShareRequest{{Participant: "ann"}, {Participant: "bob"}}}
+
+		splitService := services.NewSplitServiceMock()
+		splitService.On("SplitExpense", "1", splitReq).Return(responses.SplitResponse{ExpenseID: 1}, nil)
+
+		splitHandler := handlers.NewSplitHandler(splitService)
+
+		r := gin.Default()
+		r.PUT("/expenses/:id/split", splitHandler.SplitExpense)
+
+		body := []byte(`{"paid_by": "ann", "method": "equal", "shares": [{"participant": "ann"}, {"participant": "bob"}]}`)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/expenses/1/split", bytes.NewBuffer(body))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		splitService.AssertExpectations(t)
+	})
+
+	t.Run("split expense fail bad request because method is unknown", func(t *testing.T) {
+		//arrange
+		splitHandler := handlers.NewSplitHandler(services.NewSplitServiceMock())
+
+		r := gin.Default()
+		r.PUT("/expenses/:id/split", splitHandler.SplitExpense)
+
+		body := []byte(`{"paid_by": "ann", "method": "shares", "shares": [{"participant": "ann"}]}`)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/expenses/1/split", bytes.NewBuffer(body))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("split expense fail case because the amounts do not add up", func(t *testing.T) {
+		//arrange
+		splitReq := requests.SplitRequest{PaidBy: "ann", Method: "exact", Shares: []requests.ShareRequest{{Participant: "bob", Amount: 10}}}
+
+		splitService := services.NewSplitServiceMock()
+		splitService.On("SplitExpense", "1", splitReq).Return(responses.SplitResponse{}, helpers.NewBadRequestError("split amounts add up to 10.00 but the expense is 100.00"))
+
+		splitHandler := handlers.NewSplitHandler(splitService)
+
+		r := gin.Default()
+		r.PUT("/expenses/:id/split", splitHandler.SplitExpense)
+
+		body := []byte(`{"paid_by": "ann", "method": "exact", "shares": [{"participant": "bob", "amount": 10}]}`)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/expenses/1/split", bytes.NewBuffer(body))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCreateSettlementHandler(t *testing.T) {
+	t.Run("create settlement fail bad request because payer pays themselves", func(t *testing.T) {
+		//arrange
+		splitHandler := handlers.NewSplitHandler(services.NewSplitServiceMock())
+
+		r := gin.Default()
+		r.POST("/settlements", splitHandler.CreateSettlement)
+
+		body := []byte(`{"payer": "bob", "payee": "bob", "amount": 10}`)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/settlements", bytes.NewBuffer(body))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetBalancesHandler(t *testing.T) {
+	t.Run("get balances success case", func(t *testing.T) {
+		//arrange
+		splitService := services.NewSplitServiceMock()
+		splitService.On("GetBalances", requests.BalanceQuery{Currency: "THB"}).Return([]responses.BalanceResponse{{Currency: "THB"}}, nil)
+
+		splitHandler := handlers.NewSplitHandler(splitService)
+
+		r := gin.Default()
+		r.GET("/balances", splitHandler.GetBalances)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/balances?currency=THB", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		splitService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+)
+
+type splitRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewSplitRepositoryDB(db *gorm.DB) SplitRepository {
+	return splitRepositoryDB{db: db}
+}
+
+// SaveSplit replaces the split of the expense together with its shares.
+func (r splitRepositoryDB) SaveSplit(split *models.ExpenseSplit) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteSplit(tx, split.ExpenseID); err != nil {
+			return err
+		}
+
+		return tx.Create(split).Error
+	})
+}
+
+func (r splitRepositoryDB) GetSplitByExpenseID(expenseID string) (models.ExpenseSplit, error) {
+	var split models.ExpenseSplit
+	query := r.db
+	if err := query.Preload("Shares", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Where("expense_id = $1", expenseID).First(&split).Error; err != nil {
+		return models.ExpenseSplit{}, err
+	}
+
+	return split, nil
+}
+
+func (r splitRepositoryDB) DeleteSplitByExpenseID(expenseID string) error {
+	var split models.ExpenseSplit
+	if err := r.db.Where("expense_id = $1", expenseID).First(&split).Error; err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteSplit(tx, split.ExpenseID)
+	})
+}
+
+func deleteSplit(tx *gorm.DB, expenseID uint) error {
+	splitIDs := tx.Model(&models.ExpenseSplit{}).Select("id").Where("expense_id = ?", expenseID)
+	if err := tx.Where("split_id IN (?)", splitIDs).Delete(&models.ExpenseShare{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Where("expense_id = ?", expenseID).Delete(&models.ExpenseSplit{}).Error
+}
+
+// GetAllSplits returns the splits of expenses that are not deleted, a merged
+// duplicate no longer counts in the balances.
+func (r splitRepositoryDB) GetAllSplits(currency string) ([]models.ExpenseSplit, error) {
+	query := r.db.Preload("Shares").
+		Joins("JOIN expenses ON expenses.id = expense_splits.expense_id AND expenses.deleted_at IS NULL")
+	if currency != "" {
+		query = query.Where("expense_splits.currency = ?", currency)
+	}
+
+	var splits []models.ExpenseSplit
+	if err := query.Order("expense_splits.id").Find(&splits).Error; err != nil {
+		return nil, err
+	}
+
+	return splits, nil
+}
+
+func (r splitRepositoryDB) CreateSettlement(settlement *models.Settlement) error {
+	query := r.db
+	if err := query.Create(settlement).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r splitRepositoryDB) GetAllSettlements(currency string) ([]models.Settlement, error) {
+	query := r.db
+	if currency != "" {
+		query = query.Where("currency = ?", currency)
+	}
+
+	var settlements []models.Settlement
+	if err := query.Order("date DESC, id DESC").Find(&settlements).Error; err != nil {
+		return nil, err
+	}
+
+	return settlements, nil
+}
+
+func (r splitRepositoryDB) DeleteSettlementByID(id string) error {
+	query := r.db
+	result := query.Where("id = $1", id).Delete(&models.Settlement{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type splitRepositoryMock struct {
+	mock.Mock
+}
+
+func NewSplitRepositoryMock() *splitRepositoryMock {
+	return &splitRepositoryMock{}
+}
+
+func (m *splitRepositoryMock) SaveSplit(split *models.ExpenseSplit) error {
+	args := m.Called(split)
+	return args.Error(0)
+}
+
+func (m *splitRepositoryMock) GetSplitByExpenseID(expenseID string) (models.ExpenseSplit, error) {
+	args := m.Called(expenseID)
+	return args.Get(0).(models.ExpenseSplit), args.Error(1)
+}
+
+func (m *splitRepositoryMock) DeleteSplitByExpenseID(expenseID string) error {
+	args := m.Called(expenseID)
+	return args.Error(0)
+}
+
+func (m *splitRepositoryMock) GetAllSplits(currency string) ([]models.ExpenseSplit, error) {
+	args := m.Called(currency)
+	return args.Get(0).([]models.ExpenseSplit), args.Error(1)
+}
+
+func (m *splitRepositoryMock) CreateSettlement(settlement *models.Settlement) error {
+	args := m.Called(settlement)
+	return args.Error(0)
+}
+
+func (m *splitRepositoryMock) GetAllSettlements(currency string) ([]models.Settlement, error) {
+	args := m.Called(currency)
+	return args.Get(0).([]models.Settlement), args.Error(1)
+}
+
+func (m *splitRepositoryMock) DeleteSettlementByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import "github.com/wytquant/assessment/models"
+
+type SplitRepository interface {
+	SaveSplit(split *models.ExpenseSplit) error
+	GetSplitByExpenseID(expenseID string) (models.ExpenseSplit, error)
+	DeleteSplitByExpenseID(expenseID string) error
+	GetAllSplits(currency string) ([]models.ExpenseSplit, error)
+	CreateSettlement(*models.Settlement) error
+	GetAllSettlements(currency string) ([]models.Settlement, error)
+	DeleteSettlementByID(id string) error
+}
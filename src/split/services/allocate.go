@@ -0,0 +1,159 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// Money is handled in cents so that shares always add up to the expense.
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// allocateShares turns the requested shares into amounts in cents, it
+// reports an error when exact amounts or percentages do not add up.
+func allocateShares(total int64, method string, shares []requests.ShareRequest) ([]int64, error) {
+	amounts := make([]int64, len(shares))
+
+	switch method {
+	case models.SplitEqual:
+		weights := make([]float64, len(shares))
+		for i := range weights {
+			weights[i] = 1
+		}
+		return distribute(total, weights), nil
+	case models.SplitExact:
+		var sum int64
+		for i, share := range shares {
+			amounts[i] = toCents(share.Amount)
+			sum += amounts[i]
+		}
+		if sum != total {
+			return nil, fmt.Errorf("split amounts add up to %.2f but the expense is %.2f", fromCents(sum), fromCents(total))
+		}
+		return amounts, nil
+	case models.SplitPercentage:
+		var sum float64
+		weights := make([]float64, len(shares))
+		for i, share := range shares {
+			weights[i] = share.Percentage
+			sum += share.Percentage
+		}
+		if math.Abs(sum-100) > 0.0001 {
+			return nil, fmt.Errorf("split percentages add up to %g but must add up to 100", sum)
+		}
+		return distribute(total, weights), nil
+	}
+
+	return nil, fmt.Errorf("unknown split method %q", method)
+}
+
+// distribute divides total by weight, the cents lost to rounding go to the
+// shares with the largest remainders, earlier shares first on a tie.
+func distribute(total int64, weights []float64) []int64 {
+	var sum float64
+	for _, weight := range weights {
+		sum += weight
+	}
+
+	amounts := make([]int64, len(weights))
+	if sum == 0 {
+		return amounts
+	}
+
+	sign := int64(1)
+	if total < 0 {
+		sign, total = -1, -total
+	}
+
+	remainders := make([]float64, len(weights))
+	allocated := int64(0)
+	for i, weight := range weights {
+		exact := float64(total) * weight / sum
+		amounts[i] = int64(math.Floor(exact))
+		remainders[i] = exact - float64(amounts[i])
+		allocated += amounts[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for i := 0; allocated < total; i++ {
+		amounts[order[i%len(order)]]++
+		allocated++
+	}
+
+	for i := range amounts {
+		amounts[i] *= sign
+	}
+
+	return amounts
+}
+
+// settleUp pairs the largest debtor with the largest creditor until every
+// balance is cleared, it needs at most one transfer less than there are
+// participants with a balance.
+func settleUp(net map[string]int64) []responses.Transfer {
+	type balance struct {
+		participant string
+		cents       int64
+	}
+
+	var debtors, creditors []balance
+	for participant, cents := range net {
+		if cents < 0 {
+			debtors = append(debtors, balance{participant, -cents})
+		} else if cents > 0 {
+			creditors = append(creditors, balance{participant, cents})
+		}
+	}
+
+	byAmount := func(balances []balance) {
+		sort.Slice(balances, func(i, j int) bool {
+			if balances[i].cents != balances[j].cents {
+				return balances[i].cents > balances[j].cents
+			}
+			return balances[i].participant < balances[j].participant
+		})
+	}
+	byAmount(debtors)
+	byAmount(creditors)
+
+	transfers := []responses.Transfer{}
+	for len(debtors) > 0 && len(creditors) > 0 {
+		debtor, creditor := &debtors[0], &creditors[0]
+		cents := debtor.cents
+		if creditor.cents < cents {
+			cents = creditor.cents
+		}
+
+		transfers = append(transfers, responses.Transfer{From: debtor.participant, To: creditor.participant, Amount: fromCents(cents)})
+		debtor.cents -= cents
+		creditor.cents -= cents
+
+		if debtor.cents == 0 {
+			debtors = debtors[1:]
+		}
+		if creditor.cents == 0 {
+			creditors = creditors[1:]
+		}
+		byAmount(debtors)
+		byAmount(creditors)
+	}
+
+	return transfers
+}
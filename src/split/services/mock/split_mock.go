@@ -0,0 +1,69 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type splitServiceMock struct {
+	mock.Mock
+}
+
+func NewSplitServiceMock() *splitServiceMock {
+	return &splitServiceMock{}
+}
+
+func (m *splitServiceMock) SplitExpense(expenseID string, splitReq requests.SplitRequest) (responses.SplitResponse, error) {
+	args := m.Called(expenseID, splitReq)
+	return args.Get(0).(responses.SplitResponse), args.Error(1)
+}
+
+func (m *splitServiceMock) GetSplit(expenseID string) (responses.SplitResponse, error) {
+	args := m.Called(expenseID)
+	return args.Get(0).(responses.SplitResponse), args.Error(1)
+}
+
+func (m *splitServiceMock) DeleteSplit(expenseID string) error {
+	args := m.Called(expenseID)
+	return args.Error(0)
+}
+
+func (m *splitServiceMock) CreateSettlement(settlementReq requests.SettlementRequest) (responses.SettlementResponse, error) {
+	args := m.Called(settlementReq)
+	return args.Get(0).(responses.SettlementResponse), args.Error(1)
+}
+
+func (m *splitServiceMock) GetSettlements() ([]responses.SettlementResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.SettlementResponse), args.Error(1)
+}
+
+func (m *splitServiceMock) DeleteSettlementByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *splitServiceMock) GetBalances(query requests.BalanceQuery) ([]responses.BalanceResponse, error) {
+	args := m.Called(query)
+	return args.Get(0).([]responses.BalanceResponse), args.Error(1)
+}
+
+func (m *splitServiceMock) ProcessExpense(expense *models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
+
+func (m *splitServiceMock) ValidateExpense(expense models.Expense) error {
+	args := m.Called(expense)
+	return args.Error(0)
+}
+
+func (m *splitServiceMock) ExpenseCreated(expense models.Expense) {
+	m.Called(expense)
+}
+
+func (m *splitServiceMock) ExpenseUpdated(expense models.Expense) {
+	m.Called(expense)
+}
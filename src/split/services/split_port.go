@@ -0,0 +1,24 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// SplitService shares expenses among participants and settles up. It is a
+// processor and an observer of the expense service so that splits follow
+// the amount of their expense.
+type SplitService interface {
+	SplitExpense(expenseID string, splitReq requests.SplitRequest) (responses.SplitResponse, error)
+	GetSplit(expenseID string) (responses.SplitResponse, error)
+	DeleteSplit(expenseID string) error
+	CreateSettlement(settlementReq requests.SettlementRequest) (responses.SettlementResponse, error)
+	GetSettlements() ([]responses.SettlementResponse, error)
+	DeleteSettlementByID(id string) error
+	GetBalances(query requests.BalanceQuery) ([]responses.BalanceResponse, error)
+	ProcessExpense(expense *models.Expense) error
+	ValidateExpense(expense models.Expense) error
+	ExpenseCreated(expense models.Expense)
+	ExpenseUpdated(expense models.Expense)
+}
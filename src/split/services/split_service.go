@@ -0,0 +1,326 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	"github.com/wytquant/assessment/src/split/repositories"
+)
+
+type splitService struct {
+	splitRepo   repositories.SplitRepository
+	expenseRepo expenseRepositories.ExpenseRepository
+}
+
+func NewSplitService(splitRepo repositories.SplitRepository, expenseRepo expenseRepositories.ExpenseRepository) SplitService {
+	return splitService{splitRepo: splitRepo, expenseRepo: expenseRepo}
+}
+
+// SplitExpense replaces the split of an expense, the shares are computed
+// from the amount the expense has now.
+func (s splitService) SplitExpense(expenseID string, splitReq requests.SplitRequest) (responses.SplitResponse, error) {
+	expense, err := s.expenseRepo.GetByID(expenseID)
+	if err != nil {
+		return responses.SplitResponse{}, helpers.NewNotFoundError()
+	}
+
+	seen := map[string]bool{}
+	for _, share := range splitReq.Shares {
+		if seen[share.Participant] {
+			return responses.SplitResponse{}, helpers.NewBadRequestError("participant " + share.Participant + " is listed more than once")
+		}
+		seen[share.Participant] = true
+	}
+
+	split, err := newSplit(expense, splitReq.PaidBy, splitReq.Method, splitReq.Shares)
+	if err != nil {
+		return responses.SplitResponse{}, helpers.NewBadRequestError(err.Error())
+	}
+
+	if err := s.splitRepo.SaveSplit(&split); err != nil {
+		return responses.SplitResponse{}, helpers.NewInternalServerError()
+	}
+
+	return toSplitResponse(split), nil
+}
+
+// ProcessExpense does nothing, a new expense has no split yet.
+func (s splitService) ProcessExpense(expense *models.Expense) error {
+	return nil
+}
+
+// ValidateExpense keeps exact splits adding up to their expense, the amount
+// or currency of an expense split by exact amounts changes only once its
+// split does.
+func (s splitService) ValidateExpense(expense models.Expense) error {
+	if expense.ID == 0 {
+		return nil
+	}
+
+	split, err := s.splitRepo.GetSplitByExpenseID(strconv.FormatUint(uint64(expense.ID), 10))
+	if err != nil || split.Method != models.SplitExact {
+		return nil
+	}
+	if toCents(split.Amount) != toCents(expense.Amount) || split.Currency != currencyOf(expense) {
+		return helpers.NewConflictError(fmt.Sprintf("expense %d is split by exact amounts, change its split before its amount", expense.ID))
+	}
+
+	return nil
+}
+
+// ExpenseCreated does nothing, a new expense has no split yet.
+func (s splitService) ExpenseCreated(expense models.Expense) {}
+
+// ExpenseUpdated shares the new amount of a split expense out again the way
+// it was split, equally or by percentage.
+func (s splitService) ExpenseUpdated(expense models.Expense) {
+	split, err := s.splitRepo.GetSplitByExpenseID(strconv.FormatUint(uint64(expense.ID), 10))
+	if err != nil {
+		return
+	}
+	if toCents(split.Amount) == toCents(expense.Amount) && split.Currency == currencyOf(expense) {
+		return
+	}
+
+	shares := make([]requests.ShareRequest, len(split.Shares))
+	for i, share := range split.Shares {
+		shares[i] = requests.ShareRequest{Participant: share.Participant, Amount: share.Amount, Percentage: share.Percentage}
+	}
+
+	resplit, err := newSplit(expense, split.PaidBy, split.Method, shares)
+	if err != nil {
+		log.Println("fail to split expense again:", err)
+		return
+	}
+	if err := s.splitRepo.SaveSplit(&resplit); err != nil {
+		log.Println("fail to split expense again:", err)
+	}
+}
+
+// newSplit shares the amount the expense has now among the participants.
+func newSplit(expense models.Expense, paidBy string, method string, shares []requests.ShareRequest) (models.ExpenseSplit, error) {
+	amounts, err := allocateShares(toCents(expense.Amount), method, shares)
+	if err != nil {
+		return models.ExpenseSplit{}, err
+	}
+
+	split := models.ExpenseSplit{
+		ExpenseID: expense.ID,
+		PaidBy:    paidBy,
+		Method:    method,
+		Amount:    expense.Amount,
+		Currency:  currencyOf(expense),
+	}
+	for i, share := range shares {
+		expenseShare := models.ExpenseShare{Participant: share.Participant, Amount: fromCents(amounts[i])}
+		if method == models.SplitPercentage {
+			expenseShare.Percentage = share.Percentage
+		}
+		split.Shares = append(split.Shares, expenseShare)
+	}
+
+	return split, nil
+}
+
+func currencyOf(expense models.Expense) string {
+	if expense.Currency == "" {
+		return helpers.BaseCurrency
+	}
+	return expense.Currency
+}
+
+func (s splitService) GetSplit(expenseID string) (responses.SplitResponse, error) {
+	split, err := s.splitRepo.GetSplitByExpenseID(expenseID)
+	if err != nil {
+		return responses.SplitResponse{}, helpers.NewNotFoundError()
+	}
+
+	return toSplitResponse(split), nil
+}
+
+func (s splitService) DeleteSplit(expenseID string) error {
+	if err := s.splitRepo.DeleteSplitByExpenseID(expenseID); err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func (s splitService) CreateSettlement(settlementReq requests.SettlementRequest) (responses.SettlementResponse, error) {
+	var settlement models.Settlement
+	var settlementResp responses.SettlementResponse
+
+	copier.Copy(&settlement, &settlementReq)
+	if settlement.Currency == "" {
+		settlement.Currency = helpers.BaseCurrency
+	}
+
+	if err := s.splitRepo.CreateSettlement(&settlement); err != nil {
+		return responses.SettlementResponse{}, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&settlementResp, &settlement)
+
+	return settlementResp, nil
+}
+
+func (s splitService) GetSettlements() ([]responses.SettlementResponse, error) {
+	settlementsResp := []responses.SettlementResponse{}
+
+	settlements, err := s.splitRepo.GetAllSettlements("")
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&settlementsResp, &settlements)
+
+	return settlementsResp, nil
+}
+
+func (s splitService) DeleteSettlementByID(id string) error {
+	if err := s.splitRepo.DeleteSettlementByID(id); err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	return nil
+}
+
+type participantCents struct {
+	paid, share, settledOut, settledIn int64
+}
+
+type ledger struct {
+	participants map[string]*participantCents
+	// owes[debtor][creditor] before netting both directions
+	owes map[string]map[string]int64
+}
+
+func (l *ledger) participant(name string) *participantCents {
+	if l.participants[name] == nil {
+		l.participants[name] = &participantCents{}
+	}
+	return l.participants[name]
+}
+
+func (l *ledger) owe(debtor string, creditor string, cents int64) {
+	if l.owes[debtor] == nil {
+		l.owes[debtor] = map[string]int64{}
+	}
+	l.owes[debtor][creditor] += cents
+}
+
+// GetBalances sums every split and settlement per currency, settlements
+// count as a debtor paying back part of what they owe.
+func (s splitService) GetBalances(query requests.BalanceQuery) ([]responses.BalanceResponse, error) {
+	splits, err := s.splitRepo.GetAllSplits(query.Currency)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+	settlements, err := s.splitRepo.GetAllSettlements(query.Currency)
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	ledgers := map[string]*ledger{}
+	ledgerOf := func(currency string) *ledger {
+		if ledgers[currency] == nil {
+			ledgers[currency] = &ledger{participants: map[string]*participantCents{}, owes: map[string]map[string]int64{}}
+		}
+		return ledgers[currency]
+	}
+
+	for _, split := range splits {
+		l := ledgerOf(split.Currency)
+		l.participant(split.PaidBy).paid += toCents(split.Amount)
+		for _, share := range split.Shares {
+			cents := toCents(share.Amount)
+			l.participant(share.Participant).share += cents
+			if share.Participant != split.PaidBy {
+				l.owe(share.Participant, split.PaidBy, cents)
+			}
+		}
+	}
+
+	for _, settlement := range settlements {
+		l := ledgerOf(settlement.Currency)
+		cents := toCents(settlement.Amount)
+		l.participant(settlement.Payer).settledOut += cents
+		l.participant(settlement.Payee).settledIn += cents
+		l.owe(settlement.Payer, settlement.Payee, -cents)
+	}
+
+	currencies := make([]string, 0, len(ledgers))
+	for currency := range ledgers {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	balancesResp := []responses.BalanceResponse{}
+	for _, currency := range currencies {
+		balancesResp = append(balancesResp, ledgers[currency].balance(currency))
+	}
+
+	return balancesResp, nil
+}
+
+func (l *ledger) balance(currency string) responses.BalanceResponse {
+	names := make([]string, 0, len(l.participants))
+	for name := range l.participants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	balanceResp := responses.BalanceResponse{Currency: currency, Balances: []responses.ParticipantBalance{}, Debts: []responses.Transfer{}}
+	net := map[string]int64{}
+	for _, name := range names {
+		p := l.participants[name]
+		net[name] = p.paid - p.share + p.settledOut - p.settledIn
+		balanceResp.Balances = append(balanceResp.Balances, responses.ParticipantBalance{
+			Participant: name,
+			Paid:        fromCents(p.paid),
+			Share:       fromCents(p.share),
+			SettledOut:  fromCents(p.settledOut),
+			SettledIn:   fromCents(p.settledIn),
+			Net:         fromCents(net[name]),
+		})
+	}
+
+	for i, debtor := range names {
+		for _, creditor := range names[i+1:] {
+			cents := l.owes[debtor][creditor] - l.owes[creditor][debtor]
+			if cents > 0 {
+				balanceResp.Debts = append(balanceResp.Debts, responses.Transfer{From: debtor, To: creditor, Amount: fromCents(cents)})
+			} else if cents < 0 {
+				balanceResp.Debts = append(balanceResp.Debts, responses.Transfer{From: creditor, To: debtor, Amount: fromCents(-cents)})
+			}
+		}
+	}
+
+	balanceResp.SettleUp = settleUp(net)
+
+	return balanceResp
+}
+
+func toSplitResponse(split models.ExpenseSplit) responses.SplitResponse {
+	splitResp := responses.SplitResponse{
+		ExpenseID: split.ExpenseID,
+		PaidBy:    split.PaidBy,
+		Method:    split.Method,
+		Amount:    split.Amount,
+		Currency:  split.Currency,
+		Shares:    []responses.ShareResponse{},
+	}
+	for _, share := range split.Shares {
+		splitResp.Shares = append(splitResp.Shares, responses.ShareResponse{Participant: share.Participant, Percentage: share.Percentage, Amount: share.Amount})
+	}
+
+	return splitResp
+}
@@ -0,0 +1,254 @@
+//go:build unit
+
+package services_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	"github.com/wytquant/assessment/src/split/repositories"
+	"github.com/wytquant/assessment/src/split/services"
+	"gorm.io/gorm"
+)
+
+func shareAmounts(split responses.SplitResponse) []float64 {
+	var amounts []float64
+	for _, share := range split.Shares {
+		amounts = append(amounts, share.Amount)
+	}
+	return amounts
+}
+
+func TestSplitExpenseService(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		shares []requests.ShareRequest
+		want   []float64
+	}{
+		{
+			name:   "equal split gives the extra cent to the first participant",
+			method: models.SplitEqual,
+			shares: []requests.ShareRequest{{Participant: "ann"}, {Participant: "bob"}, {Participant: "cat"}},
+			want:   []float64{33.34, 33.33, 33.33},
+		},
+		{
+			name:   "exact split keeps the amounts",
+			method: models.SplitExact,
+			shares: []requests.ShareRequest{{Participant: "ann", Amount: 60}, {Participant: "bob", Amount: 40}},
+			want:   []float64{60, 40},
+		},
+		{
+			name:   "percentage split rounds to cents adding up to the amount",
+			method: models.SplitPercentage,
+			shares: []requests.ShareRequest{{Participant: "ann", Percentage: 33.3}, {Participant: "bob", Percentage: 33.3}, {Participant: "cat", Percentage: 33.4}},
+			want:   []float64{33.3, 33.3, 33.4},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			//arrange
+			expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+			expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1, Amount: 100, Currency: "THB"}, nil)
+
+			splitRepo := repositories.NewSplitRepositoryMock()
+			splitRepo.On("SaveSplit", mock.Anything).Return(nil)
+
+			splitService := services.NewSplitService(splitRepo, expenseRepo)
+
+			//act
+			got, err := splitService.SplitExpense("1", requests.SplitRequest{PaidBy: "ann", Method: tc.method, Shares: tc.shares})
+
+			//assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, shareAmounts(got))
+			assert.Equal(t, "THB", got.Currency)
+		})
+	}
+
+	t.Run("split expense fail case because exact amounts do not add up", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1, Amount: 100}, nil)
+
+		splitService := services.NewSplitService(repositories.NewSplitRepositoryMock(), expenseRepo)
+
+		//act
+		_, err := splitService.SplitExpense("1", requests.SplitRequest{PaidBy: "ann", Method: models.SplitExact, Shares: []requests.ShareRequest{{Participant: "ann", Amount: 60}, {Participant: "bob", Amount: 30}}})
+
+		//assert
+		assert.Equal(t, helpers.NewBadRequestError("split amounts add up to 90.00 but the expense is 100.00"), err)
+	})
+
+	t.Run("split expense fail case because percentages do not add up", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1, Amount: 100}, nil)
+
+		splitService := services.NewSplitService(repositories.NewSplitRepositoryMock(), expenseRepo)
+
+		//act
+		_, err := splitService.SplitExpense("1", requests.SplitRequest{PaidBy: "ann", Method: models.SplitPercentage, Shares: []requests.ShareRequest{{Participant: "ann", Percentage: 50}, {Participant: "bob", Percentage: 40}}})
+
+		//assert
+		assert.Equal(t, helpers.NewBadRequestError("split percentages add up to 90 but must add up to 100"), err)
+	})
+
+	t.Run("split expense fail case because a participant is listed twice", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{ID: 1, Amount: 100}, nil)
+
+		splitService := services.NewSplitService(repositories.NewSplitRepositoryMock(), expenseRepo)
+
+		//act
+		_, err := splitService.SplitExpense("1", requests.SplitRequest{PaidBy: "ann", Method: models.SplitEqual, Shares: []requests.ShareRequest{{Participant: "bob"}, {Participant: "bob"}}})
+
+		//assert
+		assert.Equal(t, helpers.NewBadRequestError("participant bob is listed more than once"), err)
+	})
+
+	t.Run("split expense fail case because the expense does not exist", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("GetByID", "1").Return(models.Expense{}, gorm.ErrRecordNotFound)
+
+		splitService := services.NewSplitService(repositories.NewSplitRepositoryMock(), expenseRepo)
+
+		//act
+		_, err := splitService.SplitExpense("1", requests.SplitRequest{PaidBy: "ann", Method: models.SplitEqual, Shares: []requests.ShareRequest{{Participant: "ann"}}})
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+	})
+}
+
+func TestGetBalancesService(t *testing.T) {
+	t.Run("get balances shows debts and the fewest transfers", func(t *testing.T) {
+		//arrange
+		splits := []models.ExpenseSplit{
+			{PaidBy: "ann", Amount: 90, Currency: "THB", Shares: []models.ExpenseShare{
+				{Participant: "ann", Amount: 30}, {Participant: "bob", Amount: 30}, {Participant: "cat", Amount: 30},
+			}},
+			{PaidBy: "bob", Amount: 60, Currency: "THB", Shares: []models.ExpenseShare{
+				{Participant: "cat", Amount: 60},
+			}},
+		}
+
+		splitRepo := repositories.NewSplitRepositoryMock()
+		splitRepo.On("GetAllSplits", "").Return(splits, nil)
+		splitRepo.On("GetAllSettlements", "").Return([]models.Settlement{}, nil)
+
+		splitService := services.NewSplitService(splitRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		//act
+		got, err := splitService.GetBalances(requests.BalanceQuery{})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, []responses.ParticipantBalance{
+				{Participant: "ann", Paid: 90, Share: 30, Net: 60},
+				{Participant: "bob", Paid: 60, Share: 30, Net: 30},
+				{Participant: "cat", Share: 90, Net: -90},
+			}, got[0].Balances)
+			assert.Equal(t, []responses.Transfer{
+				{From: "bob", To: "ann", Amount: 30},
+				{From: "cat", To: "ann", Amount: 30},
+				{From: "cat", To: "bob", Amount: 60},
+			}, got[0].Debts)
+			assert.Equal(t, []responses.Transfer{
+				{From: "cat", To: "ann", Amount: 60},
+				{From: "cat", To: "bob", Amount: 30},
+			}, got[0].SettleUp)
+		}
+	})
+
+	t.Run("get balances settlements zero out debts", func(t *testing.T) {
+		//arrange
+		splits := []models.ExpenseSplit{
+			{PaidBy: "ann", Amount: 100, Currency: "USD", Shares: []models.ExpenseShare{
+				{Participant: "ann", Amount: 50}, {Participant: "bob", Amount: 50},
+			}},
+		}
+		settlements := []models.Settlement{{Payer: "bob", Payee: "ann", Amount: 50, Currency: "USD"}}
+
+		splitRepo := repositories.NewSplitRepositoryMock()
+		splitRepo.On("GetAllSplits", "USD").Return(splits, nil)
+		splitRepo.On("GetAllSettlements", "USD").Return(settlements, nil)
+
+		splitService := services.NewSplitService(splitRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		//act
+		got, err := splitService.GetBalances(requests.BalanceQuery{Currency: "USD"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, "USD", got[0].Currency)
+			for _, balance := range got[0].Balances {
+				assert.Zero(t, balance.Net)
+			}
+			assert.Empty(t, got[0].Debts)
+			assert.Empty(t, got[0].SettleUp)
+		}
+	})
+}
+
+func TestSplitFollowsExpenseService(t *testing.T) {
+	t.Run("expense updated shares the new amount of an equal split again", func(t *testing.T) {
+		//arrange
+		split := models.ExpenseSplit{ID: 1, ExpenseID: 1, PaidBy: "ann", Method: models.SplitEqual, Amount: 100, Currency: "THB", Shares: []models.ExpenseShare{{Participant: "ann", Amount: 50}, {Participant: "bob", Amount: 50}}}
+		splitRepo := repositories.NewSplitRepositoryMock()
+		splitRepo.On("GetSplitByExpenseID", "1").Return(split, nil)
+		splitRepo.On("SaveSplit", mock.Anything).Return(nil)
+
+		splitService := services.NewSplitService(splitRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		//act
+		splitService.ExpenseUpdated(models.Expense{ID: 1, Amount: 120.01, Currency: "THB"})
+
+		//assert
+		saved := splitRepo.Calls[1].Arguments.Get(0).(*models.ExpenseSplit)
+		assert.Equal(t, 120.01, saved.Amount)
+		assert.Equal(t, "ann", saved.PaidBy)
+		assert.Equal(t, []models.ExpenseShare{{Participant: "ann", Amount: 60.01}, {Participant: "bob", Amount: 60}}, saved.Shares)
+	})
+
+	t.Run("expense updated keeps the split when the amount is the same", func(t *testing.T) {
+		//arrange
+		split := models.ExpenseSplit{ID: 1, ExpenseID: 1, Method: models.SplitEqual, Amount: 100, Currency: "THB"}
+		splitRepo := repositories.NewSplitRepositoryMock()
+		splitRepo.On("GetSplitByExpenseID", "1").Return(split, nil)
+
+		splitService := services.NewSplitService(splitRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		//act
+		splitService.ExpenseUpdated(models.Expense{ID: 1, Amount: 100, Currency: "THB"})
+
+		//assert
+		splitRepo.AssertNotCalled(t, "SaveSplit", mock.Anything)
+	})
+
+	t.Run("validate expense fail case because an exact split no longer adds up", func(t *testing.T) {
+		//arrange
+		split := models.ExpenseSplit{ID: 1, ExpenseID: 1, Method: models.SplitExact, Amount: 100, Currency: "THB"}
+		splitRepo := repositories.NewSplitRepositoryMock()
+		splitRepo.On("GetSplitByExpenseID", "1").Return(split, nil)
+
+		splitService := services.NewSplitService(splitRepo, expenseRepositories.NewExpenseReporitoryMock())
+
+		//act
+		err := splitService.ValidateExpense(models.Expense{ID: 1, Amount: 120, Currency: "THB"})
+
+		//assert
+		assert.Equal(t, helpers.NewConflictError("expense 1 is split by exact amounts, change its split before its amount"), err)
+	})
+}
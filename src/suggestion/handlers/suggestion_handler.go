@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/suggestion/services"
+)
+
+type suggestionHandler struct {
+	suggestionService services.SuggestionService
+}
+
+func NewSuggestionHandler(suggestionService services.SuggestionService) suggestionHandler {
+	return suggestionHandler{suggestionService: suggestionService}
+}
+
+func (h suggestionHandler) SuggestTags(c *gin.Context) {
+	var query requests.SuggestTagsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	suggestionsResp, err := h.suggestionService.SuggestTags(query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestionsResp)
+}
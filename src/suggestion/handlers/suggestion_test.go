@@ -0,0 +1,59 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/suggestion/handlers"
+	services "github.com/wytquant/assessment/src/suggestion/services/mock"
+)
+
+func TestSuggestTagsHandler(t *testing.T) {
+	t.Run("suggest tags success case", func(t *testing.T) {
+		//arrange
+		suggestionService := services.NewSuggestionServiceMock()
+		suggestionService.On("SuggestTags", requests.SuggestTagsQuery{Title: "starbucks", Limit: 2}).Return([]responses.TagSuggestion{
+			{Tag: "food/coffee", Score: 0.9},
+			{Tag: "travel", Score: 0.1},
+		}, nil)
+
+		suggestionHandler := handlers.NewSuggestionHandler(suggestionService)
+
+		r := gin.Default()
+		r.GET("/expenses/suggest-tags", suggestionHandler.SuggestTags)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/suggest-tags?title=starbucks&limit=2", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[{"tag": "food/coffee", "score": 0.9}, {"tag": "travel", "score": 0.1}]`, w.Body.String())
+	})
+
+	t.Run("suggest tags fail bad request because title is missing", func(t *testing.T) {
+		//arrange
+		suggestionHandler := handlers.NewSuggestionHandler(services.NewSuggestionServiceMock())
+
+		r := gin.Default()
+		r.GET("/expenses/suggest-tags", suggestionHandler.SuggestTags)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/expenses/suggest-tags", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
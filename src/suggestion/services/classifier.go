@@ -0,0 +1,142 @@
+package services
+
+import (
+	"math"
+	"sort"
+)
+
+type document struct {
+	tokens []string
+	tags   []string
+}
+
+type scoredTag struct {
+	tag         string
+	probability float64
+}
+
+// classifier is a multinomial naive Bayes model with a class per tag. It
+// keeps the documents it learned from so that an expense can be learned
+// again after it changed.
+type classifier struct {
+	docs       map[uint]document
+	tagDocs    map[string]int
+	tokenCount map[string]map[string]int
+	tokenTotal map[string]int
+	vocabulary map[string]int
+}
+
+func newClassifier() *classifier {
+	return &classifier{
+		docs:       map[uint]document{},
+		tagDocs:    map[string]int{},
+		tokenCount: map[string]map[string]int{},
+		tokenTotal: map[string]int{},
+		vocabulary: map[string]int{},
+	}
+}
+
+// learn replaces what was learned from the expense id, documents without
+// tags or words teach nothing and are forgotten.
+func (c *classifier) learn(id uint, doc document) {
+	c.forget(id)
+	if len(doc.tags) == 0 || len(doc.tokens) == 0 {
+		return
+	}
+
+	c.docs[id] = doc
+	for _, tag := range doc.tags {
+		c.tagDocs[tag]++
+		if c.tokenCount[tag] == nil {
+			c.tokenCount[tag] = map[string]int{}
+		}
+		for _, token := range doc.tokens {
+			c.tokenCount[tag][token]++
+			c.tokenTotal[tag]++
+		}
+	}
+	for _, token := range doc.tokens {
+		c.vocabulary[token]++
+	}
+}
+
+func (c *classifier) forget(id uint) {
+	doc, ok := c.docs[id]
+	if !ok {
+		return
+	}
+
+	delete(c.docs, id)
+	for _, tag := range doc.tags {
+		for _, token := range doc.tokens {
+			decrement(c.tokenCount[tag], token)
+		}
+		c.tokenTotal[tag] -= len(doc.tokens)
+		decrement(c.tagDocs, tag)
+		if c.tagDocs[tag] == 0 {
+			delete(c.tokenCount, tag)
+			delete(c.tokenTotal, tag)
+		}
+	}
+	for _, token := range doc.tokens {
+		decrement(c.vocabulary, token)
+	}
+}
+
+func decrement(counts map[string]int, key string) {
+	counts[key]--
+	if counts[key] <= 0 {
+		delete(counts, key)
+	}
+}
+
+// rank returns the most probable tags for the tokens, best first. Words
+// never seen before are ignored and nothing is suggested when no word is
+// known.
+func (c *classifier) rank(tokens []string, limit int) []scoredTag {
+	var known []string
+	for _, token := range tokens {
+		if c.vocabulary[token] > 0 {
+			known = append(known, token)
+		}
+	}
+	if len(known) == 0 {
+		return nil
+	}
+
+	vocabulary := float64(len(c.vocabulary))
+	scores := make([]scoredTag, 0, len(c.tagDocs))
+	best := math.Inf(-1)
+	for tag, docs := range c.tagDocs {
+		// the log of the prior and of every word with Laplace smoothing
+		score := math.Log(float64(docs) / float64(len(c.docs)))
+		for _, token := range known {
+			score += math.Log(float64(c.tokenCount[tag][token]+1) / (float64(c.tokenTotal[tag]) + vocabulary))
+		}
+		scores = append(scores, scoredTag{tag: tag, probability: score})
+		if score > best {
+			best = score
+		}
+	}
+
+	var sum float64
+	for i := range scores {
+		scores[i].probability = math.Exp(scores[i].probability - best)
+		sum += scores[i].probability
+	}
+	for i := range scores {
+		scores[i].probability /= sum
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].probability != scores[j].probability {
+			return scores[i].probability > scores[j].probability
+		}
+		return scores[i].tag < scores[j].tag
+	})
+	if len(scores) > limit {
+		scores = scores[:limit]
+	}
+
+	return scores
+}
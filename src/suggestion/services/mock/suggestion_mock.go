@@ -0,0 +1,29 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type suggestionServiceMock struct {
+	mock.Mock
+}
+
+func NewSuggestionServiceMock() *suggestionServiceMock {
+	return &suggestionServiceMock{}
+}
+
+func (m *suggestionServiceMock) SuggestTags(query requests.SuggestTagsQuery) ([]responses.TagSuggestion, error) {
+	args := m.Called(query)
+	return args.Get(0).([]responses.TagSuggestion), args.Error(1)
+}
+
+func (m *suggestionServiceMock) ExpenseCreated(expense models.Expense) {
+	m.Called(expense)
+}
+
+func (m *suggestionServiceMock) ExpenseUpdated(expense models.Expense) {
+	m.Called(expense)
+}
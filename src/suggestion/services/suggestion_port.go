@@ -0,0 +1,15 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type SuggestionService interface {
+	// SuggestTags ranks the tags of the personal ledger by how well they fit
+	// the title and note.
+	SuggestTags(query requests.SuggestTagsQuery) ([]responses.TagSuggestion, error)
+	ExpenseCreated(expense models.Expense)
+	ExpenseUpdated(expense models.Expense)
+}
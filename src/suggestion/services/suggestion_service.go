@@ -0,0 +1,113 @@
+package services
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+)
+
+const (
+	defaultSuggestionLimit = 5
+	trainBatchSize         = 500
+	// retrainInterval bounds how long expenses stored elsewhere, by imports
+	// or another instance, are missing from the model.
+	retrainInterval = time.Hour
+)
+
+type model struct {
+	mu         sync.Mutex
+	classifier *classifier
+	trainedAt  time.Time
+}
+
+type suggestionService struct {
+	expenseRepo expenseRepositories.ExpenseRepository
+	model       *model
+	now         func() time.Time
+}
+
+func NewSuggestionService(expenseRepo expenseRepositories.ExpenseRepository) SuggestionService {
+	return suggestionService{expenseRepo: expenseRepo, model: &model{}, now: time.Now}
+}
+
+func (s suggestionService) SuggestTags(query requests.SuggestTagsQuery) ([]responses.TagSuggestion, error) {
+	suggestionsResp := []responses.TagSuggestion{}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultSuggestionLimit
+	}
+
+	s.model.mu.Lock()
+	defer s.model.mu.Unlock()
+
+	if s.model.classifier == nil || s.now().Sub(s.model.trainedAt) >= retrainInterval {
+		if err := s.train(); err != nil {
+			return nil, helpers.NewInternalServerError()
+		}
+	}
+
+	for _, scored := range s.model.classifier.rank(tokenize(query.Title+" "+query.Note), limit) {
+		suggestionsResp = append(suggestionsResp, responses.TagSuggestion{
+			Tag:   scored.tag,
+			Score: math.Round(scored.probability*10000) / 10000,
+		})
+	}
+
+	return suggestionsResp, nil
+}
+
+// train learns every personal expense from scratch, the caller holds the
+// lock.
+func (s suggestionService) train() error {
+	trained := newClassifier()
+
+	err := s.expenseRepo.FindInBatches(expenseRepositories.ExpenseFilter{}, trainBatchSize, func(expenses []models.Expense) error {
+		for _, expense := range expenses {
+			trained.learn(expense.ID, documentOf(expense))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.model.classifier = trained
+	s.model.trainedAt = s.now()
+
+	return nil
+}
+
+func (s suggestionService) ExpenseCreated(expense models.Expense) {
+	s.learn(expense)
+}
+
+func (s suggestionService) ExpenseUpdated(expense models.Expense) {
+	s.learn(expense)
+}
+
+// learn updates a trained model with the expense, an untrained one reads it
+// from the database when it is first used.
+func (s suggestionService) learn(expense models.Expense) {
+	// suggestions are learned from the personal ledger only
+	if expense.GroupID != nil {
+		return
+	}
+
+	s.model.mu.Lock()
+	defer s.model.mu.Unlock()
+
+	if s.model.classifier != nil {
+		s.model.classifier.learn(expense.ID, documentOf(expense))
+	}
+}
+
+func documentOf(expense models.Expense) document {
+	return document{tokens: tokenize(expense.Title + " " + expense.Note), tags: expense.Tags}
+}
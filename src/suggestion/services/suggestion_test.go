@@ -0,0 +1,114 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	expenseRepositories "github.com/wytquant/assessment/src/expense/repositories"
+	"github.com/wytquant/assessment/src/suggestion/services"
+)
+
+var history = [][]models.Expense{
+	{
+		{ID: 1, Title: "Starbucks Siam", Note: "latte", Tags: pq.StringArray{"food/coffee"}},
+		{ID: 2, Title: "Starbucks Asok", Note: "mocha", Tags: pq.StringArray{"food/coffee"}},
+		{ID: 3, Title: "Grab taxi", Note: "to office", Tags: pq.StringArray{"travel"}},
+	},
+	{
+		{ID: 4, Title: "ก๋วยเตี๋ยวเรือ", Note: "lunch", Tags: pq.StringArray{"food"}},
+		{ID: 5, Title: "BTS top up", Note: "rabbit card", Tags: pq.StringArray{"travel"}},
+		{ID: 6, Title: "Starbucks card", Note: "reload"},
+	},
+}
+
+func newSuggestionService() services.SuggestionService {
+	expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+	expenseRepo.On("FindInBatches", expenseRepositories.ExpenseFilter{}, 500).Return(history, nil).Once()
+
+	return services.NewSuggestionService(expenseRepo)
+}
+
+func TestSuggestTagsService(t *testing.T) {
+	t.Run("suggest tags ranks the tags learned from history", func(t *testing.T) {
+		//arrange
+		suggestionService := newSuggestionService()
+
+		//act
+		got, err := suggestionService.SuggestTags(requests.SuggestTagsQuery{Title: "STARBUCKS Central"})
+
+		//assert
+		assert.NoError(t, err)
+		if assert.Len(t, got, 3) {
+			assert.Equal(t, "food/coffee", got[0].Tag)
+			assert.Greater(t, got[0].Score, got[1].Score)
+		}
+	})
+
+	t.Run("suggest tags matches thai words without spaces", func(t *testing.T) {
+		//arrange
+		suggestionService := newSuggestionService()
+
+		//act
+		got, err := suggestionService.SuggestTags(requests.SuggestTagsQuery{Title: "ก๋วยเตี๋ยวต้มยำ", Limit: 1})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "food", got[0].Tag)
+		assert.Len(t, got, 1)
+	})
+
+	t.Run("suggest tags returns nothing for unknown words", func(t *testing.T) {
+		//arrange
+		suggestionService := newSuggestionService()
+
+		//act
+		got, err := suggestionService.SuggestTags(requests.SuggestTagsQuery{Title: "zzz 123"})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, []responses.TagSuggestion{}, got)
+	})
+
+	t.Run("suggest tags learns created and updated expenses", func(t *testing.T) {
+		//arrange
+		suggestionService := newSuggestionService()
+		suggestionService.SuggestTags(requests.SuggestTagsQuery{Title: "train"})
+
+		groupID := uint(2)
+
+		//act
+		suggestionService.ExpenseCreated(models.Expense{ID: 7, Title: "Airport rail link", Tags: pq.StringArray{"food"}})
+		suggestionService.ExpenseUpdated(models.Expense{ID: 7, Title: "Airport rail link", Tags: pq.StringArray{"travel/train"}})
+		suggestionService.ExpenseCreated(models.Expense{ID: 8, GroupID: &groupID, Title: "Rail pass", Tags: pq.StringArray{"trip"}})
+		got, err := suggestionService.SuggestTags(requests.SuggestTagsQuery{Title: "rail"})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, "travel/train", got[0].Tag)
+		for _, suggestion := range got {
+			assert.NotEqual(t, "trip", suggestion.Tag)
+		}
+	})
+
+	t.Run("suggest tags fail case because history cannot be read", func(t *testing.T) {
+		//arrange
+		expenseRepo := expenseRepositories.NewExpenseReporitoryMock()
+		expenseRepo.On("FindInBatches", expenseRepositories.ExpenseFilter{}, 500).Return([][]models.Expense{}, errors.New("connection refused"))
+
+		suggestionService := services.NewSuggestionService(expenseRepo)
+
+		//act
+		_, err := suggestionService.SuggestTags(requests.SuggestTagsQuery{Title: "starbucks"})
+
+		//assert
+		assert.Equal(t, helpers.NewInternalServerError(), err)
+	})
+}
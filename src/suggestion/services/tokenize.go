@@ -0,0 +1,24 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/wytquant/assessment/helpers"
+)
+
+// tokenize splits text into words for the classifier. Runs of Thai are split
+// into bigrams as Thai has no spaces, numbers and single letters are left
+// out.
+func tokenize(text string) []string {
+	var tokens []string
+	for _, word := range helpers.SplitWords(text) {
+		if helpers.IsThai(word) {
+			tokens = append(tokens, helpers.Bigrams(word)...)
+		} else if len([]rune(word)) > 1 && strings.IndexFunc(word, unicode.IsLetter) >= 0 {
+			tokens = append(tokens, word)
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/tag/services"
+)
+
+type tagHandler struct {
+	tagService services.TagService
+}
+
+func NewTagHandler(tagService services.TagService) tagHandler {
+	return tagHandler{tagService: tagService}
+}
+
+func (h tagHandler) GetTags(c *gin.Context) {
+	tagsResp, err := h.tagService.GetTags()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tagsResp)
+}
+
+func (h tagHandler) SetTag(c *gin.Context) {
+	var tagReq requests.TagRequest
+	if err := c.ShouldBindJSON(&tagReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	tagResp, err := h.tagService.SetTag(tagReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tagResp)
+}
+
+func (h tagHandler) RenameTag(c *gin.Context) {
+	var renameReq requests.RenameTagRequest
+	if err := c.ShouldBindJSON(&renameReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	tagsResp, err := h.tagService.RenameTag(renameReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tagsResp)
+}
+
+func (h tagHandler) MergeTags(c *gin.Context) {
+	var mergeReq requests.MergeTagsRequest
+	if err := c.ShouldBindJSON(&mergeReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	tagsResp, err := h.tagService.MergeTags(mergeReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tagsResp)
+}
@@ -0,0 +1,109 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/tag/handlers"
+	services "github.com/wytquant/assessment/src/tag/services/mock"
+)
+
+func TestSetTagHandler(t *testing.T) {
+	t.Run("set tag success case", func(t *testing.T) {
+		//arrange
+		tagReq := requests.TagRequest{Name: "food/coffee", Color: "#6F4E37", Icon: "coffee"}
+
+		tagService := services.NewTagServiceMock()
+		tagService.On("SetTag", tagReq).Return(responses.TagResponse{Name: "food/coffee", Parent: "food", Color: "#6f4e37", Icon: "coffee"}, nil)
+
+		tagHandler := handlers.NewTagHandler(tagService)
+
+		r := gin.Default()
+		r.PUT("/tags", tagHandler.SetTag)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/tags", bytes.NewBufferString(`{"name": "food/coffee", "color": "#6F4E37", "icon": "coffee"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		tagService.AssertExpectations(t)
+	})
+
+	t.Run("set tag fail bad request because color is not a hex color", func(t *testing.T) {
+		//arrange
+		tagHandler := handlers.NewTagHandler(services.NewTagServiceMock())
+
+		r := gin.Default()
+		r.PUT("/tags", tagHandler.SetTag)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/tags", bytes.NewBufferString(`{"name": "food", "color": "brown"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRenameTagHandler(t *testing.T) {
+	t.Run("rename tag fail case because the new name is taken", func(t *testing.T) {
+		//arrange
+		renameReq := requests.RenameTagRequest{From: "beverge", To: "beverage"}
+
+		tagService := services.NewTagServiceMock()
+		tagService.On("RenameTag", renameReq).Return([]responses.TagResponse{}, helpers.NewConflictError("tag beverage already exists, merge the tags instead"))
+
+		tagHandler := handlers.NewTagHandler(tagService)
+
+		r := gin.Default()
+		r.POST("/tags/rename", tagHandler.RenameTag)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/tags/rename", bytes.NewBufferString(`{"from": "beverge", "to": "beverage"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestMergeTagsHandler(t *testing.T) {
+	t.Run("merge tags success case", func(t *testing.T) {
+		//arrange
+		mergeReq := requests.MergeTagsRequest{From: []string{"beverge", "drinks"}, To: "beverage"}
+
+		tagService := services.NewTagServiceMock()
+		tagService.On("MergeTags", mergeReq).Return([]responses.TagResponse{{Name: "beverage", Count: 8, TotalCount: 8}}, nil)
+
+		tagHandler := handlers.NewTagHandler(tagService)
+
+		r := gin.Default()
+		r.POST("/tags/merge", tagHandler.MergeTags)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/tags/merge", bytes.NewBufferString(`{"from": ["beverge", "drinks"], "to": "beverage"}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		tagService.AssertExpectations(t)
+	})
+}
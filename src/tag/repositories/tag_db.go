@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"unicode/utf8"
+
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/src/webhook/outbox"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tagRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewTagRepositoryDB(db *gorm.DB) TagRepository {
+	return tagRepositoryDB{db: db}
+}
+
+// GetUsage counts the tags of the personal ledger, ancestors of the tags in
+// use are listed too.
+func (r tagRepositoryDB) GetUsage() ([]TagUsage, error) {
+	var usage []TagUsage
+
+	err := r.db.Raw(`SELECT rolled_up.tag AS name,
+			COUNT(*) FILTER (WHERE rolled_up.direct) AS count,
+			COUNT(*) AS total_count
+		FROM expenses
+		CROSS JOIN LATERAL (
+			SELECT array_to_string((string_to_array(t.name, '/'))[1:n.n], '/') AS tag,
+				bool_or(n.n = array_length(string_to_array(t.name, '/'), 1)) AS direct
+			FROM unnest(expenses.tags) AS t(name)
+			CROSS JOIN LATERAL generate_series(1, array_length(string_to_array(t.name, '/'), 1)) AS n(n)
+			GROUP BY 1
+		) AS rolled_up
+		WHERE expenses.group_id IS NULL AND expenses.deleted_at IS NULL
+		GROUP BY rolled_up.tag
+		ORDER BY rolled_up.tag`).Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+func (r tagRepositoryDB) GetAll() ([]models.Tag, error) {
+	query := r.db
+	var tags []models.Tag
+
+	if err := query.Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r tagRepositoryDB) Save(tag *models.Tag) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"color", "icon", "updated_at"}),
+	}).Create(tag).Error
+}
+
+func (r tagRepositoryDB) Rename(from []string, to string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, name := range from {
+			if err := renameTag(tx, name, to); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// renameTag rewrites the personal expenses, the recurring templates, the
+// budgets and the tag metadata. A tag array keeps its order and loses the
+// duplicates a merge creates.
+func renameTag(tx *gorm.DB, from string, to string) error {
+	prefix := from + "/"
+	args := map[string]interface{}{
+		"from":      from,
+		"to":        to,
+		"prefix":    prefix,
+		"prefixlen": utf8.RuneCountInString(prefix),
+	}
+	renamed := func(column string) string {
+		return "CASE WHEN " + column + " = @from THEN @to WHEN left(" + column + ", @prefixlen) = @prefix THEN @to || substr(" + column + ", @prefixlen) ELSE " + column + " END"
+	}
+	matches := func(column string) string {
+		return "(" + column + " = @from OR left(" + column + ", @prefixlen) = @prefix)"
+	}
+
+	for _, table := range []string{"expenses", "recurring_expenses"} {
+		ledger, returning := "", ""
+		if table == "expenses" {
+			ledger = "expenses.group_id IS NULL AND "
+			returning = " RETURNING expenses.id"
+		}
+		var changed []uint
+		if err := tx.Raw(`UPDATE `+table+` SET tags = ARRAY(
+				SELECT renamed.name FROM (
+					SELECT `+renamed("t.name")+` AS name, t.n
+					FROM unnest(`+table+`.tags) WITH ORDINALITY AS t(name, n)
+				) AS renamed
+				GROUP BY renamed.name
+				ORDER BY MIN(renamed.n))
+			WHERE `+ledger+`EXISTS (SELECT 1 FROM unnest(`+table+`.tags) AS t(name) WHERE `+matches("t.name")+`)`+returning, args).
+			Scan(&changed).Error; err != nil {
+			return err
+		}
+		if err := outbox.RecordChanged(tx, models.EventExpenseUpdated, changed...); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec(`UPDATE budgets SET tag = `+renamed("tag")+` WHERE `+matches("tag"), args).Error; err != nil {
+		return err
+	}
+
+	// metadata of the target wins over the one of the merged tag
+	if err := tx.Exec(`DELETE FROM tags WHERE `+matches("tags.name")+`
+		AND EXISTS (SELECT 1 FROM tags AS existing WHERE existing.name = `+renamed("tags.name")+`)`, args).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`UPDATE tags SET name = `+renamed("name")+`, updated_at = now() WHERE `+matches("name"), args).Error
+}
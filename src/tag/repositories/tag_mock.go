@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type tagRepositoryMock struct {
+	mock.Mock
+}
+
+func NewTagRepositoryMock() *tagRepositoryMock {
+	return &tagRepositoryMock{}
+}
+
+func (m *tagRepositoryMock) GetUsage() ([]TagUsage, error) {
+	args := m.Called()
+	return args.Get(0).([]TagUsage), args.Error(1)
+}
+
+func (m *tagRepositoryMock) GetAll() ([]models.Tag, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *tagRepositoryMock) Save(tag *models.Tag) error {
+	args := m.Called(tag)
+	return args.Error(0)
+}
+
+func (m *tagRepositoryMock) Rename(from []string, to string) error {
+	args := m.Called(from, to)
+	return args.Error(0)
+}
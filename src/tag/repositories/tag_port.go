@@ -0,0 +1,18 @@
+package repositories
+
+import "github.com/wytquant/assessment/models"
+
+type TagUsage struct {
+	Name       string
+	Count      int64
+	TotalCount int64
+}
+
+type TagRepository interface {
+	GetUsage() ([]TagUsage, error)
+	GetAll() ([]models.Tag, error)
+	Save(tag *models.Tag) error
+	// Rename moves every tag of from together with its children to to, in
+	// one transaction.
+	Rename(from []string, to string) error
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type tagServiceMock struct {
+	mock.Mock
+}
+
+func NewTagServiceMock() *tagServiceMock {
+	return &tagServiceMock{}
+}
+
+func (m *tagServiceMock) GetTags() ([]responses.TagResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.TagResponse), args.Error(1)
+}
+
+func (m *tagServiceMock) SetTag(tagReq requests.TagRequest) (responses.TagResponse, error) {
+	args := m.Called(tagReq)
+	return args.Get(0).(responses.TagResponse), args.Error(1)
+}
+
+func (m *tagServiceMock) RenameTag(renameReq requests.RenameTagRequest) ([]responses.TagResponse, error) {
+	args := m.Called(renameReq)
+	return args.Get(0).([]responses.TagResponse), args.Error(1)
+}
+
+func (m *tagServiceMock) MergeTags(mergeReq requests.MergeTagsRequest) ([]responses.TagResponse, error) {
+	args := m.Called(mergeReq)
+	return args.Get(0).([]responses.TagResponse), args.Error(1)
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type TagService interface {
+	GetTags() ([]responses.TagResponse, error)
+	SetTag(tagReq requests.TagRequest) (responses.TagResponse, error)
+	RenameTag(renameReq requests.RenameTagRequest) ([]responses.TagResponse, error)
+	MergeTags(mergeReq requests.MergeTagsRequest) ([]responses.TagResponse, error)
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/tag/repositories"
+)
+
+type tagService struct {
+	tagRepo repositories.TagRepository
+}
+
+func NewTagService(tagRepo repositories.TagRepository) TagService {
+	return tagService{tagRepo: tagRepo}
+}
+
+// GetTags lists the tags in use, their ancestors and the tags that only
+// have metadata, sorted by name so that children follow their parent.
+func (s tagService) GetTags() ([]responses.TagResponse, error) {
+	usage, err := s.tagRepo.GetUsage()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+	tags, err := s.tagRepo.GetAll()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	byName := map[string]*responses.TagResponse{}
+	for _, tag := range usage {
+		byName[tag.Name] = &responses.TagResponse{Name: tag.Name, Count: tag.Count, TotalCount: tag.TotalCount}
+	}
+	for _, tag := range tags {
+		if byName[tag.Name] == nil {
+			byName[tag.Name] = &responses.TagResponse{Name: tag.Name}
+		}
+		byName[tag.Name].Color = tag.Color
+		byName[tag.Name].Icon = tag.Icon
+	}
+
+	tagsResp := []responses.TagResponse{}
+	for name, tag := range byName {
+		tag.Parent = parentOf(name)
+		tagsResp = append(tagsResp, *tag)
+	}
+	sort.Slice(tagsResp, func(i, j int) bool {
+		return tagsResp[i].Name < tagsResp[j].Name
+	})
+
+	return tagsResp, nil
+}
+
+func (s tagService) SetTag(tagReq requests.TagRequest) (responses.TagResponse, error) {
+	if err := validateName(tagReq.Name); err != nil {
+		return responses.TagResponse{}, err
+	}
+
+	tag := models.Tag{Name: tagReq.Name, Color: strings.ToLower(tagReq.Color), Icon: tagReq.Icon}
+	if err := s.tagRepo.Save(&tag); err != nil {
+		return responses.TagResponse{}, helpers.NewInternalServerError()
+	}
+
+	tags, err := s.GetTags()
+	if err != nil {
+		return responses.TagResponse{}, err
+	}
+	for _, tagResp := range tags {
+		if tagResp.Name == tag.Name {
+			return tagResp, nil
+		}
+	}
+
+	return responses.TagResponse{Name: tag.Name, Parent: parentOf(tag.Name), Color: tag.Color, Icon: tag.Icon}, nil
+}
+
+// RenameTag renames a tag and its children, the new name must not be taken,
+// use MergeTags to combine tags.
+func (s tagService) RenameTag(renameReq requests.RenameTagRequest) ([]responses.TagResponse, error) {
+	if err := validateName(renameReq.To); err != nil {
+		return nil, err
+	}
+	if isWithin(renameReq.To, renameReq.From) {
+		return nil, helpers.NewBadRequestError("a tag cannot be moved below itself")
+	}
+
+	known, err := s.knownTags()
+	if err != nil {
+		return nil, err
+	}
+	if !known[renameReq.From] {
+		return nil, helpers.NewNotFoundError()
+	}
+	if known[renameReq.To] {
+		return nil, helpers.NewConflictError(fmt.Sprintf("tag %s already exists, merge the tags instead", renameReq.To))
+	}
+
+	if err := s.tagRepo.Rename([]string{renameReq.From}, renameReq.To); err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	return s.GetTags()
+}
+
+// MergeTags replaces the tags in From and their children by To, an expense
+// tagged with several of them keeps To once.
+func (s tagService) MergeTags(mergeReq requests.MergeTagsRequest) ([]responses.TagResponse, error) {
+	if err := validateName(mergeReq.To); err != nil {
+		return nil, err
+	}
+
+	var from []string
+	for _, name := range mergeReq.From {
+		if name == mergeReq.To {
+			continue
+		}
+		if isWithin(mergeReq.To, name) {
+			return nil, helpers.NewBadRequestError(fmt.Sprintf("tag %s cannot be merged into its own child %s", name, mergeReq.To))
+		}
+		from = append(from, name)
+	}
+	if len(from) == 0 {
+		return nil, helpers.NewBadRequestError("there is no other tag to merge")
+	}
+
+	known, err := s.knownTags()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range from {
+		if !known[name] {
+			return nil, helpers.NewBadRequestError(fmt.Sprintf("tag %s does not exist", name))
+		}
+	}
+
+	if err := s.tagRepo.Rename(from, mergeReq.To); err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	return s.GetTags()
+}
+
+func (s tagService) knownTags() (map[string]bool, error) {
+	tags, err := s.GetTags()
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[string]bool{}
+	for _, tag := range tags {
+		known[tag.Name] = true
+	}
+
+	return known, nil
+}
+
+func validateName(name string) error {
+	if strings.TrimSpace(name) != name || name == "" {
+		return helpers.NewBadRequestError("tag names must not be empty or start or end with spaces")
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == "" {
+			return helpers.NewBadRequestError(fmt.Sprintf("tag %s has an empty level, use names like food/coffee", name))
+		}
+	}
+
+	return nil
+}
+
+func parentOf(name string) string {
+	if i := strings.LastIndex(name, "/"); i > 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// isWithin reports whether name is ancestor itself or one of its children.
+func isWithin(name string, ancestor string) bool {
+	return name == ancestor || strings.HasPrefix(name, ancestor+"/")
+}
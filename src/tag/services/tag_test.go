@@ -0,0 +1,136 @@
+//go:build unit
+
+package services_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/tag/repositories"
+	"github.com/wytquant/assessment/src/tag/services"
+)
+
+var usage = []repositories.TagUsage{
+	{Name: "beverge", Count: 3, TotalCount: 3},
+	{Name: "beverage", Count: 5, TotalCount: 5},
+	{Name: "food", Count: 2, TotalCount: 6},
+	{Name: "food/coffee", Count: 4, TotalCount: 4},
+}
+
+func TestGetTagsService(t *testing.T) {
+	t.Run("get tags lists usage with parents and metadata", func(t *testing.T) {
+		//arrange
+		tagRepo := repositories.NewTagRepositoryMock()
+		tagRepo.On("GetUsage").Return(usage, nil)
+		tagRepo.On("GetAll").Return([]models.Tag{{Name: "food", Color: "#ff8800", Icon: "utensils"}, {Name: "travel", Color: "#0000ff"}}, nil)
+
+		tagService := services.NewTagService(tagRepo)
+
+		//act
+		got, err := tagService.GetTags()
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, []responses.TagResponse{
+			{Name: "beverage", Count: 5, TotalCount: 5},
+			{Name: "beverge", Count: 3, TotalCount: 3},
+			{Name: "food", Count: 2, TotalCount: 6, Color: "#ff8800", Icon: "utensils"},
+			{Name: "food/coffee", Parent: "food", Count: 4, TotalCount: 4},
+			{Name: "travel", Color: "#0000ff"},
+		}, got)
+	})
+}
+
+func TestRenameTagService(t *testing.T) {
+	t.Run("rename tag success case", func(t *testing.T) {
+		//arrange
+		tagRepo := repositories.NewTagRepositoryMock()
+		tagRepo.On("GetUsage").Return(usage, nil)
+		tagRepo.On("GetAll").Return([]models.Tag{}, nil)
+		tagRepo.On("Rename", []string{"food"}, "meals").Return(nil)
+
+		tagService := services.NewTagService(tagRepo)
+
+		//act
+		_, err := tagService.RenameTag(requests.RenameTagRequest{From: "food", To: "meals"})
+
+		//assert
+		assert.NoError(t, err)
+		tagRepo.AssertExpectations(t)
+	})
+
+	t.Run("rename tag fail case because the new name is taken", func(t *testing.T) {
+		//arrange
+		tagRepo := repositories.NewTagRepositoryMock()
+		tagRepo.On("GetUsage").Return(usage, nil)
+		tagRepo.On("GetAll").Return([]models.Tag{}, nil)
+
+		tagService := services.NewTagService(tagRepo)
+
+		//act
+		_, err := tagService.RenameTag(requests.RenameTagRequest{From: "beverge", To: "beverage"})
+
+		//assert
+		assert.Equal(t, helpers.NewConflictError("tag beverage already exists, merge the tags instead"), err)
+	})
+
+	t.Run("rename tag fail case because the tag does not exist", func(t *testing.T) {
+		//arrange
+		tagRepo := repositories.NewTagRepositoryMock()
+		tagRepo.On("GetUsage").Return(usage, nil)
+		tagRepo.On("GetAll").Return([]models.Tag{}, nil)
+
+		tagService := services.NewTagService(tagRepo)
+
+		//act
+		_, err := tagService.RenameTag(requests.RenameTagRequest{From: "rent", To: "housing"})
+
+		//assert
+		assert.Equal(t, helpers.NewNotFoundError(), err)
+	})
+
+	t.Run("rename tag fail case because the name has an empty level", func(t *testing.T) {
+		//arrange
+		tagService := services.NewTagService(repositories.NewTagRepositoryMock())
+
+		//act
+		_, err := tagService.RenameTag(requests.RenameTagRequest{From: "food", To: "food//coffee"})
+
+		//assert
+		assert.Equal(t, helpers.NewBadRequestError("tag food//coffee has an empty level, use names like food/coffee"), err)
+	})
+}
+
+func TestMergeTagsService(t *testing.T) {
+	t.Run("merge tags success case", func(t *testing.T) {
+		//arrange
+		tagRepo := repositories.NewTagRepositoryMock()
+		tagRepo.On("GetUsage").Return(usage, nil)
+		tagRepo.On("GetAll").Return([]models.Tag{}, nil)
+		tagRepo.On("Rename", []string{"beverge"}, "beverage").Return(nil)
+
+		tagService := services.NewTagService(tagRepo)
+
+		//act
+		_, err := tagService.MergeTags(requests.MergeTagsRequest{From: []string{"beverge", "beverage"}, To: "beverage"})
+
+		//assert
+		assert.NoError(t, err)
+		tagRepo.AssertExpectations(t)
+	})
+
+	t.Run("merge tags fail case because the target is a child of a source", func(t *testing.T) {
+		//arrange
+		tagService := services.NewTagService(repositories.NewTagRepositoryMock())
+
+		//act
+		_, err := tagService.MergeTags(requests.MergeTagsRequest{From: []string{"food"}, To: "food/coffee"})
+
+		//assert
+		assert.Equal(t, helpers.NewBadRequestError("tag food cannot be merged into its own child food/coffee"), err)
+	})
+}
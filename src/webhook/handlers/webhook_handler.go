@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/webhook/services"
+)
+
+type webhookHandler struct {
+	webhookService services.WebhookService
+}
+
+func NewWebhookHandler(webhookService services.WebhookService) webhookHandler {
+	return webhookHandler{webhookService: webhookService}
+}
+
+func (h webhookHandler) CreateWebhook(c *gin.Context) {
+	var webhookReq requests.WebhookRequest
+	if err := c.ShouldBindJSON(&webhookReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	webhookResp, err := h.webhookService.CreateWebhook(webhookReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhookResp)
+}
+
+func (h webhookHandler) GetAllWebhooks(c *gin.Context) {
+	webhooksResp, err := h.webhookService.GetWebhooks()
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooksResp)
+}
+
+func (h webhookHandler) GetWebhookByID(c *gin.Context) {
+	webhookResp, err := h.webhookService.GetWebhookByID(c.Param("id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, webhookResp)
+}
+
+func (h webhookHandler) UpdateWebhookByID(c *gin.Context) {
+	var webhookReq requests.WebhookRequest
+	if err := c.ShouldBindJSON(&webhookReq); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	webhookResp, err := h.webhookService.UpdateWebhookByID(c.Param("id"), webhookReq)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, webhookResp)
+}
+
+func (h webhookHandler) DeleteWebhookByID(c *gin.Context) {
+	if err := h.webhookService.DeleteWebhookByID(c.Param("id")); err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h webhookHandler) GetDeliveries(c *gin.Context) {
+	var query requests.WebhookDeliveryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	deliveriesResp, err := h.webhookService.GetDeliveries(c.Param("id"), query)
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveriesResp)
+}
+
+func (h webhookHandler) ReplayDelivery(c *gin.Context) {
+	deliveryResp, err := h.webhookService.ReplayDelivery(c.Param("id"), c.Param("delivery_id"))
+	if err != nil {
+		appErr, ok := err.(*helpers.AppError)
+		if ok {
+			c.JSON(appErr.StatusCode, gin.H{"message": appErr.Message})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, deliveryResp)
+}
@@ -0,0 +1,128 @@
+//go:build unit
+
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/webhook/handlers"
+	services "github.com/wytquant/assessment/src/webhook/services/mock"
+)
+
+func TestCreateWebhookHandler(t *testing.T) {
+	t.Run("create webhook success case", func(t *testing.T) {
+		//arrange
+		webhookReq := requests.WebhookRequest{URL: "https://example.com/hook", Events: []string{models.EventExpenseCreated}}
+		webhookService := services.NewWebhookServiceMock()
+		webhookService.On("CreateWebhook", webhookReq).Return(responses.WebhookResponse{ID: 1, URL: "https://example.com/hook", Secret: "s3cr3t"}, nil)
+
+		webhookHandler := handlers.NewWebhookHandler(webhookService)
+
+		r := gin.Default()
+		r.POST("/webhooks", webhookHandler.CreateWebhook)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(`{"url": "https://example.com/hook", "events": ["expense.created"]}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"secret":"s3cr3t"`)
+		webhookService.AssertExpectations(t)
+	})
+
+	t.Run("create webhook fail bad request because the event is unknown", func(t *testing.T) {
+		//arrange
+		webhookHandler := handlers.NewWebhookHandler(services.NewWebhookServiceMock())
+
+		r := gin.Default()
+		r.POST("/webhooks", webhookHandler.CreateWebhook)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(`{"url": "https://example.com/hook", "events": ["expense.paid"]}`))
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetDeliveriesHandler(t *testing.T) {
+	t.Run("get dead deliveries success case", func(t *testing.T) {
+		//arrange
+		webhookService := services.NewWebhookServiceMock()
+		webhookService.On("GetDeliveries", "1", requests.WebhookDeliveryQuery{Status: models.DeliveryDead}).Return([]responses.WebhookDeliveryResponse{{ID: 5, Status: models.DeliveryDead}}, nil)
+
+		webhookHandler := handlers.NewWebhookHandler(webhookService)
+
+		r := gin.Default()
+		r.GET("/webhooks/:id/deliveries", webhookHandler.GetDeliveries)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/webhooks/1/deliveries?status=dead", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		webhookService.AssertExpectations(t)
+	})
+}
+
+func TestReplayDeliveryHandler(t *testing.T) {
+	t.Run("replay delivery success case", func(t *testing.T) {
+		//arrange
+		webhookService := services.NewWebhookServiceMock()
+		webhookService.On("ReplayDelivery", "1", "5").Return(responses.WebhookDeliveryResponse{ID: 6, Status: models.DeliveryPending}, nil)
+
+		webhookHandler := handlers.NewWebhookHandler(webhookService)
+
+		r := gin.Default()
+		r.POST("/webhooks/:id/deliveries/:delivery_id/replay", webhookHandler.ReplayDelivery)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks/1/deliveries/5/replay", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusCreated, w.Code)
+		webhookService.AssertExpectations(t)
+	})
+
+	t.Run("replay delivery fail conflict because it is still pending", func(t *testing.T) {
+		//arrange
+		webhookService := services.NewWebhookServiceMock()
+		webhookService.On("ReplayDelivery", "1", "5").Return(responses.WebhookDeliveryResponse{}, helpers.NewConflictError("delivery 5 is still pending"))
+
+		webhookHandler := handlers.NewWebhookHandler(webhookService)
+
+		r := gin.Default()
+		r.POST("/webhooks/:id/deliveries/:delivery_id/replay", webhookHandler.ReplayDelivery)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks/1/deliveries/5/replay", nil)
+
+		//act
+		r.ServeHTTP(w, req)
+
+		//assert
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, `{"message":"delivery 5 is still pending"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,48 @@
+package outbox
+
+import (
+	"encoding/json"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/responses"
+	"gorm.io/gorm"
+)
+
+// Record adds the event of each expense to the outbox. tx is the
+// transaction changing the expenses, so an event is stored exactly when its
+// change is.
+func Record(tx *gorm.DB, event string, expenses ...models.Expense) error {
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	events := make([]models.ExpenseEvent, 0, len(expenses))
+	for _, expense := range expenses {
+		var expenseResp responses.ExpenseResponse
+		copier.Copy(&expenseResp, &expense)
+		payload, err := json.Marshal(expenseResp)
+		if err != nil {
+			return err
+		}
+		events = append(events, models.ExpenseEvent{Event: event, ExpenseID: expense.ID, Payload: string(payload)})
+	}
+
+	return tx.CreateInBatches(&events, 100).Error
+}
+
+// RecordChanged adds the event of the expenses of the ids as they are in tx,
+// for changes made by an update that does not load the expenses. Soft
+// deleted expenses are left out.
+func RecordChanged(tx *gorm.DB, event string, ids ...uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var expenses []models.Expense
+	if err := tx.Where("id IN ?", ids).Order("id").Find(&expenses).Error; err != nil {
+		return err
+	}
+
+	return Record(tx, event, expenses...)
+}
@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+	"gorm.io/gorm"
+)
+
+type webhookRepositoryDB struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepositoryDB(db *gorm.DB) WebhookRepository {
+	return webhookRepositoryDB{db: db}
+}
+
+func (r webhookRepositoryDB) CreateSubscription(subscription *models.WebhookSubscription) error {
+	query := r.db
+	if err := query.Create(subscription).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r webhookRepositoryDB) GetSubscriptions() ([]models.WebhookSubscription, error) {
+	query := r.db
+	var subscriptions []models.WebhookSubscription
+
+	if err := query.Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r webhookRepositoryDB) GetSubscriptionByID(id string) (models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	query := r.db
+	if err := query.Where("id = ?", id).First(&subscription).Error; err != nil {
+		return models.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (r webhookRepositoryDB) UpdateSubscription(subscription models.WebhookSubscription) error {
+	query := r.db
+	if err := query.Model(&subscription).Select("url", "events", "secret").Updates(subscription).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSubscriptionByID soft deletes the subscription, its deliveries stay
+// in the log and the pending ones are no longer sent.
+func (r webhookRepositoryDB) DeleteSubscriptionByID(id string) error {
+	result := r.db.Where("id = ?", id).Delete(&models.WebhookSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r webhookRepositoryDB) GetDeliveries(filter DeliveryFilter) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	query := r.db.Where("subscription_id = ?", filter.SubscriptionID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Order("id DESC").Limit(filter.Limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r webhookRepositoryDB) GetDelivery(subscriptionID string, deliveryID string) (models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	query := r.db
+	if err := query.Where("id = ? AND subscription_id = ?", deliveryID, subscriptionID).First(&delivery).Error; err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (r webhookRepositoryDB) CreateDelivery(delivery *models.WebhookDelivery) error {
+	query := r.db
+	if err := query.Create(delivery).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r webhookRepositoryDB) FanOut(now time.Time, limit int) (int, error) {
+	var ids []uint
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(`SELECT id FROM expense_events
+			WHERE dispatched_at IS NULL
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED`, limit).Scan(&ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Exec(`INSERT INTO webhook_deliveries
+			(subscription_id, event_id, event, status, attempts, next_attempt_at, created_at, updated_at)
+			SELECT s.id, e.id, e.event, ?, 0, ?, ?, ?
+			FROM expense_events e
+			JOIN webhook_subscriptions s ON s.deleted_at IS NULL AND s.created_at <= e.created_at
+				AND (s.events IS NULL OR cardinality(s.events) = 0 OR e.event = ANY(s.events))
+			LEFT JOIN expenses x ON x.id = e.expense_id
+			WHERE e.id IN ? AND x.group_id IS NULL
+			ORDER BY e.id, s.id`, models.DeliveryPending, now, now, now, ids).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.ExpenseEvent{}).Where("id IN ?", ids).Update("dispatched_at", now).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+// ClaimDueDeliveries leases pending deliveries by pushing their next attempt
+// into the future, so concurrent dispatchers never pick the same row.
+func (r webhookRepositoryDB) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+
+	err := r.db.Raw(`UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+				AND subscription_id IN (SELECT id FROM webhook_subscriptions WHERE deleted_at IS NULL)
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), models.DeliveryPending, now, limit).Scan(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r webhookRepositoryDB) UpdateDelivery(delivery models.WebhookDelivery) error {
+	query := r.db
+	if err := query.Model(&delivery).
+		Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at").
+		Updates(delivery).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r webhookRepositoryDB) GetEvents(ids []uint) ([]models.ExpenseEvent, error) {
+	var events []models.ExpenseEvent
+	if len(ids) == 0 {
+		return events, nil
+	}
+
+	if err := r.db.Where("id IN ?", ids).Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
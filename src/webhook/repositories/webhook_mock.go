@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/models"
+)
+
+type webhookRepositoryMock struct {
+	mock.Mock
+}
+
+func NewWebhookRepositoryMock() *webhookRepositoryMock {
+	return &webhookRepositoryMock{}
+}
+
+func (m *webhookRepositoryMock) CreateSubscription(subscription *models.WebhookSubscription) error {
+	args := m.Called(subscription)
+	return args.Error(0)
+}
+
+func (m *webhookRepositoryMock) GetSubscriptions() ([]models.WebhookSubscription, error) {
+	args := m.Called()
+	return args.Get(0).([]models.WebhookSubscription), args.Error(1)
+}
+
+func (m *webhookRepositoryMock) GetSubscriptionByID(id string) (models.WebhookSubscription, error) {
+	args := m.Called(id)
+	return args.Get(0).(models.WebhookSubscription), args.Error(1)
+}
+
+func (m *webhookRepositoryMock) UpdateSubscription(subscription models.WebhookSubscription) error {
+	args := m.Called(subscription)
+	return args.Error(0)
+}
+
+func (m *webhookRepositoryMock) DeleteSubscriptionByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *webhookRepositoryMock) GetDeliveries(filter DeliveryFilter) ([]models.WebhookDelivery, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]models.WebhookDelivery), args.Error(1)
+}
+
+func (m *webhookRepositoryMock) GetDelivery(subscriptionID string, deliveryID string) (models.WebhookDelivery, error) {
+	args := m.Called(subscriptionID, deliveryID)
+	return args.Get(0).(models.WebhookDelivery), args.Error(1)
+}
+
+func (m *webhookRepositoryMock) CreateDelivery(delivery *models.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *webhookRepositoryMock) FanOut(now time.Time, limit int) (int, error) {
+	args := m.Called(now, limit)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *webhookRepositoryMock) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	args := m.Called(now, lease, limit)
+	return args.Get(0).([]models.WebhookDelivery), args.Error(1)
+}
+
+func (m *webhookRepositoryMock) UpdateDelivery(delivery models.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *webhookRepositoryMock) GetEvents(ids []uint) ([]models.ExpenseEvent, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.ExpenseEvent), args.Error(1)
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/models"
+)
+
+type DeliveryFilter struct {
+	SubscriptionID uint
+	Status         string
+	Limit          int
+}
+
+type WebhookRepository interface {
+	CreateSubscription(*models.WebhookSubscription) error
+	GetSubscriptions() ([]models.WebhookSubscription, error)
+	GetSubscriptionByID(id string) (models.WebhookSubscription, error)
+	UpdateSubscription(subscription models.WebhookSubscription) error
+	// DeleteSubscriptionByID deletes the subscription with its deliveries.
+	DeleteSubscriptionByID(id string) error
+	// GetDeliveries returns the deliveries of a subscription, newest first.
+	GetDeliveries(filter DeliveryFilter) ([]models.WebhookDelivery, error)
+	GetDelivery(subscriptionID string, deliveryID string) (models.WebhookDelivery, error)
+	CreateDelivery(*models.WebhookDelivery) error
+	// FanOut creates a pending delivery of up to limit undispatched events
+	// for every subscription that wants them and existed when they happened,
+	// and returns how many events it dispatched. Events of group ledgers are
+	// dispatched without deliveries, subscriptions belong to the owner.
+	FanOut(now time.Time, limit int) (int, error)
+	// ClaimDueDeliveries leases the pending deliveries due at now.
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+	UpdateDelivery(delivery models.WebhookDelivery) error
+	GetEvents(ids []uint) ([]models.ExpenseEvent, error)
+}
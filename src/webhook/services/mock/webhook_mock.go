@@ -0,0 +1,57 @@
+package services
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+type webhookServiceMock struct {
+	mock.Mock
+}
+
+func NewWebhookServiceMock() *webhookServiceMock {
+	return &webhookServiceMock{}
+}
+
+func (m *webhookServiceMock) CreateWebhook(webhookReq requests.WebhookRequest) (responses.WebhookResponse, error) {
+	args := m.Called(webhookReq)
+	return args.Get(0).(responses.WebhookResponse), args.Error(1)
+}
+
+func (m *webhookServiceMock) GetWebhooks() ([]responses.WebhookResponse, error) {
+	args := m.Called()
+	return args.Get(0).([]responses.WebhookResponse), args.Error(1)
+}
+
+func (m *webhookServiceMock) GetWebhookByID(id string) (responses.WebhookResponse, error) {
+	args := m.Called(id)
+	return args.Get(0).(responses.WebhookResponse), args.Error(1)
+}
+
+func (m *webhookServiceMock) UpdateWebhookByID(id string, webhookReq requests.WebhookRequest) (responses.WebhookResponse, error) {
+	args := m.Called(id, webhookReq)
+	return args.Get(0).(responses.WebhookResponse), args.Error(1)
+}
+
+func (m *webhookServiceMock) DeleteWebhookByID(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *webhookServiceMock) GetDeliveries(id string, query requests.WebhookDeliveryQuery) ([]responses.WebhookDeliveryResponse, error) {
+	args := m.Called(id, query)
+	return args.Get(0).([]responses.WebhookDeliveryResponse), args.Error(1)
+}
+
+func (m *webhookServiceMock) ReplayDelivery(id string, deliveryID string) (responses.WebhookDeliveryResponse, error) {
+	args := m.Called(id, deliveryID)
+	return args.Get(0).(responses.WebhookDeliveryResponse), args.Error(1)
+}
+
+func (m *webhookServiceMock) DispatchPending(now time.Time) error {
+	args := m.Called(now)
+	return args.Error(0)
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunDispatcher delivers expense events every interval until ctx is done.
+func RunDispatcher(ctx context.Context, webhookService WebhookService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := webhookService.DispatchPending(now); err != nil {
+				log.Println("fail to dispatch webhooks:", err)
+			}
+		}
+	}
+}
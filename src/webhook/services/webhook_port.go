@@ -0,0 +1,25 @@
+package services
+
+import (
+	"time"
+
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+)
+
+// WebhookService manages the subscriptions to expense events and delivers
+// the events recorded in the outbox to them.
+type WebhookService interface {
+	CreateWebhook(webhookReq requests.WebhookRequest) (responses.WebhookResponse, error)
+	GetWebhooks() ([]responses.WebhookResponse, error)
+	GetWebhookByID(id string) (responses.WebhookResponse, error)
+	UpdateWebhookByID(id string, webhookReq requests.WebhookRequest) (responses.WebhookResponse, error)
+	DeleteWebhookByID(id string) error
+	GetDeliveries(id string, query requests.WebhookDeliveryQuery) ([]responses.WebhookDeliveryResponse, error)
+	// ReplayDelivery sends the event of a finished delivery again as a new
+	// delivery.
+	ReplayDelivery(id string, deliveryID string) (responses.WebhookDeliveryResponse, error)
+	// DispatchPending fans the new events out to the subscriptions and sends
+	// the deliveries that are due.
+	DispatchPending(now time.Time) error
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/responses"
+	"github.com/wytquant/assessment/src/alert/senders"
+	"github.com/wytquant/assessment/src/webhook/repositories"
+)
+
+// A delivery is attempted maxDeliveryAttempts times, deliveryRetryBase
+// after the first failure and twice as long after each next one, about two
+// hours in all before it is dead.
+const (
+	maxDeliveryAttempts = 8
+	deliveryRetryBase   = time.Minute
+	deliveryLease       = time.Minute
+	deliveryBatchSize   = 50
+	fanOutBatchSize     = 200
+	defaultLogLimit     = 100
+)
+
+type webhookService struct {
+	webhookRepo repositories.WebhookRepository
+	sender      senders.WebhookSender
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepository, sender senders.WebhookSender) WebhookService {
+	return webhookService{webhookRepo: webhookRepo, sender: sender}
+}
+
+func (s webhookService) CreateWebhook(webhookReq requests.WebhookRequest) (responses.WebhookResponse, error) {
+	subscription := models.WebhookSubscription{URL: webhookReq.URL, Events: webhookReq.Events, Secret: webhookReq.Secret}
+	if subscription.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return responses.WebhookResponse{}, helpers.NewInternalServerError()
+		}
+		subscription.Secret = secret
+	}
+
+	if err := s.webhookRepo.CreateSubscription(&subscription); err != nil {
+		return responses.WebhookResponse{}, helpers.NewInternalServerError()
+	}
+
+	webhookResp := webhookResponse(subscription)
+	webhookResp.Secret = subscription.Secret
+
+	return webhookResp, nil
+}
+
+func (s webhookService) GetWebhooks() ([]responses.WebhookResponse, error) {
+	webhooksResp := []responses.WebhookResponse{}
+
+	subscriptions, err := s.webhookRepo.GetSubscriptions()
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	for _, subscription := range subscriptions {
+		webhooksResp = append(webhooksResp, webhookResponse(subscription))
+	}
+
+	return webhooksResp, nil
+}
+
+func (s webhookService) GetWebhookByID(id string) (responses.WebhookResponse, error) {
+	subscription, err := s.webhookRepo.GetSubscriptionByID(id)
+	if err != nil {
+		return responses.WebhookResponse{}, helpers.NewNotFoundError()
+	}
+
+	return webhookResponse(subscription), nil
+}
+
+func (s webhookService) UpdateWebhookByID(id string, webhookReq requests.WebhookRequest) (responses.WebhookResponse, error) {
+	subscription, err := s.webhookRepo.GetSubscriptionByID(id)
+	if err != nil {
+		return responses.WebhookResponse{}, helpers.NewNotFoundError()
+	}
+
+	subscription.URL = webhookReq.URL
+	subscription.Events = webhookReq.Events
+	if webhookReq.Secret != "" {
+		subscription.Secret = webhookReq.Secret
+	}
+
+	if err := s.webhookRepo.UpdateSubscription(subscription); err != nil {
+		return responses.WebhookResponse{}, helpers.NewInternalServerError()
+	}
+
+	return webhookResponse(subscription), nil
+}
+
+func (s webhookService) DeleteWebhookByID(id string) error {
+	if err := s.webhookRepo.DeleteSubscriptionByID(id); err != nil {
+		return helpers.NewNotFoundError()
+	}
+
+	return nil
+}
+
+func (s webhookService) GetDeliveries(id string, query requests.WebhookDeliveryQuery) ([]responses.WebhookDeliveryResponse, error) {
+	subscription, err := s.webhookRepo.GetSubscriptionByID(id)
+	if err != nil {
+		return nil, helpers.NewNotFoundError()
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultLogLimit
+	}
+
+	deliveries, err := s.webhookRepo.GetDeliveries(repositories.DeliveryFilter{SubscriptionID: subscription.ID, Status: query.Status, Limit: limit})
+	if err != nil {
+		return nil, helpers.NewInternalServerError()
+	}
+
+	deliveriesResp := []responses.WebhookDeliveryResponse{}
+	copier.Copy(&deliveriesResp, &deliveries)
+
+	return deliveriesResp, nil
+}
+
+func (s webhookService) ReplayDelivery(id string, deliveryID string) (responses.WebhookDeliveryResponse, error) {
+	var deliveryResp responses.WebhookDeliveryResponse
+
+	delivery, err := s.webhookRepo.GetDelivery(id, deliveryID)
+	if err != nil {
+		return responses.WebhookDeliveryResponse{}, helpers.NewNotFoundError()
+	}
+	if delivery.Status == models.DeliveryPending {
+		return responses.WebhookDeliveryResponse{}, helpers.NewConflictError(fmt.Sprintf("delivery %d is still pending", delivery.ID))
+	}
+
+	replay := models.WebhookDelivery{
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		Event:          delivery.Event,
+		Status:         models.DeliveryPending,
+		NextAttemptAt:  time.Now(),
+		ReplayOfID:     &delivery.ID,
+	}
+	if err := s.webhookRepo.CreateDelivery(&replay); err != nil {
+		return responses.WebhookDeliveryResponse{}, helpers.NewInternalServerError()
+	}
+
+	copier.Copy(&deliveryResp, &replay)
+
+	return deliveryResp, nil
+}
+
+// DispatchPending sends the due deliveries once, rescheduling failures with
+// exponential backoff until maxDeliveryAttempts is reached and the delivery
+// is dead.
+func (s webhookService) DispatchPending(now time.Time) error {
+	if _, err := s.webhookRepo.FanOut(now, fanOutBatchSize); err != nil {
+		return err
+	}
+
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(now, deliveryLease, deliveryBatchSize)
+	if err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var eventIDs []uint
+	for _, delivery := range deliveries {
+		eventIDs = append(eventIDs, delivery.EventID)
+	}
+	events, err := s.webhookRepo.GetEvents(eventIDs)
+	if err != nil {
+		return err
+	}
+	eventByID := map[uint]models.ExpenseEvent{}
+	for _, event := range events {
+		eventByID[event.ID] = event
+	}
+
+	subscriptions, err := s.webhookRepo.GetSubscriptions()
+	if err != nil {
+		return err
+	}
+	subscriptionByID := map[uint]models.WebhookSubscription{}
+	for _, subscription := range subscriptions {
+		subscriptionByID[subscription.ID] = subscription
+	}
+
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptionByID[delivery.SubscriptionID]
+		if !ok {
+			// deleted meanwhile, its deliveries are not sent any more
+			continue
+		}
+
+		code, sendErr := s.send(subscription, delivery, eventByID[delivery.EventID])
+		if err := s.webhookRepo.UpdateDelivery(nextDeliveryState(now, delivery, code, sendErr)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send posts the event signed with the secret of the subscription, the
+// X-Webhook-Signature header is the hex HMAC-SHA256 of the body.
+func (s webhookService) send(subscription models.WebhookSubscription, delivery models.WebhookDelivery, event models.ExpenseEvent) (int, error) {
+	payload, err := json.Marshal(responses.ExpenseEventPayload{
+		ID:        event.ID,
+		Event:     event.Event,
+		CreatedAt: event.CreatedAt,
+		Expense:   json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return s.sender.Send(subscription.URL, payload, map[string]string{
+		"X-Webhook-Event":     event.Event,
+		"X-Webhook-Delivery":  strconv.FormatUint(uint64(delivery.ID), 10),
+		"X-Webhook-Signature": "sha256=" + Sign(subscription.Secret, payload),
+	})
+}
+
+// Sign returns the hex HMAC-SHA256 of the payload with the secret.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func nextDeliveryState(now time.Time, delivery models.WebhookDelivery, code int, sendErr error) models.WebhookDelivery {
+	delivery.Attempts++
+	delivery.LastStatusCode = code
+	if sendErr == nil {
+		delivery.Status = models.DeliverySent
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return delivery
+	}
+
+	delivery.LastError = sendErr.Error()
+	if delivery.Attempts >= maxDeliveryAttempts {
+		delivery.Status = models.DeliveryDead
+		return delivery
+	}
+
+	delivery.Status = models.DeliveryPending
+	delivery.NextAttemptAt = now.Add(deliveryRetryBase << (delivery.Attempts - 1))
+	return delivery
+}
+
+func webhookResponse(subscription models.WebhookSubscription) responses.WebhookResponse {
+	events := []string{}
+	events = append(events, subscription.Events...)
+
+	return responses.WebhookResponse{
+		ID:        subscription.ID,
+		URL:       subscription.URL,
+		Events:    events,
+		CreatedAt: subscription.CreatedAt,
+		UpdatedAt: subscription.UpdatedAt,
+	}
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
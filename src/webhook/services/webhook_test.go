@@ -0,0 +1,164 @@
+//go:build unit
+
+package services_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wytquant/assessment/helpers"
+	"github.com/wytquant/assessment/models"
+	"github.com/wytquant/assessment/requests"
+	"github.com/wytquant/assessment/src/alert/senders"
+	"github.com/wytquant/assessment/src/webhook/repositories"
+	"github.com/wytquant/assessment/src/webhook/services"
+)
+
+var now = time.Date(2023, 3, 6, 12, 0, 0, 0, time.UTC)
+
+func subscription() models.WebhookSubscription {
+	return models.WebhookSubscription{ID: 1, URL: "https://example.com/hook", Events: pq.StringArray{models.EventExpenseCreated}, Secret: "0123456789abcdef"}
+}
+
+func TestCreateWebhookService(t *testing.T) {
+	t.Run("create webhook generates a secret when none is given", func(t *testing.T) {
+		//arrange
+		webhookRepo := repositories.NewWebhookRepositoryMock()
+		webhookRepo.On("CreateSubscription", mock.AnythingOfType("*models.WebhookSubscription")).Return(nil)
+
+		webhookService := services.NewWebhookService(webhookRepo, senders.NewWebhookSenderMock())
+
+		//act
+		got, err := webhookService.CreateWebhook(requests.WebhookRequest{URL: "https://example.com/hook", Events: []string{models.EventExpenseCreated}})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Len(t, got.Secret, 64)
+		assert.Equal(t, []string{models.EventExpenseCreated}, got.Events)
+	})
+}
+
+func TestUpdateWebhookByIDService(t *testing.T) {
+	t.Run("update webhook keeps the secret when none is given", func(t *testing.T) {
+		//arrange
+		want := subscription()
+		want.URL = "https://example.com/other"
+		webhookRepo := repositories.NewWebhookRepositoryMock()
+		webhookRepo.On("GetSubscriptionByID", "1").Return(subscription(), nil)
+		webhookRepo.On("UpdateSubscription", want).Return(nil)
+
+		webhookService := services.NewWebhookService(webhookRepo, senders.NewWebhookSenderMock())
+
+		//act
+		got, err := webhookService.UpdateWebhookByID("1", requests.WebhookRequest{URL: "https://example.com/other", Events: []string{models.EventExpenseCreated}})
+
+		//assert
+		assert.NoError(t, err)
+		assert.Empty(t, got.Secret)
+		webhookRepo.AssertExpectations(t)
+	})
+}
+
+func TestDispatchPendingService(t *testing.T) {
+	cases := []struct {
+		name     string
+		attempts int
+		code     int
+		sendErr  error
+		want     models.WebhookDelivery
+	}{
+		{
+			name: "delivered event is sent",
+			code: http.StatusOK,
+			want: models.WebhookDelivery{ID: 5, SubscriptionID: 1, EventID: 3, Event: models.EventExpenseCreated, Status: models.DeliverySent, Attempts: 1, NextAttemptAt: now, LastStatusCode: http.StatusOK, DeliveredAt: &now},
+		},
+		{
+			name:     "failed delivery is retried with backoff",
+			attempts: 2,
+			code:     http.StatusBadGateway,
+			sendErr:  errors.New("unexpected status 502"),
+			want:     models.WebhookDelivery{ID: 5, SubscriptionID: 1, EventID: 3, Event: models.EventExpenseCreated, Status: models.DeliveryPending, Attempts: 3, NextAttemptAt: now.Add(4 * time.Minute), LastStatusCode: http.StatusBadGateway, LastError: "unexpected status 502"},
+		},
+		{
+			name:     "delivery is dead after the last attempt",
+			attempts: 7,
+			sendErr:  errors.New("connection refused"),
+			want:     models.WebhookDelivery{ID: 5, SubscriptionID: 1, EventID: 3, Event: models.EventExpenseCreated, Status: models.DeliveryDead, Attempts: 8, NextAttemptAt: now, LastError: "connection refused"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			//arrange
+			delivery := models.WebhookDelivery{ID: 5, SubscriptionID: 1, EventID: 3, Event: models.EventExpenseCreated, Status: models.DeliveryPending, Attempts: tc.attempts, NextAttemptAt: now}
+			event := models.ExpenseEvent{ID: 3, Event: models.EventExpenseCreated, ExpenseID: 7, Payload: `{"id":7}`, CreatedAt: now}
+
+			webhookRepo := repositories.NewWebhookRepositoryMock()
+			webhookRepo.On("FanOut", now, mock.Anything).Return(1, nil)
+			webhookRepo.On("ClaimDueDeliveries", now, mock.Anything, mock.Anything).Return([]models.WebhookDelivery{delivery}, nil)
+			webhookRepo.On("GetEvents", []uint{3}).Return([]models.ExpenseEvent{event}, nil)
+			webhookRepo.On("GetSubscriptions").Return([]models.WebhookSubscription{subscription()}, nil)
+			webhookRepo.On("UpdateDelivery", tc.want).Return(nil)
+
+			var headers map[string]string
+			var payload []byte
+			sender := senders.NewWebhookSenderMock()
+			sender.On("Send", "https://example.com/hook", mock.Anything, mock.Anything).Return(tc.code, tc.sendErr).Run(func(args mock.Arguments) {
+				payload = args.Get(1).([]byte)
+				headers = args.Get(2).(map[string]string)
+			})
+
+			webhookService := services.NewWebhookService(webhookRepo, sender)
+
+			//act
+			err := webhookService.DispatchPending(now)
+
+			//assert
+			assert.NoError(t, err)
+			assert.Equal(t, "sha256="+services.Sign("0123456789abcdef", payload), headers["X-Webhook-Signature"])
+			assert.Equal(t, models.EventExpenseCreated, headers["X-Webhook-Event"])
+			assert.Contains(t, string(payload), `"expense":{"id":7}`)
+			webhookRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReplayDeliveryService(t *testing.T) {
+	t.Run("replay dead delivery creates a new pending one", func(t *testing.T) {
+		//arrange
+		webhookRepo := repositories.NewWebhookRepositoryMock()
+		webhookRepo.On("GetDelivery", "1", "5").Return(models.WebhookDelivery{ID: 5, SubscriptionID: 1, EventID: 3, Event: models.EventExpenseCreated, Status: models.DeliveryDead, Attempts: 8}, nil)
+		webhookRepo.On("CreateDelivery", mock.MatchedBy(func(delivery *models.WebhookDelivery) bool {
+			return delivery.Status == models.DeliveryPending && delivery.Attempts == 0 && *delivery.ReplayOfID == 5 && delivery.EventID == 3
+		})).Return(nil)
+
+		webhookService := services.NewWebhookService(webhookRepo, senders.NewWebhookSenderMock())
+
+		//act
+		got, err := webhookService.ReplayDelivery("1", "5")
+
+		//assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.DeliveryPending, got.Status)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("replay pending delivery fails conflict", func(t *testing.T) {
+		//arrange
+		webhookRepo := repositories.NewWebhookRepositoryMock()
+		webhookRepo.On("GetDelivery", "1", "5").Return(models.WebhookDelivery{ID: 5, Status: models.DeliveryPending}, nil)
+
+		webhookService := services.NewWebhookService(webhookRepo, senders.NewWebhookSenderMock())
+
+		//act
+		_, err := webhookService.ReplayDelivery("1", "5")
+
+		//assert
+		assert.Equal(t, helpers.NewConflictError("delivery 5 is still pending"), err)
+	})
+}